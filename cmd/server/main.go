@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
 	"delivery-system/internal/config"
 	"delivery-system/internal/database"
@@ -87,22 +89,110 @@ func main() {
 	defer lagMonitor.Stop()
 
 	// Инициализация сервисов
-	geoService := services.NewGeolocationService(&cfg.Geolocation, redisClient, log)
-	orderService := services.NewOrderService(db, log, geoService, &cfg.Business)
-	courierService := services.NewCourierService(db, log)
-	reviewService := services.NewReviewService(db, log)
+	geocoder, err := services.NewGeocoder(&cfg.Geolocation, log)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to create geocoder")
+	}
+	router, err := services.NewRouter(&cfg.Geolocation, log)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to create router")
+	}
+	geoService := services.NewGeolocationService(geocoder, router, redisClient, log, &cfg.Geolocation)
+	blobStore, err := services.NewBlobStore(&cfg.BlobStore)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to create blob store")
+	}
+	orderEventService := services.NewOrderEventService(db, log, &cfg.EventStore)
+	outboxService := services.NewOutboxService(db, producer, log, &cfg.Kafka.Topics, &cfg.Outbox)
+	zoneService := services.NewZoneService(db, log, &cfg.Business)
+	etaService := services.NewETAService(db, log, geoService, redisClient, outboxService, &cfg.ETA, &cfg.SLA)
+	surgeService := services.NewSurgeService(db, log, outboxService, &cfg.Surge)
+	tariffService := services.NewTariffService(db, log, geoService, surgeService, zoneService, &cfg.Business)
+	customerService := services.NewCustomerService(db, log, geoService)
+	merchantService := services.NewMerchantService(db, log, geoService, &cfg.Business)
+	orderSchedulerService := services.NewOrderSchedulerService(db, log, etaService, orderEventService, outboxService, &cfg.Scheduling)
+	orderService := services.NewOrderService(db, log, geoService, tariffService, zoneService, etaService, orderSchedulerService, customerService, merchantService, orderEventService, outboxService, &cfg.Batching, &cfg.Cancellation)
+	shiftService := services.NewShiftService(db, log, &cfg.Shifts)
+	courierService := services.NewCourierService(db, log, orderEventService, outboxService, shiftService, &cfg.Batching)
+	reviewService := services.NewReviewService(db, log, orderEventService)
+	promoCodeService := services.NewPromoCodeService(db, log)
+	analyticsService := services.NewAnalyticsService(db, redisClient, log, &cfg.Analytics)
+	assignmentService := services.NewCourierAssignmentService(db, log, geoService, orderService, courierService, &cfg.Assignment)
+	orderPreparationService := services.NewOrderPreparationService(db, log, orderService, assignmentService, orderEventService, outboxService, &cfg.Preparation)
+	batchingService := services.NewBatchingService(db, log, geoService, courierService, &cfg.Batching)
+	deliveryProofService := services.NewDeliveryProofService(db, log, geoService, blobStore, orderEventService, outboxService, &cfg.Batching, &cfg.DeliveryProof)
 	redisService := services.NewRedisService(redisClient, log)
 	kafkeMetricsService := services.NewKafkaMetricsService(kafkaMetrics)
+	rateLimitService := services.NewRateLimitService(redisClient, log, &cfg.RateLimit)
+	apiKeyService := services.NewAPIKeyService(db, redisClient, log, &cfg.APIKeys)
+	courierLocationService := services.NewCourierLocationService(db, redisClient, log, &cfg.Tracking)
+
+	// Запуск релея outbox. Останавливается до закрытия Kafka producer
+	outboxService.Start()
+	defer outboxService.Stop()
+
+	// Запуск пересчёта динамического коэффициента. Останавливается до релея outbox
+	surgeService.Start()
+	defer surgeService.Stop()
+
+	// Запуск контроля смен курьеров
+	shiftService.Start()
+	defer shiftService.Stop()
+
+	// Запуск контроля SLA. Останавливается до релея outbox
+	etaService.Start()
+	defer etaService.Stop()
+
+	// Запуск передачи отложенных заказов в работу. Останавливается до релея outbox
+	orderSchedulerService.Start()
+	defer orderSchedulerService.Stop()
+
+	// Запуск поиска курьеров для заказов продавцов к их готовности. Останавливается до релея outbox
+	orderPreparationService.Start()
+	defer orderPreparationService.Stop()
 
 	// Инициализация handlers
-	orderHandler := handlers.NewOrderHandler(orderService, reviewService, producer, redisClient, log)
+	orderHandler := handlers.NewOrderHandler(orderService, reviewService, assignmentService, orderEventService, redisClient, log)
+	customerHandler := handlers.NewCustomerHandler(customerService, log)
+	merchantHandler := handlers.NewMerchantHandler(merchantService, log)
 	courierHandler := handlers.NewCourierHandler(courierService, reviewService, producer, redisClient, log)
+	courierLocationHandler := handlers.NewCourierLocationHandler(courierLocationService, orderService, producer, redisClient, log)
+	promoCodeHandler := handlers.NewPromoCodeHandler(promoCodeService, log)
+	tariffHandler := handlers.NewTariffHandler(tariffService, log)
+	surgeHandler := handlers.NewSurgeHandler(surgeService, log)
+	zoneHandler := handlers.NewZoneHandler(zoneService, log)
+	shiftHandler := handlers.NewShiftHandler(shiftService, log)
+	batchHandler := handlers.NewBatchHandler(batchingService, redisClient, log)
+	preparationHandler := handlers.NewPreparationHandler(orderPreparationService, redisClient, log)
+	deliveryProofHandler := handlers.NewDeliveryProofHandler(deliveryProofService, redisClient, log, cfg.DeliveryProof.MaxUploadSize)
+	slaHandler := handlers.NewSLAHandler(etaService, log)
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService, log)
 	healthHandler := handlers.NewHealthHandler(db, redisClient)
 	cacheHandler := handlers.NewRedisMetricsHandler(redisService, log)
 	kafkaMetricsHandler := handlers.NewKafkaMetricsHandler(kafkeMetricsService, log)
+	rateLimitHandler := handlers.NewRateLimitHandler(rateLimitService, log)
+	outboxMetricsHandler := handlers.NewOutboxMetricsHandler(outboxService, log)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService, log, cfg.APIKeys.UsageRetentionDays)
+
+	// Аутентификация по JWT и API-ключам. Без неё инициатор запроса определяется заголовками X-Actor-Role и X-Actor-ID
+	var authHandler *handlers.AuthHandler
+	if cfg.Auth.Enabled {
+		authService, err := services.NewAuthService(&cfg.Auth)
+		if err != nil {
+			log.WithError(err).Fatal("Failed to initialize authentication")
+		}
+		authHandler = handlers.NewAuthHandler(authService, apiKeyService, orderService, handlers.DefaultAccessPolicy, log)
+	} else {
+		log.Warn("Authentication is disabled, API is open to anyone")
+	}
+	for _, origin := range cfg.Server.AllowedOrigins {
+		if origin == "*" {
+			log.Warn("CORS allows requests from any origin")
+		}
+	}
 
 	// Регистрация обработчиков событий Kafka
-	registerEventHandlers(consumer, log)
+	registerEventHandlers(consumer, etaService, log)
 
 	// Запуск Kafka consumer
 	if err := consumer.Start(); err != nil {
@@ -110,7 +200,7 @@ func main() {
 	}
 
 	// Настройка HTTP роутера
-	mux := setupRoutes(orderHandler, courierHandler, healthHandler, cacheHandler, kafkaMetricsHandler)
+	mux := setupRoutes(orderHandler, customerHandler, merchantHandler, courierHandler, courierLocationHandler, promoCodeHandler, tariffHandler, surgeHandler, zoneHandler, shiftHandler, batchHandler, deliveryProofHandler, preparationHandler, slaHandler, analyticsHandler, healthHandler, cacheHandler, kafkaMetricsHandler, outboxMetricsHandler, rateLimitHandler, apiKeyHandler, authHandler, cfg.Auth.DevTokensEnabled, cfg.Server.AllowedOrigins)
 
 	// Создание HTTP сервера
 	server := &http.Server{
@@ -149,12 +239,43 @@ func main() {
 // setupRoutes настраивает маршруты HTTP сервера
 func setupRoutes(
 	orderHandler *handlers.OrderHandler,
+	customerHandler *handlers.CustomerHandler,
+	merchantHandler *handlers.MerchantHandler,
 	courierHandler *handlers.CourierHandler,
+	courierLocationHandler *handlers.CourierLocationHandler,
+	promoCodeHandler *handlers.PromoCodeHandler,
+	tariffHandler *handlers.TariffHandler,
+	surgeHandler *handlers.SurgeHandler,
+	zoneHandler *handlers.ZoneHandler,
+	shiftHandler *handlers.ShiftHandler,
+	batchHandler *handlers.BatchHandler,
+	deliveryProofHandler *handlers.DeliveryProofHandler,
+	preparationHandler *handlers.PreparationHandler,
+	slaHandler *handlers.SLAHandler,
+	analyticsHandler *handlers.AnalyticsHandler,
 	healthHandler *handlers.HealthHandler,
 	cacheHandler *handlers.RedisMetricsHandler,
 	kafkaMetricsHandler *handlers.KafkaMetricsHandler,
+	outboxMetricsHandler *handlers.OutboxMetricsHandler,
+	rateLimitHandler *handlers.RateLimitHandler,
+	apiKeyHandler *handlers.APIKeyHandler,
+	authHandler *handlers.AuthHandler,
+	devTokens bool,
+	allowedOrigins []string,
 ) *http.ServeMux {
 	mux := http.NewServeMux()
+	corsMiddleware := newCORSMiddleware(allowedOrigins)
+
+	// apiMiddleware применяет к API эндпоинтам CORS, проверку токена и прав доступа, если аутентификация
+	// включена, и ограничение частоты запросов. Лимит проверяется после аутентификации, чтобы запросы
+	// по API-ключу учитывались в лимите ключа. Preflight-запросы OPTIONS в лимите не учитываются
+	apiMiddleware := func(next http.HandlerFunc) http.HandlerFunc {
+		next = rateLimitHandler.Middleware(next)
+		if authHandler != nil {
+			next = authHandler.Middleware(next)
+		}
+		return corsMiddleware(next)
+	}
 
 	// Health check endpoints
 	mux.HandleFunc("/health", corsMiddleware(healthHandler.Health))
@@ -162,19 +283,71 @@ func setupRoutes(
 	mux.HandleFunc("/health/liveness", corsMiddleware(healthHandler.Liveness))
 
 	// Order endpoints
-	mux.HandleFunc("/api/orders", corsMiddleware(handleOrdersRoute(orderHandler)))
-	mux.HandleFunc("/api/orders/", corsMiddleware(handleOrderRoute(orderHandler)))
+	mux.HandleFunc("/api/orders", apiMiddleware(handleOrdersRoute(orderHandler)))
+	mux.HandleFunc("/api/orders/", apiMiddleware(handleOrderRoute(orderHandler, courierLocationHandler, deliveryProofHandler, preparationHandler)))
+	mux.HandleFunc("/api/orders/batches", apiMiddleware(batchHandler.GetBatches))
+	mux.HandleFunc("/api/orders/at-risk", apiMiddleware(slaHandler.GetAtRiskOrders))
+
+	// Customer endpoints
+	mux.HandleFunc("/api/customers", apiMiddleware(handleCustomersRoute(customerHandler)))
+	mux.HandleFunc("/api/customers/", apiMiddleware(handleCustomerRoute(customerHandler)))
+
+	// Merchant endpoints
+	mux.HandleFunc("/api/merchants", apiMiddleware(handleMerchantsRoute(merchantHandler)))
+	mux.HandleFunc("/api/merchants/", apiMiddleware(handleMerchantRoute(merchantHandler)))
 
 	// Courier endpoints
-	mux.HandleFunc("/api/couriers", corsMiddleware(handleCouriersRoute(courierHandler)))
-	mux.HandleFunc("/api/couriers/", corsMiddleware(handleCourierRoute(courierHandler)))
-	mux.HandleFunc("/api/couriers/available", corsMiddleware(courierHandler.GetAvailableCouriers))
+	mux.HandleFunc("/api/couriers", apiMiddleware(handleCouriersRoute(courierHandler)))
+	mux.HandleFunc("/api/couriers/", apiMiddleware(handleCourierRoute(courierHandler, courierLocationHandler, shiftHandler, batchHandler)))
+	mux.HandleFunc("/api/couriers/available", apiMiddleware(courierHandler.GetAvailableCouriers))
+	mux.HandleFunc("/api/couriers/nearby", apiMiddleware(courierLocationHandler.GetNearbyCouriers))
+
+	// Promo code endpoints
+	mux.HandleFunc("/api/promo-codes", apiMiddleware(handlePromoCodesRoute(promoCodeHandler)))
+	mux.HandleFunc("/api/promo-codes/", apiMiddleware(handlePromoCodeRoute(promoCodeHandler)))
+
+	// Tariff endpoints
+	mux.HandleFunc("/api/tariffs", apiMiddleware(handleTariffsRoute(tariffHandler)))
+	mux.HandleFunc("/api/tariffs/", apiMiddleware(handleTariffRoute(tariffHandler)))
+	mux.HandleFunc("/api/delivery/quote", apiMiddleware(tariffHandler.QuoteDelivery))
+	mux.HandleFunc("/api/delivery/surge", apiMiddleware(surgeHandler.GetSurgeMultipliers))
+
+	// Delivery zone endpoints
+	mux.HandleFunc("/api/zones", apiMiddleware(handleZonesRoute(zoneHandler)))
+	mux.HandleFunc("/api/zones/", apiMiddleware(handleZoneRoute(zoneHandler)))
+
+	// Courier shift endpoints
+	mux.HandleFunc("/api/shifts/", apiMiddleware(handleShiftRoute(shiftHandler)))
+
+	// Analytics endpoints
+	mux.HandleFunc("/api/analytics/summary", apiMiddleware(analyticsHandler.GetSummary))
+	mux.HandleFunc("/api/analytics/top-items", apiMiddleware(analyticsHandler.GetTopItems))
+	mux.HandleFunc("/api/analytics/couriers", apiMiddleware(analyticsHandler.GetCourierReport))
 
 	// Cache statistics endpont
-	mux.HandleFunc("/api/cache/metrics", corsMiddleware(cacheHandler.GetStatistics))
+	mux.HandleFunc("/api/cache/metrics", apiMiddleware(cacheHandler.GetStatistics))
 
 	// Kafka metrics endpoint
-	mux.HandleFunc("/api/kafka/stats", corsMiddleware(kafkaMetricsHandler.GetStatistics))
+	mux.HandleFunc("/api/kafka/stats", apiMiddleware(kafkaMetricsHandler.GetStatistics))
+
+	// Outbox metrics endpoint
+	mux.HandleFunc("/api/outbox/metrics", apiMiddleware(outboxMetricsHandler.GetMetrics))
+
+	// API key endpoints
+	mux.HandleFunc("/api/api-keys", apiMiddleware(handleAPIKeysRoute(apiKeyHandler)))
+	mux.HandleFunc("/api/api-keys/", apiMiddleware(handleAPIKeyRoute(apiKeyHandler)))
+
+	// Rate limit status endpoint
+	rateLimitStatus := rateLimitHandler.GetStatus
+	if authHandler != nil {
+		rateLimitStatus = authHandler.Middleware(rateLimitStatus)
+	}
+	mux.HandleFunc("/api/rate-limit/status", corsMiddleware(rateLimitStatus))
+
+	// Выпуск токенов для локальной разработки
+	if authHandler != nil && devTokens {
+		mux.HandleFunc("/api/auth/token", corsMiddleware(rateLimitHandler.Middleware(authHandler.IssueToken)))
+	}
 
 	return mux
 }
@@ -194,21 +367,113 @@ func handleOrdersRoute(handler *handlers.OrderHandler) http.HandlerFunc {
 }
 
 // handleOrderRoute обрабатывает маршруты для отдельного заказа
-func handleOrderRoute(handler *handlers.OrderHandler) http.HandlerFunc {
+func handleOrderRoute(
+	handler *handlers.OrderHandler,
+	locationHandler *handlers.CourierLocationHandler,
+	proofHandler *handlers.DeliveryProofHandler,
+	preparationHandler *handlers.PreparationHandler,
+) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if strings.HasSuffix(r.URL.Path, "/status") {
+		if strings.HasSuffix(r.URL.Path, "/delivery-proof") {
+			// Подтверждение доставки заказа
+			switch r.Method {
+			case http.MethodPost:
+				proofHandler.ConfirmDelivery(w, r)
+			case http.MethodGet:
+				proofHandler.GetDeliveryProof(w, r)
+			default:
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.Contains(r.URL.Path, "/delivery-proof/") {
+			// Фото и подпись из подтверждения доставки
+			if r.Method == http.MethodGet {
+				proofHandler.GetDeliveryProofFile(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/status") {
 			// Обновление статуса заказа
 			if r.Method == http.MethodPut {
 				handler.UpdateOrderStatus(w, r)
 			} else {
 				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
 			}
+		} else if strings.HasSuffix(r.URL.Path, "/cancel") {
+			// Отмена заказа
+			if r.Method == http.MethodPost {
+				handler.CancelOrder(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/accept") {
+			// Принятие заказа продавцом
+			if r.Method == http.MethodPost {
+				preparationHandler.AcceptOrder(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/reject") {
+			// Отказ продавца от заказа
+			if r.Method == http.MethodPost {
+				preparationHandler.RejectOrder(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/preparing") {
+			// Начало приготовления заказа
+			if r.Method == http.MethodPost {
+				preparationHandler.StartPreparing(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/ready") {
+			// Готовность заказа к передаче курьеру
+			if r.Method == http.MethodPost {
+				preparationHandler.MarkReady(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/cancellation") {
+			// Сведения об отмене заказа
+			if r.Method == http.MethodGet {
+				handler.GetOrderCancellation(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
 		} else if strings.HasSuffix(r.URL.Path, "/review") {
 			if r.Method == http.MethodPost {
 				handler.CreateReview(w, r)
 			} else {
 				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
 			}
+		} else if strings.HasSuffix(r.URL.Path, "/auto-assign") {
+			// Автоназначение курьера на заказ
+			if r.Method == http.MethodPost {
+				handler.AutoAssignOrder(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/events") {
+			// Журнал событий заказа
+			if r.Method == http.MethodGet {
+				handler.GetOrderEvents(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/replay") {
+			// Восстановление состояния заказа из журнала событий
+			if r.Method == http.MethodGet {
+				handler.ReplayOrder(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/courier/stream") {
+			// Трансляция местоположения курьера заказа (SSE)
+			if r.Method == http.MethodGet {
+				locationHandler.StreamOrderCourierLocation(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
 		} else {
 			// Получение заказа по ID
 			if r.Method == http.MethodGet {
@@ -220,6 +485,143 @@ func handleOrderRoute(handler *handlers.OrderHandler) http.HandlerFunc {
 	}
 }
 
+// handleCustomersRoute обрабатывает маршруты для коллекции клиентов
+func handleCustomersRoute(handler *handlers.CustomerHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetCustomers(w, r)
+		case http.MethodPost:
+			handler.CreateCustomer(w, r)
+		default:
+			writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handleCustomerRoute обрабатывает маршруты для отдельного клиента
+func handleCustomerRoute(handler *handlers.CustomerHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/orders") {
+			// История заказов клиента
+			if r.Method == http.MethodGet {
+				handler.GetCustomerOrders(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/addresses") {
+			// Сохранение адреса клиента
+			if r.Method == http.MethodPost {
+				handler.AddCustomerAddress(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.Contains(r.URL.Path, "/addresses/") {
+			// Удаление сохранённого адреса клиента
+			if r.Method == http.MethodDelete {
+				handler.DeleteCustomerAddress(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else {
+			switch r.Method {
+			case http.MethodGet:
+				handler.GetCustomer(w, r)
+			case http.MethodPut:
+				handler.UpdateCustomer(w, r)
+			default:
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		}
+	}
+}
+
+// handleMerchantsRoute обрабатывает маршруты для коллекции продавцов
+func handleMerchantsRoute(handler *handlers.MerchantHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetMerchants(w, r)
+		case http.MethodPost:
+			handler.CreateMerchant(w, r)
+		default:
+			writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handleMerchantRoute обрабатывает маршруты для отдельного продавца и его каталога
+func handleMerchantRoute(handler *handlers.MerchantHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/products") {
+			// Каталог товаров продавца
+			switch r.Method {
+			case http.MethodGet:
+				handler.GetProducts(w, r)
+			case http.MethodPost:
+				handler.CreateProduct(w, r)
+			default:
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.Contains(r.URL.Path, "/products/") {
+			// Отдельный товар каталога
+			switch r.Method {
+			case http.MethodPut:
+				handler.UpdateProduct(w, r)
+			case http.MethodDelete:
+				handler.DeleteProduct(w, r)
+			default:
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else {
+			switch r.Method {
+			case http.MethodGet:
+				handler.GetMerchant(w, r)
+			case http.MethodPut:
+				handler.UpdateMerchant(w, r)
+			case http.MethodDelete:
+				handler.DeleteMerchant(w, r)
+			default:
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		}
+	}
+}
+
+// handleAPIKeysRoute обрабатывает маршруты для коллекции API-ключей
+func handleAPIKeysRoute(handler *handlers.APIKeyHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetAPIKeys(w, r)
+		case http.MethodPost:
+			handler.IssueAPIKey(w, r)
+		default:
+			writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handleAPIKeyRoute обрабатывает маршруты для отдельного API-ключа
+func handleAPIKeyRoute(handler *handlers.APIKeyHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/rotate") {
+			handler.RotateAPIKey(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/usage") {
+			handler.GetUsage(w, r)
+		} else {
+			switch r.Method {
+			case http.MethodGet:
+				handler.GetAPIKey(w, r)
+			case http.MethodDelete:
+				handler.RevokeAPIKey(w, r)
+			default:
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		}
+	}
+}
+
 // handleCouriersRoute обрабатывает маршруты для коллекции курьеров
 func handleCouriersRoute(handler *handlers.CourierHandler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -235,9 +637,18 @@ func handleCouriersRoute(handler *handlers.CourierHandler) http.HandlerFunc {
 }
 
 // handleCourierRoute обрабатывает маршруты для отдельного курьера
-func handleCourierRoute(handler *handlers.CourierHandler) http.HandlerFunc {
+func handleCourierRoute(
+	handler *handlers.CourierHandler,
+	locationHandler *handlers.CourierLocationHandler,
+	shiftHandler *handlers.ShiftHandler,
+	batchHandler *handlers.BatchHandler,
+) http.HandlerFunc {
+	shiftRoute := handleCourierShiftRoute(shiftHandler)
 	return func(w http.ResponseWriter, r *http.Request) {
-		if strings.HasSuffix(r.URL.Path, "/status") {
+		if strings.Contains(r.URL.Path, "/shifts") {
+			// Смены курьера
+			shiftRoute(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/status") {
 			// Обновление статуса курьера
 			if r.Method == http.MethodPut {
 				handler.UpdateCourierStatus(w, r)
@@ -251,12 +662,54 @@ func handleCourierRoute(handler *handlers.CourierHandler) http.HandlerFunc {
 			} else {
 				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
 			}
+		} else if strings.HasSuffix(r.URL.Path, "/zone") {
+			// Привязка курьера к зоне доставки
+			if r.Method == http.MethodPut {
+				handler.UpdateCourierZone(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/capacity") {
+			// Изменение вместимости курьера
+			if r.Method == http.MethodPut {
+				handler.UpdateCourierCapacity(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/batch") {
+			// Назначение курьеру пакета заказов
+			if r.Method == http.MethodPost {
+				batchHandler.AssignBatch(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/route") {
+			// Маршрут курьера по активным заказам
+			if r.Method == http.MethodGet {
+				batchHandler.GetCourierRoute(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
 		} else if strings.HasSuffix(r.URL.Path, "/reviews") {
 			if r.Method == http.MethodGet {
 				handler.GetCourierReviews(w, r)
 			} else {
 				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
 			}
+		} else if strings.HasSuffix(r.URL.Path, "/location") {
+			// Обновление местоположения курьера
+			if r.Method == http.MethodPost {
+				locationHandler.UpdateCourierLocation(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/track") {
+			// Трек курьера в формате GeoJSON
+			if r.Method == http.MethodGet {
+				locationHandler.GetCourierTrack(w, r)
+			} else {
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
 
 		} else {
 			// Получение курьера по ID
@@ -269,8 +722,138 @@ func handleCourierRoute(handler *handlers.CourierHandler) http.HandlerFunc {
 	}
 }
 
+// handleCourierShiftRoute обрабатывает маршруты смен отдельного курьера
+func handleCourierShiftRoute(handler *handlers.ShiftHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		switch {
+		case strings.HasSuffix(path, "/shifts/break/start"):
+			handler.StartBreak(w, r)
+		case strings.HasSuffix(path, "/shifts/break/end"):
+			handler.EndBreak(w, r)
+		case strings.HasSuffix(path, "/shifts/start"):
+			handler.StartShift(w, r)
+		case strings.HasSuffix(path, "/shifts/end"):
+			handler.EndShift(w, r)
+		case strings.HasSuffix(path, "/shifts"):
+			switch r.Method {
+			case http.MethodGet:
+				handler.GetCourierShifts(w, r)
+			case http.MethodPost:
+				handler.ScheduleShift(w, r)
+			default:
+				writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		default:
+			writeErrorResponse(w, http.StatusNotFound, "Not found")
+		}
+	}
+}
+
+// handleShiftRoute обрабатывает маршруты для отдельной смены
+func handleShiftRoute(handler *handlers.ShiftHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetShift(w, r)
+		case http.MethodDelete:
+			handler.CancelShift(w, r)
+		default:
+			writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handlePromoCodesRoute обрабатывает маршруты для коллекции промокодов
+func handlePromoCodesRoute(handler *handlers.PromoCodeHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetPromoCodes(w, r)
+		case http.MethodPost:
+			handler.CreatePromoCode(w, r)
+		default:
+			writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handlePromoCodeRoute обрабатывает маршруты для отдельного промокода
+func handlePromoCodeRoute(handler *handlers.PromoCodeHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetPromoCode(w, r)
+		case http.MethodPut:
+			handler.UpdatePromoCode(w, r)
+		case http.MethodDelete:
+			handler.DeletePromoCode(w, r)
+		default:
+			writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handleTariffsRoute обрабатывает маршруты для коллекции тарифов
+func handleTariffsRoute(handler *handlers.TariffHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetTariffs(w, r)
+		case http.MethodPost:
+			handler.CreateTariff(w, r)
+		default:
+			writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handleTariffRoute обрабатывает маршруты для отдельной версии тарифа
+func handleTariffRoute(handler *handlers.TariffHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetTariff(w, r)
+		case http.MethodDelete:
+			handler.RetireTariff(w, r)
+		default:
+			writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handleZonesRoute обрабатывает маршруты для коллекции зон доставки
+func handleZonesRoute(handler *handlers.ZoneHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetZones(w, r)
+		case http.MethodPost:
+			handler.CreateZone(w, r)
+		default:
+			writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handleZoneRoute обрабатывает маршруты для отдельной зоны доставки
+func handleZoneRoute(handler *handlers.ZoneHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetZone(w, r)
+		case http.MethodPut:
+			handler.UpdateZone(w, r)
+		case http.MethodDelete:
+			handler.DeleteZone(w, r)
+		default:
+			writeErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
 // registerEventHandlers регистрирует обработчики событий Kafka
-func registerEventHandlers(consumer *kafka.Consumer, log *logger.Logger) {
+func registerEventHandlers(consumer *kafka.Consumer, etaService *services.ETAService, log *logger.Logger) {
 	// Пример обработчика событий - можно расширить по необходимости
 	consumer.RegisterHandler(models.EventTypeOrderCreated, func(ctx context.Context, event *models.Event) error {
 		log.WithField("event_id", event.ID).Info("Processing order created event")
@@ -278,15 +861,54 @@ func registerEventHandlers(consumer *kafka.Consumer, log *logger.Logger) {
 		return nil
 	})
 
+	// Прогноз времени доставки пересчитывается при каждой смене статуса заказа
 	consumer.RegisterHandler(models.EventTypeOrderStatusChanged, func(ctx context.Context, event *models.Event) error {
 		log.WithField("event_id", event.ID).Info("Processing order status changed event")
-		// Здесь можно добавить логику уведомлений, обновления статистики и т.д.
+		var change models.OrderStatusChangedEvent
+		if err := decodeEventData(event, &change); err != nil {
+			return err
+		}
+		return etaService.RefreshOrder(change.OrderID)
+	})
+
+	consumer.RegisterHandler(models.EventTypeOrderCancelled, func(ctx context.Context, event *models.Event) error {
+		log.WithField("event_id", event.ID).Info("Processing order cancelled event")
+		return nil
+	})
+
+	consumer.RegisterHandler(models.EventTypeOrderRejected, func(ctx context.Context, event *models.Event) error {
+		log.WithField("event_id", event.ID).Info("Processing order rejected event")
+		return nil
+	})
+
+	// Этапы приготовления заказа продавцом уточняют прогноз времени доставки
+	for _, eventType := range []models.EventType{
+		models.EventTypeOrderAccepted,
+		models.EventTypeOrderPreparing,
+		models.EventTypeOrderReady,
+	} {
+		consumer.RegisterHandler(eventType, func(ctx context.Context, event *models.Event) error {
+			log.WithField("event_id", event.ID).Info("Processing order preparation event")
+			var preparation models.OrderPreparationEvent
+			if err := decodeEventData(event, &preparation); err != nil {
+				return err
+			}
+			return etaService.RefreshOrder(preparation.OrderID)
+		})
+	}
+
+	consumer.RegisterHandler(models.EventTypeOrderSLABreached, func(ctx context.Context, event *models.Event) error {
+		log.WithField("event_id", event.ID).Info("Processing order SLA breached event")
 		return nil
 	})
 
 	consumer.RegisterHandler(models.EventTypeCourierAssigned, func(ctx context.Context, event *models.Event) error {
 		log.WithField("event_id", event.ID).Info("Processing courier assignment event")
-		return nil
+		var assigned models.CourierAssignedEvent
+		if err := decodeEventData(event, &assigned); err != nil {
+			return err
+		}
+		return etaService.RefreshOrder(assigned.OrderID)
 	})
 
 	consumer.RegisterHandler(models.EventTypeCourierStatusChanged, func(ctx context.Context, event *models.Event) error {
@@ -294,25 +916,59 @@ func registerEventHandlers(consumer *kafka.Consumer, log *logger.Logger) {
 		return nil
 	})
 
+	// Прогноз активных заказов курьера уточняется по его новому местоположению
 	consumer.RegisterHandler(models.EventTypeLocationUpdated, func(ctx context.Context, event *models.Event) error {
 		log.WithField("event_id", event.ID).Info("Processing location update event")
-		return nil
+		var location models.LocationUpdatedEvent
+		if err := decodeEventData(event, &location); err != nil {
+			return err
+		}
+		return etaService.RefreshCourierOrders(location.CourierID)
 	})
 }
 
-// corsMiddleware и другие helper функции
-func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+// decodeEventData преобразует данные события, полученного из Kafka, в структуру события конкретного типа
+func decodeEventData(event *models.Event, target interface{}) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event data: %w", err)
+	}
+	if err = json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("failed to unmarshal event data: %w", err)
+	}
+	return nil
+}
 
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusOK)
-			return
+// newCORSMiddleware создаёт middleware, добавляющий CORS заголовки для разрешённых источников.
+// "*" в списке разрешает любой источник
+func newCORSMiddleware(allowedOrigins []string) func(http.HandlerFunc) http.HandlerFunc {
+	allowAny := false
+	allowed := make(map[string]bool, len(allowedOrigins))
+	for _, origin := range allowedOrigins {
+		if origin == "*" {
+			allowAny = true
 		}
+		allowed[origin] = true
+	}
+
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if allowAny {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else if origin := r.Header.Get("Origin"); allowed[origin] {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Add("Vary", "Origin")
+			}
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Actor-Role, X-Actor-ID, X-API-Key")
+
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
 
-		next(w, r)
+			next(w, r)
+		}
 	}
 }
 
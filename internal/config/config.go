@@ -10,21 +10,40 @@ import (
 
 // Config представляет конфигурацию приложения
 type Config struct {
-	Server      ServerConfig      `json:"server"`
-	Database    DatabaseConfig    `json:"database"`
-	Redis       RedisConfig       `json:"redis"`
-	Kafka       KafkaConfig       `json:"kafka"`
-	Logger      LoggerConfig      `json:"logger"`
-	Geolocation GeolocationConfig `json:"geolocation"`
-	Business    BusinessConfig    `json:"business"`
+	Server        ServerConfig        `json:"server"`
+	Database      DatabaseConfig      `json:"database"`
+	Redis         RedisConfig         `json:"redis"`
+	Kafka         KafkaConfig         `json:"kafka"`
+	Logger        LoggerConfig        `json:"logger"`
+	Geolocation   GeolocationConfig   `json:"geolocation"`
+	Business      BusinessConfig      `json:"business"`
+	Assignment    AssignmentConfig    `json:"assignment"`
+	Analytics     AnalyticsConfig     `json:"analytics"`
+	EventStore    EventStoreConfig    `json:"event_store"`
+	RateLimit     RateLimitConfig     `json:"rate_limit"`
+	Outbox        OutboxConfig        `json:"outbox"`
+	Tracking      TrackingConfig      `json:"tracking"`
+	Surge         SurgeConfig         `json:"surge"`
+	Shifts        ShiftConfig         `json:"shifts"`
+	Batching      BatchingConfig      `json:"batching"`
+	Cancellation  CancellationConfig  `json:"cancellation"`
+	BlobStore     BlobStoreConfig     `json:"blob_store"`
+	DeliveryProof DeliveryProofConfig `json:"delivery_proof"`
+	ETA           ETAConfig           `json:"eta"`
+	SLA           SLAConfig           `json:"sla"`
+	Scheduling    SchedulingConfig    `json:"scheduling"`
+	Preparation   PreparationConfig   `json:"preparation"`
+	Auth          AuthConfig          `json:"auth"`
+	APIKeys       APIKeyConfig        `json:"api_keys"`
 }
 
 // ServerConfig представляет конфигурацию HTTP сервера
 type ServerConfig struct {
-	Port         string `json:"port"`
-	Host         string `json:"host"`
-	ReadTimeout  int    `json:"read_timeout"`
-	WriteTimeout int    `json:"write_timeout"`
+	Port           string   `json:"port"`
+	Host           string   `json:"host"`
+	ReadTimeout    int      `json:"read_timeout"`
+	WriteTimeout   int      `json:"write_timeout"`
+	AllowedOrigins []string `json:"allowed_origins"`
 }
 
 // DatabaseConfig представляет конфигурацию базы данных
@@ -56,9 +75,11 @@ type KafkaConfig struct {
 
 // Topics представляет список топиков Kafka
 type Topics struct {
-	Orders    string `json:"orders"`
-	Couriers  string `json:"couriers"`
-	Locations string `json:"locations"`
+	Orders        string `json:"orders"`
+	Couriers      string `json:"couriers"`
+	Locations     string `json:"locations"`
+	Pricing       string `json:"pricing"`
+	Notifications string `json:"notifications"`
 }
 
 // LoggerConfig представляет конфигурацию логгера
@@ -68,15 +89,200 @@ type LoggerConfig struct {
 	File   string `json:"file"`
 }
 
-// GeolocationConfig представляет конфигурацию для геосервиса (Openrouteservice)
+// GeolocationConfig представляет конфигурацию геосервиса: выбор провайдеров геокодирования
+// и построения маршрутов и их параметры
 type GeolocationConfig struct {
-	OperouteAPIKey string `json:"openroute_api_key"`
-	YandexAPIKey   string `json:"yandex_api_key"`
+	Geocoders          []string `json:"geocoders"`
+	Routers            []string `json:"routers"`
+	OperouteAPIKey     string   `json:"openroute_api_key"`
+	YandexAPIKey       string   `json:"yandex_api_key"`
+	YandexURL          string   `json:"yandex_url"`
+	OpenrouteURL       string   `json:"openroute_url"`
+	NominatimURL       string   `json:"nominatim_url"`
+	OSRMURL            string   `json:"osrm_url"`
+	OSRMProfile        string   `json:"osrm_profile"`
+	UserAgent          string   `json:"user_agent"`
+	AddressBookFile    string   `json:"address_book_file"`
+	Timeout            int      `json:"timeout"`
+	GeocodeCacheTTL    int      `json:"geocode_cache_ttl"`
+	RouteCacheTTL      int      `json:"route_cache_ttl"`
+	BreakerThreshold   int      `json:"breaker_threshold"`
+	BreakerOpenTimeout int      `json:"breaker_open_timeout"`
 }
 
-// BusinessConfig включает в себя бизнес-показатели
+// BusinessConfig включает в себя бизнес-показатели. TimeZone - часовой пояс,
+// в котором определяются ночные часы и выходные дни тарифов доставки, MaxRouteDistance -
+// максимальная длина маршрута доставки в метрах для зон без собственного ограничения (0 - без ограничения)
 type BusinessConfig struct {
-	DeliveryRate int
+	TimeZone         string `json:"time_zone"`
+	MaxRouteDistance int    `json:"max_route_distance"`
+}
+
+// AssignmentConfig представляет весовые коэффициенты и ограничения автоназначения курьеров
+type AssignmentConfig struct {
+	DistanceWeight float64 `json:"distance_weight"`
+	RatingWeight   float64 `json:"rating_weight"`
+	LoadWeight     float64 `json:"load_weight"`
+	MaxDistanceKm  float64 `json:"max_distance_km"`
+	MaxLoad        int     `json:"max_load"`
+	DefaultRating  float64 `json:"default_rating"`
+}
+
+// AnalyticsConfig представляет конфигурацию аналитических отчётов
+type AnalyticsConfig struct {
+	CacheTTL int `json:"cache_ttl"`
+}
+
+// EventStoreConfig представляет конфигурацию журнала событий заказов
+type EventStoreConfig struct {
+	SnapshotInterval int `json:"snapshot_interval"`
+}
+
+// RateLimitConfig представляет конфигурацию ограничения частоты запросов
+type RateLimitConfig struct {
+	Enabled      bool `json:"enabled"`
+	Window       int  `json:"window"`
+	DefaultLimit int  `json:"default_limit"`
+	VIPLimit     int  `json:"vip_limit"`
+	BanThreshold int  `json:"ban_threshold"`
+	BanDuration  int  `json:"ban_duration"`
+	TrustProxy   bool `json:"trust_proxy"`
+}
+
+// OutboxConfig представляет конфигурацию релея transactional outbox
+type OutboxConfig struct {
+	PollInterval   int `json:"poll_interval"`
+	BatchSize      int `json:"batch_size"`
+	MaxBackoff     int `json:"max_backoff"`
+	RetentionHours int `json:"retention_hours"`
+}
+
+// TrackingConfig представляет конфигурацию отслеживания местоположения и поиска курьеров поблизости
+type TrackingConfig struct {
+	StaleAfter          int `json:"stale_after"`
+	NearbyDefaultRadius int `json:"nearby_default_radius"`
+	NearbyMaxRadius     int `json:"nearby_max_radius"`
+}
+
+// SurgeConfig представляет конфигурацию динамического коэффициента стоимости доставки.
+// Целевой коэффициент зоны равен 1 + Sensitivity * (заказы / курьеры - RatioThreshold),
+// не превышает MaxMultiplier и сглаживается экспоненциально с весом Smoothing
+type SurgeConfig struct {
+	Enabled        bool    `json:"enabled"`
+	Interval       int     `json:"interval"`
+	Smoothing      float64 `json:"smoothing"`
+	RatioThreshold float64 `json:"ratio_threshold"`
+	Sensitivity    float64 `json:"sensitivity"`
+	MaxMultiplier  float64 `json:"max_multiplier"`
+}
+
+// ShiftConfig представляет конфигурацию смен курьеров. EarlyStart - за сколько минут до начала
+// запланированной смены курьер может её открыть, AutoEndGrace - через сколько минут после окончания
+// смена без активных заказов закрывается автоматически
+type ShiftConfig struct {
+	Enabled      bool `json:"enabled"`
+	Interval     int  `json:"interval"`
+	EarlyStart   int  `json:"early_start"`
+	AutoEndGrace int  `json:"auto_end_grace"`
+}
+
+// BatchingConfig представляет конфигурацию одновременной доставки нескольких заказов одним курьером.
+// DefaultCapacity - вместимость курьера без собственного ограничения, PickupRadius и DeliveryRadius -
+// максимальные расстояния в метрах между точками получения и точками доставки заказов одного пакета
+type BatchingConfig struct {
+	DefaultCapacity int `json:"default_capacity"`
+	PickupRadius    int `json:"pickup_radius"`
+	DeliveryRadius  int `json:"delivery_radius"`
+}
+
+// CancellationConfig представляет конфигурацию платы за отмену заказа по инициативе клиента.
+// Плата задаётся в процентах от оплаченной суммы для каждого этапа заказа; до начала приготовления
+// отмена бесплатна
+type CancellationConfig struct {
+	PreparingFeePercent  float64 `json:"preparing_fee_percent"`
+	ReadyFeePercent      float64 `json:"ready_fee_percent"`
+	InDeliveryFeePercent float64 `json:"in_delivery_fee_percent"`
+}
+
+// BlobStoreConfig представляет конфигурацию хранилища файлов. Provider - реализация хранилища,
+// LocalDir - каталог для провайдера local
+type BlobStoreConfig struct {
+	Provider string `json:"provider"`
+	LocalDir string `json:"local_dir"`
+}
+
+// DeliveryProofConfig представляет конфигурацию подтверждения доставки. LocationTolerance - допустимое
+// расстояние в метрах от курьера до точки доставки, MaxPinAttempts - количество неверных вводов PIN-кода,
+// после которого подтверждение блокируется, MaxUploadSize - максимальный размер запроса с фото в мегабайтах
+type DeliveryProofConfig struct {
+	LocationTolerance int `json:"location_tolerance"`
+	MaxPinAttempts    int `json:"max_pin_attempts"`
+	MaxUploadSize     int `json:"max_upload_size"`
+}
+
+// ETAConfig представляет конфигурацию прогноза времени доставки. HistoryDays - за сколько дней истории
+// статусов рассчитывается средняя длительность этапов заказа, DefaultStageMinutes - длительность этапа без
+// истории, CourierSpeed - средняя скорость курьера в км/ч, LocationRefresh - как часто в секундах прогноз
+// пересчитывается по обновлениям местоположения курьера
+type ETAConfig struct {
+	HistoryDays         int     `json:"history_days"`
+	DefaultStageMinutes int     `json:"default_stage_minutes"`
+	CourierSpeed        float64 `json:"courier_speed"`
+	LocationRefresh     int     `json:"location_refresh"`
+}
+
+// SLAConfig представляет конфигурацию контроля сроков доставки. DefaultMinutes - срок доставки для зон
+// без своего SLA, AtRiskMinutes - за сколько минут до срока заказ считается под угрозой нарушения,
+// Interval - период проверки нарушений в секундах
+type SLAConfig struct {
+	Enabled        bool `json:"enabled"`
+	Interval       int  `json:"interval"`
+	DefaultMinutes int  `json:"default_minutes"`
+	AtRiskMinutes  int  `json:"at_risk_minutes"`
+}
+
+// SchedulingConfig представляет конфигурацию отложенных заказов. Interval - период проверки заказов
+// к передаче в работу в секундах, LeadBuffer - запас в минутах сверх прогнозируемой длительности
+// выполнения заказа, MaxDaysAhead - на сколько дней вперёд можно оформить отложенный заказ
+type SchedulingConfig struct {
+	Enabled      bool `json:"enabled"`
+	Interval     int  `json:"interval"`
+	LeadBuffer   int  `json:"lead_buffer"`
+	MaxDaysAhead int  `json:"max_days_ahead"`
+}
+
+// PreparationConfig представляет конфигурацию приготовления заказов продавцами. Interval - период поиска
+// курьеров для заказов продавцов в секундах, CourierLead - за сколько минут до готовности заказа ищется курьер
+// (среднее время прибытия курьера к точке получения), MaxPrepMinutes - максимальное заявляемое время приготовления
+type PreparationConfig struct {
+	Enabled        bool `json:"enabled"`
+	Interval       int  `json:"interval"`
+	CourierLead    int  `json:"courier_lead"`
+	MaxPrepMinutes int  `json:"max_prep_minutes"`
+}
+
+// AuthConfig представляет конфигурацию аутентификации по JWT. Algorithm - HS256 с общим секретом Secret
+// или RS256 с открытым ключом PublicKeyFile; закрытый ключ PrivateKeyFile нужен только для выпуска
+// токенов разработки. Issuer и Audience, если заданы, проверяются в токене, RoleClaim - claim с ролью.
+// TokenTTL - срок действия выпускаемых токенов в минутах
+type AuthConfig struct {
+	Enabled          bool   `json:"enabled"`
+	Algorithm        string `json:"algorithm"`
+	Secret           string `json:"-"`
+	PublicKeyFile    string `json:"public_key_file"`
+	PrivateKeyFile   string `json:"-"`
+	Issuer           string `json:"issuer"`
+	Audience         string `json:"audience"`
+	RoleClaim        string `json:"role_claim"`
+	TokenTTL         int    `json:"token_ttl"`
+	DevTokensEnabled bool   `json:"dev_tokens_enabled"`
+}
+
+// APIKeyConfig представляет конфигурацию API-ключей машинных клиентов. RotationGrace - сколько минут после
+// ротации принимается прежний ключ, UsageRetentionDays - сколько дней хранятся счётчики запросов по ключу
+type APIKeyConfig struct {
+	RotationGrace      int `json:"rotation_grace"`
+	UsageRetentionDays int `json:"usage_retention_days"`
 }
 
 // Load загружает конфигурацию из переменных окружения
@@ -84,10 +290,11 @@ func Load() *Config {
 	_ = godotenv.Load()
 	return &Config{
 		Server: ServerConfig{
-			Port:         getEnv("SERVER_PORT", "8080"),
-			Host:         getEnv("SERVER_HOST", "0.0.0.0"),
-			ReadTimeout:  getEnvAsInt("SERVER_READ_TIMEOUT", 10),
-			WriteTimeout: getEnvAsInt("SERVER_WRITE_TIMEOUT", 10),
+			Port:           getEnv("SERVER_PORT", "8080"),
+			Host:           getEnv("SERVER_HOST", "0.0.0.0"),
+			ReadTimeout:    getEnvAsInt("SERVER_READ_TIMEOUT", 10),
+			WriteTimeout:   getEnvAsInt("SERVER_WRITE_TIMEOUT", 10),
+			AllowedOrigins: getEnvAsSlice("CORS_ALLOWED_ORIGINS", ""),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -107,12 +314,14 @@ func Load() *Config {
 			Brokers: strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
 			GroupID: getEnv("KAFKA_GROUP_ID", "delivery-service"),
 			Topics: Topics{
-				Orders:    getEnv("KAFKA_TOPIC_ORDERS", "orders"),
-				Couriers:  getEnv("KAFKA_TOPIC_COURIERS", "couriers"),
-				Locations: getEnv("KAFKA_TOPIC_LOCATIONS", "locations"),
+				Orders:        getEnv("KAFKA_TOPIC_ORDERS", "orders"),
+				Couriers:      getEnv("KAFKA_TOPIC_COURIERS", "couriers"),
+				Locations:     getEnv("KAFKA_TOPIC_LOCATIONS", "locations"),
+				Pricing:       getEnv("KAFKA_TOPIC_PRICING", "pricing"),
+				Notifications: getEnv("KAFKA_TOPIC_NOTIFICATIONS", "notifications"),
 			},
 			ConsumerLag:     int64(getEnvAsInt("KAFKA_CONSUMER_LAG", 1000)),
-			MonitorInterval: getEnvAsInt("KAFKA_MONITOR_INTERVAL_MINUTES", 15),
+			MonitorInterval: getEnvAsInterval("KAFKA_MONITOR_INTERVAL_MINUTES", 15),
 		},
 		Logger: LoggerConfig{
 			Level:  getEnv("LOG_LEVEL", "info"),
@@ -120,11 +329,133 @@ func Load() *Config {
 			File:   getEnv("LOG_FILE", ""),
 		},
 		Geolocation: GeolocationConfig{
-			OperouteAPIKey: getEnv("OPENROUTE_API_KEY", ""),
-			YandexAPIKey:   getEnv("YANDEX_API_KEY", ""),
+			Geocoders:          getEnvAsSlice("GEOCODER_PROVIDERS", "yandex"),
+			Routers:            getEnvAsSlice("ROUTER_PROVIDERS", "openroute"),
+			OperouteAPIKey:     getEnv("OPENROUTE_API_KEY", ""),
+			YandexAPIKey:       getEnv("YANDEX_API_KEY", ""),
+			YandexURL:          getEnv("YANDEX_GEOCODER_URL", "https://geocode-maps.yandex.ru/v1/"),
+			OpenrouteURL:       getEnv("OPENROUTE_DIRECTIONS_URL", "https://api.openrouteservice.org/v2/directions/foot-walking"),
+			NominatimURL:       getEnv("NOMINATIM_URL", "https://nominatim.openstreetmap.org"),
+			OSRMURL:            getEnv("OSRM_URL", "https://router.project-osrm.org"),
+			OSRMProfile:        getEnv("OSRM_PROFILE", "foot"),
+			UserAgent:          getEnv("GEO_USER_AGENT", "delivery-system"),
+			AddressBookFile:    getEnv("GEO_ADDRESS_BOOK_FILE", ""),
+			Timeout:            getEnvAsInt("GEO_HTTP_TIMEOUT_SECONDS", 5),
+			GeocodeCacheTTL:    getEnvAsInt("GEO_GEOCODE_CACHE_TTL_HOURS", 720),
+			RouteCacheTTL:      getEnvAsInt("GEO_ROUTE_CACHE_TTL_HOURS", 168),
+			BreakerThreshold:   getEnvAsInt("GEO_BREAKER_FAILURE_THRESHOLD", 5),
+			BreakerOpenTimeout: getEnvAsInt("GEO_BREAKER_OPEN_SECONDS", 30),
 		},
 		Business: BusinessConfig{
-			DeliveryRate: getEnvAsInt("DELIVERY_RATE", 100),
+			TimeZone:         getEnv("BUSINESS_TIMEZONE", "Europe/Moscow"),
+			MaxRouteDistance: getEnvAsInt("MAX_ROUTE_DISTANCE_M", 50000),
+		},
+		Assignment: AssignmentConfig{
+			DistanceWeight: getEnvAsFloat("ASSIGNMENT_WEIGHT_DISTANCE", 0.4),
+			RatingWeight:   getEnvAsFloat("ASSIGNMENT_WEIGHT_RATING", 0.3),
+			LoadWeight:     getEnvAsFloat("ASSIGNMENT_WEIGHT_LOAD", 0.3),
+			MaxDistanceKm:  getEnvAsFloat("ASSIGNMENT_MAX_DISTANCE_KM", 10),
+			MaxLoad:        getEnvAsInt("ASSIGNMENT_MAX_LOAD", 3),
+			DefaultRating:  getEnvAsFloat("ASSIGNMENT_DEFAULT_RATING", 3),
+		},
+		Analytics: AnalyticsConfig{
+			CacheTTL: getEnvAsInt("ANALYTICS_CACHE_TTL_SECONDS", 300),
+		},
+		EventStore: EventStoreConfig{
+			SnapshotInterval: getEnvAsInt("ORDER_SNAPSHOT_INTERVAL", 10),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:      getEnvAsBool("RATE_LIMIT_ENABLED", true),
+			Window:       getEnvAsInt("RATE_LIMIT_WINDOW_SECONDS", 60),
+			DefaultLimit: getEnvAsInt("RATE_LIMIT_DEFAULT", 100),
+			VIPLimit:     getEnvAsInt("RATE_LIMIT_VIP", 1000),
+			BanThreshold: getEnvAsInt("RATE_LIMIT_BAN_THRESHOLD", 5),
+			BanDuration:  getEnvAsInt("RATE_LIMIT_BAN_SECONDS", 300),
+			TrustProxy:   getEnvAsBool("RATE_LIMIT_TRUST_PROXY", false),
+		},
+		Outbox: OutboxConfig{
+			PollInterval:   getEnvAsInterval("OUTBOX_POLL_INTERVAL_MS", 1000),
+			BatchSize:      getEnvAsInt("OUTBOX_BATCH_SIZE", 100),
+			MaxBackoff:     getEnvAsInt("OUTBOX_MAX_BACKOFF_SECONDS", 300),
+			RetentionHours: getEnvAsInt("OUTBOX_RETENTION_HOURS", 24),
+		},
+		Tracking: TrackingConfig{
+			StaleAfter:          getEnvAsInt("COURIER_LOCATION_STALE_SECONDS", 300),
+			NearbyDefaultRadius: getEnvAsInt("NEARBY_DEFAULT_RADIUS_M", 3000),
+			NearbyMaxRadius:     getEnvAsInt("NEARBY_MAX_RADIUS_M", 20000),
+		},
+		Surge: SurgeConfig{
+			Enabled:        getEnvAsBool("SURGE_ENABLED", true),
+			Interval:       getEnvAsInterval("SURGE_INTERVAL_SECONDS", 30),
+			Smoothing:      getEnvAsFloat("SURGE_SMOOTHING", 0.3),
+			RatioThreshold: getEnvAsFloat("SURGE_RATIO_THRESHOLD", 1),
+			Sensitivity:    getEnvAsFloat("SURGE_SENSITIVITY", 0.5),
+			MaxMultiplier:  getEnvAsFloat("SURGE_MAX_MULTIPLIER", 2),
+		},
+		Shifts: ShiftConfig{
+			Enabled:      getEnvAsBool("SHIFTS_ENABLED", true),
+			Interval:     getEnvAsInterval("SHIFT_CHECK_INTERVAL_SECONDS", 60),
+			EarlyStart:   getEnvAsInt("SHIFT_EARLY_START_MINUTES", 15),
+			AutoEndGrace: getEnvAsInt("SHIFT_AUTO_END_GRACE_MINUTES", 30),
+		},
+		Batching: BatchingConfig{
+			DefaultCapacity: getEnvAsInt("COURIER_DEFAULT_CAPACITY", 3),
+			PickupRadius:    getEnvAsInt("BATCH_PICKUP_RADIUS_M", 500),
+			DeliveryRadius:  getEnvAsInt("BATCH_DELIVERY_RADIUS_M", 2000),
+		},
+		Cancellation: CancellationConfig{
+			PreparingFeePercent:  getEnvAsFloat("CANCELLATION_FEE_PREPARING_PERCENT", 30),
+			ReadyFeePercent:      getEnvAsFloat("CANCELLATION_FEE_READY_PERCENT", 50),
+			InDeliveryFeePercent: getEnvAsFloat("CANCELLATION_FEE_IN_DELIVERY_PERCENT", 100),
+		},
+		BlobStore: BlobStoreConfig{
+			Provider: getEnv("BLOB_STORE_PROVIDER", "local"),
+			LocalDir: getEnv("BLOB_STORE_DIR", "./data/blobs"),
+		},
+		DeliveryProof: DeliveryProofConfig{
+			LocationTolerance: getEnvAsInt("DELIVERY_PROOF_TOLERANCE_M", 150),
+			MaxPinAttempts:    getEnvAsInt("DELIVERY_PIN_MAX_ATTEMPTS", 5),
+			MaxUploadSize:     getEnvAsInt("DELIVERY_PROOF_MAX_UPLOAD_MB", 10),
+		},
+		ETA: ETAConfig{
+			HistoryDays:         getEnvAsInt("ETA_HISTORY_DAYS", 14),
+			DefaultStageMinutes: getEnvAsInt("ETA_DEFAULT_STAGE_MINUTES", 10),
+			CourierSpeed:        getEnvAsFloat("ETA_COURIER_SPEED_KMH", 15),
+			LocationRefresh:     getEnvAsInt("ETA_LOCATION_REFRESH_SECONDS", 30),
+		},
+		SLA: SLAConfig{
+			Enabled:        getEnvAsBool("SLA_ENABLED", true),
+			Interval:       getEnvAsInterval("SLA_CHECK_INTERVAL_SECONDS", 60),
+			DefaultMinutes: getEnvAsInt("SLA_DEFAULT_MINUTES", 60),
+			AtRiskMinutes:  getEnvAsInt("SLA_AT_RISK_MINUTES", 10),
+		},
+		Scheduling: SchedulingConfig{
+			Enabled:      getEnvAsBool("SCHEDULER_ENABLED", true),
+			Interval:     getEnvAsInterval("SCHEDULER_INTERVAL_SECONDS", 30),
+			LeadBuffer:   getEnvAsInt("SCHEDULED_LEAD_BUFFER_MINUTES", 10),
+			MaxDaysAhead: getEnvAsInt("SCHEDULED_MAX_DAYS_AHEAD", 7),
+		},
+		Preparation: PreparationConfig{
+			Enabled:        getEnvAsBool("MERCHANT_DISPATCH_ENABLED", true),
+			Interval:       getEnvAsInterval("MERCHANT_DISPATCH_INTERVAL_SECONDS", 30),
+			CourierLead:    getEnvAsInt("MERCHANT_COURIER_LEAD_MINUTES", 10),
+			MaxPrepMinutes: getEnvAsInt("MERCHANT_MAX_PREP_MINUTES", 180),
+		},
+		Auth: AuthConfig{
+			Enabled:          getEnvAsBool("AUTH_ENABLED", true),
+			Algorithm:        getEnv("AUTH_JWT_ALGORITHM", "HS256"),
+			Secret:           getEnv("AUTH_JWT_SECRET", ""),
+			PublicKeyFile:    getEnv("AUTH_JWT_PUBLIC_KEY_FILE", ""),
+			PrivateKeyFile:   getEnv("AUTH_JWT_PRIVATE_KEY_FILE", ""),
+			Issuer:           getEnv("AUTH_JWT_ISSUER", "delivery-system"),
+			Audience:         getEnv("AUTH_JWT_AUDIENCE", ""),
+			RoleClaim:        getEnv("AUTH_ROLE_CLAIM", "role"),
+			TokenTTL:         getEnvAsInt("AUTH_TOKEN_TTL_MINUTES", 60),
+			DevTokensEnabled: getEnvAsBool("AUTH_DEV_TOKENS_ENABLED", false),
+		},
+		APIKeys: APIKeyConfig{
+			RotationGrace:      getEnvAsInt("API_KEY_ROTATION_GRACE_MINUTES", 60),
+			UsageRetentionDays: getEnvAsInt("API_KEY_USAGE_RETENTION_DAYS", 90),
 		},
 	}
 }
@@ -145,3 +476,41 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvAsInterval получает период фоновой задачи как положительный int.
+// Нулевое или отрицательное значение заменяется значением по умолчанию: тикер с таким периодом не создать
+func getEnvAsInterval(key string, defaultValue int) int {
+	if value := getEnvAsInt(key, defaultValue); value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvAsFloat получает значение переменной окружения как float64 с значением по умолчанию
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseFloat(valueStr, 64); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvAsBool получает значение переменной окружения как bool с значением по умолчанию
+func getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := getEnv(key, "")
+	if value, err := strconv.ParseBool(valueStr); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+// getEnvAsSlice получает значение переменной окружения как список через запятую без пустых элементов
+func getEnvAsSlice(key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"delivery-system/internal/models"
+)
+
+// Сегменты шаблона пути правила доступа
+const (
+	segmentAny   = "*"
+	segmentSelf  = "{self}"
+	segmentOrder = "{order}"
+)
+
+// AccessRule описывает, каким ролям разрешён метод маршрута. Path - шаблон пути по сегментам: "*" - любой
+// сегмент, "{self}" - идентификатор самого инициатора (курьера, клиента или продавца), "{order}" - заказ,
+// в котором участвует инициатор. Roles разрешён доступ к любому ресурсу маршрута, Owners - только к своему.
+// Администратору разрешены все маршруты
+type AccessRule struct {
+	Method string
+	Path   string
+	Roles  []models.Role
+	Owners []models.Role
+}
+
+// Наборы ролей для правил доступа
+var (
+	staffRoles = []models.Role{models.RoleDispatcher}
+	allRoles   = []models.Role{models.RoleDispatcher, models.RoleCourier, models.RoleCustomer, models.RoleMerchant}
+)
+
+// DefaultAccessPolicy - правила доступа к API. Правила проверяются по порядку, применяется первое
+// подходящее; маршрут без правила доступен только администратору
+var DefaultAccessPolicy = []AccessRule{
+	// Заказы
+	{Method: http.MethodGet, Path: "/api/orders", Roles: staffRoles},
+	{Method: http.MethodPost, Path: "/api/orders", Roles: []models.Role{models.RoleDispatcher, models.RoleCustomer}},
+	{Method: http.MethodGet, Path: "/api/orders/batches", Roles: staffRoles},
+	{Method: http.MethodGet, Path: "/api/orders/at-risk", Roles: staffRoles},
+	{Method: http.MethodGet, Path: "/api/orders/{order}", Roles: staffRoles,
+		Owners: []models.Role{models.RoleCourier, models.RoleCustomer, models.RoleMerchant}},
+	{Method: http.MethodPut, Path: "/api/orders/{order}/status", Roles: staffRoles,
+		Owners: []models.Role{models.RoleCourier}},
+	{Method: http.MethodPost, Path: "/api/orders/{order}/cancel", Roles: staffRoles,
+		Owners: []models.Role{models.RoleCustomer, models.RoleMerchant}},
+	{Method: http.MethodGet, Path: "/api/orders/{order}/cancellation", Roles: staffRoles,
+		Owners: []models.Role{models.RoleCustomer, models.RoleMerchant}},
+	{Method: http.MethodPost, Path: "/api/orders/{order}/accept", Roles: staffRoles, Owners: []models.Role{models.RoleMerchant}},
+	{Method: http.MethodPost, Path: "/api/orders/{order}/reject", Roles: staffRoles, Owners: []models.Role{models.RoleMerchant}},
+	{Method: http.MethodPost, Path: "/api/orders/{order}/preparing", Roles: staffRoles, Owners: []models.Role{models.RoleMerchant}},
+	{Method: http.MethodPost, Path: "/api/orders/{order}/ready", Roles: staffRoles, Owners: []models.Role{models.RoleMerchant}},
+	{Method: http.MethodPost, Path: "/api/orders/{order}/review", Owners: []models.Role{models.RoleCustomer}},
+	{Method: http.MethodPost, Path: "/api/orders/*/auto-assign", Roles: staffRoles},
+	{Method: http.MethodGet, Path: "/api/orders/*/events", Roles: staffRoles},
+	{Method: http.MethodGet, Path: "/api/orders/*/replay", Roles: staffRoles},
+	{Method: http.MethodGet, Path: "/api/orders/{order}/courier/stream", Roles: staffRoles,
+		Owners: []models.Role{models.RoleCustomer, models.RoleMerchant}},
+	{Method: http.MethodPost, Path: "/api/orders/{order}/delivery-proof", Owners: []models.Role{models.RoleCourier}},
+	{Method: http.MethodGet, Path: "/api/orders/{order}/delivery-proof", Roles: staffRoles,
+		Owners: []models.Role{models.RoleCourier, models.RoleCustomer}},
+	{Method: http.MethodGet, Path: "/api/orders/{order}/delivery-proof/*", Roles: staffRoles,
+		Owners: []models.Role{models.RoleCourier, models.RoleCustomer}},
+
+	// Клиенты
+	{Method: http.MethodGet, Path: "/api/customers", Roles: staffRoles},
+	{Method: http.MethodPost, Path: "/api/customers", Roles: staffRoles},
+	{Method: http.MethodGet, Path: "/api/customers/{self}", Roles: staffRoles, Owners: []models.Role{models.RoleCustomer}},
+	{Method: http.MethodPut, Path: "/api/customers/{self}", Roles: staffRoles, Owners: []models.Role{models.RoleCustomer}},
+	{Method: http.MethodGet, Path: "/api/customers/{self}/orders", Roles: staffRoles, Owners: []models.Role{models.RoleCustomer}},
+	{Method: http.MethodPost, Path: "/api/customers/{self}/addresses", Roles: staffRoles, Owners: []models.Role{models.RoleCustomer}},
+	{Method: http.MethodDelete, Path: "/api/customers/{self}/addresses/*", Roles: staffRoles, Owners: []models.Role{models.RoleCustomer}},
+
+	// Продавцы и каталоги
+	{Method: http.MethodGet, Path: "/api/merchants", Roles: allRoles},
+	{Method: http.MethodGet, Path: "/api/merchants/*", Roles: allRoles},
+	{Method: http.MethodPut, Path: "/api/merchants/{self}", Owners: []models.Role{models.RoleMerchant}},
+	{Method: http.MethodGet, Path: "/api/merchants/*/products", Roles: allRoles},
+	{Method: http.MethodPost, Path: "/api/merchants/{self}/products", Owners: []models.Role{models.RoleMerchant}},
+	{Method: http.MethodPut, Path: "/api/merchants/{self}/products/*", Owners: []models.Role{models.RoleMerchant}},
+	{Method: http.MethodDelete, Path: "/api/merchants/{self}/products/*", Owners: []models.Role{models.RoleMerchant}},
+
+	// Курьеры. Создание курьеров доступно только администратору
+	{Method: http.MethodGet, Path: "/api/couriers", Roles: staffRoles},
+	{Method: http.MethodGet, Path: "/api/couriers/available", Roles: staffRoles},
+	{Method: http.MethodGet, Path: "/api/couriers/nearby", Roles: staffRoles},
+	{Method: http.MethodGet, Path: "/api/couriers/{self}", Roles: staffRoles, Owners: []models.Role{models.RoleCourier}},
+	{Method: http.MethodPut, Path: "/api/couriers/{self}/status", Roles: staffRoles, Owners: []models.Role{models.RoleCourier}},
+	{Method: http.MethodPost, Path: "/api/couriers/*/assign", Roles: staffRoles},
+	{Method: http.MethodPut, Path: "/api/couriers/*/zone", Roles: staffRoles},
+	{Method: http.MethodPut, Path: "/api/couriers/*/capacity", Roles: staffRoles},
+	{Method: http.MethodPost, Path: "/api/couriers/*/batch", Roles: staffRoles},
+	{Method: http.MethodGet, Path: "/api/couriers/{self}/route", Roles: staffRoles, Owners: []models.Role{models.RoleCourier}},
+	{Method: http.MethodGet, Path: "/api/couriers/{self}/reviews", Roles: staffRoles, Owners: []models.Role{models.RoleCourier}},
+	{Method: http.MethodPost, Path: "/api/couriers/{self}/location", Owners: []models.Role{models.RoleCourier}},
+	{Method: http.MethodGet, Path: "/api/couriers/{self}/track", Roles: staffRoles, Owners: []models.Role{models.RoleCourier}},
+
+	// Смены курьеров
+	{Method: http.MethodGet, Path: "/api/couriers/{self}/shifts", Roles: staffRoles, Owners: []models.Role{models.RoleCourier}},
+	{Method: http.MethodPost, Path: "/api/couriers/{self}/shifts", Roles: staffRoles, Owners: []models.Role{models.RoleCourier}},
+	{Method: http.MethodPost, Path: "/api/couriers/{self}/shifts/start", Roles: staffRoles, Owners: []models.Role{models.RoleCourier}},
+	{Method: http.MethodPost, Path: "/api/couriers/{self}/shifts/end", Roles: staffRoles, Owners: []models.Role{models.RoleCourier}},
+	{Method: http.MethodPost, Path: "/api/couriers/{self}/shifts/break/start", Roles: staffRoles, Owners: []models.Role{models.RoleCourier}},
+	{Method: http.MethodPost, Path: "/api/couriers/{self}/shifts/break/end", Roles: staffRoles, Owners: []models.Role{models.RoleCourier}},
+	{Method: http.MethodGet, Path: "/api/shifts/*", Roles: staffRoles},
+	{Method: http.MethodDelete, Path: "/api/shifts/*", Roles: staffRoles},
+
+	// Справочники: изменение промокодов, тарифов и зон доступно только администратору
+	{Method: http.MethodGet, Path: "/api/promo-codes", Roles: staffRoles},
+	{Method: http.MethodGet, Path: "/api/promo-codes/*", Roles: staffRoles},
+	{Method: http.MethodGet, Path: "/api/tariffs", Roles: staffRoles},
+	{Method: http.MethodGet, Path: "/api/tariffs/*", Roles: staffRoles},
+	{Method: http.MethodPost, Path: "/api/delivery/quote", Roles: allRoles},
+	{Method: http.MethodGet, Path: "/api/delivery/surge", Roles: staffRoles},
+	{Method: http.MethodGet, Path: "/api/zones", Roles: staffRoles},
+	{Method: http.MethodGet, Path: "/api/zones/*", Roles: staffRoles},
+
+	// API-ключи: выпуск, ротация и отзыв доступны только администратору. Владельцу ключа
+	// его данные и статистика отдаются обработчиком
+	{Method: http.MethodGet, Path: "/api/api-keys", Roles: allRoles},
+	{Method: http.MethodGet, Path: "/api/api-keys/*", Roles: allRoles},
+	{Method: http.MethodGet, Path: "/api/api-keys/*/usage", Roles: allRoles},
+
+	// Оставшийся лимит запросов - свой для каждого клиента
+	{Method: http.MethodGet, Path: "/api/rate-limit/status", Roles: allRoles},
+}
+
+// matchAccessRule находит первое правило для метода и пути запроса. Возвращает значения сегментов
+// {self} и {order} найденного правила
+func matchAccessRule(policy []AccessRule, method, path string) (*AccessRule, map[string]string) {
+	segments := splitPath(path)
+	for i := range policy {
+		rule := &policy[i]
+		if rule.Method != method {
+			continue
+		}
+		if params, ok := matchPath(splitPath(rule.Path), segments); ok {
+			return rule, params
+		}
+	}
+	return nil, nil
+}
+
+// matchPath сопоставляет сегменты пути с сегментами шаблона
+func matchPath(pattern, segments []string) (map[string]string, bool) {
+	if len(pattern) != len(segments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, part := range pattern {
+		switch part {
+		case segmentAny:
+		case segmentSelf, segmentOrder:
+			params[part] = segments[i]
+		default:
+			if part != segments[i] {
+				return nil, false
+			}
+		}
+	}
+	return params, true
+}
+
+// apiKeyScope возвращает область действия API-ключа, необходимую для запроса: "<ресурс>:read" для GET
+// и "<ресурс>:write" для остальных методов. Ресурс - первый сегмент пути после /api
+func apiKeyScope(method, path string) string {
+	resource := ""
+	if segments := splitPath(path); len(segments) > 1 {
+		resource = segments[1]
+	}
+
+	access := models.APIKeyAccessWrite
+	if method == http.MethodGet || method == http.MethodHead {
+		access = models.APIKeyAccessRead
+	}
+	return resource + ":" + access
+}
+
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
+
+func hasRole(roles []models.Role, role models.Role) bool {
+	for _, allowed := range roles {
+		if allowed == role {
+			return true
+		}
+	}
+	return false
+}
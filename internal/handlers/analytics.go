@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/services"
+)
+
+const (
+	// defaultAnalyticsRange - период отчёта по умолчанию, если не указан параметр from
+	defaultAnalyticsRange = 30 * 24 * time.Hour
+	// defaultTopItemsLimit - количество товаров в отчёте по умолчанию
+	defaultTopItemsLimit = 10
+	// analyticsDateLayout - формат даты без времени в параметрах from/to
+	analyticsDateLayout = "2006-01-02"
+	formatJSON          = "json"
+	formatCSV           = "csv"
+)
+
+// AnalyticsHandler представляет обработчик аналитических отчётов
+type AnalyticsHandler struct {
+	analyticsService services.AnalyticsServiceInterface
+	log              *logger.Logger
+}
+
+// NewAnalyticsHandler создает новый обработчик аналитики
+func NewAnalyticsHandler(analyticsService services.AnalyticsServiceInterface, log *logger.Logger) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+		log:              log,
+	}
+}
+
+// GetSummary возвращает сводные метрики по заказам с группировкой по периодам
+func (h *AnalyticsHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	filter, format, err := parseAnalyticsRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.analyticsService.GetSummary(filter)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to build summary report")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to build summary report")
+		return
+	}
+
+	if format == formatCSV {
+		WriteCSVResponse(w, "summary.csv", summaryCSVRecords(report))
+		return
+	}
+	WriteJSONResponse(w, http.StatusOK, report)
+}
+
+// GetTopItems возвращает самые популярные товары за период
+func (h *AnalyticsHandler) GetTopItems(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	filter, format, err := parseAnalyticsRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter.Limit = defaultTopItemsLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			filter.Limit = l
+		}
+	}
+
+	report, err := h.analyticsService.GetTopItems(filter)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to build top items report")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to build top items report")
+		return
+	}
+
+	if format == formatCSV {
+		WriteCSVResponse(w, "top_items.csv", topItemsCSVRecords(report))
+		return
+	}
+	WriteJSONResponse(w, http.StatusOK, report)
+}
+
+// GetCourierReport возвращает отчёт по доставкам, рейтингу и заработку курьеров
+func (h *AnalyticsHandler) GetCourierReport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	filter, format, err := parseAnalyticsRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.analyticsService.GetCourierReport(filter)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to build courier report")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to build courier report")
+		return
+	}
+
+	if format == formatCSV {
+		WriteCSVResponse(w, "couriers.csv", courierCSVRecords(report))
+		return
+	}
+	WriteJSONResponse(w, http.StatusOK, report)
+}
+
+// parseAnalyticsRequest разбирает параметры from, to, group и format.
+// Дата без времени в параметре to включает весь указанный день.
+// По умолчанию отчёт строится за последние 30 дней по дням в формате JSON
+func parseAnalyticsRequest(r *http.Request) (models.AnalyticsFilter, string, error) {
+	query := r.URL.Query()
+
+	// Граница по умолчанию выравнивается по суткам, чтобы повторные запросы попадали в кеш
+	filter := models.AnalyticsFilter{
+		To:      time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour),
+		GroupBy: models.AnalyticsGroupByDay,
+	}
+
+	if toStr := query.Get("to"); toStr != "" {
+		to, dateOnly, err := parseAnalyticsTime(toStr)
+		if err != nil {
+			return filter, "", fmt.Errorf("invalid to parameter")
+		}
+		if dateOnly {
+			to = to.Add(24 * time.Hour)
+		}
+		filter.To = to
+	}
+
+	filter.From = filter.To.Add(-defaultAnalyticsRange)
+	if fromStr := query.Get("from"); fromStr != "" {
+		from, _, err := parseAnalyticsTime(fromStr)
+		if err != nil {
+			return filter, "", fmt.Errorf("invalid from parameter")
+		}
+		filter.From = from
+	}
+
+	if !filter.From.Before(filter.To) {
+		return filter, "", fmt.Errorf("from must be before to")
+	}
+
+	if groupStr := query.Get("group"); groupStr != "" {
+		filter.GroupBy = models.AnalyticsGroupBy(groupStr)
+		if !filter.GroupBy.IsValid() {
+			return filter, "", fmt.Errorf("invalid group parameter")
+		}
+	}
+
+	format := formatJSON
+	if formatStr := query.Get("format"); formatStr != "" {
+		if formatStr != formatJSON && formatStr != formatCSV {
+			return filter, "", fmt.Errorf("invalid format parameter")
+		}
+		format = formatStr
+	}
+
+	return filter, format, nil
+}
+
+// parseAnalyticsTime разбирает дату в формате RFC3339 или YYYY-MM-DD (UTC)
+func parseAnalyticsTime(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	t, err := time.Parse(analyticsDateLayout, value)
+	return t, true, err
+}
+
+func summaryCSVRecords(report *models.SummaryReport) [][]string {
+	records := [][]string{{
+		"period", "orders_count", "delivered_count", "cancelled_count", "revenue",
+		"discount_amount", "average_order_value", "avg_delivery_time_minutes", "cancellation_rate",
+	}}
+
+	// Итоговая строка выводится последней
+	rows := make([]models.SalesMetrics, 0, len(report.Periods)+1)
+	rows = append(rows, report.Periods...)
+	rows = append(rows, report.Totals)
+	for _, metrics := range rows {
+		period := "total"
+		if metrics.Period != nil {
+			period = metrics.Period.Format(analyticsDateLayout)
+		}
+		records = append(records, []string{
+			period,
+			strconv.Itoa(metrics.OrdersCount),
+			strconv.Itoa(metrics.DeliveredCount),
+			strconv.Itoa(metrics.CancelledCount),
+			formatAmount(metrics.Revenue),
+			formatAmount(metrics.DiscountAmount),
+			formatAmount(metrics.AverageOrderValue),
+			formatAmount(metrics.AvgDeliveryTimeMinutes),
+			strconv.FormatFloat(metrics.CancellationRate, 'f', 4, 64),
+		})
+	}
+	return records
+}
+
+func topItemsCSVRecords(report *models.TopItemsReport) [][]string {
+	records := [][]string{{"name", "quantity", "revenue", "orders_count"}}
+	for _, item := range report.Items {
+		records = append(records, []string{
+			item.Name,
+			strconv.Itoa(item.Quantity),
+			formatAmount(item.Revenue),
+			strconv.Itoa(item.OrdersCount),
+		})
+	}
+	return records
+}
+
+func courierCSVRecords(report *models.CourierReport) [][]string {
+	records := [][]string{{
+		"courier_id", "name", "deliveries", "earnings", "avg_delivery_time_minutes", "avg_rating", "reviews_count",
+	}}
+	for _, courier := range report.Couriers {
+		rating := ""
+		if courier.AvgRating != nil {
+			rating = formatAmount(*courier.AvgRating)
+		}
+		records = append(records, []string{
+			courier.CourierID.String(),
+			courier.Name,
+			strconv.Itoa(courier.Deliveries),
+			formatAmount(courier.Earnings),
+			formatAmount(courier.AvgDeliveryTimeMinutes),
+			rating,
+			strconv.Itoa(courier.ReviewsCount),
+		})
+	}
+	return records
+}
+
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
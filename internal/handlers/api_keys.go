@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/services"
+)
+
+// apiKeyPathPrefix - префикс путей эндпоинтов отдельного API-ключа
+const apiKeyPathPrefix = "/api/api-keys/"
+
+// defaultAPIKeyUsageDays - период статистики запросов по ключу по умолчанию в сутках
+const defaultAPIKeyUsageDays = 7
+
+// APIKeyHandler представляет обработчик API-ключей машинных клиентов
+type APIKeyHandler struct {
+	apiKeyService services.APIKeyServiceInterface
+	log           *logger.Logger
+	maxUsageDays  int
+}
+
+// NewAPIKeyHandler создает новый обработчик API-ключей. maxUsageDays - сколько суток хранится
+// статистика запросов по ключу
+func NewAPIKeyHandler(apiKeyService services.APIKeyServiceInterface, log *logger.Logger, maxUsageDays int) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		log:           log,
+		maxUsageDays:  maxUsageDays,
+	}
+}
+
+// IssueAPIKey выпускает новый API-ключ. Ключ возвращается только в этом ответе
+func (h *APIKeyHandler) IssueAPIKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req models.APIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.validateAPIKeyRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	apiKey, err := h.apiKeyService.IssueAPIKey(&req)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to issue api key")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to issue API key")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusCreated, apiKey)
+}
+
+// GetAPIKeys получает список API-ключей. Администратор видит все ключи и может отфильтровать их
+// по владельцу параметрами owner_role и owner_id, остальные - только свои ключи
+func (h *APIKeyHandler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	actor, err := ActorFromRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	owner := &actor
+	if actor.Role == models.RoleAdmin {
+		owner = nil
+		query := r.URL.Query()
+		if role := models.Role(query.Get("owner_role")); role != "" {
+			if !role.IsExternal() {
+				WriteErrorResponse(w, http.StatusBadRequest, "Invalid owner_role parameter")
+				return
+			}
+			owner = &models.Actor{Role: role, ID: query.Get("owner_id")}
+		}
+	}
+
+	apiKeys, err := h.apiKeyService.GetAPIKeys(owner)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get api keys")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get API keys")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, apiKeys)
+}
+
+// GetAPIKey получает API-ключ по ID. Ключ доступен администратору и своему владельцу
+func (h *APIKeyHandler) GetAPIKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	apiKey, ok := h.ownedAPIKey(w, r)
+	if !ok {
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, apiKey)
+}
+
+// RotateAPIKey перевыпускает API-ключ. Прежний ключ ещё некоторое время принимается
+func (h *APIKeyHandler) RotateAPIKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	keyID, err := ExtractUUIDFromPath(r.URL.Path, apiKeyPathPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid API key ID")
+		return
+	}
+
+	apiKey, err := h.apiKeyService.RotateAPIKey(keyID)
+	if err != nil {
+		if errors.Is(err, services.ErrAPIKeyInactive) {
+			WriteErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "API key not found")
+		} else {
+			h.log.WithError(err).Error("Failed to rotate api key")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to rotate API key")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, apiKey)
+}
+
+// RevokeAPIKey отзывает API-ключ
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	keyID, err := ExtractUUIDFromPath(r.URL.Path, apiKeyPathPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid API key ID")
+		return
+	}
+
+	if err := h.apiKeyService.RevokeAPIKey(keyID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "API key not found")
+		} else {
+			h.log.WithError(err).Error("Failed to revoke api key")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to revoke API key")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetUsage возвращает количество запросов по API-ключу по суткам за последние days суток
+// (по умолчанию 7). Статистика доступна администратору и владельцу ключа
+func (h *APIKeyHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	days := defaultAPIKeyUsageDays
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 || parsed > h.maxUsageDays {
+			WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("days must be between 1 and %d", h.maxUsageDays))
+			return
+		}
+		days = parsed
+	}
+
+	apiKey, ok := h.ownedAPIKey(w, r)
+	if !ok {
+		return
+	}
+
+	usage, err := h.apiKeyService.GetUsage(apiKey.ID, days)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get api key usage")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get API key usage")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, usage)
+}
+
+// ownedAPIKey получает ключ из пути запроса и проверяет, что инициатор - администратор или владелец ключа.
+// Ключ без идентификатора владельца доступен всем пользователям с ролью владельца
+func (h *APIKeyHandler) ownedAPIKey(w http.ResponseWriter, r *http.Request) (*models.APIKey, bool) {
+	keyID, err := ExtractUUIDFromPath(r.URL.Path, apiKeyPathPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid API key ID")
+		return nil, false
+	}
+
+	actor, err := ActorFromRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+
+	apiKey, err := h.apiKeyService.GetAPIKey(keyID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "API key not found")
+		} else {
+			h.log.WithError(err).Error("Failed to get api key")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get API key")
+		}
+		return nil, false
+	}
+
+	if actor.Role != models.RoleAdmin && actor != apiKey.Owner {
+		WriteErrorResponse(w, http.StatusForbidden, "Access denied")
+		return nil, false
+	}
+	return apiKey, true
+}
+
+// validateAPIKeyRequest валидирует запрос выпуска API-ключа
+func (h *APIKeyHandler) validateAPIKeyRequest(req *models.APIKeyRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return fmt.Errorf("api key name is required")
+	}
+	if len(req.Name) > 255 {
+		return fmt.Errorf("api key name must not exceed 255 characters")
+	}
+
+	if len(req.Scopes) == 0 {
+		return fmt.Errorf("at least one scope is required")
+	}
+	for _, scope := range req.Scopes {
+		if !models.IsValidAPIKeyScope(scope) {
+			return fmt.Errorf("unknown scope: %s", scope)
+		}
+	}
+
+	if !req.OwnerRole.IsExternal() {
+		return fmt.Errorf("unknown owner role: %s", req.OwnerRole)
+	}
+	req.OwnerID = strings.TrimSpace(req.OwnerID)
+	if req.OwnerID == "" && roleRequiresID(req.OwnerRole) {
+		return fmt.Errorf("owner_id is required for role %s", req.OwnerRole)
+	}
+	if req.RateLimitTier != "" && !req.RateLimitTier.IsValid() {
+		return fmt.Errorf("unknown rate limit tier: %s", req.RateLimitTier)
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("expires_at must be in the future")
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/services"
+
+	"github.com/google/uuid"
+)
+
+// Заголовки аутентификации
+const (
+	headerAuthorization   = "Authorization"
+	headerWWWAuthenticate = "WWW-Authenticate"
+	bearerPrefix          = "Bearer "
+	queryAccessToken      = "access_token"
+)
+
+// maxDevTokenTTL - максимальный срок действия токена разработки в минутах
+const maxDevTokenTTL = 7 * 24 * 60
+
+// actorContextKey - ключ контекста запроса, под которым хранится аутентифицированный инициатор
+type actorContextKey struct{}
+
+// apiKeyContextKey - ключ контекста запроса, под которым хранится API-ключ, которым аутентифицирован запрос
+type apiKeyContextKey struct{}
+
+// AuthHandler представляет middleware аутентификации по JWT и проверки прав доступа к маршрутам,
+// а также эндпоинт выпуска токенов для локальной разработки
+type AuthHandler struct {
+	authService   services.AuthServiceInterface
+	apiKeyService services.APIKeyServiceInterface
+	orderService  services.OrderServiceInterface
+	policy        []AccessRule
+	log           *logger.Logger
+}
+
+// NewAuthHandler создает новый обработчик аутентификации с правилами доступа policy
+func NewAuthHandler(
+	authService services.AuthServiceInterface,
+	apiKeyService services.APIKeyServiceInterface,
+	orderService services.OrderServiceInterface,
+	policy []AccessRule,
+	log *logger.Logger,
+) *AuthHandler {
+	return &AuthHandler{
+		authService:   authService,
+		apiKeyService: apiKeyService,
+		orderService:  orderService,
+		policy:        policy,
+		log:           log,
+	}
+}
+
+// Middleware аутентифицирует запрос по токену из заголовка Authorization или, для машинных клиентов,
+// по API-ключу из заголовка X-API-Key и проверяет, что роль инициатора имеет доступ к маршруту.
+// Отсутствующий или неверный токен отклоняется с 401, недостаточные права - с 403. Инициатор передаётся
+// обработчику в контексте запроса, заголовки X-Actor-Role и X-Actor-ID не учитываются
+func (h *AuthHandler) Middleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var actor models.Actor
+		var apiKey *models.APIKey
+		var ok bool
+		if key := r.Header.Get(headerAPIKey); key != "" && r.Header.Get(headerAuthorization) == "" {
+			if apiKey, ok = h.authenticateAPIKey(w, r, key); ok {
+				actor = apiKey.Owner
+			}
+		} else {
+			actor, ok = h.authenticateToken(w, r)
+		}
+		if !ok {
+			return
+		}
+
+		allowed, err := h.authorize(r, actor)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				WriteErrorResponse(w, http.StatusNotFound, "Order not found")
+			} else {
+				h.log.WithError(err).Error("Failed to authorize request")
+				WriteErrorResponse(w, http.StatusInternalServerError, "Failed to authorize request")
+			}
+			return
+		}
+		if !allowed {
+			h.log.WithFields(map[string]interface{}{
+				"actor":  actor.String(),
+				"method": r.Method,
+				"path":   r.URL.Path,
+			}).Warn("Access denied")
+			WriteErrorResponse(w, http.StatusForbidden, "Access denied")
+			return
+		}
+
+		ctx := ContextWithActor(r.Context(), actor)
+		if apiKey != nil {
+			ctx = ContextWithAPIKey(ctx, apiKey)
+		}
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// ContextWithActor возвращает контекст с аутентифицированным инициатором запроса
+func ContextWithActor(ctx context.Context, actor models.Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ContextWithAPIKey возвращает контекст с API-ключом, которым аутентифицирован запрос
+func ContextWithAPIKey(ctx context.Context, apiKey *models.APIKey) context.Context {
+	return context.WithValue(ctx, apiKeyContextKey{}, apiKey)
+}
+
+// IssueToken выпускает токен для локальной разработки. Эндпоинт регистрируется, только если
+// выпуск токенов разработки включён в конфигурации
+func (h *AuthHandler) IssueToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req models.TokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if !req.Role.IsExternal() {
+		WriteErrorResponse(w, http.StatusBadRequest, "unknown role: "+string(req.Role))
+		return
+	}
+	if req.Subject == "" && roleRequiresID(req.Role) {
+		WriteErrorResponse(w, http.StatusBadRequest, "subject is required for role "+string(req.Role))
+		return
+	}
+	if req.TTLMinutes < 0 || req.TTLMinutes > maxDevTokenTTL {
+		WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("ttl_minutes must be between 1 and %d", maxDevTokenTTL))
+		return
+	}
+
+	token, err := h.authService.IssueToken(&req)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to issue token")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to issue token")
+		return
+	}
+
+	h.log.WithField("actor", token.Actor.String()).Info("Development token issued")
+	WriteJSONResponse(w, http.StatusCreated, token)
+}
+
+// authenticateToken определяет инициатора по JWT из заголовка Authorization. При ошибке отправляет ответ
+// и возвращает false
+func (h *AuthHandler) authenticateToken(w http.ResponseWriter, r *http.Request) (models.Actor, bool) {
+	header := r.Header.Get(headerAuthorization)
+	token := strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix))
+	// EventSource в браузере не передаёт заголовки, поэтому потоки событий принимают токен в параметре запроса
+	if header == "" && strings.HasSuffix(r.URL.Path, "/stream") {
+		header, token = bearerPrefix, r.URL.Query().Get(queryAccessToken)
+	}
+	if !strings.HasPrefix(header, bearerPrefix) || token == "" {
+		w.Header().Set(headerWWWAuthenticate, "Bearer")
+		WriteErrorResponse(w, http.StatusUnauthorized, "Authentication required")
+		return models.Actor{}, false
+	}
+
+	actor, err := h.authService.Authenticate(token)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidToken) {
+			w.Header().Set(headerWWWAuthenticate, `Bearer error="invalid_token"`)
+			WriteErrorResponse(w, http.StatusUnauthorized, "Invalid or expired token")
+		} else {
+			h.log.WithError(err).Error("Failed to authenticate request")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to authenticate request")
+		}
+		return models.Actor{}, false
+	}
+	return actor, true
+}
+
+// authenticateAPIKey находит действующий API-ключ: запрос выполняется от имени владельца ключа,
+// если область действия ключа разрешает запрос. При ошибке отправляет ответ и возвращает false
+func (h *AuthHandler) authenticateAPIKey(w http.ResponseWriter, r *http.Request, key string) (*models.APIKey, bool) {
+	apiKey, err := h.apiKeyService.Authenticate(key)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAPIKey) {
+			WriteErrorResponse(w, http.StatusUnauthorized, "Invalid, revoked or expired API key")
+		} else {
+			h.log.WithError(err).Error("Failed to authenticate api key")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to authenticate request")
+		}
+		return nil, false
+	}
+
+	if scope := apiKeyScope(r.Method, r.URL.Path); !apiKey.HasScope(scope) {
+		h.log.WithFields(map[string]interface{}{
+			"api_key_id": apiKey.ID,
+			"scope":      scope,
+		}).Warn("API key scope does not allow request")
+		WriteErrorResponse(w, http.StatusForbidden, "API key scope does not allow this request")
+		return nil, false
+	}
+	return apiKey, true
+}
+
+// authorize проверяет доступ инициатора к маршруту запроса по правилам доступа
+func (h *AuthHandler) authorize(r *http.Request, actor models.Actor) (bool, error) {
+	if actor.Role == models.RoleAdmin {
+		return true, nil
+	}
+
+	rule, params := matchAccessRule(h.policy, r.Method, r.URL.Path)
+	if rule == nil {
+		return false, nil
+	}
+	if hasRole(rule.Roles, actor.Role) {
+		return true, nil
+	}
+	if !hasRole(rule.Owners, actor.Role) || actor.ID == "" {
+		return false, nil
+	}
+
+	if self, ok := params[segmentSelf]; ok {
+		return self == actor.ID, nil
+	}
+	if orderID, ok := params[segmentOrder]; ok {
+		return h.participatesInOrder(orderID, actor)
+	}
+	return true, nil
+}
+
+// roleRequiresID проверяет, что пользователь с ролью role действует от имени конкретного курьера,
+// клиента или продавца и должен иметь идентификатор
+func roleRequiresID(role models.Role) bool {
+	return role == models.RoleCourier || role == models.RoleCustomer || role == models.RoleMerchant
+}
+
+// participatesInOrder проверяет, что инициатор - клиент, курьер или продавец заказа
+func (h *AuthHandler) participatesInOrder(orderIDStr string, actor models.Actor) (bool, error) {
+	orderID, err := uuid.Parse(orderIDStr)
+	if err != nil {
+		return false, nil
+	}
+
+	order, err := h.orderService.GetOrder(orderID)
+	if err != nil {
+		return false, err
+	}
+
+	var participant *uuid.UUID
+	switch actor.Role {
+	case models.RoleCustomer:
+		participant = order.CustomerID
+	case models.RoleCourier:
+		participant = order.CourierID
+	case models.RoleMerchant:
+		participant = order.MerchantID
+	}
+	return participant != nil && participant.String() == actor.ID, nil
+}
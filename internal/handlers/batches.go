@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/redis"
+	"delivery-system/internal/services"
+
+	"github.com/google/uuid"
+)
+
+// BatchHandler представляет обработчик пакетной доставки заказов
+type BatchHandler struct {
+	batchingService services.BatchingServiceInterface
+	redisClient     redis.RedisClientInterface
+	log             *logger.Logger
+}
+
+// NewBatchHandler создает новый обработчик пакетной доставки заказов
+func NewBatchHandler(
+	batchingService services.BatchingServiceInterface,
+	redisClient redis.RedisClientInterface,
+	log *logger.Logger,
+) *BatchHandler {
+	return &BatchHandler{
+		batchingService: batchingService,
+		redisClient:     redisClient,
+		log:             log,
+	}
+}
+
+// GetBatches возвращает предлагаемые пакеты ожидающих курьера заказов с маршрутами
+func (h *BatchHandler) GetBatches(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	batches, err := h.batchingService.GetBatches()
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get order batches")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get order batches")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, batches)
+}
+
+// AssignBatch назначает курьеру пакет заказов и возвращает его маршрут
+func (h *BatchHandler) AssignBatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	courierID, err := ExtractUUIDFromPath(r.URL.Path, apiCourierPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid courier ID")
+		return
+	}
+
+	var req models.AssignBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if len(req.OrderIDs) == 0 {
+		WriteErrorResponse(w, http.StatusBadRequest, "order_ids are required")
+		return
+	}
+	seen := make(map[uuid.UUID]struct{}, len(req.OrderIDs))
+	for _, orderID := range req.OrderIDs {
+		if orderID == uuid.Nil {
+			WriteErrorResponse(w, http.StatusBadRequest, "Invalid order ID")
+			return
+		}
+		if _, ok := seen[orderID]; ok {
+			WriteErrorResponse(w, http.StatusBadRequest, "order_ids must be unique")
+			return
+		}
+		seen[orderID] = struct{}{}
+	}
+
+	actor, err := ActorFromRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	route, err := h.batchingService.AssignBatch(courierID, req.OrderIDs, actor)
+	if err != nil {
+		if errors.Is(err, services.ErrOrderAlreadyAssigned) {
+			WriteErrorResponse(w, http.StatusConflict, err.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, err.Error())
+		} else if strings.Contains(err.Error(), "not available") {
+			WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		} else {
+			h.log.WithError(err).Error("Failed to assign order batch")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to assign order batch")
+		}
+		return
+	}
+
+	// Инвалидация кеша курьера и заказов
+	keys := []string{redis.GenerateKey(redis.KeyPrefixCourier, courierID.String())}
+	for _, orderID := range req.OrderIDs {
+		keys = append(keys, redis.GenerateKey(redis.KeyPrefixOrder, orderID.String()))
+	}
+	for _, key := range keys {
+		if err = h.redisClient.Delete(r.Context(), key); err != nil {
+			h.log.WithError(err).Error("Failed to invalidate cache")
+		}
+	}
+
+	WriteJSONResponse(w, http.StatusOK, route)
+}
+
+// GetCourierRoute возвращает маршрут курьера по его активным заказам
+func (h *BatchHandler) GetCourierRoute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	courierID, err := ExtractUUIDFromPath(r.URL.Path, apiCourierPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid courier ID")
+		return
+	}
+
+	route, err := h.batchingService.GetCourierRoute(courierID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Courier not found")
+		} else {
+			h.log.WithError(err).Error("Failed to get courier route")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get courier route")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, route)
+}
@@ -0,0 +1,364 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"delivery-system/internal/kafka"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/redis"
+	"delivery-system/internal/services"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultTrackPeriod - период трека, если параметр from не передан
+	defaultTrackPeriod = 24 * time.Hour
+	// locationStreamHeartbeat - интервал комментариев SSE, не дающих прокси закрыть соединение
+	locationStreamHeartbeat = 15 * time.Second
+	// maxLocationClockSkew - допустимое опережение часов устройства курьера
+	maxLocationClockSkew = time.Minute
+	// defaultNearbyLimit и maxNearbyLimit ограничивают количество курьеров в поиске поблизости
+	defaultNearbyLimit = 20
+	maxNearbyLimit     = 100
+)
+
+// CourierLocationHandler представляет обработчик местоположения курьеров
+type CourierLocationHandler struct {
+	locationService services.CourierLocationServiceInterface
+	orderService    services.OrderServiceInterface
+	producer        kafka.ProducerInterface
+	redisClient     redis.RedisClientInterface
+	log             *logger.Logger
+}
+
+// NewCourierLocationHandler создает новый обработчик местоположения курьеров
+func NewCourierLocationHandler(
+	locationService services.CourierLocationServiceInterface,
+	orderService services.OrderServiceInterface,
+	producer kafka.ProducerInterface,
+	redisClient redis.RedisClientInterface,
+	log *logger.Logger,
+) *CourierLocationHandler {
+	return &CourierLocationHandler{
+		locationService: locationService,
+		orderService:    orderService,
+		producer:        producer,
+		redisClient:     redisClient,
+		log:             log,
+	}
+}
+
+// UpdateCourierLocation принимает новую точку местоположения курьера
+func (h *CourierLocationHandler) UpdateCourierLocation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	courierID, err := ExtractUUIDFromPath(r.URL.Path, apiCourierPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid courier ID")
+		return
+	}
+
+	var req models.UpdateLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.validateUpdateLocationRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	location, err := h.locationService.UpdateLocation(courierID, &req)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Courier not found")
+		} else {
+			h.log.WithError(err).Error("Failed to update courier location")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to update courier location")
+		}
+		return
+	}
+
+	// Публикация события обновления местоположения
+	if err := h.producer.PublishLocationUpdated(courierID, location.Lat, location.Lon); err != nil {
+		h.log.WithError(err).Error("Failed to publish location updated event")
+	}
+
+	// Инвалидация кеша
+	cacheKey := redis.GenerateKey(redis.KeyPrefixCourier, courierID.String())
+	if err := h.redisClient.Delete(r.Context(), cacheKey); err != nil {
+		h.log.WithError(err).Error("Failed to invalidate courier cache")
+	}
+
+	WriteJSONResponse(w, http.StatusOK, location)
+}
+
+// GetCourierTrack возвращает трек курьера за период в формате GeoJSON.
+// Параметры from и to задаются в RFC3339, по умолчанию - последние 24 часа
+func (h *CourierLocationHandler) GetCourierTrack(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	courierID, err := ExtractUUIDFromPath(r.URL.Path, apiCourierPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid courier ID")
+		return
+	}
+
+	query := r.URL.Query()
+
+	to := time.Now()
+	if toStr := query.Get("to"); toStr != "" {
+		if to, err = time.Parse(time.RFC3339, toStr); err != nil {
+			WriteErrorResponse(w, http.StatusBadRequest, "Invalid to parameter, RFC3339 expected")
+			return
+		}
+	}
+
+	from := to.Add(-defaultTrackPeriod)
+	if fromStr := query.Get("from"); fromStr != "" {
+		if from, err = time.Parse(time.RFC3339, fromStr); err != nil {
+			WriteErrorResponse(w, http.StatusBadRequest, "Invalid from parameter, RFC3339 expected")
+			return
+		}
+	}
+
+	if !from.Before(to) {
+		WriteErrorResponse(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	track, err := h.locationService.GetTrack(courierID, from, to)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Courier not found")
+		} else {
+			h.log.WithError(err).Error("Failed to get courier track")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get courier track")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/geo+json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(track); err != nil {
+		h.log.WithError(err).Error("Failed to encode courier track")
+	}
+}
+
+// GetNearbyCouriers возвращает доступных курьеров рядом с точкой, отсортированных по расстоянию
+func (h *CourierLocationHandler) GetNearbyCouriers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+
+	lat, err := strconv.ParseFloat(query.Get("lat"), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		WriteErrorResponse(w, http.StatusBadRequest, "lat must be a number between -90 and 90")
+		return
+	}
+	lon, err := strconv.ParseFloat(query.Get("lon"), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		WriteErrorResponse(w, http.StatusBadRequest, "lon must be a number between -180 and 180")
+		return
+	}
+
+	var radius float64
+	if radiusStr := query.Get("radius_m"); radiusStr != "" {
+		if radius, err = strconv.ParseFloat(radiusStr, 64); err != nil || radius <= 0 {
+			WriteErrorResponse(w, http.StatusBadRequest, "radius_m must be a positive number")
+			return
+		}
+	}
+
+	limit := defaultNearbyLimit
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= maxNearbyLimit {
+			limit = l
+		}
+	}
+
+	couriers, err := h.locationService.GetNearbyCouriers(lat, lon, radius, limit)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get nearby couriers")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get nearby couriers")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, couriers)
+}
+
+// StreamOrderCourierLocation транслирует местоположение назначенного на заказ курьера
+// через Server-Sent Events. Первым событием отправляется последняя известная позиция.
+// Когда заказ доставлен или отменён, отправляется событие completed и поток закрывается.
+// Покупатель (X-Actor-Role: customer) с заданным X-Actor-ID может следить только за своим заказом
+func (h *CourierLocationHandler) StreamOrderCourierLocation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	orderID, err := ExtractUUIDFromPath(r.URL.Path, apiOrderPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	actor, err := ActorFromRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	order, err := h.orderService.GetOrder(orderID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Order not found")
+		} else {
+			h.log.WithError(err).Error("Failed to get order")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get order")
+		}
+		return
+	}
+
+	if actor.Role == models.RoleCustomer && (order.CustomerID == nil || actor.ID != order.CustomerID.String()) {
+		WriteErrorResponse(w, http.StatusForbidden, "Order belongs to another customer")
+		return
+	}
+
+	if order.Status.IsTerminal() {
+		WriteErrorResponse(w, http.StatusConflict, "Order is already completed")
+		return
+	}
+	if order.CourierID == nil {
+		WriteErrorResponse(w, http.StatusConflict, "No courier assigned to the order")
+		return
+	}
+	courierID := *order.CourierID
+
+	// Подписка оформляется до чтения последней позиции, чтобы не потерять обновления между ними
+	locations, err := h.locationService.Subscribe(r.Context(), courierID)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to subscribe to courier location")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to subscribe to courier location")
+		return
+	}
+
+	// Поток живёт дольше WriteTimeout сервера, поэтому дедлайн записи снимается
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		h.log.WithError(err).Debug("Failed to reset write deadline for location stream")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	if last, err := h.locationService.GetLastLocation(courierID); err == nil {
+		if err := writeLocationEvent(w, last); err != nil {
+			return
+		}
+	} else if !strings.Contains(err.Error(), "not found") {
+		h.log.WithError(err).Error("Failed to get last courier location")
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	h.log.WithField("order_id", orderID).WithField("courier_id", courierID).Debug("Courier location stream opened")
+
+	heartbeat := time.NewTicker(locationStreamHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			h.log.WithField("order_id", orderID).Debug("Courier location stream closed")
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case location, ok := <-locations:
+			if !ok {
+				return
+			}
+			if err := writeLocationEvent(w, location); err != nil {
+				return
+			}
+		}
+
+		// Статус заказа проверяется при каждом событии потока: после завершения заказа следить больше не за чем
+		if status, completed := h.completedOrderStatus(orderID); completed {
+			if _, err := fmt.Fprintf(w, "event: completed\ndata: {\"status\":%q}\n\n", status); err == nil {
+				rc.Flush()
+			}
+			h.log.WithField("order_id", orderID).WithField("status", status).Debug("Courier location stream completed")
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// completedOrderStatus возвращает статус заказа и признак того, что заказ завершён.
+// Ошибка чтения заказа не прерывает поток: статус будет проверен при следующем событии
+func (h *CourierLocationHandler) completedOrderStatus(orderID uuid.UUID) (models.OrderStatus, bool) {
+	order, err := h.orderService.GetOrder(orderID)
+	if err != nil {
+		h.log.WithError(err).WithField("order_id", orderID).Warn("Failed to check order status for location stream")
+		return "", false
+	}
+	return order.Status, order.Status.IsTerminal()
+}
+
+// validateUpdateLocationRequest валидирует запрос на обновление местоположения
+func (h *CourierLocationHandler) validateUpdateLocationRequest(req *models.UpdateLocationRequest) error {
+	if req.Lat < -90 || req.Lat > 90 {
+		return fmt.Errorf("lat must be between -90 and 90")
+	}
+	if req.Lon < -180 || req.Lon > 180 {
+		return fmt.Errorf("lon must be between -180 and 180")
+	}
+	if req.Accuracy != nil && *req.Accuracy < 0 {
+		return fmt.Errorf("accuracy must not be negative")
+	}
+	if req.Speed != nil && *req.Speed < 0 {
+		return fmt.Errorf("speed must not be negative")
+	}
+	if req.Heading != nil && (*req.Heading < 0 || *req.Heading >= 360) {
+		return fmt.Errorf("heading must be in range [0, 360)")
+	}
+	if req.RecordedAt != nil && req.RecordedAt.After(time.Now().Add(maxLocationClockSkew)) {
+		return fmt.Errorf("recorded_at must not be in the future")
+	}
+	return nil
+}
+
+// writeLocationEvent записывает местоположение курьера как SSE-событие location
+func writeLocationEvent(w http.ResponseWriter, location *models.CourierLocation) error {
+	data, err := json.Marshal(location)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: location\ndata: %s\n\n", data)
+	return err
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -64,6 +65,10 @@ func (h *CourierHandler) CreateCourier(w http.ResponseWriter, r *http.Request) {
 	// Создание курьера
 	courier, err := h.courierService.CreateCourier(&req)
 	if err != nil {
+		if strings.Contains(err.Error(), "zone not found") {
+			WriteErrorResponse(w, http.StatusUnprocessableEntity, "Delivery zone not found")
+			return
+		}
 		h.log.WithError(err).Error("Failed to create courier")
 		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to create courier")
 		return
@@ -144,6 +149,12 @@ func (h *CourierHandler) UpdateCourierStatus(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Статус busy выставляется автоматически, когда загрузка курьера достигает его вместимости
+	if req.Status != models.CourierStatusOffline && req.Status != models.CourierStatusAvailable {
+		WriteErrorResponse(w, http.StatusBadRequest, "status must be offline or available")
+		return
+	}
+
 	// Получение текущего курьера для определения старого статуса
 	currentCourier, err := h.courierService.GetCourier(courierID)
 	if err != nil {
@@ -159,6 +170,11 @@ func (h *CourierHandler) UpdateCourierStatus(w http.ResponseWriter, r *http.Requ
 
 	// Обновление статуса
 	if err := h.courierService.UpdateCourierStatus(courierID, &req); err != nil {
+		var shiftErr *services.ShiftError
+		if errors.As(err, &shiftErr) {
+			WriteErrorResponse(w, http.StatusConflict, shiftErr.Error())
+			return
+		}
 		if strings.Contains(err.Error(), "not found") {
 			WriteErrorResponse(w, http.StatusNotFound, "Courier not found")
 		} else {
@@ -190,6 +206,98 @@ func (h *CourierHandler) UpdateCourierStatus(w http.ResponseWriter, r *http.Requ
 	WriteJSONResponse(w, http.StatusOK, map[string]string{"message": "Courier status updated successfully"})
 }
 
+// UpdateCourierZone привязывает курьера к зоне доставки
+func (h *CourierHandler) UpdateCourierZone(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	courierID, err := ExtractUUIDFromPath(r.URL.Path, "/api/couriers/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid courier ID")
+		return
+	}
+
+	var req models.UpdateCourierZoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	req.Zone = normalizeZoneCode(req.Zone)
+	if req.Zone != nil && len(*req.Zone) > maxZoneCodeLength {
+		WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("zone must be no longer than %d characters", maxZoneCodeLength))
+		return
+	}
+
+	if err := h.courierService.UpdateCourierZone(courierID, req.Zone); err != nil {
+		switch {
+		case strings.Contains(err.Error(), "zone not found"):
+			WriteErrorResponse(w, http.StatusUnprocessableEntity, "Delivery zone not found")
+		case strings.Contains(err.Error(), "not found"):
+			WriteErrorResponse(w, http.StatusNotFound, "Courier not found")
+		default:
+			h.log.WithError(err).Error("Failed to update courier zone")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to update courier zone")
+		}
+		return
+	}
+
+	// Инвалидация кеша
+	cacheKey := redis.GenerateKey(redis.KeyPrefixCourier, courierID.String())
+	if err := h.redisClient.Delete(r.Context(), cacheKey); err != nil {
+		h.log.WithError(err).Error("Failed to invalidate courier cache")
+	}
+
+	h.log.WithField("courier_id", courierID).WithField("zone", req.Zone).Info("Courier zone updated")
+	WriteJSONResponse(w, http.StatusOK, map[string]string{"message": "Courier zone updated successfully"})
+}
+
+// UpdateCourierCapacity изменяет количество заказов, которые курьер может везти одновременно
+func (h *CourierHandler) UpdateCourierCapacity(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	courierID, err := ExtractUUIDFromPath(r.URL.Path, "/api/couriers/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid courier ID")
+		return
+	}
+
+	var req models.UpdateCourierCapacityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Capacity != nil && *req.Capacity <= 0 {
+		WriteErrorResponse(w, http.StatusBadRequest, "capacity must be positive")
+		return
+	}
+
+	if err := h.courierService.UpdateCourierCapacity(courierID, req.Capacity); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Courier not found")
+		} else {
+			h.log.WithError(err).Error("Failed to update courier capacity")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to update courier capacity")
+		}
+		return
+	}
+
+	// Инвалидация кеша
+	cacheKey := redis.GenerateKey(redis.KeyPrefixCourier, courierID.String())
+	if err := h.redisClient.Delete(r.Context(), cacheKey); err != nil {
+		h.log.WithError(err).Error("Failed to invalidate courier cache")
+	}
+
+	h.log.WithField("courier_id", courierID).WithField("capacity", req.Capacity).Info("Courier capacity updated")
+	WriteJSONResponse(w, http.StatusOK, map[string]string{"message": "Courier capacity updated successfully"})
+}
+
 // GetCouriers получает список курьеров с фильтрацией
 func (h *CourierHandler) GetCouriers(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -280,9 +388,17 @@ func (h *CourierHandler) AssignOrderToCourier(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	actor, err := ActorFromRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Назначение заказа курьеру
-	if err := h.courierService.AssignOrderToCourier(req.OrderID, courierID); err != nil {
-		if strings.Contains(err.Error(), "not found") {
+	if err := h.courierService.AssignOrderToCourier(req.OrderID, courierID, actor); err != nil {
+		if errors.Is(err, services.ErrOrderAlreadyAssigned) {
+			WriteErrorResponse(w, http.StatusConflict, err.Error())
+		} else if strings.Contains(err.Error(), "not found") {
 			WriteErrorResponse(w, http.StatusNotFound, err.Error())
 		} else if strings.Contains(err.Error(), "not available") {
 			WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -293,11 +409,6 @@ func (h *CourierHandler) AssignOrderToCourier(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// Публикация события назначения курьера
-	if err := h.producer.PublishCourierAssigned(req.OrderID, courierID); err != nil {
-		h.log.WithError(err).Error("Failed to publish courier assigned event")
-	}
-
 	// Инвалидация кеша курьера и заказа
 	courierCacheKey := redis.GenerateKey(redis.KeyPrefixCourier, courierID.String())
 	orderCacheKey := redis.GenerateKey(redis.KeyPrefixOrder, req.OrderID.String())
@@ -348,5 +459,12 @@ func (h *CourierHandler) validateCreateCourierRequest(req *models.CreateCourierR
 	if req.Phone == "" {
 		return fmt.Errorf("courier phone is required")
 	}
+	req.Zone = normalizeZoneCode(req.Zone)
+	if req.Zone != nil && len(*req.Zone) > maxZoneCodeLength {
+		return fmt.Errorf("zone must be no longer than %d characters", maxZoneCodeLength)
+	}
+	if req.Capacity != nil && *req.Capacity <= 0 {
+		return fmt.Errorf("capacity must be positive")
+	}
 	return nil
 }
@@ -0,0 +1,298 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/services"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// apiCustomerPrefix - префикс путей эндпоинтов клиента
+	apiCustomerPrefix = "/api/customers/"
+	// minPhoneDigits и maxPhoneDigits ограничивают количество цифр в телефоне клиента
+	minPhoneDigits = 10
+	maxPhoneDigits = 15
+	// maxAddressLabelLength соответствует размеру колонки customer_addresses.label
+	maxAddressLabelLength = 64
+)
+
+// CustomerHandler представляет обработчик клиентов
+type CustomerHandler struct {
+	customerService services.CustomerServiceInterface
+	log             *logger.Logger
+}
+
+// NewCustomerHandler создает новый обработчик клиентов
+func NewCustomerHandler(customerService services.CustomerServiceInterface, log *logger.Logger) *CustomerHandler {
+	return &CustomerHandler{
+		customerService: customerService,
+		log:             log,
+	}
+}
+
+// CreateCustomer создает нового клиента
+func (h *CustomerHandler) CreateCustomer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req models.CustomerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.validateCustomerRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	customer, err := h.customerService.CreateCustomer(&req)
+	if err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			WriteErrorResponse(w, http.StatusConflict, "Customer with this phone already exists")
+			return
+		}
+		h.log.WithError(err).Error("Failed to create customer")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to create customer")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusCreated, customer)
+}
+
+// GetCustomer получает клиента по ID вместе с сохранёнными адресами
+func (h *CustomerHandler) GetCustomer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	customerID, err := ExtractUUIDFromPath(r.URL.Path, apiCustomerPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid customer ID")
+		return
+	}
+
+	customer, err := h.customerService.GetCustomer(customerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Customer not found")
+		} else {
+			h.log.WithError(err).Error("Failed to get customer")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get customer")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, customer)
+}
+
+// GetCustomers получает список клиентов с поиском по телефону
+func (h *CustomerHandler) GetCustomers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	limit, offset := parsePagination(query.Get("limit"), query.Get("offset"))
+
+	customers, err := h.customerService.GetCustomers(query.Get("phone"), limit, offset)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get customers")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get customers")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, customers)
+}
+
+// UpdateCustomer обновляет имя и телефон клиента
+func (h *CustomerHandler) UpdateCustomer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	customerID, err := ExtractUUIDFromPath(r.URL.Path, apiCustomerPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid customer ID")
+		return
+	}
+
+	var req models.CustomerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.validateCustomerRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	customer, err := h.customerService.UpdateCustomer(customerID, &req)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Customer not found")
+		} else if strings.Contains(err.Error(), "already exists") {
+			WriteErrorResponse(w, http.StatusConflict, "Customer with this phone already exists")
+		} else {
+			h.log.WithError(err).Error("Failed to update customer")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to update customer")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, customer)
+}
+
+// AddCustomerAddress сохраняет адрес клиента вместе с его координатами
+func (h *CustomerHandler) AddCustomerAddress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	customerID, err := ExtractUUIDFromPath(r.URL.Path, apiCustomerPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid customer ID")
+		return
+	}
+
+	var req models.CustomerAddressRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	req.Address = strings.TrimSpace(req.Address)
+	req.Label = strings.TrimSpace(req.Label)
+	if req.Address == "" {
+		WriteErrorResponse(w, http.StatusBadRequest, "address is required")
+		return
+	}
+	if len(req.Label) > maxAddressLabelLength {
+		WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("label must be no longer than %d characters", maxAddressLabelLength))
+		return
+	}
+
+	address, err := h.customerService.AddAddress(customerID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrAddressNotFound) {
+			WriteErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+		} else if errors.Is(err, services.ErrGeoUnavailable) {
+			h.log.WithError(err).Error("Geo providers unavailable")
+			WriteErrorResponse(w, http.StatusServiceUnavailable, "Geolocation service is temporarily unavailable")
+		} else if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Customer not found")
+		} else {
+			h.log.WithError(err).Error("Failed to save customer address")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to save customer address")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusCreated, address)
+}
+
+// DeleteCustomerAddress удаляет сохранённый адрес клиента
+func (h *CustomerHandler) DeleteCustomerAddress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	customerID, err := ExtractUUIDFromPath(r.URL.Path, apiCustomerPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid customer ID")
+		return
+	}
+
+	addressID, err := uuid.Parse(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid address ID")
+		return
+	}
+
+	if err := h.customerService.DeleteAddress(customerID, addressID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Customer address not found")
+		} else {
+			h.log.WithError(err).Error("Failed to delete customer address")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to delete customer address")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCustomerOrders получает историю заказов клиента
+func (h *CustomerHandler) GetCustomerOrders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	customerID, err := ExtractUUIDFromPath(r.URL.Path, apiCustomerPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid customer ID")
+		return
+	}
+
+	query := r.URL.Query()
+	limit, offset := parsePagination(query.Get("limit"), query.Get("offset"))
+
+	orders, err := h.customerService.GetCustomerOrders(customerID, limit, offset)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Customer not found")
+		} else {
+			h.log.WithError(err).Error("Failed to get customer orders")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get customer orders")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, orders)
+}
+
+// validateCustomerRequest валидирует запрос создания или обновления клиента
+func (h *CustomerHandler) validateCustomerRequest(req *models.CustomerRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return fmt.Errorf("customer name is required")
+	}
+	if digits := len(models.NormalizePhone(req.Phone)); digits < minPhoneDigits || digits > maxPhoneDigits {
+		return fmt.Errorf("customer phone must contain %d to %d digits", minPhoneDigits, maxPhoneDigits)
+	}
+	return nil
+}
+
+// parsePagination разбирает параметры limit и offset. Некорректные значения заменяются значениями
+// по умолчанию: 50 записей с начала списка
+func parsePagination(limitStr, offsetStr string) (int, int) {
+	limit := 50
+	if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	offset := 0
+	if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+		offset = o
+	}
+
+	return limit, offset
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/redis"
+	"delivery-system/internal/services"
+)
+
+// deliveryPinLength - длина PIN-кода подтверждения доставки
+const deliveryPinLength = 4
+
+// DeliveryProofHandler представляет обработчик подтверждения доставки заказов
+type DeliveryProofHandler struct {
+	proofService  services.DeliveryProofServiceInterface
+	redisClient   redis.RedisClientInterface
+	log           *logger.Logger
+	maxUploadSize int64
+}
+
+// NewDeliveryProofHandler создает новый обработчик подтверждения доставки. maxUploadSize - максимальный
+// размер запроса подтверждения в мегабайтах
+func NewDeliveryProofHandler(
+	proofService services.DeliveryProofServiceInterface,
+	redisClient redis.RedisClientInterface,
+	log *logger.Logger,
+	maxUploadSize int,
+) *DeliveryProofHandler {
+	return &DeliveryProofHandler{
+		proofService:  proofService,
+		redisClient:   redisClient,
+		log:           log,
+		maxUploadSize: int64(maxUploadSize) << 20,
+	}
+}
+
+// ConfirmDelivery подтверждает доставку заказа. Запрос передается как multipart/form-data
+// с полями pin, lat, lon и необязательными файлами photo и signature
+func (h *DeliveryProofHandler) ConfirmDelivery(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	orderID, err := ExtractUUIDFromPath(r.URL.Path, "/api/orders/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxUploadSize)
+	if err := r.ParseMultipartForm(h.maxUploadSize); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			WriteErrorResponse(w, http.StatusRequestEntityTooLarge, "Request body is too large")
+		} else {
+			WriteErrorResponse(w, http.StatusBadRequest, "Invalid multipart form")
+		}
+		return
+	}
+
+	req, err := h.parseDeliveryProofRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := ActorFromRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	proof, err := h.proofService.ConfirmDelivery(orderID, req, actor)
+	if err != nil {
+		var transitionErr *services.InvalidTransitionError
+		var proofErr *services.DeliveryProofError
+		if errors.As(err, &transitionErr) {
+			WriteErrorResponse(w, http.StatusConflict, transitionErr.Error())
+		} else if errors.As(err, &proofErr) {
+			WriteErrorResponse(w, http.StatusUnprocessableEntity, proofErr.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Order not found")
+		} else {
+			h.log.WithError(err).Error("Failed to confirm delivery")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to confirm delivery")
+		}
+		return
+	}
+
+	// Инвалидация кеша заказа и курьера
+	keys := []string{redis.GenerateKey(redis.KeyPrefixOrder, orderID.String())}
+	if proof.CourierID != nil {
+		keys = append(keys, redis.GenerateKey(redis.KeyPrefixCourier, proof.CourierID.String()))
+	}
+	for _, key := range keys {
+		if err := h.redisClient.Delete(r.Context(), key); err != nil {
+			h.log.WithError(err).Error("Failed to invalidate cache")
+		}
+	}
+
+	WriteJSONResponse(w, http.StatusOK, proof)
+}
+
+// GetDeliveryProof получает подтверждение доставки заказа
+func (h *DeliveryProofHandler) GetDeliveryProof(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	orderID, err := ExtractUUIDFromPath(r.URL.Path, "/api/orders/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	proof, err := h.proofService.GetDeliveryProof(orderID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Delivery proof not found")
+		} else {
+			h.log.WithError(err).Error("Failed to get delivery proof")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get delivery proof")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, proof)
+}
+
+// GetDeliveryProofFile возвращает фото или подпись из подтверждения доставки заказа
+func (h *DeliveryProofHandler) GetDeliveryProofFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	orderID, err := ExtractUUIDFromPath(r.URL.Path, "/api/orders/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	kind := models.ProofFileKind(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+	if kind != models.ProofFilePhoto && kind != models.ProofFileSignature {
+		WriteErrorResponse(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	data, err := h.proofService.GetDeliveryProofFile(orderID, kind)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, fmt.Sprintf("Delivery proof %s not found", kind))
+		} else {
+			h.log.WithError(err).Error("Failed to get delivery proof file")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get delivery proof file")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", http.DetectContentType(data))
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		h.log.WithError(err).Error("Failed to write delivery proof file")
+	}
+}
+
+// parseDeliveryProofRequest извлекает и валидирует данные подтверждения доставки из формы
+func (h *DeliveryProofHandler) parseDeliveryProofRequest(r *http.Request) (*models.DeliveryProofRequest, error) {
+	req := &models.DeliveryProofRequest{Pin: strings.TrimSpace(r.FormValue("pin"))}
+	if len(req.Pin) != deliveryPinLength {
+		return nil, fmt.Errorf("pin must be %d digits", deliveryPinLength)
+	}
+	for _, c := range req.Pin {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("pin must be %d digits", deliveryPinLength)
+		}
+	}
+
+	lat, err := strconv.ParseFloat(r.FormValue("lat"), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return nil, fmt.Errorf("lat must be a number between -90 and 90")
+	}
+	lon, err := strconv.ParseFloat(r.FormValue("lon"), 64)
+	if err != nil || lon < -180 || lon > 180 {
+		return nil, fmt.Errorf("lon must be a number between -180 and 180")
+	}
+	req.Lat, req.Lon = lat, lon
+
+	if req.Photo, err = readProofFile(r, models.ProofFilePhoto); err != nil {
+		return nil, err
+	}
+	if req.Signature, err = readProofFile(r, models.ProofFileSignature); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// readProofFile читает необязательное изображение из формы. Допускаются JPEG, PNG и WebP,
+// тип определяется по содержимому файла
+func readProofFile(r *http.Request, kind models.ProofFileKind) (*models.ProofFile, error) {
+	file, _, err := r.FormFile(string(kind))
+	if err != nil {
+		if errors.Is(err, http.ErrMissingFile) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("invalid %s file", kind)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s file", kind)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%s file is empty", kind)
+	}
+
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/jpeg", "image/png", "image/webp":
+	default:
+		return nil, fmt.Errorf("%s must be a JPEG, PNG or WebP image", kind)
+	}
+
+	return &models.ProofFile{ContentType: contentType, Data: data}, nil
+}
@@ -0,0 +1,395 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/services"
+
+	"github.com/google/uuid"
+)
+
+// apiMerchantPrefix - префикс путей эндпоинтов продавца
+const apiMerchantPrefix = "/api/merchants/"
+
+// MerchantHandler представляет обработчик продавцов и их каталогов
+type MerchantHandler struct {
+	merchantService services.MerchantServiceInterface
+	log             *logger.Logger
+}
+
+// NewMerchantHandler создает новый обработчик продавцов
+func NewMerchantHandler(merchantService services.MerchantServiceInterface, log *logger.Logger) *MerchantHandler {
+	return &MerchantHandler{
+		merchantService: merchantService,
+		log:             log,
+	}
+}
+
+// CreateMerchant создает нового продавца
+func (h *MerchantHandler) CreateMerchant(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req models.MerchantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.validateMerchantRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	merchant, err := h.merchantService.CreateMerchant(&req)
+	if err != nil {
+		if errors.Is(err, services.ErrAddressNotFound) {
+			WriteErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+		} else if errors.Is(err, services.ErrGeoUnavailable) {
+			h.log.WithError(err).Error("Geo providers unavailable")
+			WriteErrorResponse(w, http.StatusServiceUnavailable, "Geolocation service is temporarily unavailable")
+		} else {
+			h.log.WithError(err).Error("Failed to create merchant")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to create merchant")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusCreated, merchant)
+}
+
+// GetMerchant получает продавца по ID
+func (h *MerchantHandler) GetMerchant(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	merchantID, err := ExtractUUIDFromPath(r.URL.Path, apiMerchantPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid merchant ID")
+		return
+	}
+
+	merchant, err := h.merchantService.GetMerchant(merchantID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Merchant not found")
+		} else {
+			h.log.WithError(err).Error("Failed to get merchant")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get merchant")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, merchant)
+}
+
+// GetMerchants получает список продавцов
+func (h *MerchantHandler) GetMerchants(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	activeOnly := false
+	if activeStr := r.URL.Query().Get("active"); activeStr != "" {
+		active, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			WriteErrorResponse(w, http.StatusBadRequest, "Invalid active parameter")
+			return
+		}
+		activeOnly = active
+	}
+
+	merchants, err := h.merchantService.GetMerchants(activeOnly)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get merchants")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get merchants")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, merchants)
+}
+
+// UpdateMerchant обновляет продавца
+func (h *MerchantHandler) UpdateMerchant(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	merchantID, err := ExtractUUIDFromPath(r.URL.Path, apiMerchantPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid merchant ID")
+		return
+	}
+
+	var req models.MerchantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.validateMerchantRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	merchant, err := h.merchantService.UpdateMerchant(merchantID, &req)
+	if err != nil {
+		if errors.Is(err, services.ErrAddressNotFound) {
+			WriteErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+		} else if errors.Is(err, services.ErrGeoUnavailable) {
+			h.log.WithError(err).Error("Geo providers unavailable")
+			WriteErrorResponse(w, http.StatusServiceUnavailable, "Geolocation service is temporarily unavailable")
+		} else if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Merchant not found")
+		} else {
+			h.log.WithError(err).Error("Failed to update merchant")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to update merchant")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, merchant)
+}
+
+// DeleteMerchant деактивирует продавца
+func (h *MerchantHandler) DeleteMerchant(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	merchantID, err := ExtractUUIDFromPath(r.URL.Path, apiMerchantPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid merchant ID")
+		return
+	}
+
+	if err := h.merchantService.DeleteMerchant(merchantID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Merchant not found")
+		} else {
+			h.log.WithError(err).Error("Failed to delete merchant")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to delete merchant")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateProduct добавляет товар в каталог продавца
+func (h *MerchantHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	merchantID, err := ExtractUUIDFromPath(r.URL.Path, apiMerchantPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid merchant ID")
+		return
+	}
+
+	var req models.ProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.validateProductRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	product, err := h.merchantService.CreateProduct(merchantID, &req)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Merchant not found")
+		} else {
+			h.log.WithError(err).Error("Failed to create product")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to create product")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusCreated, product)
+}
+
+// GetProducts получает каталог товаров продавца
+func (h *MerchantHandler) GetProducts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	merchantID, err := ExtractUUIDFromPath(r.URL.Path, apiMerchantPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid merchant ID")
+		return
+	}
+
+	availableOnly := false
+	if availableStr := r.URL.Query().Get("available"); availableStr != "" {
+		available, err := strconv.ParseBool(availableStr)
+		if err != nil {
+			WriteErrorResponse(w, http.StatusBadRequest, "Invalid available parameter")
+			return
+		}
+		availableOnly = available
+	}
+
+	products, err := h.merchantService.GetProducts(merchantID, availableOnly)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Merchant not found")
+		} else {
+			h.log.WithError(err).Error("Failed to get products")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get products")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, products)
+}
+
+// UpdateProduct обновляет товар каталога продавца
+func (h *MerchantHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	merchantID, err := ExtractUUIDFromPath(r.URL.Path, apiMerchantPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid merchant ID")
+		return
+	}
+
+	productID, err := uuid.Parse(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req models.ProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.validateProductRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	product, err := h.merchantService.UpdateProduct(merchantID, productID, &req)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Product not found")
+		} else {
+			h.log.WithError(err).Error("Failed to update product")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to update product")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, product)
+}
+
+// DeleteProduct удаляет товар из каталога продавца
+func (h *MerchantHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	merchantID, err := ExtractUUIDFromPath(r.URL.Path, apiMerchantPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid merchant ID")
+		return
+	}
+
+	productID, err := uuid.Parse(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	if err := h.merchantService.DeleteProduct(merchantID, productID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Product not found")
+		} else {
+			h.log.WithError(err).Error("Failed to delete product")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to delete product")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateMerchantRequest валидирует название, адрес, телефон и часы работы продавца
+func (h *MerchantHandler) validateMerchantRequest(req *models.MerchantRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	req.PickupAddress = strings.TrimSpace(req.PickupAddress)
+	req.Phone = strings.TrimSpace(req.Phone)
+	if req.Name == "" {
+		return fmt.Errorf("merchant name is required")
+	}
+	if req.PickupAddress == "" {
+		return fmt.Errorf("pickup address is required")
+	}
+	if req.Phone != "" {
+		if digits := len(models.NormalizePhone(req.Phone)); digits < minPhoneDigits || digits > maxPhoneDigits {
+			return fmt.Errorf("merchant phone must contain %d to %d digits", minPhoneDigits, maxPhoneDigits)
+		}
+	}
+
+	for i, hours := range req.OpeningHours {
+		if hours.Weekday < time.Sunday || hours.Weekday > time.Saturday {
+			return fmt.Errorf("opening hours %d: weekday must be between 0 and 6", i+1)
+		}
+		open, err := models.ParseClock(hours.Open)
+		if err != nil {
+			return fmt.Errorf("opening hours %d: %w", i+1, err)
+		}
+		closing, err := models.ParseClock(hours.Close)
+		if err != nil {
+			return fmt.Errorf("opening hours %d: %w", i+1, err)
+		}
+		if open == closing {
+			return fmt.Errorf("opening hours %d: open and close time must differ", i+1)
+		}
+	}
+
+	return nil
+}
+
+// validateProductRequest валидирует название, цену и вес товара
+func (h *MerchantHandler) validateProductRequest(req *models.ProductRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return fmt.Errorf("product name is required")
+	}
+	if req.Price < 0 {
+		return fmt.Errorf("price cannot be negative")
+	}
+	if req.WeightKg != nil && *req.WeightKg < 0 {
+		return fmt.Errorf("weight cannot be negative")
+	}
+	return nil
+}
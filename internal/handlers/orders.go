@@ -2,12 +2,13 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
-	"delivery-system/internal/kafka"
 	"delivery-system/internal/logger"
 	"delivery-system/internal/models"
 	"delivery-system/internal/redis"
@@ -16,29 +17,39 @@ import (
 	"github.com/google/uuid"
 )
 
+// merchantPreparationActions - статусы приготовления заказа продавца и эндпоинты, которые в них переводят
+var merchantPreparationActions = map[models.OrderStatus]string{
+	models.OrderStatusAccepted:  "accept",
+	models.OrderStatusPreparing: "preparing",
+	models.OrderStatusReady:     "ready",
+}
+
 // OrderHandler представляет обработчик заказов
 type OrderHandler struct {
-	orderService  services.OrderServiceInterface
-	reviewService services.ReviewServiceInterface
-	producer      kafka.ProducerInterface
-	redisClient   redis.RedisClientInterface
-	log           *logger.Logger
+	orderService      services.OrderServiceInterface
+	reviewService     services.ReviewServiceInterface
+	assignmentService services.CourierAssignmentServiceInterface
+	eventService      services.OrderEventServiceInterface
+	redisClient       redis.RedisClientInterface
+	log               *logger.Logger
 }
 
 // NewOrderHandler создает новый обработчик заказов
 func NewOrderHandler(
 	orderService services.OrderServiceInterface,
 	reviewService services.ReviewServiceInterface,
-	producer kafka.ProducerInterface,
+	assignmentService services.CourierAssignmentServiceInterface,
+	eventService services.OrderEventServiceInterface,
 	redisClient redis.RedisClientInterface,
 	log *logger.Logger,
 ) *OrderHandler {
 	return &OrderHandler{
-		orderService:  orderService,
-		reviewService: reviewService,
-		producer:      producer,
-		redisClient:   redisClient,
-		log:           log,
+		orderService:      orderService,
+		reviewService:     reviewService,
+		assignmentService: assignmentService,
+		eventService:      eventService,
+		redisClient:       redisClient,
+		log:               log,
 	}
 }
 
@@ -61,25 +72,79 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	actor, err := ActorFromRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Клиент создаёт заказ только от своего имени и по стоимости доставки, рассчитанной по тарифу
+	if actor.Role == models.RoleCustomer {
+		customerID, err := uuid.Parse(actor.ID)
+		if err != nil || (req.CustomerID != nil && *req.CustomerID != customerID) {
+			WriteErrorResponse(w, http.StatusForbidden, "Customers can only place orders for themselves")
+			return
+		}
+		if req.DeliveryCost != nil {
+			WriteErrorResponse(w, http.StatusForbidden, "Delivery cost cannot be set by a customer")
+			return
+		}
+		req.CustomerID = &customerID
+	}
+
 	// Создание заказа
-	order, err := h.orderService.CreateOrder(&req)
+	order, err := h.orderService.CreateOrder(&req, actor)
 	if err != nil {
+		var promoErr *services.PromoCodeError
+		if errors.As(err, &promoErr) {
+			WriteErrorResponse(w, http.StatusUnprocessableEntity, promoErr.Error())
+			return
+		}
+		if errors.Is(err, services.ErrAddressNotFound) || errors.Is(err, services.ErrRouteNotFound) ||
+			errors.Is(err, services.ErrOutsideDeliveryArea) || errors.Is(err, services.ErrRouteTooLong) ||
+			errors.Is(err, services.ErrScheduleTooFar) || errors.Is(err, services.ErrCustomerNotFound) ||
+			errors.Is(err, services.ErrCustomerAddressNotFound) || errors.Is(err, services.ErrMerchantNotFound) ||
+			errors.Is(err, services.ErrMerchantUnavailable) || errors.Is(err, services.ErrProductUnavailable) {
+			WriteErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if errors.Is(err, services.ErrGeoUnavailable) {
+			h.log.WithError(err).Error("Geo providers unavailable")
+			WriteErrorResponse(w, http.StatusServiceUnavailable, "Geolocation service is temporarily unavailable")
+			return
+		}
+		if errors.Is(err, services.ErrNoActiveTariff) {
+			WriteErrorResponse(w, http.StatusUnprocessableEntity, "No active delivery tariff")
+			return
+		}
 		h.log.WithError(err).Error("Failed to create order")
 		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to create order")
 		return
 	}
 
-	// Публикация события в Kafka
-	if err := h.producer.PublishOrderCreated(order); err != nil {
-		h.log.WithError(err).Error("Failed to publish order created event")
-		// Не возвращаем ошибку клиенту, так как заказ уже создан
+	// Автоназначение курьера, если клиент запросил его при создании заказа.
+	// Неудачное назначение не отменяет создание заказа. Статус после назначения зависит от заказа,
+	// поэтому назначенный заказ перечитывается
+	cacheable := true
+	if req.AutoAssign {
+		if result, err := h.autoAssign(r, order.ID, actor); err != nil {
+			h.log.WithError(err).WithField("order_id", order.ID).Warn("Auto-assignment on order creation failed")
+		} else if assigned, err := h.orderService.GetOrder(order.ID); err != nil {
+			h.log.WithError(err).WithField("order_id", order.ID).Warn("Failed to reload auto-assigned order")
+			order.CourierID = &result.CourierID
+			cacheable = false
+		} else {
+			order = assigned
+		}
 	}
 
 	// Кеширование заказа в Redis
-	cacheKey := redis.GenerateKey(redis.KeyPrefixOrder, order.ID.String())
-	if err := h.redisClient.Set(r.Context(), cacheKey, order, defaultCacheTTL); err != nil {
-		h.log.WithError(err).Error("Failed to cache order")
-		// Не возвращаем ошибку клиенту
+	if cacheable {
+		cacheKey := redis.GenerateKey(redis.KeyPrefixOrder, order.ID.String())
+		if err := h.redisClient.Set(r.Context(), cacheKey, order, defaultCacheTTL); err != nil {
+			h.log.WithError(err).Error("Failed to cache order")
+			// Не возвращаем ошибку клиенту
+		}
 	}
 
 	h.log.WithField("order_id", order.ID).Info("Order created successfully")
@@ -151,22 +216,50 @@ func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Получение текущего заказа для определения старого статуса
-	currentOrder, err := h.orderService.GetOrder(orderID)
+	if !req.Status.IsValid() {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid order status")
+		return
+	}
+
+	// Отмена фиксирует причину и плату за отмену, поэтому выполняется только через отдельный эндпоинт
+	if req.Status == models.OrderStatusCancelled {
+		WriteErrorResponse(w, http.StatusBadRequest, "Use POST /api/orders/{id}/cancel to cancel an order")
+		return
+	}
+
+	actor, err := ActorFromRequest(r)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			WriteErrorResponse(w, http.StatusNotFound, "Order not found")
-		} else {
-			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get order")
-		}
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	oldStatus := currentOrder.Status
+	// Приготовление заказа продавца фиксирует время готовности и планирует поиск курьера,
+	// поэтому выполняется только через отдельные эндпоинты
+	if action, ok := merchantPreparationActions[req.Status]; ok {
+		order, err := h.orderService.GetOrder(orderID)
+		if err != nil {
+			if strings.Contains(err.Error(), "not found") {
+				WriteErrorResponse(w, http.StatusNotFound, "Order not found")
+			} else {
+				h.log.WithError(err).Error("Failed to get order")
+				WriteErrorResponse(w, http.StatusInternalServerError, "Failed to update order status")
+			}
+			return
+		}
+		if order.MerchantID != nil {
+			WriteErrorResponse(w, http.StatusBadRequest,
+				fmt.Sprintf("Use POST /api/orders/{id}/%s to move a merchant order to %s", action, req.Status))
+			return
+		}
+	}
 
-	// Обновление статуса
-	if err := h.orderService.UpdateOrderStatus(orderID, &req); err != nil {
-		if strings.Contains(err.Error(), "not found") {
+	// Обновление статуса. Старый статус определяется сервисом в той же транзакции
+	change, err := h.orderService.UpdateOrderStatus(orderID, &req, actor)
+	if err != nil {
+		var transitionErr *services.InvalidTransitionError
+		if errors.As(err, &transitionErr) {
+			WriteErrorResponse(w, http.StatusConflict, transitionErr.Error())
+		} else if strings.Contains(err.Error(), "not found") {
 			WriteErrorResponse(w, http.StatusNotFound, "Order not found")
 		} else {
 			h.log.WithError(err).Error("Failed to update order status")
@@ -175,21 +268,105 @@ func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Публикация события изменения статуса
-	if err := h.producer.PublishOrderStatusChanged(orderID, oldStatus, req.Status, req.CourierID); err != nil {
-		h.log.WithError(err).Error("Failed to publish order status changed event")
-	}
-
 	// Инвалидация кеша
 	cacheKey := redis.GenerateKey(redis.KeyPrefixOrder, orderID.String())
 	if err := h.redisClient.Delete(r.Context(), cacheKey); err != nil {
 		h.log.WithError(err).Error("Failed to invalidate order cache")
 	}
 
-	h.log.WithField("order_id", orderID).WithField("new_status", req.Status).Info("Order status updated")
+	h.log.WithFields(map[string]interface{}{
+		"order_id":   orderID,
+		"old_status": change.OldStatus,
+		"new_status": change.NewStatus,
+	}).Info("Order status updated")
 	WriteJSONResponse(w, http.StatusOK, map[string]string{"message": "Order status updated successfully"})
 }
 
+// CancelOrder отменяет заказ с указанием причины и возвращает сведения об отмене
+func (h *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	orderID, err := ExtractUUIDFromPath(r.URL.Path, "/api/orders/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	var req models.CancelOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.validateCancelOrderRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := ActorFromRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cancellation, err := h.orderService.CancelOrder(orderID, &req, actor)
+	if err != nil {
+		var transitionErr *services.InvalidTransitionError
+		if errors.As(err, &transitionErr) {
+			WriteErrorResponse(w, http.StatusConflict, transitionErr.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Order not found")
+		} else {
+			h.log.WithError(err).Error("Failed to cancel order")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to cancel order")
+		}
+		return
+	}
+
+	// Инвалидация кеша заказа и освобождённого курьера
+	keys := []string{redis.GenerateKey(redis.KeyPrefixOrder, orderID.String())}
+	if cancellation.CourierID != nil {
+		keys = append(keys, redis.GenerateKey(redis.KeyPrefixCourier, cancellation.CourierID.String()))
+	}
+	for _, key := range keys {
+		if err := h.redisClient.Delete(r.Context(), key); err != nil {
+			h.log.WithError(err).Error("Failed to invalidate cache")
+		}
+	}
+
+	WriteJSONResponse(w, http.StatusOK, cancellation)
+}
+
+// GetOrderCancellation получает сведения об отмене заказа
+func (h *OrderHandler) GetOrderCancellation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	orderID, err := ExtractUUIDFromPath(r.URL.Path, "/api/orders/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	cancellation, err := h.orderService.GetOrderCancellation(orderID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Order cancellation not found")
+		} else {
+			h.log.WithError(err).Error("Failed to get order cancellation")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get order cancellation")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, cancellation)
+}
+
 // GetOrders получает список заказов с фильтрацией
 func (h *OrderHandler) GetOrders(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -268,6 +445,12 @@ func (h *OrderHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	actor, err := ActorFromRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	// Попытка получить заказ из кеша. Если не вышло - получаем из БД
 	orderCacheKey := redis.GenerateKey(redis.KeyPrefixOrder, orderID.String())
 	var order *models.Order
@@ -288,7 +471,7 @@ func (h *OrderHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Создаём отзыв
-	review, err := h.reviewService.CreateReview(&req, order)
+	review, err := h.reviewService.CreateReview(&req, order, actor)
 	if err != nil {
 		h.log.WithError(err).Error("Failed to create review")
 		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to create review")
@@ -316,26 +499,175 @@ func (h *OrderHandler) CreateReview(w http.ResponseWriter, r *http.Request) {
 	WriteJSONResponse(w, http.StatusCreated, review)
 }
 
+// AutoAssignOrder автоматически назначает на заказ оптимального курьера
+func (h *OrderHandler) AutoAssignOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	orderID, err := ExtractUUIDFromPath(r.URL.Path, apiOrderPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	actor, err := ActorFromRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := h.autoAssign(r, orderID, actor)
+	if err != nil {
+		if errors.Is(err, services.ErrOrderAlreadyAssigned) {
+			WriteErrorResponse(w, http.StatusConflict, err.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Order not found")
+		} else if strings.Contains(err.Error(), "no available couriers") || strings.Contains(err.Error(), "awaiting merchant") {
+			WriteErrorResponse(w, http.StatusConflict, err.Error())
+		} else {
+			h.log.WithError(err).Error("Failed to auto-assign courier")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to auto-assign courier")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// GetOrderEvents возвращает журнал событий заказа с инициаторами изменений
+func (h *OrderHandler) GetOrderEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	orderID, err := ExtractUUIDFromPath(r.URL.Path, apiOrderPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	events, err := h.eventService.GetEvents(orderID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Order not found")
+		} else {
+			h.log.WithError(err).Error("Failed to get order events")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get order events")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, events)
+}
+
+// ReplayOrder восстанавливает состояние заказа из журнала событий на указанную версию
+func (h *OrderHandler) ReplayOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	orderID, err := ExtractUUIDFromPath(r.URL.Path, apiOrderPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	version := 0 // По умолчанию - последняя версия
+	if versionStr := r.URL.Query().Get("version"); versionStr != "" {
+		v, err := strconv.Atoi(versionStr)
+		if err != nil || v <= 0 {
+			WriteErrorResponse(w, http.StatusBadRequest, "Invalid version")
+			return
+		}
+		version = v
+	}
+
+	result, err := h.eventService.Replay(orderID, version)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Order events not found")
+		} else {
+			h.log.WithError(err).Error("Failed to replay order")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to replay order")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, result)
+}
+
+// autoAssign выполняет автоназначение курьера и инвалидирует кеш
+func (h *OrderHandler) autoAssign(r *http.Request, orderID uuid.UUID, actor models.Actor) (*models.AssignmentResult, error) {
+	result, err := h.assignmentService.AutoAssign(orderID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	// Инвалидация кеша курьера и заказа
+	courierCacheKey := redis.GenerateKey(redis.KeyPrefixCourier, result.CourierID.String())
+	if err := h.redisClient.Delete(r.Context(), courierCacheKey); err != nil {
+		h.log.WithError(err).Error("Failed to invalidate courier cache")
+	}
+	orderCacheKey := redis.GenerateKey(redis.KeyPrefixOrder, result.OrderID.String())
+	if err := h.redisClient.Delete(r.Context(), orderCacheKey); err != nil {
+		h.log.WithError(err).Error("Failed to invalidate order cache")
+	}
+
+	h.log.WithField("order_id", result.OrderID).WithField("courier_id", result.CourierID).Info("Courier auto-assigned")
+	return result, nil
+}
+
 // validateCreateOrderRequest валидирует запрос на создание заказа
 func (h *OrderHandler) validateCreateOrderRequest(req *models.CreateOrderRequest) error {
-	if req.CustomerName == "" {
+	// Имя, телефон и сохранённый адрес доставки известного клиента берутся из его профиля
+	if req.CustomerName == "" && req.CustomerID == nil {
 		return fmt.Errorf("customer name is required")
 	}
-	if req.CustomerPhone == "" {
+	if req.CustomerPhone == "" && req.CustomerID == nil {
 		return fmt.Errorf("customer phone is required")
 	}
-	if req.PickupAddress == "" {
+	if req.DeliveryAddressID != nil && req.CustomerID == nil {
+		return fmt.Errorf("delivery address id requires customer id")
+	}
+	// Адрес получения заказа продавца - его точка получения
+	if req.PickupAddress == "" && req.MerchantID == nil {
 		return fmt.Errorf("pickup address is required")
 	}
-	if req.DeliveryAddress == "" {
+	if req.DeliveryAddress == "" && req.DeliveryAddressID == nil {
 		return fmt.Errorf("delivery address is required")
 	}
 	if len(req.Items) == 0 {
 		return fmt.Errorf("order items are required")
 	}
+	if len(req.PromoCode) > maxPromoCodeLength {
+		return fmt.Errorf("promo code must be no longer than %d characters", maxPromoCodeLength)
+	}
+	if req.ScheduledFor != nil {
+		if !req.ScheduledFor.After(time.Now()) {
+			return fmt.Errorf("scheduled time must be in the future")
+		}
+		if req.AutoAssign {
+			return fmt.Errorf("auto assign is not available for scheduled orders")
+		}
+	}
+	// Курьер для заказа продавца назначается к готовности заказа
+	if req.MerchantID != nil && req.AutoAssign {
+		return fmt.Errorf("auto assign is not available for merchant orders")
+	}
 
+	// Название и цена товара продавца берутся из каталога
 	for i, item := range req.Items {
-		if item.Name == "" {
+		if req.MerchantID != nil && item.ProductID == nil {
+			return fmt.Errorf("item %d: product id is required for merchant orders", i+1)
+		}
+		if req.MerchantID == nil && item.ProductID != nil {
+			return fmt.Errorf("item %d: product id requires merchant id", i+1)
+		}
+		if item.Name == "" && item.ProductID == nil {
 			return fmt.Errorf("item %d: name is required", i+1)
 		}
 		if item.Quantity <= 0 {
@@ -344,6 +676,9 @@ func (h *OrderHandler) validateCreateOrderRequest(req *models.CreateOrderRequest
 		if item.Price < 0 {
 			return fmt.Errorf("item %d: price cannot be negative", i+1)
 		}
+		if item.WeightKg != nil && *item.WeightKg < 0 {
+			return fmt.Errorf("item %d: weight cannot be negative", i+1)
+		}
 	}
 
 	return nil
@@ -359,3 +694,20 @@ func (h *OrderHandler) validateCreateReviewRequest(req *models.CreateReviewReque
 	}
 	return nil
 }
+
+// validateCancelOrderRequest валидирует запрос отмены заказа
+func (h *OrderHandler) validateCancelOrderRequest(req *models.CancelOrderRequest) error {
+	if req.Reason == "" {
+		return fmt.Errorf("cancellation reason is required")
+	}
+	if !req.Reason.IsValid() {
+		return fmt.Errorf("unknown cancellation reason: %s", req.Reason)
+	}
+	if req.Reason == models.CancellationReasonOther && strings.TrimSpace(req.Comment) == "" {
+		return fmt.Errorf("comment is required for cancellation reason other")
+	}
+	if len(req.Comment) > 500 {
+		return fmt.Errorf("cancellation comment must be no longer than 500 characters")
+	}
+	return nil
+}
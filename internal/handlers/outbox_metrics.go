@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/services"
+)
+
+// OutboxMetricsHandler представляет обработчик метрик transactional outbox
+type OutboxMetricsHandler struct {
+	outboxService services.OutboxServiceInterface
+	log           *logger.Logger
+}
+
+// NewOutboxMetricsHandler создает новый обработчик метрик outbox
+func NewOutboxMetricsHandler(outboxService services.OutboxServiceInterface, log *logger.Logger) *OutboxMetricsHandler {
+	return &OutboxMetricsHandler{
+		outboxService: outboxService,
+		log:           log,
+	}
+}
+
+// GetMetrics возвращает глубину outbox и задержку публикации событий
+func (h *OutboxMetricsHandler) GetMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	metrics, err := h.outboxService.GetMetrics()
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get outbox metrics")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get outbox metrics")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, metrics)
+}
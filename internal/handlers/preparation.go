@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/redis"
+	"delivery-system/internal/services"
+
+	"github.com/google/uuid"
+)
+
+// PreparationHandler представляет обработчик приготовления заказов продавцами
+type PreparationHandler struct {
+	preparationService services.OrderPreparationServiceInterface
+	redisClient        redis.RedisClientInterface
+	log                *logger.Logger
+}
+
+// NewPreparationHandler создает новый обработчик приготовления заказов
+func NewPreparationHandler(
+	preparationService services.OrderPreparationServiceInterface,
+	redisClient redis.RedisClientInterface,
+	log *logger.Logger,
+) *PreparationHandler {
+	return &PreparationHandler{
+		preparationService: preparationService,
+		redisClient:        redisClient,
+		log:                log,
+	}
+}
+
+// AcceptOrder принимает заказ продавцом с заявленным временем приготовления
+func (h *PreparationHandler) AcceptOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	orderID, err := ExtractUUIDFromPath(r.URL.Path, "/api/orders/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	var req models.AcceptOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.PrepMinutes <= 0 {
+		WriteErrorResponse(w, http.StatusBadRequest, "prep_minutes must be positive")
+		return
+	}
+
+	actor, err := ActorFromRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	event, err := h.preparationService.AcceptOrder(orderID, req.PrepMinutes, actor)
+	h.writePreparationResponse(w, r, orderID, event, err, "accept order")
+}
+
+// RejectOrder отказывается от заказа продавцом с указанием причины
+func (h *PreparationHandler) RejectOrder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	orderID, err := ExtractUUIDFromPath(r.URL.Path, "/api/orders/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	var req models.CancelOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.validateRejectOrderRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	actor, err := ActorFromRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cancellation, err := h.preparationService.RejectOrder(orderID, &req, actor)
+	if err != nil {
+		var transitionErr *services.InvalidTransitionError
+		if errors.As(err, &transitionErr) {
+			WriteErrorResponse(w, http.StatusConflict, transitionErr.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Order not found")
+		} else {
+			h.log.WithError(err).Error("Failed to reject order")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to reject order")
+		}
+		return
+	}
+
+	// Инвалидация кеша заказа
+	if err := h.redisClient.Delete(r.Context(), redis.GenerateKey(redis.KeyPrefixOrder, orderID.String())); err != nil {
+		h.log.WithError(err).Error("Failed to invalidate cache")
+	}
+
+	WriteJSONResponse(w, http.StatusOK, cancellation)
+}
+
+// StartPreparing отмечает начало приготовления заказа
+func (h *PreparationHandler) StartPreparing(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	orderID, err := ExtractUUIDFromPath(r.URL.Path, "/api/orders/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	actor, err := ActorFromRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	event, err := h.preparationService.StartPreparing(orderID, actor)
+	h.writePreparationResponse(w, r, orderID, event, err, "start order preparation")
+}
+
+// MarkReady отмечает готовность заказа к передаче курьеру
+func (h *PreparationHandler) MarkReady(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	orderID, err := ExtractUUIDFromPath(r.URL.Path, "/api/orders/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	actor, err := ActorFromRequest(r)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	event, err := h.preparationService.MarkReady(orderID, actor)
+	h.writePreparationResponse(w, r, orderID, event, err, "mark order ready")
+}
+
+// writePreparationResponse записывает ответ на смену этапа приготовления заказа. action - действие
+// для сообщений об ошибке
+func (h *PreparationHandler) writePreparationResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	orderID uuid.UUID,
+	event *models.OrderPreparationEvent,
+	err error,
+	action string,
+) {
+	if err != nil {
+		var transitionErr *services.InvalidTransitionError
+		if errors.As(err, &transitionErr) {
+			WriteErrorResponse(w, http.StatusConflict, transitionErr.Error())
+		} else if errors.Is(err, services.ErrPrepTimeTooLong) {
+			WriteErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Order not found")
+		} else {
+			h.log.WithError(err).Error("Failed to " + action)
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to "+action)
+		}
+		return
+	}
+
+	// Инвалидация кеша заказа
+	if err := h.redisClient.Delete(r.Context(), redis.GenerateKey(redis.KeyPrefixOrder, orderID.String())); err != nil {
+		h.log.WithError(err).Error("Failed to invalidate cache")
+	}
+
+	WriteJSONResponse(w, http.StatusOK, event)
+}
+
+// validateRejectOrderRequest валидирует запрос отказа продавца от заказа
+func (h *PreparationHandler) validateRejectOrderRequest(req *models.CancelOrderRequest) error {
+	if req.Reason == "" {
+		return fmt.Errorf("rejection reason is required")
+	}
+	if !req.Reason.IsValid() {
+		return fmt.Errorf("unknown rejection reason: %s", req.Reason)
+	}
+	if req.Reason == models.CancellationReasonOther && strings.TrimSpace(req.Comment) == "" {
+		return fmt.Errorf("comment is required for rejection reason other")
+	}
+	if len(req.Comment) > 500 {
+		return fmt.Errorf("rejection comment must be no longer than 500 characters")
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/services"
+)
+
+// maxPromoCodeLength - максимальная длина промокода (соответствует размеру колонки promo_codes.code)
+const maxPromoCodeLength = 64
+
+// PromoCodeHandler представляет обработчик промокодов
+type PromoCodeHandler struct {
+	promoCodeService services.PromoCodeServiceInterface
+	log              *logger.Logger
+}
+
+// NewPromoCodeHandler создает новый обработчик промокодов
+func NewPromoCodeHandler(promoCodeService services.PromoCodeServiceInterface, log *logger.Logger) *PromoCodeHandler {
+	return &PromoCodeHandler{
+		promoCodeService: promoCodeService,
+		log:              log,
+	}
+}
+
+// CreatePromoCode создает новый промокод
+func (h *PromoCodeHandler) CreatePromoCode(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req models.PromoCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.validatePromoCodeRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	promoCode, err := h.promoCodeService.CreatePromoCode(&req)
+	if err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			WriteErrorResponse(w, http.StatusConflict, "Promo code already exists")
+			return
+		}
+		h.log.WithError(err).Error("Failed to create promo code")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to create promo code")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusCreated, promoCode)
+}
+
+// GetPromoCode получает промокод по ID
+func (h *PromoCodeHandler) GetPromoCode(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	promoCodeID, err := ExtractUUIDFromPath(r.URL.Path, apiPromoCodePrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid promo code ID")
+		return
+	}
+
+	promoCode, err := h.promoCodeService.GetPromoCode(promoCodeID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Promo code not found")
+		} else {
+			h.log.WithError(err).Error("Failed to get promo code")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get promo code")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, promoCode)
+}
+
+// GetPromoCodes получает список промокодов
+func (h *PromoCodeHandler) GetPromoCodes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+
+	activeOnly := false
+	if activeStr := query.Get("active"); activeStr != "" {
+		active, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			WriteErrorResponse(w, http.StatusBadRequest, "Invalid active parameter")
+			return
+		}
+		activeOnly = active
+	}
+
+	limit := 50 // По умолчанию
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	offset := 0
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	promoCodes, err := h.promoCodeService.GetPromoCodes(activeOnly, limit, offset)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get promo codes")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get promo codes")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, promoCodes)
+}
+
+// UpdatePromoCode обновляет параметры промокода
+func (h *PromoCodeHandler) UpdatePromoCode(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	promoCodeID, err := ExtractUUIDFromPath(r.URL.Path, apiPromoCodePrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid promo code ID")
+		return
+	}
+
+	var req models.PromoCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.validatePromoCodeRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	promoCode, err := h.promoCodeService.UpdatePromoCode(promoCodeID, &req)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"):
+			WriteErrorResponse(w, http.StatusNotFound, "Promo code not found")
+		case strings.Contains(err.Error(), "already exists"):
+			WriteErrorResponse(w, http.StatusConflict, "Promo code already exists")
+		default:
+			h.log.WithError(err).Error("Failed to update promo code")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to update promo code")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, promoCode)
+}
+
+// DeletePromoCode деактивирует промокод
+func (h *PromoCodeHandler) DeletePromoCode(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	promoCodeID, err := ExtractUUIDFromPath(r.URL.Path, apiPromoCodePrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid promo code ID")
+		return
+	}
+
+	if err := h.promoCodeService.DeletePromoCode(promoCodeID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Promo code not found")
+		} else {
+			h.log.WithError(err).Error("Failed to delete promo code")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to delete promo code")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validatePromoCodeRequest валидирует запрос создания или обновления промокода
+func (h *PromoCodeHandler) validatePromoCodeRequest(req *models.PromoCodeRequest) error {
+	code := strings.TrimSpace(req.Code)
+	if code == "" {
+		return fmt.Errorf("code is required")
+	}
+	if len(code) > maxPromoCodeLength {
+		return fmt.Errorf("code must be no longer than %d characters", maxPromoCodeLength)
+	}
+	if !req.DiscountType.IsValid() {
+		return fmt.Errorf("invalid discount type")
+	}
+	if req.DiscountType != models.DiscountTypeFreeDelivery && req.DiscountValue <= 0 {
+		return fmt.Errorf("discount value must be positive")
+	}
+	if req.DiscountType == models.DiscountTypePercent && req.DiscountValue > 100 {
+		return fmt.Errorf("percent discount cannot exceed 100")
+	}
+	if req.MinOrderAmount < 0 {
+		return fmt.Errorf("min order amount cannot be negative")
+	}
+	if req.ValidFrom != nil && req.ValidUntil != nil && !req.ValidUntil.After(*req.ValidFrom) {
+		return fmt.Errorf("valid_until must be after valid_from")
+	}
+	if req.MaxUses != nil && *req.MaxUses <= 0 {
+		return fmt.Errorf("max uses must be positive")
+	}
+	if req.MaxUsesPerCustomer != nil && *req.MaxUsesPerCustomer <= 0 {
+		return fmt.Errorf("max uses per customer must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/services"
+)
+
+// Заголовки ограничения частоты запросов
+const (
+	headerAPIKey             = "X-API-Key"
+	headerForwardedFor       = "X-Forwarded-For"
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+	headerRetryAfter         = "Retry-After"
+)
+
+// RateLimitHandler представляет middleware ограничения частоты запросов и эндпоинт статуса лимита
+type RateLimitHandler struct {
+	rateLimitService services.RateLimitServiceInterface
+	log              *logger.Logger
+}
+
+// NewRateLimitHandler создает новый обработчик ограничения частоты запросов
+func NewRateLimitHandler(rateLimitService services.RateLimitServiceInterface, log *logger.Logger) *RateLimitHandler {
+	return &RateLimitHandler{
+		rateLimitService: rateLimitService,
+		log:              log,
+	}
+}
+
+// Middleware учитывает запрос в лимите клиента и отклоняет его с 429, если лимит исчерпан
+// или клиент временно заблокирован. Должен выполняться после аутентификации, чтобы запросы
+// по API-ключу учитывались в лимите ключа
+func (h *RateLimitHandler) Middleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := h.rateLimitService.Allow(rateLimitClientFromRequest(r))
+		writeRateLimitHeaders(w, status)
+
+		if !status.Allowed {
+			w.Header().Set(headerRetryAfter, strconv.Itoa(status.RetryAfter))
+			message := "Rate limit exceeded"
+			if status.Banned {
+				message = "Too many requests, client is temporarily banned"
+			}
+			WriteErrorResponse(w, http.StatusTooManyRequests, message)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// GetStatus возвращает оставшийся лимит запросов клиента. Сам запрос в лимите не учитывается
+func (h *RateLimitHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	status, err := h.rateLimitService.GetStatus(rateLimitClientFromRequest(r))
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get rate limit status")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get rate limit status")
+		return
+	}
+
+	writeRateLimitHeaders(w, status)
+	WriteJSONResponse(w, http.StatusOK, status)
+}
+
+// rateLimitClientFromRequest извлекает из запроса данные для идентификации клиента. API-ключ учитывается,
+// только если им аутентифицирован запрос: заголовок X-API-Key без проверки позволял бы обойти лимит по IP
+func rateLimitClientFromRequest(r *http.Request) models.RateLimitClient {
+	client := models.RateLimitClient{
+		RemoteAddr:   r.RemoteAddr,
+		ForwardedFor: r.Header.Get(headerForwardedFor),
+	}
+	if apiKey, ok := r.Context().Value(apiKeyContextKey{}).(*models.APIKey); ok {
+		client.APIKeyID = apiKey.ID.String()
+		client.Tier = apiKey.RateLimitTier
+	}
+	return client
+}
+
+func writeRateLimitHeaders(w http.ResponseWriter, status *models.RateLimitStatus) {
+	w.Header().Set(headerRateLimitLimit, strconv.Itoa(status.Limit))
+	w.Header().Set(headerRateLimitRemaining, strconv.Itoa(status.Remaining))
+	if !status.ResetAt.IsZero() {
+		w.Header().Set(headerRateLimitReset, strconv.FormatInt(status.ResetAt.Unix(), 10))
+	}
+}
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/services"
+
+	"github.com/google/uuid"
+)
+
+// maxShiftDuration - максимальная продолжительность запланированной смены
+const maxShiftDuration = 24 * time.Hour
+
+// ShiftHandler представляет обработчик смен курьеров
+type ShiftHandler struct {
+	shiftService services.ShiftServiceInterface
+	log          *logger.Logger
+}
+
+// NewShiftHandler создает новый обработчик смен курьеров
+func NewShiftHandler(shiftService services.ShiftServiceInterface, log *logger.Logger) *ShiftHandler {
+	return &ShiftHandler{
+		shiftService: shiftService,
+		log:          log,
+	}
+}
+
+// ScheduleShift планирует смену курьера
+func (h *ShiftHandler) ScheduleShift(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	courierID, err := ExtractUUIDFromPath(r.URL.Path, apiCourierPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid courier ID")
+		return
+	}
+
+	var req models.ScheduleShiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.ScheduledStart.IsZero() || req.ScheduledEnd.IsZero() {
+		WriteErrorResponse(w, http.StatusBadRequest, "scheduled_start and scheduled_end are required")
+		return
+	}
+	if !req.ScheduledEnd.After(req.ScheduledStart) {
+		WriteErrorResponse(w, http.StatusBadRequest, "scheduled_end must be after scheduled_start")
+		return
+	}
+	if req.ScheduledEnd.Sub(req.ScheduledStart) > maxShiftDuration {
+		WriteErrorResponse(w, http.StatusBadRequest, "shift must be no longer than 24 hours")
+		return
+	}
+	if !req.ScheduledEnd.After(time.Now()) {
+		WriteErrorResponse(w, http.StatusBadRequest, "scheduled_end must be in the future")
+		return
+	}
+
+	shift, err := h.shiftService.ScheduleShift(courierID, &req)
+	if err != nil {
+		h.writeShiftError(w, err, "Failed to schedule shift")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusCreated, shift)
+}
+
+// StartShift начинает смену курьера
+func (h *ShiftHandler) StartShift(w http.ResponseWriter, r *http.Request) {
+	h.courierShiftAction(w, r, h.shiftService.StartShift, "Failed to start shift")
+}
+
+// EndShift завершает смену курьера
+func (h *ShiftHandler) EndShift(w http.ResponseWriter, r *http.Request) {
+	h.courierShiftAction(w, r, h.shiftService.EndShift, "Failed to end shift")
+}
+
+// StartBreak начинает перерыв в смене курьера
+func (h *ShiftHandler) StartBreak(w http.ResponseWriter, r *http.Request) {
+	h.courierShiftAction(w, r, h.shiftService.StartBreak, "Failed to start break")
+}
+
+// EndBreak завершает перерыв в смене курьера
+func (h *ShiftHandler) EndBreak(w http.ResponseWriter, r *http.Request) {
+	h.courierShiftAction(w, r, h.shiftService.EndBreak, "Failed to end break")
+}
+
+// GetCourierShifts получает смены курьера. Параметры from и to задаются в RFC3339
+func (h *ShiftHandler) GetCourierShifts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	courierID, err := ExtractUUIDFromPath(r.URL.Path, apiCourierPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid courier ID")
+		return
+	}
+
+	query := r.URL.Query()
+
+	var from, to *time.Time
+	if fromStr := query.Get("from"); fromStr != "" {
+		t, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			WriteErrorResponse(w, http.StatusBadRequest, "Invalid from parameter, RFC3339 expected")
+			return
+		}
+		from = &t
+	}
+	if toStr := query.Get("to"); toStr != "" {
+		t, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			WriteErrorResponse(w, http.StatusBadRequest, "Invalid to parameter, RFC3339 expected")
+			return
+		}
+		to = &t
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		WriteErrorResponse(w, http.StatusBadRequest, "from must be before to")
+		return
+	}
+
+	limit := 50 // По умолчанию
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	offset := 0
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	shifts, err := h.shiftService.GetCourierShifts(courierID, from, to, limit, offset)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get courier shifts")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get courier shifts")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, shifts)
+}
+
+// GetShift получает смену по ID
+func (h *ShiftHandler) GetShift(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	shiftID, err := ExtractUUIDFromPath(r.URL.Path, "/api/shifts/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid shift ID")
+		return
+	}
+
+	shift, err := h.shiftService.GetShift(shiftID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Shift not found")
+		} else {
+			h.log.WithError(err).Error("Failed to get shift")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get shift")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, shift)
+}
+
+// CancelShift отменяет запланированную смену
+func (h *ShiftHandler) CancelShift(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	shiftID, err := ExtractUUIDFromPath(r.URL.Path, "/api/shifts/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid shift ID")
+		return
+	}
+
+	if err := h.shiftService.CancelShift(shiftID); err != nil {
+		var shiftErr *services.ShiftError
+		if errors.As(err, &shiftErr) {
+			WriteErrorResponse(w, http.StatusConflict, shiftErr.Error())
+		} else if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Shift not found")
+		} else {
+			h.log.WithError(err).Error("Failed to cancel shift")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to cancel shift")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// courierShiftAction выполняет действие со сменой курьера из пути запроса и возвращает обновлённую смену
+func (h *ShiftHandler) courierShiftAction(
+	w http.ResponseWriter,
+	r *http.Request,
+	action func(courierID uuid.UUID) (*models.CourierShift, error),
+	failureMessage string,
+) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	courierID, err := ExtractUUIDFromPath(r.URL.Path, apiCourierPrefix)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid courier ID")
+		return
+	}
+
+	shift, err := action(courierID)
+	if err != nil {
+		h.writeShiftError(w, err, failureMessage)
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, shift)
+}
+
+// writeShiftError отвечает 409 на недопустимое действие со сменой и 404, если курьер не найден
+func (h *ShiftHandler) writeShiftError(w http.ResponseWriter, err error, failureMessage string) {
+	var shiftErr *services.ShiftError
+	if errors.As(err, &shiftErr) {
+		WriteErrorResponse(w, http.StatusConflict, shiftErr.Error())
+		return
+	}
+	if strings.Contains(err.Error(), "not found") {
+		WriteErrorResponse(w, http.StatusNotFound, "Courier not found")
+		return
+	}
+	h.log.WithError(err).Error(failureMessage)
+	WriteErrorResponse(w, http.StatusInternalServerError, failureMessage)
+}
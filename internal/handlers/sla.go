@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/services"
+)
+
+// defaultAtRiskLimit и maxAtRiskLimit - количество заказов под угрозой нарушения SLA в ответе
+// по умолчанию и максимальное
+const (
+	defaultAtRiskLimit = 50
+	maxAtRiskLimit     = 500
+)
+
+// SLAHandler представляет обработчик контроля сроков доставки заказов
+type SLAHandler struct {
+	etaService services.ETAServiceInterface
+	log        *logger.Logger
+}
+
+// NewSLAHandler создает новый обработчик контроля сроков доставки
+func NewSLAHandler(etaService services.ETAServiceInterface, log *logger.Logger) *SLAHandler {
+	return &SLAHandler{
+		etaService: etaService,
+		log:        log,
+	}
+}
+
+// GetAtRiskOrders возвращает активные заказы, срок доставки которых нарушен или под угрозой нарушения
+func (h *SLAHandler) GetAtRiskOrders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	limit := defaultAtRiskLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l <= 0 || l > maxAtRiskLimit {
+			WriteErrorResponse(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		limit = l
+	}
+
+	orders, err := h.etaService.GetAtRiskOrders(limit)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get at-risk orders")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get at-risk orders")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, orders)
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"net/http"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/services"
+)
+
+// SurgeHandler представляет обработчик динамического коэффициента стоимости доставки
+type SurgeHandler struct {
+	surgeService services.SurgeServiceInterface
+	log          *logger.Logger
+}
+
+// NewSurgeHandler создает новый обработчик динамического коэффициента
+func NewSurgeHandler(surgeService services.SurgeServiceInterface, log *logger.Logger) *SurgeHandler {
+	return &SurgeHandler{
+		surgeService: surgeService,
+		log:          log,
+	}
+}
+
+// GetSurgeMultipliers возвращает текущие коэффициенты зон вместе со спросом и предложением
+func (h *SurgeHandler) GetSurgeMultipliers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	multipliers, err := h.surgeService.GetSurgeMultipliers()
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get surge multipliers")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get surge multipliers")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, multipliers)
+}
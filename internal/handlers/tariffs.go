@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/services"
+)
+
+// maxTariffCodeLength соответствует размеру колонки tariffs.code
+const maxTariffCodeLength = 64
+
+// TariffHandler представляет обработчик тарифов доставки
+type TariffHandler struct {
+	tariffService services.TariffServiceInterface
+	log           *logger.Logger
+}
+
+// NewTariffHandler создает новый обработчик тарифов доставки
+func NewTariffHandler(tariffService services.TariffServiceInterface, log *logger.Logger) *TariffHandler {
+	return &TariffHandler{
+		tariffService: tariffService,
+		log:           log,
+	}
+}
+
+// CreateTariff создает новую версию тарифа
+func (h *TariffHandler) CreateTariff(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req models.TariffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.validateTariffRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tariff, err := h.tariffService.CreateTariff(&req)
+	if err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			WriteErrorResponse(w, http.StatusConflict, "Tariff version already exists")
+			return
+		}
+		h.log.WithError(err).Error("Failed to create tariff")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to create tariff")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusCreated, tariff)
+}
+
+// GetTariff получает версию тарифа по ID
+func (h *TariffHandler) GetTariff(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	tariffID, err := ExtractUUIDFromPath(r.URL.Path, "/api/tariffs/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid tariff ID")
+		return
+	}
+
+	tariff, err := h.tariffService.GetTariff(tariffID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Tariff not found")
+		} else {
+			h.log.WithError(err).Error("Failed to get tariff")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get tariff")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, tariff)
+}
+
+// GetTariffs получает список версий тарифов
+func (h *TariffHandler) GetTariffs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	query := r.URL.Query()
+
+	activeOnly := false
+	if activeStr := query.Get("active"); activeStr != "" {
+		active, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			WriteErrorResponse(w, http.StatusBadRequest, "Invalid active parameter")
+			return
+		}
+		activeOnly = active
+	}
+
+	limit := 50 // По умолчанию
+	if limitStr := query.Get("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	offset := 0
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	tariffs, err := h.tariffService.GetTariffs(query.Get("code"), activeOnly, limit, offset)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get tariffs")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get tariffs")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, tariffs)
+}
+
+// RetireTariff завершает действие версии тарифа
+func (h *TariffHandler) RetireTariff(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	tariffID, err := ExtractUUIDFromPath(r.URL.Path, "/api/tariffs/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid tariff ID")
+		return
+	}
+
+	tariff, err := h.tariffService.RetireTariff(tariffID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Tariff not found")
+		} else {
+			h.log.WithError(err).Error("Failed to retire tariff")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to retire tariff")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, tariff)
+}
+
+// QuoteDelivery рассчитывает стоимость доставки с детализацией без создания заказа
+func (h *TariffHandler) QuoteDelivery(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req models.DeliveryQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.validateDeliveryQuoteRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	quote, err := h.tariffService.QuoteDelivery(&req)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrAddressNotFound), errors.Is(err, services.ErrRouteNotFound),
+			errors.Is(err, services.ErrOutsideDeliveryArea), errors.Is(err, services.ErrRouteTooLong):
+			WriteErrorResponse(w, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, services.ErrNoActiveTariff):
+			WriteErrorResponse(w, http.StatusUnprocessableEntity, "No active delivery tariff")
+		case errors.Is(err, services.ErrGeoUnavailable):
+			h.log.WithError(err).Error("Geo providers unavailable")
+			WriteErrorResponse(w, http.StatusServiceUnavailable, "Geolocation service is temporarily unavailable")
+		default:
+			h.log.WithError(err).Error("Failed to quote delivery")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to quote delivery")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, quote)
+}
+
+// validateTariffRequest валидирует запрос создания версии тарифа
+func (h *TariffHandler) validateTariffRequest(req *models.TariffRequest) error {
+	req.Code = strings.TrimSpace(req.Code)
+	if req.Code == "" {
+		return fmt.Errorf("code is required")
+	}
+	if len(req.Code) > maxTariffCodeLength {
+		return fmt.Errorf("code must be no longer than %d characters", maxTariffCodeLength)
+	}
+	if req.Zone != nil && (*req.Zone == "" || len(*req.Zone) > maxZoneCodeLength) {
+		return fmt.Errorf("zone must be between 1 and %d characters", maxZoneCodeLength)
+	}
+	if req.BaseFee < 0 {
+		return fmt.Errorf("base fee cannot be negative")
+	}
+
+	if len(req.DistanceBands) == 0 {
+		return fmt.Errorf("at least one distance band is required")
+	}
+	prevUpToKm := 0.0
+	for i, band := range req.DistanceBands {
+		if band.PerKm < 0 {
+			return fmt.Errorf("distance band %d: per_km cannot be negative", i+1)
+		}
+		if band.UpToKm == nil {
+			if i != len(req.DistanceBands)-1 {
+				return fmt.Errorf("distance band %d: only the last band may omit up_to_km", i+1)
+			}
+			continue
+		}
+		if *band.UpToKm <= prevUpToKm {
+			return fmt.Errorf("distance band %d: up_to_km must be greater than the previous band", i+1)
+		}
+		prevUpToKm = *band.UpToKm
+	}
+
+	if req.MinPrice != nil && *req.MinPrice < 0 {
+		return fmt.Errorf("min price cannot be negative")
+	}
+	if req.MaxPrice != nil && *req.MaxPrice < 0 {
+		return fmt.Errorf("max price cannot be negative")
+	}
+	if req.MinPrice != nil && req.MaxPrice != nil && *req.MinPrice > *req.MaxPrice {
+		return fmt.Errorf("min price cannot exceed max price")
+	}
+
+	if err := validateSurcharges("weight surcharge", req.WeightSurcharges); err != nil {
+		return err
+	}
+	if err := validateSurcharges("item count surcharge", req.ItemCountSurcharges); err != nil {
+		return err
+	}
+
+	if req.NightMultiplier != nil && *req.NightMultiplier <= 0 {
+		return fmt.Errorf("night multiplier must be positive")
+	}
+	if req.WeekendMultiplier != nil && *req.WeekendMultiplier <= 0 {
+		return fmt.Errorf("weekend multiplier must be positive")
+	}
+	if req.NightStartHour != nil && (*req.NightStartHour < 0 || *req.NightStartHour > 23) {
+		return fmt.Errorf("night start hour must be between 0 and 23")
+	}
+	if req.NightEndHour != nil && (*req.NightEndHour < 0 || *req.NightEndHour > 23) {
+		return fmt.Errorf("night end hour must be between 0 and 23")
+	}
+	if req.EffectiveFrom != nil && req.EffectiveTo != nil && !req.EffectiveTo.After(*req.EffectiveFrom) {
+		return fmt.Errorf("effective_to must be after effective_from")
+	}
+	return nil
+}
+
+// validateDeliveryQuoteRequest валидирует запрос расчёта стоимости доставки
+func (h *TariffHandler) validateDeliveryQuoteRequest(req *models.DeliveryQuoteRequest) error {
+	if req.PickupAddress == "" {
+		return fmt.Errorf("pickup address is required")
+	}
+	if req.DeliveryAddress == "" {
+		return fmt.Errorf("delivery address is required")
+	}
+	for i, item := range req.Items {
+		if item.Quantity <= 0 {
+			return fmt.Errorf("item %d: quantity must be positive", i+1)
+		}
+		if item.WeightKg != nil && *item.WeightKg < 0 {
+			return fmt.Errorf("item %d: weight cannot be negative", i+1)
+		}
+	}
+	return nil
+}
+
+// validateSurcharges проверяет, что пороги и суммы надбавок неотрицательны
+func validateSurcharges(name string, surcharges []models.Surcharge) error {
+	for i, surcharge := range surcharges {
+		if surcharge.Over < 0 {
+			return fmt.Errorf("%s %d: threshold cannot be negative", name, i+1)
+		}
+		if surcharge.Amount < 0 {
+			return fmt.Errorf("%s %d: amount cannot be negative", name, i+1)
+		}
+	}
+	return nil
+}
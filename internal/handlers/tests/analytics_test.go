@@ -0,0 +1,105 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/services/services_mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestGetAnalyticsSummary выполняет тестирование получения сводного отчёта
+func TestGetAnalyticsSummary(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range analyticsSummaryTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockAnalyticsService := services_mocks.NewMockAnalyticsServiceInterface(t)
+
+			h := handlers.NewAnalyticsHandler(mockAnalyticsService, discardLogger)
+			mux := setupTestAnalyticsRoutes(h)
+
+			server := httptest.NewServer(mux)
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockAnalyticsService.On("GetSummary", *tc.expectedFilter).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			req := e.GET("/api/analytics/summary")
+			for key, value := range tc.query {
+				req.WithQuery(key, value)
+			}
+			resp := req.Expect().Status(tc.expectedStatusCode)
+
+			if tc.expectedStatusCode == http.StatusOK {
+				if tc.expectedCSV {
+					resp.Header("Content-Type").HasPrefix("text/csv")
+					resp.Body().HasPrefix("period,orders_count").Contains("total,10,8,2,8000.00")
+				} else {
+					obj := resp.JSON().Object()
+					obj.Value("totals").Object().Value("orders_count").Number().IsEqual(tc.returnedValue.Totals.OrdersCount)
+					obj.Value("periods").Array().Length().IsEqual(len(tc.returnedValue.Periods))
+				}
+			}
+
+			mockAnalyticsService.AssertExpectations(t)
+			server.Close()
+		})
+	}
+}
+
+// TestGetAnalyticsTopItems выполняет тестирование получения отчёта по популярным товарам
+func TestGetAnalyticsTopItems(t *testing.T) {
+	mockAnalyticsService := services_mocks.NewMockAnalyticsServiceInterface(t)
+	discardLogger := logger.NewTest()
+
+	h := handlers.NewAnalyticsHandler(mockAnalyticsService, discardLogger)
+	mux := setupTestAnalyticsRoutes(h)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mockAnalyticsService.
+		On("GetTopItems", topItemsReport.Filter).
+		Return(topItemsReport, nil).Once()
+
+	e := httpexpect.Default(t, server.URL)
+	items := e.GET("/api/analytics/top-items").
+		WithQuery("from", "2025-01-01").WithQuery("to", "2025-01-31").WithQuery("limit", 5).
+		Expect().Status(http.StatusOK).JSON().Object().Value("items").Array()
+	items.Length().IsEqual(len(topItemsReport.Items))
+	items.Value(0).Object().Value("name").String().IsEqual(topItemsReport.Items[0].Name)
+
+	mockAnalyticsService.AssertExpectations(t)
+}
+
+// TestGetAnalyticsCouriers выполняет тестирование получения отчёта по курьерам
+func TestGetAnalyticsCouriers(t *testing.T) {
+	mockAnalyticsService := services_mocks.NewMockAnalyticsServiceInterface(t)
+	discardLogger := logger.NewTest()
+
+	h := handlers.NewAnalyticsHandler(mockAnalyticsService, discardLogger)
+	mux := setupTestAnalyticsRoutes(h)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mockAnalyticsService.
+		On("GetCourierReport", mock.MatchedBy(func(filter models.AnalyticsFilter) bool {
+			return filter.GroupBy == models.AnalyticsGroupByDay && filter.To.Sub(filter.From) == 30*24*time.Hour
+		})).
+		Return(courierReport, nil).Once()
+
+	e := httpexpect.Default(t, server.URL)
+	e.GET("/api/analytics/couriers").WithQuery("format", "csv").
+		Expect().Status(http.StatusOK).
+		Body().Contains(courier1.ID.String() + "," + courier1.Name + ",8,2400.00,42.50,4.50,2")
+
+	mockAnalyticsService.AssertExpectations(t)
+}
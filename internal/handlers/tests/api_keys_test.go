@@ -0,0 +1,206 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/services/services_mocks"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/mock"
+)
+
+// apiKeyUsageRetentionDays - срок хранения статистики запросов по ключу в тестах
+const apiKeyUsageRetentionDays = 90
+
+// TestIssueAPIKey выполняет тестирование выпуска API-ключа
+func TestIssueAPIKey(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range issueAPIKeyTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockAPIKeyService := services_mocks.NewMockAPIKeyServiceInterface(t)
+
+			h := handlers.NewAPIKeyHandler(mockAPIKeyService, discardLogger, apiKeyUsageRetentionDays)
+			mux := setupTestAPIKeyRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockAPIKeyService.On("IssueAPIKey", tc.payload).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.POST("/api/api-keys").WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusCreated {
+				obj := resp.JSON().Object()
+				obj.Value("id").String().IsEqual(tc.returnedValue.ID.String())
+				obj.Value("key").String().IsEqual(tc.returnedValue.Key)
+				obj.Value("prefix").String().IsEqual(tc.returnedValue.Prefix)
+				obj.Value("owner").Object().Value("role").String().IsEqual(string(tc.returnedValue.Owner.Role))
+				obj.NotContainsKey("key_hash")
+			}
+		})
+	}
+}
+
+// TestGetAPIKeys выполняет тестирование получения списка API-ключей
+func TestGetAPIKeys(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getAPIKeysTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockAPIKeyService := services_mocks.NewMockAPIKeyServiceInterface(t)
+
+			h := handlers.NewAPIKeyHandler(mockAPIKeyService, discardLogger, apiKeyUsageRetentionDays)
+			mux := setupTestAPIKeyRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockAPIKeyService.On("GetAPIKeys", tc.owner).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.GET("/api/api-keys").
+				WithQueryString(tc.query).
+				WithHeader("X-Actor-Role", string(tc.actor.Role)).
+				WithHeader("X-Actor-ID", tc.actor.ID).
+				Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				array := resp.JSON().Array()
+				array.Length().IsEqual(len(tc.returnedValue))
+				array.Value(0).Object().Value("id").String().IsEqual(tc.returnedValue[0].ID.String())
+				array.Value(0).Object().NotContainsKey("key")
+			}
+		})
+	}
+}
+
+// TestGetAPIKey выполняет тестирование получения API-ключа владельцем
+func TestGetAPIKey(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	mockAPIKeyService := services_mocks.NewMockAPIKeyServiceInterface(t)
+
+	h := handlers.NewAPIKeyHandler(mockAPIKeyService, discardLogger, apiKeyUsageRetentionDays)
+	mux := setupTestAPIKeyRoutes(h)
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mockAPIKeyService.On("GetAPIKey", apiKeyID).Return(merchantAPIKey, nil)
+
+	e := httpexpect.Default(t, server.URL)
+	obj := e.GET(fmt.Sprintf("/api/api-keys/%s", apiKeyID)).
+		WithHeader("X-Actor-Role", string(merchantActor.Role)).
+		WithHeader("X-Actor-ID", merchantActor.ID).
+		Expect().Status(http.StatusOK).JSON().Object()
+	obj.Value("id").String().IsEqual(apiKeyID.String())
+	obj.Value("scopes").Array().ContainsAll("orders:read", "orders:write")
+
+	e.GET(fmt.Sprintf("/api/api-keys/%s", apiKeyID)).
+		WithHeader("X-Actor-Role", string(courierActor.Role)).
+		WithHeader("X-Actor-ID", merchantActor.ID).
+		Expect().Status(http.StatusForbidden)
+}
+
+// TestGetAPIKeyUsage выполняет тестирование получения статистики запросов по API-ключу
+func TestGetAPIKeyUsage(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getAPIKeyUsageTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockAPIKeyService := services_mocks.NewMockAPIKeyServiceInterface(t)
+
+			h := handlers.NewAPIKeyHandler(mockAPIKeyService, discardLogger, apiKeyUsageRetentionDays)
+			mux := setupTestAPIKeyRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.apiKey != nil || tc.getError != nil {
+				mockAPIKeyService.On("GetAPIKey", apiKeyID).Return(tc.apiKey, tc.getError)
+			}
+			if tc.days != 0 {
+				mockAPIKeyService.On("GetUsage", apiKeyID, tc.days).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.GET(fmt.Sprintf("/api/api-keys/%s/usage", apiKeyID)).
+				WithQueryString(tc.query).
+				WithHeader("X-Actor-Role", string(tc.actor.Role)).
+				WithHeader("X-Actor-ID", tc.actor.ID).
+				Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				obj := resp.JSON().Object()
+				obj.Value("total").Number().IsEqual(tc.returnedValue.Total)
+				obj.Value("daily").Array().Length().IsEqual(len(tc.returnedValue.Daily))
+				obj.Value("daily").Array().Value(1).Object().Value("requests").Number().IsEqual(tc.returnedValue.Daily[1].Requests)
+			}
+		})
+	}
+}
+
+// TestRotateAPIKey выполняет тестирование ротации API-ключа
+func TestRotateAPIKey(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range rotateAPIKeyTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockAPIKeyService := services_mocks.NewMockAPIKeyServiceInterface(t)
+
+			h := handlers.NewAPIKeyHandler(mockAPIKeyService, discardLogger, apiKeyUsageRetentionDays)
+			mux := setupTestAPIKeyRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockAPIKeyService.On("RotateAPIKey", mock.Anything).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.POST(fmt.Sprintf("/api/api-keys/%s/rotate", tc.id)).Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				obj := resp.JSON().Object()
+				obj.Value("id").String().IsEqual(tc.returnedValue.ID.String())
+				obj.Value("key").String().IsEqual(tc.returnedValue.Key)
+			}
+		})
+	}
+}
+
+// TestRevokeAPIKey выполняет тестирование отзыва API-ключа
+func TestRevokeAPIKey(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range revokeAPIKeyTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockAPIKeyService := services_mocks.NewMockAPIKeyServiceInterface(t)
+
+			h := handlers.NewAPIKeyHandler(mockAPIKeyService, discardLogger, apiKeyUsageRetentionDays)
+			mux := setupTestAPIKeyRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockAPIKeyService.On("RevokeAPIKey", mock.Anything).Return(tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			e.DELETE(fmt.Sprintf("/api/api-keys/%s", tc.id)).Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/services/services_mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+)
+
+// TestAuthMiddleware выполняет тестирование аутентификации и проверки прав доступа к маршрутам
+func TestAuthMiddleware(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range authMiddlewareTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockAuthService := services_mocks.NewMockAuthServiceInterface(t)
+			mockAPIKeyService := services_mocks.NewMockAPIKeyServiceInterface(t)
+			mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
+
+			h := handlers.NewAuthHandler(mockAuthService, mockAPIKeyService, mockOrderService, handlers.DefaultAccessPolicy, discardLogger)
+			mux := setupTestAuthRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if strings.HasPrefix(tc.authorization, "Bearer ") || strings.Contains(tc.path, "access_token=") {
+				mockAuthService.On("Authenticate", accessToken).Return(tc.actor, tc.authError)
+			}
+			if tc.order != nil || tc.orderError != nil {
+				mockOrderService.On("GetOrder", orderID).Return(tc.order, tc.orderError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			path, query, _ := strings.Cut(tc.path, "?")
+			req := e.Request(tc.method, path).
+				WithQueryString(query).
+				WithHeader("X-Actor-Role", "admin")
+			if tc.authorization != "" {
+				req = req.WithHeader("Authorization", tc.authorization)
+			}
+
+			resp := req.Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				// Инициатор определяется токеном, а не заголовками
+				obj := resp.JSON().Object()
+				obj.Value("role").String().IsEqual(string(tc.actor.Role))
+				if tc.actor.ID != "" {
+					obj.Value("id").String().IsEqual(tc.actor.ID)
+				}
+			}
+			if tc.expectedStatusCode == http.StatusUnauthorized {
+				resp.Header("WWW-Authenticate").NotEmpty()
+			}
+		})
+	}
+}
+
+// TestAPIKeyMiddleware выполняет тестирование аутентификации машинных клиентов по API-ключу
+func TestAPIKeyMiddleware(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range apiKeyMiddlewareTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockAuthService := services_mocks.NewMockAuthServiceInterface(t)
+			mockAPIKeyService := services_mocks.NewMockAPIKeyServiceInterface(t)
+			mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
+
+			h := handlers.NewAuthHandler(mockAuthService, mockAPIKeyService, mockOrderService, handlers.DefaultAccessPolicy, discardLogger)
+			mux := setupTestAuthRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockAPIKeyService.On("Authenticate", apiKeyValue).Return(tc.apiKey, tc.authError)
+			if tc.order != nil {
+				mockOrderService.On("GetOrder", orderID).Return(tc.order, nil)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.Request(tc.method, tc.path).
+				WithHeader("X-API-Key", apiKeyValue).
+				Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				// Запрос по ключу выполняется от имени его владельца
+				obj := resp.JSON().Object()
+				obj.Value("role").String().IsEqual(string(tc.apiKey.Owner.Role))
+				obj.Value("id").String().IsEqual(tc.apiKey.Owner.ID)
+			}
+		})
+	}
+}
+
+// TestIssueToken выполняет тестирование выпуска токена разработки
+func TestIssueToken(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range issueTokenTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockAuthService := services_mocks.NewMockAuthServiceInterface(t)
+			mockAPIKeyService := services_mocks.NewMockAPIKeyServiceInterface(t)
+			mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
+
+			h := handlers.NewAuthHandler(mockAuthService, mockAPIKeyService, mockOrderService, handlers.DefaultAccessPolicy, discardLogger)
+			mux := setupTestAuthRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockAuthService.On("IssueToken", tc.payload).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.POST("/api/auth/token").WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusCreated {
+				obj := resp.JSON().Object()
+				obj.Value("access_token").String().IsEqual(tc.returnedValue.AccessToken)
+				obj.Value("token_type").String().IsEqual("Bearer")
+				obj.Value("expires_at").String().AsDateTime().IsEqual(tc.returnedValue.ExpiresAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/redis/redis_mocks"
+	"delivery-system/internal/services/services_mocks"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestGetBatches выполняет тестирование получения предлагаемых пакетов заказов
+func TestGetBatches(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getBatchesTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockBatchingService := services_mocks.NewMockBatchingServiceInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+
+			h := handlers.NewBatchHandler(mockBatchingService, mockRedis, discardLogger)
+			mux := setupTestBatchRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockBatchingService.On("GetBatches").Return(tc.returnedValue, tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.GET("/api/orders/batches").Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				arr := resp.JSON().Array()
+				arr.Length().IsEqual(len(tc.returnedValue))
+				for i, batch := range tc.returnedValue {
+					obj := arr.Value(i).Object()
+					obj.Value("order_ids").Array().Length().IsEqual(len(batch.OrderIDs))
+					obj.Value("route").Object().Value("stops").Array().Length().IsEqual(len(batch.Route.Stops))
+				}
+			}
+		})
+	}
+}
+
+// TestAssignBatch выполняет тестирование назначения курьеру пакета заказов
+func TestAssignBatch(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range assignBatchTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockBatchingService := services_mocks.NewMockBatchingServiceInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+
+			h := handlers.NewBatchHandler(mockBatchingService, mockRedis, discardLogger)
+			mux := setupTestBatchRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest || tc.returnedError != nil {
+				mockBatchingService.
+					On("AssignBatch", tc.courierID, tc.payload.OrderIDs, dispatcherActor).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+			if tc.expectedStatusCode == http.StatusOK {
+				// Инвалидируется кеш курьера и каждого заказа пакета
+				mockRedis.On("Delete", mock.Anything, mock.Anything).Return(nil).Times(len(tc.payload.OrderIDs) + 1)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.POST(fmt.Sprintf("/api/couriers/%s/batch", tc.courierID)).
+				WithHeader("X-Actor-Role", string(dispatcherActor.Role)).
+				WithJSON(tc.payload).
+				Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				obj := resp.JSON().Object()
+				obj.Value("courier_id").String().IsEqual(tc.courierID.String())
+				obj.Value("stops").Array().Length().IsEqual(len(tc.returnedValue.Stops))
+				obj.Value("distance_m").Number().IsEqual(tc.returnedValue.DistanceMeters)
+			}
+		})
+	}
+}
+
+// TestGetCourierRoute выполняет тестирование получения маршрута курьера
+func TestGetCourierRoute(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getCourierRouteTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockBatchingService := services_mocks.NewMockBatchingServiceInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+
+			h := handlers.NewBatchHandler(mockBatchingService, mockRedis, discardLogger)
+			mux := setupTestBatchRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockBatchingService.On("GetCourierRoute", tc.courierID).Return(tc.returnedValue, tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.GET(fmt.Sprintf("/api/couriers/%s/route", tc.courierID)).Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				stops := resp.JSON().Object().Value("stops").Array()
+				stops.Length().IsEqual(len(tc.returnedValue.Stops))
+				stops.Value(0).Object().Value("type").String().IsEqual(string(tc.returnedValue.Stops[0].Type))
+			}
+		})
+	}
+}
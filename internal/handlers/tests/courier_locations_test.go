@@ -0,0 +1,197 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/kafka/kafka_mocks"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/redis/redis_mocks"
+	"delivery-system/internal/services/services_mocks"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestUpdateCourierLocation выполняет тестирование обновления местоположения курьера
+func TestUpdateCourierLocation(t *testing.T) {
+	for _, tc := range updateCourierLocationTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockLocationService := services_mocks.NewMockCourierLocationServiceInterface(t)
+			mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
+			mockProducer := kafka_mocks.NewMockProducerInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+			discardLogger := logger.NewTest()
+
+			h := handlers.NewCourierLocationHandler(mockLocationService, mockOrderService, mockProducer, mockRedis, discardLogger)
+			mux := setupTestCourierLocationRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockLocationService.
+					On("UpdateLocation", courierID, mock.AnythingOfType("*models.UpdateLocationRequest")).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+			if tc.expectedStatusCode == http.StatusOK {
+				mockProducer.On("PublishLocationUpdated", courierID, tc.returnedValue.Lat, tc.returnedValue.Lon).Return(nil).Once()
+				mockRedis.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			obj := e.POST(fmt.Sprintf("/api/couriers/%s/location", courierID)).WithJSON(tc.payload).
+				Expect().Status(tc.expectedStatusCode).JSON().Object()
+			if tc.expectedStatusCode == http.StatusOK {
+				obj.Value("courier_id").String().IsEqual(courierID.String())
+				obj.Value("lat").Number().IsEqual(tc.returnedValue.Lat)
+				obj.Value("lon").Number().IsEqual(tc.returnedValue.Lon)
+			}
+		})
+	}
+}
+
+// TestGetCourierTrack выполняет тестирование получения трека курьера
+func TestGetCourierTrack(t *testing.T) {
+	for _, tc := range getCourierTrackTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockLocationService := services_mocks.NewMockCourierLocationServiceInterface(t)
+			mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
+			mockProducer := kafka_mocks.NewMockProducerInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+			discardLogger := logger.NewTest()
+
+			h := handlers.NewCourierLocationHandler(mockLocationService, mockOrderService, mockProducer, mockRedis, discardLogger)
+			mux := setupTestCourierLocationRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockLocationService.
+					On("GetTrack", courierID, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			req := e.GET(fmt.Sprintf("/api/couriers/%s/track", courierID))
+			for key, value := range tc.query {
+				req = req.WithQuery(key, value)
+			}
+
+			resp := req.Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				resp.Header("Content-Type").IsEqual("application/geo+json")
+				obj := resp.JSON(httpexpect.ContentOpts{MediaType: "application/geo+json"}).Object()
+				obj.Value("type").String().IsEqual(models.GeoJSONTypeFeature)
+				obj.Value("geometry").Object().Value("type").String().IsEqual(models.GeoJSONTypeLineString)
+				obj.Value("geometry").Object().Value("coordinates").Array().Length().IsEqual(2)
+			}
+		})
+	}
+}
+
+// TestStreamOrderCourierLocation выполняет тестирование трансляции местоположения курьера заказа
+func TestStreamOrderCourierLocation(t *testing.T) {
+	for _, tc := range streamOrderCourierLocationTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockLocationService := services_mocks.NewMockCourierLocationServiceInterface(t)
+			mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
+			mockProducer := kafka_mocks.NewMockProducerInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+			discardLogger := logger.NewTest()
+
+			h := handlers.NewCourierLocationHandler(mockLocationService, mockOrderService, mockProducer, mockRedis, discardLogger)
+			mux := setupTestCourierLocationRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.completedOrder != nil {
+				// Заказ завершается после открытия потока
+				mockOrderService.On("GetOrder", orderID).Return(tc.returnedOrder, nil).Once()
+				mockOrderService.On("GetOrder", orderID).Return(tc.completedOrder, nil)
+			} else if tc.expectedStatusCode != http.StatusBadRequest {
+				mockOrderService.On("GetOrder", orderID).Return(tc.returnedOrder, tc.returnedError)
+			}
+			if tc.expectedStatusCode == http.StatusOK {
+				// Закрытый канал завершает поток после отправки накопленных обновлений,
+				// а при завершении заказа поток закрывается сам
+				updates := make(chan *models.CourierLocation, 1)
+				updates <- &models.CourierLocation{CourierID: courierID, Lat: 55.76, Lon: 37.62, Timestamp: time.Now()}
+				if tc.completedOrder == nil {
+					close(updates)
+				}
+
+				mockLocationService.On("Subscribe", mock.Anything, courierID).Return((<-chan *models.CourierLocation)(updates), nil)
+				mockLocationService.On("GetLastLocation", courierID).Return(courierLocation, nil)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.GET(fmt.Sprintf("/api/orders/%s/courier/stream", orderID)).
+				WithHeader("X-Actor-Role", tc.actorRole).
+				WithHeader("X-Actor-ID", tc.actorID).
+				Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				resp.Header("Content-Type").IsEqual("text/event-stream")
+				body := resp.Body()
+				body.Contains("event: location")
+				body.Contains(`"lat":55.7558`)
+				body.Contains(`"lat":55.76`)
+				if tc.completedOrder != nil {
+					body.Contains("event: completed")
+					body.Contains(string(tc.completedOrder.Status))
+				} else {
+					body.NotContains("event: completed")
+				}
+			}
+		})
+	}
+}
+
+// TestGetNearbyCouriers выполняет тестирование поиска курьеров поблизости
+func TestGetNearbyCouriers(t *testing.T) {
+	for _, tc := range getNearbyCouriersTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockLocationService := services_mocks.NewMockCourierLocationServiceInterface(t)
+			mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
+			mockProducer := kafka_mocks.NewMockProducerInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+			discardLogger := logger.NewTest()
+
+			h := handlers.NewCourierLocationHandler(mockLocationService, mockOrderService, mockProducer, mockRedis, discardLogger)
+			mux := setupTestCourierLocationRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockLocationService.
+					On("GetNearbyCouriers", 55.7558, 37.6173, tc.expectedRadius, tc.expectedLimit).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			req := e.GET("/api/couriers/nearby")
+			for key, value := range tc.query {
+				req = req.WithQuery(key, value)
+			}
+
+			resp := req.Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				arr := resp.JSON().Array()
+				arr.Length().IsEqual(len(tc.returnedValue))
+				arr.Value(0).Object().Value("id").String().IsEqual(tc.returnedValue[0].ID.String())
+				arr.Value(0).Object().Value("distance_m").Number().IsEqual(tc.returnedValue[0].DistanceMeters)
+			}
+		})
+	}
+}
@@ -132,6 +132,66 @@ func TestUpdateCourierStatus(t *testing.T) {
 	mockCourierService.AssertExpectations(t)
 }
 
+// TestUpdateCourierZone выполняет тестирование привязки курьера к зоне доставки
+func TestUpdateCourierZone(t *testing.T) {
+	mockReviewService := services_mocks.NewMockReviewServiceInterface(t)
+	mockProducer := kafka_mocks.NewMockProducerInterface(t)
+	discardLogger := logger.NewTest()
+
+	for _, tc := range updateCourierZoneTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockCourierService := services_mocks.NewMockCourierServiceInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+
+			h := handlers.NewCourierHandler(mockCourierService, mockReviewService, mockProducer, mockRedis, discardLogger)
+			mux := setupTestCourierRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockCourierService.On("UpdateCourierZone", tc.id, tc.zone).Return(tc.returnedError)
+			if tc.expectedStatusCode == http.StatusOK {
+				mockRedis.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			e.PUT(fmt.Sprintf("/api/couriers/%s/zone", tc.id)).WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
+
+// TestUpdateCourierCapacity выполняет тестирование изменения вместимости курьера
+func TestUpdateCourierCapacity(t *testing.T) {
+	mockReviewService := services_mocks.NewMockReviewServiceInterface(t)
+	mockProducer := kafka_mocks.NewMockProducerInterface(t)
+	discardLogger := logger.NewTest()
+
+	for _, tc := range updateCourierCapacityTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockCourierService := services_mocks.NewMockCourierServiceInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+
+			h := handlers.NewCourierHandler(mockCourierService, mockReviewService, mockProducer, mockRedis, discardLogger)
+			mux := setupTestCourierRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockCourierService.On("UpdateCourierCapacity", tc.id, tc.capacity).Return(tc.returnedError)
+			}
+			if tc.expectedStatusCode == http.StatusOK {
+				mockRedis.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			e.PUT(fmt.Sprintf("/api/couriers/%s/capacity", tc.id)).WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
+
 // TestGetCouriers выполняет тестирование получения списка курьеров
 func TestGetCouriers(t *testing.T) {
 	mockReviewService := services_mocks.NewMockReviewServiceInterface(t)
@@ -257,15 +317,13 @@ func TestAssignOrderToCourier(t *testing.T) {
 	mockRedis := redis_mocks.NewMockRedisClientInterface(t)
 	discardLogger := logger.NewTest()
 
-	mockProducer.On("PublishCourierAssigned", mock.Anything, mock.Anything).
-		Return(nil).Once()
 	mockRedis.On("Delete", mock.Anything, mock.Anything).Return(nil).Twice()
 
 	for _, tc := range assignOrderToCourierTestCases {
 		mockCourierService := services_mocks.NewMockCourierServiceInterface(t)
 
 		if !strings.Contains(tc.name, "_bad_") {
-			mockCourierService.On("AssignOrderToCourier", tc.payload.OrderID, tc.courierID).Return(tc.returnedError)
+			mockCourierService.On("AssignOrderToCourier", tc.payload.OrderID, tc.courierID, dispatcherActor).Return(tc.returnedError)
 		}
 
 		h := handlers.NewCourierHandler(mockCourierService, mockReviewService, mockProducer, mockRedis, discardLogger)
@@ -275,6 +333,7 @@ func TestAssignOrderToCourier(t *testing.T) {
 
 		e := httpexpect.Default(t, server.URL)
 		e.POST(fmt.Sprintf("/api/couriers/%s/assign", tc.courierID.String())).WithJSON(tc.payload).
+			WithHeader("X-Actor-Role", string(dispatcherActor.Role)).
 			Expect().Status(tc.expectedStatusCode)
 
 		mockCourierService.AssertExpectations(t)
@@ -0,0 +1,209 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/services/services_mocks"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+)
+
+// TestCreateCustomer выполняет тестирование создания клиента
+func TestCreateCustomer(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range createCustomerTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockCustomerService := services_mocks.NewMockCustomerServiceInterface(t)
+
+			h := handlers.NewCustomerHandler(mockCustomerService, discardLogger)
+			mux := setupTestCustomerRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockCustomerService.On("CreateCustomer", tc.payload).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			obj := e.POST("/api/customers").WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode).JSON().Object()
+			if tc.expectedStatusCode == http.StatusCreated {
+				obj.Value("id").String().IsEqual(tc.returnedValue.ID.String())
+				obj.Value("name").String().IsEqual(tc.payload.Name)
+				obj.Value("phone").String().IsEqual(tc.returnedValue.Phone)
+			}
+		})
+	}
+}
+
+// TestGetCustomer выполняет тестирование получения клиента
+func TestGetCustomer(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getCustomerTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockCustomerService := services_mocks.NewMockCustomerServiceInterface(t)
+
+			h := handlers.NewCustomerHandler(mockCustomerService, discardLogger)
+			mux := setupTestCustomerRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockCustomerService.On("GetCustomer", tc.id).Return(tc.returnedValue, tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.GET(fmt.Sprintf("/api/customers/%s", tc.id)).Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				addresses := resp.JSON().Object().Value("addresses").Array()
+				addresses.Length().IsEqual(len(tc.returnedValue.Addresses))
+				addresses.Value(0).Object().Value("lat").Number().IsEqual(tc.returnedValue.Addresses[0].Lat)
+			}
+		})
+	}
+}
+
+// TestGetCustomers выполняет тестирование получения списка клиентов
+func TestGetCustomers(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getCustomersTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockCustomerService := services_mocks.NewMockCustomerServiceInterface(t)
+
+			h := handlers.NewCustomerHandler(mockCustomerService, discardLogger)
+			mux := setupTestCustomerRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockCustomerService.On("GetCustomers", tc.phone, 50, 0).Return(tc.returnedValue, tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			req := e.GET("/api/customers")
+			if tc.phone != "" {
+				req.WithQuery("phone", tc.phone)
+			}
+			resp := req.Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				resp.JSON().Array().Length().IsEqual(len(tc.returnedValue))
+			}
+		})
+	}
+}
+
+// TestUpdateCustomer выполняет тестирование обновления клиента
+func TestUpdateCustomer(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range updateCustomerTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockCustomerService := services_mocks.NewMockCustomerServiceInterface(t)
+
+			h := handlers.NewCustomerHandler(mockCustomerService, discardLogger)
+			mux := setupTestCustomerRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockCustomerService.On("UpdateCustomer", tc.id, tc.payload).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			e.PUT(fmt.Sprintf("/api/customers/%s", tc.id)).WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
+
+// TestAddCustomerAddress выполняет тестирование сохранения адреса клиента
+func TestAddCustomerAddress(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range addCustomerAddressTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockCustomerService := services_mocks.NewMockCustomerServiceInterface(t)
+
+			h := handlers.NewCustomerHandler(mockCustomerService, discardLogger)
+			mux := setupTestCustomerRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockCustomerService.On("AddAddress", tc.id, tc.payload).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			obj := e.POST(fmt.Sprintf("/api/customers/%s/addresses", tc.id)).WithJSON(tc.payload).
+				Expect().Status(tc.expectedStatusCode).JSON().Object()
+			if tc.expectedStatusCode == http.StatusCreated {
+				obj.Value("id").String().IsEqual(tc.returnedValue.ID.String())
+				obj.Value("address").String().IsEqual(tc.payload.Address)
+				obj.Value("lon").Number().IsEqual(tc.returnedValue.Lon)
+			}
+		})
+	}
+}
+
+// TestDeleteCustomerAddress выполняет тестирование удаления сохранённого адреса клиента
+func TestDeleteCustomerAddress(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range deleteCustomerAddressTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockCustomerService := services_mocks.NewMockCustomerServiceInterface(t)
+
+			h := handlers.NewCustomerHandler(mockCustomerService, discardLogger)
+			mux := setupTestCustomerRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockCustomerService.On("DeleteAddress", tc.id, tc.addressID).Return(tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			e.DELETE(fmt.Sprintf("/api/customers/%s/addresses/%s", tc.id, tc.addressID)).
+				Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
+
+// TestGetCustomerOrders выполняет тестирование получения истории заказов клиента
+func TestGetCustomerOrders(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getCustomerOrdersTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockCustomerService := services_mocks.NewMockCustomerServiceInterface(t)
+
+			h := handlers.NewCustomerHandler(mockCustomerService, discardLogger)
+			mux := setupTestCustomerRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockCustomerService.On("GetCustomerOrders", tc.id, 20, 0).Return(tc.returnedValue, tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.GET(fmt.Sprintf("/api/customers/%s/orders", tc.id)).
+				WithQuery("limit", 20).
+				Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				resp.JSON().Array().Length().IsEqual(len(tc.returnedValue))
+			}
+		})
+	}
+}
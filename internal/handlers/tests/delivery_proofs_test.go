@@ -0,0 +1,152 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/redis/redis_mocks"
+	"delivery-system/internal/services/services_mocks"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestConfirmDelivery выполняет тестирование подтверждения доставки заказа курьером
+func TestConfirmDelivery(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range confirmDeliveryTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockProofService := services_mocks.NewMockDeliveryProofServiceInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+
+			h := handlers.NewDeliveryProofHandler(mockProofService, mockRedis, discardLogger, 1)
+			mux := setupTestDeliveryProofRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockProofService.On("ConfirmDelivery", tc.id, tc.request, courierActor).Return(tc.returnedValue, tc.returnedError)
+			}
+			if tc.expectedStatusCode == http.StatusOK {
+				// Инвалидируется кеш заказа и курьера
+				mockRedis.On("Delete", mock.Anything, mock.Anything).Return(nil).Times(2)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			req := e.POST(fmt.Sprintf("/api/orders/%s/delivery-proof", tc.id)).
+				WithHeader("X-Actor-Role", string(courierActor.Role)).
+				WithHeader("X-Actor-ID", courierActor.ID).
+				WithMultipart().
+				WithFormField("pin", tc.form.Pin).
+				WithFormField("lat", tc.form.Lat).
+				WithFormField("lon", tc.form.Lon)
+			if tc.form.Photo != nil {
+				req = req.WithFileBytes("photo", "photo.png", tc.form.Photo)
+			}
+			if tc.form.Signature != nil {
+				req = req.WithFileBytes("signature", "signature.png", tc.form.Signature)
+			}
+
+			resp := req.Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				obj := resp.JSON().Object()
+				obj.Value("order_id").String().IsEqual(tc.returnedValue.OrderID.String())
+				obj.Value("pin_verified").Boolean().IsEqual(tc.returnedValue.PinVerified)
+				obj.Value("distance_m").Number().IsEqual(tc.returnedValue.DistanceMeters)
+			}
+		})
+	}
+}
+
+// TestConfirmDeliveryTooLarge выполняет тестирование отклонения слишком большого запроса подтверждения доставки
+func TestConfirmDeliveryTooLarge(t *testing.T) {
+	discardLogger := logger.NewTest()
+	mockProofService := services_mocks.NewMockDeliveryProofServiceInterface(t)
+	mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+
+	h := handlers.NewDeliveryProofHandler(mockProofService, mockRedis, discardLogger, 1)
+	mux := setupTestDeliveryProofRoutes(h)
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	photo := append(append([]byte{}, proofImage...), make([]byte, 2<<20)...)
+
+	e := httpexpect.Default(t, server.URL)
+	e.POST(fmt.Sprintf("/api/orders/%s/delivery-proof", orderID)).
+		WithMultipart().
+		WithFormField("pin", deliveryProofForm.Pin).
+		WithFormField("lat", deliveryProofForm.Lat).
+		WithFormField("lon", deliveryProofForm.Lon).
+		WithFileBytes("photo", "photo.png", photo).
+		Expect().Status(http.StatusRequestEntityTooLarge)
+}
+
+// TestGetDeliveryProof выполняет тестирование получения подтверждения доставки заказа
+func TestGetDeliveryProof(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getDeliveryProofTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockProofService := services_mocks.NewMockDeliveryProofServiceInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+
+			h := handlers.NewDeliveryProofHandler(mockProofService, mockRedis, discardLogger, 1)
+			mux := setupTestDeliveryProofRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockProofService.On("GetDeliveryProof", tc.id).Return(tc.returnedValue, tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.GET(fmt.Sprintf("/api/orders/%s/delivery-proof", tc.id)).Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				obj := resp.JSON().Object()
+				obj.Value("courier_id").String().IsEqual(tc.returnedValue.CourierID.String())
+				obj.Value("photo_key").String().IsEqual(*tc.returnedValue.PhotoKey)
+				obj.NotContainsKey("signature_key")
+			}
+		})
+	}
+}
+
+// TestGetDeliveryProofFile выполняет тестирование получения фото и подписи из подтверждения доставки
+func TestGetDeliveryProofFile(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getDeliveryProofFileTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockProofService := services_mocks.NewMockDeliveryProofServiceInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+
+			h := handlers.NewDeliveryProofHandler(mockProofService, mockRedis, discardLogger, 1)
+			mux := setupTestDeliveryProofRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.returnedValue != nil || tc.returnedError != nil {
+				mockProofService.On("GetDeliveryProofFile", tc.id, models.ProofFileKind(tc.kind)).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.GET(fmt.Sprintf("/api/orders/%s/delivery-proof/%s", tc.id, tc.kind)).
+				Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				resp.Header("Content-Type").IsEqual("image/png")
+				resp.Body().IsEqual(string(tc.returnedValue))
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package handler_tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"delivery-system/internal/services"
+)
+
+// TestLocalGeocoder выполняет тестирование поиска координат в адресной книге офлайн-геокодера
+func TestLocalGeocoder(t *testing.T) {
+	geocoder := services.NewLocalGeocoderFromMap(localAddressBook)
+
+	for _, tc := range localGeocoderTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			lng, lat, err := geocoder.GetCoordinates(tc.address)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedLng, lng)
+			assert.Equal(t, tc.expectedLat, lat)
+		})
+	}
+}
+
+// TestLoadAddressBook выполняет тестирование загрузки адресной книги офлайн-геокодера из файла
+func TestLoadAddressBook(t *testing.T) {
+	for _, tc := range loadAddressBookTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "address_book.json")
+			if !tc.noFile {
+				require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+			}
+
+			geocoder, err := services.NewLocalGeocoder(path)
+			if tc.hasError {
+				assert.Error(t, err)
+				assert.Nil(t, geocoder)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, geocoder)
+		})
+	}
+
+	t.Run("test_path_required", func(t *testing.T) {
+		_, err := services.NewLocalGeocoder("")
+		assert.Error(t, err)
+	})
+
+	t.Run("test_loaded_addresses", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "address_book.json")
+		require.NoError(t, os.WriteFile(path, []byte(loadAddressBookTestCases[0].content), 0o600))
+
+		geocoder, err := services.NewLocalGeocoder(path)
+		require.NoError(t, err)
+
+		lng, lat, err := geocoder.GetCoordinates("москва, тверская улица, 1")
+		require.NoError(t, err)
+		assert.Equal(t, 37.6117, lng)
+		assert.Equal(t, 55.7574, lat)
+	})
+}
+
+// TestHaversineRouter выполняет тестирование офлайн-расчёта длины маршрута
+func TestHaversineRouter(t *testing.T) {
+	router := services.NewHaversineRouter()
+
+	for _, tc := range haversineRouterTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			distance, err := router.MakeRoute(tc.coordinates)
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tc.expectedDistance, distance, 1)
+		})
+	}
+}
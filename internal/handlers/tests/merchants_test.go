@@ -0,0 +1,260 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/services/services_mocks"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+)
+
+// TestCreateMerchant выполняет тестирование создания продавца
+func TestCreateMerchant(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range createMerchantTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockMerchantService := services_mocks.NewMockMerchantServiceInterface(t)
+
+			h := handlers.NewMerchantHandler(mockMerchantService, discardLogger)
+			mux := setupTestMerchantRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockMerchantService.On("CreateMerchant", tc.payload).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			obj := e.POST("/api/merchants").WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode).JSON().Object()
+			if tc.expectedStatusCode == http.StatusCreated {
+				obj.Value("id").String().IsEqual(tc.returnedValue.ID.String())
+				obj.Value("pickup_address").String().IsEqual(tc.payload.PickupAddress)
+				obj.Value("lat").Number().IsEqual(tc.returnedValue.Lat)
+				obj.Value("opening_hours").Array().Length().IsEqual(len(tc.payload.OpeningHours))
+			}
+		})
+	}
+}
+
+// TestGetMerchant выполняет тестирование получения продавца
+func TestGetMerchant(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getMerchantTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockMerchantService := services_mocks.NewMockMerchantServiceInterface(t)
+
+			h := handlers.NewMerchantHandler(mockMerchantService, discardLogger)
+			mux := setupTestMerchantRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockMerchantService.On("GetMerchant", tc.id).Return(tc.returnedValue, tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.GET(fmt.Sprintf("/api/merchants/%s", tc.id)).Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				hours := resp.JSON().Object().Value("opening_hours").Array()
+				hours.Value(0).Object().Value("open").String().IsEqual(tc.returnedValue.OpeningHours[0].Open)
+			}
+		})
+	}
+}
+
+// TestGetMerchants выполняет тестирование получения списка продавцов
+func TestGetMerchants(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getMerchantsTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockMerchantService := services_mocks.NewMockMerchantServiceInterface(t)
+
+			h := handlers.NewMerchantHandler(mockMerchantService, discardLogger)
+			mux := setupTestMerchantRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockMerchantService.On("GetMerchants", tc.activeOnly).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			req := e.GET("/api/merchants")
+			if tc.active != "" {
+				req.WithQuery("active", tc.active)
+			}
+			resp := req.Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				resp.JSON().Array().Length().IsEqual(len(tc.returnedValue))
+			}
+		})
+	}
+}
+
+// TestUpdateMerchant выполняет тестирование обновления продавца
+func TestUpdateMerchant(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range updateMerchantTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockMerchantService := services_mocks.NewMockMerchantServiceInterface(t)
+
+			h := handlers.NewMerchantHandler(mockMerchantService, discardLogger)
+			mux := setupTestMerchantRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockMerchantService.On("UpdateMerchant", tc.id, tc.payload).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			e.PUT(fmt.Sprintf("/api/merchants/%s", tc.id)).WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
+
+// TestDeleteMerchant выполняет тестирование деактивации продавца
+func TestDeleteMerchant(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range deleteMerchantTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockMerchantService := services_mocks.NewMockMerchantServiceInterface(t)
+
+			h := handlers.NewMerchantHandler(mockMerchantService, discardLogger)
+			mux := setupTestMerchantRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockMerchantService.On("DeleteMerchant", tc.id).Return(tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			e.DELETE(fmt.Sprintf("/api/merchants/%s", tc.id)).Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
+
+// TestCreateProduct выполняет тестирование добавления товара в каталог продавца
+func TestCreateProduct(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range createProductTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockMerchantService := services_mocks.NewMockMerchantServiceInterface(t)
+
+			h := handlers.NewMerchantHandler(mockMerchantService, discardLogger)
+			mux := setupTestMerchantRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockMerchantService.On("CreateProduct", tc.id, tc.payload).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			obj := e.POST(fmt.Sprintf("/api/merchants/%s/products", tc.id)).WithJSON(tc.payload).
+				Expect().Status(tc.expectedStatusCode).JSON().Object()
+			if tc.expectedStatusCode == http.StatusCreated {
+				obj.Value("id").String().IsEqual(tc.returnedValue.ID.String())
+				obj.Value("merchant_id").String().IsEqual(tc.id.String())
+				obj.Value("price").Number().IsEqual(tc.payload.Price)
+			}
+		})
+	}
+}
+
+// TestGetProducts выполняет тестирование получения каталога продавца
+func TestGetProducts(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getProductsTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockMerchantService := services_mocks.NewMockMerchantServiceInterface(t)
+
+			h := handlers.NewMerchantHandler(mockMerchantService, discardLogger)
+			mux := setupTestMerchantRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockMerchantService.On("GetProducts", tc.id, true).Return(tc.returnedValue, tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.GET(fmt.Sprintf("/api/merchants/%s/products", tc.id)).
+				WithQuery("available", "true").
+				Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				resp.JSON().Array().Length().IsEqual(len(tc.returnedValue))
+			}
+		})
+	}
+}
+
+// TestUpdateProduct выполняет тестирование обновления товара каталога
+func TestUpdateProduct(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range updateProductTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockMerchantService := services_mocks.NewMockMerchantServiceInterface(t)
+
+			h := handlers.NewMerchantHandler(mockMerchantService, discardLogger)
+			mux := setupTestMerchantRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockMerchantService.On("UpdateProduct", tc.id, tc.productID, tc.payload).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			e.PUT(fmt.Sprintf("/api/merchants/%s/products/%s", tc.id, tc.productID)).WithJSON(tc.payload).
+				Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
+
+// TestDeleteProduct выполняет тестирование удаления товара из каталога
+func TestDeleteProduct(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range deleteProductTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockMerchantService := services_mocks.NewMockMerchantServiceInterface(t)
+
+			h := handlers.NewMerchantHandler(mockMerchantService, discardLogger)
+			mux := setupTestMerchantRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockMerchantService.On("DeleteProduct", tc.id, tc.productID).Return(tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			e.DELETE(fmt.Sprintf("/api/merchants/%s/products/%s", tc.id, tc.productID)).
+				Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
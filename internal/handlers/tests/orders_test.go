@@ -7,11 +7,12 @@ import (
 	"testing"
 
 	"github.com/gavv/httpexpect/v2"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 
 	"delivery-system/internal/handlers"
-	"delivery-system/internal/kafka/kafka_mocks"
 	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
 	"delivery-system/internal/redis/redis_mocks"
 	"delivery-system/internal/services/services_mocks"
 )
@@ -20,12 +21,13 @@ import (
 func TestGetOrder(t *testing.T) {
 	mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
 	mockReviewService := services_mocks.NewMockReviewServiceInterface(t)
-	mockProducer := kafka_mocks.NewMockProducerInterface(t)
 	mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+	mockAssignmentService := services_mocks.NewMockCourierAssignmentServiceInterface(t)
+	mockEventService := services_mocks.NewMockOrderEventServiceInterface(t)
 	discardLogger := logger.NewTest()
 
 	// Создаём хендлер
-	h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockProducer, mockRedis, discardLogger)
+	h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockAssignmentService, mockEventService, mockRedis, discardLogger)
 	mux := setupTestOrderRoutes(h)
 
 	mockRedis.
@@ -59,8 +61,9 @@ func TestGetOrder(t *testing.T) {
 // TestCreateOrder выполняет тестирование создания заказа
 func TestCreateOrder(t *testing.T) {
 	mockReviewService := services_mocks.NewMockReviewServiceInterface(t)
-	mockProducer := kafka_mocks.NewMockProducerInterface(t)
 	mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+	mockAssignmentService := services_mocks.NewMockCourierAssignmentServiceInterface(t)
+	mockEventService := services_mocks.NewMockOrderEventServiceInterface(t)
 	discardLogger := logger.NewTest()
 
 	for _, tc := range createOrderTestCases {
@@ -68,28 +71,27 @@ func TestCreateOrder(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
 
-			h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockProducer, mockRedis, discardLogger)
+			h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockAssignmentService, mockEventService, mockRedis, discardLogger)
 			mux := setupTestOrderRoutes(h)
 
 			server := httptest.NewServer(mux)
 
 			if tc.expectedStatusCode != http.StatusBadRequest {
-				mockOrderService.On("CreateOrder", tc.payload).Return(tc.returnedValue, tc.returnedError)
-				mockProducer.On("PublishOrderCreated", mock.Anything).Return(nil).Maybe()
+				mockOrderService.On("CreateOrder", tc.payload, dispatcherActor).Return(tc.returnedValue, tc.returnedError)
 				mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 			}
 
 			e := httpexpect.Default(t, server.URL)
 
-			obj := e.POST("/api/orders").WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode).JSON().Object()
+			obj := e.POST("/api/orders").WithHeader("X-Actor-Role", string(dispatcherActor.Role)).WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode).JSON().Object()
 			if tc.expectedStatusCode == http.StatusCreated {
-				obj.Value("customer_name").String().IsEqual(tc.payload.CustomerName)
-				obj.Value("customer_phone").String().IsEqual(tc.payload.CustomerPhone)
-				obj.Value("pickup_address").String().IsEqual(tc.payload.PickupAddress)
-				obj.Value("delivery_address").String().IsEqual(tc.payload.DeliveryAddress)
+				obj.Value("customer_name").String().IsEqual(tc.returnedValue.CustomerName)
+				obj.Value("customer_phone").String().IsEqual(tc.returnedValue.CustomerPhone)
+				obj.Value("pickup_address").String().IsEqual(tc.returnedValue.PickupAddress)
+				obj.Value("delivery_address").String().IsEqual(tc.returnedValue.DeliveryAddress)
 				itemsArray := obj.Value("items").Array()
-				itemsArray.Length().IsEqual(len(tc.payload.Items))
-				for i, item := range tc.payload.Items {
+				itemsArray.Length().IsEqual(len(tc.returnedValue.Items))
+				for i, item := range tc.returnedValue.Items {
 					expectedItem := itemsArray.Value(i).Object()
 					expectedItem.Value("name").String().IsEqual(item.Name)
 					expectedItem.Value("quantity").Number().IsEqual(item.Quantity)
@@ -98,6 +100,17 @@ func TestCreateOrder(t *testing.T) {
 					expectedItem.Value("order_id").String().NotEmpty()
 				}
 				obj.Value("id").String().NotEmpty()
+				obj.Value("eta").String().NotEmpty()
+				obj.Value("sla_deadline").String().NotEmpty()
+				obj.Value("status").String().IsEqual(string(tc.returnedValue.Status))
+				if tc.payload.MerchantID != nil {
+					obj.Value("merchant_id").String().IsEqual(tc.payload.MerchantID.String())
+					itemsArray.Value(0).Object().Value("product_id").String().IsEqual(tc.payload.Items[0].ProductID.String())
+				}
+				if tc.payload.ScheduledFor != nil {
+					obj.Value("scheduled_for").String().NotEmpty()
+					obj.Value("dispatch_at").String().NotEmpty()
+				}
 			}
 			mockOrderService.AssertExpectations(t)
 			server.Close()
@@ -105,59 +118,333 @@ func TestCreateOrder(t *testing.T) {
 	}
 }
 
+// TestCreateOrderAsCustomer выполняет тестирование создания заказа клиентом: заказ привязывается
+// к клиенту-инициатору, а заказ от имени другого клиента и собственная стоимость доставки запрещены
+func TestCreateOrderAsCustomer(t *testing.T) {
+	mockReviewService := services_mocks.NewMockReviewServiceInterface(t)
+	mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+	mockAssignmentService := services_mocks.NewMockCourierAssignmentServiceInterface(t)
+	mockEventService := services_mocks.NewMockOrderEventServiceInterface(t)
+	discardLogger := logger.NewTest()
+
+	mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	for _, tc := range createOrderAsCustomerTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
+
+			h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockAssignmentService, mockEventService, mockRedis, discardLogger)
+			server := httptest.NewServer(setupTestOrderRoutes(h))
+			defer server.Close()
+
+			if tc.expectedStatusCode == http.StatusCreated {
+				actor := models.Actor{Role: models.RoleCustomer, ID: tc.actorID}
+				mockOrderService.
+					On("CreateOrder", mock.MatchedBy(func(req *models.CreateOrderRequest) bool {
+						return req.CustomerID != nil && req.CustomerID.String() == tc.actorID && req.DeliveryCost == nil
+					}), actor).
+					Return(order1, nil)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			e.POST("/api/orders").
+				WithHeader("X-Actor-Role", string(models.RoleCustomer)).
+				WithHeader("X-Actor-ID", tc.actorID).
+				WithJSON(tc.payload).
+				Expect().Status(tc.expectedStatusCode)
+
+			mockOrderService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestCreateOrderWithAutoAssign выполняет тестирование создания заказа с автоназначением курьера
+func TestCreateOrderWithAutoAssign(t *testing.T) {
+	mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
+	mockReviewService := services_mocks.NewMockReviewServiceInterface(t)
+	mockAssignmentService := services_mocks.NewMockCourierAssignmentServiceInterface(t)
+	mockEventService := services_mocks.NewMockOrderEventServiceInterface(t)
+	mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+	discardLogger := logger.NewTest()
+
+	h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockAssignmentService, mockEventService, mockRedis, discardLogger)
+	mux := setupTestOrderRoutes(h)
+
+	createdOrder := *order3
+	assignedOrder := *order3
+	assignedOrder.CourierID = &courier1.ID
+	assignedOrder.Status = models.OrderStatusAccepted
+	mockOrderService.On("CreateOrder", &createOrderAutoAssignRequest, dispatcherActor).Return(&createdOrder, nil)
+	mockAssignmentService.On("AutoAssign", order3.ID, dispatcherActor).Return(&models.AssignmentResult{
+		OrderID: order3.ID, CourierID: courier1.ID, Score: 0.9,
+	}, nil)
+	// Статус назначенного заказа берётся из перечитанного заказа
+	mockOrderService.On("GetOrder", order3.ID).Return(&assignedOrder, nil)
+	mockRedis.On("Delete", mock.Anything, mock.Anything).Return(nil).Twice()
+	mockRedis.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	obj := e.POST("/api/orders").WithHeader("X-Actor-Role", string(dispatcherActor.Role)).WithJSON(createOrderAutoAssignRequest).Expect().Status(http.StatusCreated).JSON().Object()
+	obj.Value("courier_id").String().IsEqual(courier1.ID.String())
+	obj.Value("status").String().IsEqual(string(models.OrderStatusAccepted))
+}
+
+// TestAutoAssignOrder выполняет тестирование автоназначения курьера на заказ
+func TestAutoAssignOrder(t *testing.T) {
+	mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
+	mockReviewService := services_mocks.NewMockReviewServiceInterface(t)
+	mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+	discardLogger := logger.NewTest()
+	mockRedis.On("Delete", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	for _, tc := range autoAssignOrderTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockAssignmentService := services_mocks.NewMockCourierAssignmentServiceInterface(t)
+			mockEventService := services_mocks.NewMockOrderEventServiceInterface(t)
+
+			h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockAssignmentService, mockEventService, mockRedis, discardLogger)
+			mux := setupTestOrderRoutes(h)
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockAssignmentService.
+					On("AutoAssign", mock.AnythingOfType("uuid.UUID"), dispatcherActor).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			e := httpexpect.Default(t, server.URL)
+			obj := e.POST(fmt.Sprintf("/api/orders/%s/auto-assign", tc.orderID)).
+				WithHeader("X-Actor-Role", string(dispatcherActor.Role)).
+				Expect().Status(tc.expectedStatusCode).JSON().Object()
+			if tc.expectedStatusCode == http.StatusOK {
+				obj.Value("courier_id").String().IsEqual(tc.returnedValue.CourierID.String())
+				obj.Value("candidates").Array().Length().IsEqual(len(tc.returnedValue.Candidates))
+			}
+			mockAssignmentService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestGetOrderEvents выполняет тестирование получения журнала событий заказа
+func TestGetOrderEvents(t *testing.T) {
+	mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
+	mockReviewService := services_mocks.NewMockReviewServiceInterface(t)
+	mockAssignmentService := services_mocks.NewMockCourierAssignmentServiceInterface(t)
+	mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getOrderEventsTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockEventService := services_mocks.NewMockOrderEventServiceInterface(t)
+
+			h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockAssignmentService, mockEventService, mockRedis, discardLogger)
+			mux := setupTestOrderRoutes(h)
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockEventService.
+					On("GetEvents", uuid.MustParse(tc.orderID)).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.GET(fmt.Sprintf("/api/orders/%s/events", tc.orderID)).Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				events := resp.JSON().Array()
+				events.Length().IsEqual(len(tc.returnedValue))
+				events.Value(1).Object().Value("version").Number().IsEqual(2)
+				events.Value(1).Object().Value("actor").Object().Value("role").String().IsEqual(string(models.RoleDispatcher))
+				events.Value(1).Object().Value("actor").Object().Value("id").String().IsEqual("dispatcher-1")
+				events.Value(1).Object().Value("payload").Object().Value("new_status").String().IsEqual("accepted")
+			}
+			mockEventService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestReplayOrder выполняет тестирование восстановления заказа из журнала событий
+func TestReplayOrder(t *testing.T) {
+	mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
+	mockReviewService := services_mocks.NewMockReviewServiceInterface(t)
+	mockAssignmentService := services_mocks.NewMockCourierAssignmentServiceInterface(t)
+	mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+	discardLogger := logger.NewTest()
+
+	for _, tc := range replayOrderTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockEventService := services_mocks.NewMockOrderEventServiceInterface(t)
+
+			h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockAssignmentService, mockEventService, mockRedis, discardLogger)
+			mux := setupTestOrderRoutes(h)
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockEventService.
+					On("Replay", orderID, tc.expectedVersion).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			e := httpexpect.Default(t, server.URL)
+			req := e.GET(fmt.Sprintf("/api/orders/%s/replay", orderID))
+			if tc.version != "" {
+				req = req.WithQuery("version", tc.version)
+			}
+			resp := req.Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				obj := resp.JSON().Object()
+				obj.Value("version").Number().IsEqual(tc.returnedValue.Version)
+				obj.Value("snapshot_version").Number().IsEqual(tc.returnedValue.SnapshotVersion)
+				obj.Value("order").Object().Value("id").String().IsEqual(orderID.String())
+			}
+			mockEventService.AssertExpectations(t)
+		})
+	}
+}
+
 // TestUpdateOrderStatus выполняет тестировани обновление статуса заказа
 func TestUpdateOrderStatus(t *testing.T) {
 	// Создаём моки сервисов
 	mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
 	mockReviewService := services_mocks.NewMockReviewServiceInterface(t)
-	mockProducer := kafka_mocks.NewMockProducerInterface(t)
 	mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+	mockAssignmentService := services_mocks.NewMockCourierAssignmentServiceInterface(t)
+	mockEventService := services_mocks.NewMockOrderEventServiceInterface(t)
 	discardLogger := logger.NewTest()
 
 	// Создаём хендлер
-	h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockProducer, mockRedis, discardLogger)
+	h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockAssignmentService, mockEventService, mockRedis, discardLogger)
 	mux := setupTestOrderRoutes(h)
 
-	// Задаём ожидания для моков OrderService, Kafka Producer, Redis Client
-	mockProducer.
-		On("PublishOrderStatusChanged", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-		Return(nil).Maybe().Once()
+	// Задаём ожидания для моков Kafka Producer, Redis Client
 	mockRedis.
 		On("Delete", mock.Anything, mock.Anything).
-		Return(nil).Maybe().Once()
-	mockOrderService.
-		On("GetOrder", mock.AnythingOfType("uuid.UUID")).
-		Return(order1, nil)
+		Return(nil).Maybe()
 
 	for _, tc := range updateOrderStatusTestCases {
 		tc := tc
-		mockOrderService.
-			On("UpdateOrderStatus", tc.id, mock.AnythingOfType("*models.UpdateOrderStatusRequest")).
-			Return(tc.returnedError)
+		// Перед переводом в статусы приготовления обработчик проверяет, не является ли заказ заказом продавца
+		if tc.order != nil || tc.orderError != nil {
+			mockOrderService.On("GetOrder", tc.id).Return(tc.order, tc.orderError).Once()
+		}
+		if tc.orderError == nil && tc.expectedStatusCode != http.StatusBadRequest {
+			mockOrderService.
+				On("UpdateOrderStatus", tc.id, mock.AnythingOfType("*models.UpdateOrderStatusRequest"), mock.AnythingOfType("models.Actor")).
+				Return(tc.returnedValue, tc.returnedError).Once()
+		}
 
 		server := httptest.NewServer(mux)
 
 		e := httpexpect.Default(t, server.URL)
-		e.PUT(fmt.Sprintf("/api/orders/%s/status", tc.id)).WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode)
+		req := e.PUT(fmt.Sprintf("/api/orders/%s/status", tc.id)).WithJSON(tc.payload)
+		if tc.actorRole != "" {
+			req = req.WithHeader("X-Actor-Role", tc.actorRole)
+		}
+		req.Expect().Status(tc.expectedStatusCode)
 
 		server.Close()
 	}
-	mockProducer.AssertExpectations(t)
 	mockRedis.AssertExpectations(t)
 	mockOrderService.AssertExpectations(t)
 }
 
+// TestCancelOrder выполняет тестирование отмены заказа
+func TestCancelOrder(t *testing.T) {
+	mockReviewService := services_mocks.NewMockReviewServiceInterface(t)
+	mockAssignmentService := services_mocks.NewMockCourierAssignmentServiceInterface(t)
+	mockEventService := services_mocks.NewMockOrderEventServiceInterface(t)
+	discardLogger := logger.NewTest()
+
+	for _, tc := range cancelOrderTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+
+			h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockAssignmentService, mockEventService, mockRedis, discardLogger)
+			mux := setupTestOrderRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockOrderService.
+					On("CancelOrder", tc.id, tc.payload, mock.AnythingOfType("models.Actor")).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+			if tc.expectedStatusCode == http.StatusOK {
+				// Инвалидируется кеш заказа и освобождённого курьера
+				mockRedis.On("Delete", mock.Anything, mock.Anything).Return(nil).Twice()
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			req := e.POST(fmt.Sprintf("/api/orders/%s/cancel", tc.id)).WithJSON(tc.payload)
+			if tc.actorRole != "" {
+				req = req.WithHeader("X-Actor-Role", tc.actorRole).WithHeader("X-Actor-ID", tc.actorID)
+			}
+			resp := req.Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				obj := resp.JSON().Object()
+				obj.Value("reason").String().IsEqual(string(tc.returnedValue.Reason))
+				obj.Value("stage").String().IsEqual(string(tc.returnedValue.Stage))
+				obj.Value("fee").Number().IsEqual(tc.returnedValue.Fee)
+				obj.Value("refund_amount").Number().IsEqual(tc.returnedValue.RefundAmount)
+			}
+		})
+	}
+}
+
+// TestGetOrderCancellation выполняет тестирование получения сведений об отмене заказа
+func TestGetOrderCancellation(t *testing.T) {
+	mockReviewService := services_mocks.NewMockReviewServiceInterface(t)
+	mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+	mockAssignmentService := services_mocks.NewMockCourierAssignmentServiceInterface(t)
+	mockEventService := services_mocks.NewMockOrderEventServiceInterface(t)
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getOrderCancellationTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
+
+			h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockAssignmentService, mockEventService, mockRedis, discardLogger)
+			mux := setupTestOrderRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockOrderService.On("GetOrderCancellation", tc.id).Return(tc.returnedValue, tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			e.GET(fmt.Sprintf("/api/orders/%s/cancellation", tc.id)).Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
+
 // TestGetOrders выполняет тестирование получения списка заказов
 func TestGetOrders(t *testing.T) {
 	mockReviewService := services_mocks.NewMockReviewServiceInterface(t)
-	mockProducer := kafka_mocks.NewMockProducerInterface(t)
 	mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+	mockAssignmentService := services_mocks.NewMockCourierAssignmentServiceInterface(t)
+	mockEventService := services_mocks.NewMockOrderEventServiceInterface(t)
 	discardLogger := logger.NewTest()
 
 	for _, tc := range getOrdersTestCases {
 		mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
 
-		h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockProducer, mockRedis, discardLogger)
+		h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockAssignmentService, mockEventService, mockRedis, discardLogger)
 		mux := setupTestOrderRoutes(h)
 
 		tc := tc
@@ -212,8 +499,9 @@ func TestGetOrders(t *testing.T) {
 
 // TestCreateReview выполняет тестирование создания отзыва
 func TestCreateReview(t *testing.T) {
-	mockProducer := kafka_mocks.NewMockProducerInterface(t)
 	mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+	mockAssignmentService := services_mocks.NewMockCourierAssignmentServiceInterface(t)
+	mockEventService := services_mocks.NewMockOrderEventServiceInterface(t)
 	discardLogger := logger.NewTest()
 
 	mockRedis.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(errorNotFound).Twice()
@@ -225,14 +513,14 @@ func TestCreateReview(t *testing.T) {
 			mockOrderService := services_mocks.NewMockOrderServiceInterface(t)
 			mockReviewService := services_mocks.NewMockReviewServiceInterface(t)
 
-			h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockProducer, mockRedis, discardLogger)
+			h := handlers.NewOrderHandler(mockOrderService, mockReviewService, mockAssignmentService, mockEventService, mockRedis, discardLogger)
 			mux := setupTestOrderRoutes(h)
 
 			server := httptest.NewServer(mux)
 
 			if tc.expectedStatusCode != http.StatusBadRequest {
 				mockOrderService.On("GetOrder", tc.order.ID).Return(tc.order, nil)
-				mockReviewService.On("CreateReview", tc.payload, tc.order).Return(tc.returnedValue, tc.returnedError)
+				mockReviewService.On("CreateReview", tc.payload, tc.order, dispatcherActor).Return(tc.returnedValue, tc.returnedError)
 				if tc.expectedStatusCode == http.StatusCreated {
 					mockReviewService.On("RecalculateRating", mock.Anything).Return(nil)
 				}
@@ -241,6 +529,7 @@ func TestCreateReview(t *testing.T) {
 			e := httpexpect.Default(t, server.URL)
 
 			obj := e.POST(fmt.Sprintf("/api/orders/%s/review", tc.orderID)).
+				WithHeader("X-Actor-Role", string(dispatcherActor.Role)).
 				WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode).
 				JSON().Object()
 			if tc.expectedStatusCode == http.StatusCreated {
@@ -0,0 +1,39 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/services/services_mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+)
+
+// TestGetOutboxMetrics выполняет тестирование получения метрик outbox
+func TestGetOutboxMetrics(t *testing.T) {
+	for _, tc := range getOutboxMetricsTestCases {
+		mockOutboxService := services_mocks.NewMockOutboxServiceInterface(t)
+		discardLogger := logger.NewTest()
+
+		h := handlers.NewOutboxMetricsHandler(mockOutboxService, discardLogger)
+		mux := setupTestOutboxMetricsRoute(h)
+
+		server := httptest.NewServer(mux)
+
+		e := httpexpect.Default(t, server.URL)
+		mockOutboxService.On("GetMetrics").Return(tc.returnedValue, tc.returnedError)
+
+		obj := e.GET("/api/outbox/metrics").Expect().Status(tc.expectedStatusCode).JSON().Object()
+		if tc.expectedStatusCode == http.StatusOK {
+			obj.Value("pending").Number().IsEqual(tc.returnedValue.Pending)
+			obj.Value("retrying").Number().IsEqual(tc.returnedValue.Retrying)
+			obj.Value("oldest_pending_age_seconds").Number().IsEqual(tc.returnedValue.OldestPendingAgeSeconds)
+			obj.Value("published").Number().IsEqual(tc.returnedValue.Published)
+			obj.Value("avg_publish_age_seconds").Number().IsEqual(tc.returnedValue.AvgPublishAgeSeconds)
+		}
+		mockOutboxService.AssertExpectations(t)
+		server.Close()
+	}
+}
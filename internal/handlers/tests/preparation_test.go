@@ -0,0 +1,130 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/redis/redis_mocks"
+	"delivery-system/internal/services/services_mocks"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestAcceptOrder выполняет тестирование принятия заказа продавцом
+func TestAcceptOrder(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range acceptOrderTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockPreparationService := services_mocks.NewMockOrderPreparationServiceInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+
+			h := handlers.NewPreparationHandler(mockPreparationService, mockRedis, discardLogger)
+			mux := setupTestPreparationRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockPreparationService.On("AcceptOrder", tc.id, tc.payload.PrepMinutes, merchantActor).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+			if tc.expectedStatusCode == http.StatusOK {
+				mockRedis.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.POST(fmt.Sprintf("/api/orders/%s/accept", tc.id)).
+				WithHeader("X-Actor-Role", string(merchantActor.Role)).
+				WithHeader("X-Actor-ID", merchantActor.ID).
+				WithJSON(tc.payload).
+				Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				obj := resp.JSON().Object()
+				obj.Value("new_status").String().IsEqual(string(tc.returnedValue.NewStatus))
+				obj.Value("prep_minutes").Number().IsEqual(*tc.returnedValue.PrepMinutes)
+				obj.Value("ready_at").String().AsDateTime().IsEqual(*tc.returnedValue.ReadyAt)
+			}
+		})
+	}
+}
+
+// TestRejectOrder выполняет тестирование отказа продавца от заказа
+func TestRejectOrder(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range rejectOrderTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockPreparationService := services_mocks.NewMockOrderPreparationServiceInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+
+			h := handlers.NewPreparationHandler(mockPreparationService, mockRedis, discardLogger)
+			mux := setupTestPreparationRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockPreparationService.On("RejectOrder", tc.id, tc.payload, merchantActor).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+			if tc.expectedStatusCode == http.StatusOK {
+				mockRedis.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.POST(fmt.Sprintf("/api/orders/%s/reject", tc.id)).
+				WithHeader("X-Actor-Role", string(merchantActor.Role)).
+				WithHeader("X-Actor-ID", merchantActor.ID).
+				WithJSON(tc.payload).
+				Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				obj := resp.JSON().Object()
+				obj.Value("reason").String().IsEqual(string(tc.returnedValue.Reason))
+				obj.Value("refund_amount").Number().IsEqual(tc.returnedValue.RefundAmount)
+			}
+		})
+	}
+}
+
+// TestPreparationSteps выполняет тестирование отметок начала приготовления и готовности заказа
+func TestPreparationSteps(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range preparationStepTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockPreparationService := services_mocks.NewMockOrderPreparationServiceInterface(t)
+			mockRedis := redis_mocks.NewMockRedisClientInterface(t)
+
+			h := handlers.NewPreparationHandler(mockPreparationService, mockRedis, discardLogger)
+			mux := setupTestPreparationRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockPreparationService.On(tc.method, tc.id, merchantActor).Return(tc.returnedValue, tc.returnedError)
+			if tc.expectedStatusCode == http.StatusOK {
+				mockRedis.On("Delete", mock.Anything, mock.Anything).Return(nil).Once()
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.POST(fmt.Sprintf("/api/orders/%s/%s", tc.id, tc.path)).
+				WithHeader("X-Actor-Role", string(merchantActor.Role)).
+				WithHeader("X-Actor-ID", merchantActor.ID).
+				Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				obj := resp.JSON().Object()
+				obj.Value("old_status").String().IsEqual(string(tc.returnedValue.OldStatus))
+				obj.Value("new_status").String().IsEqual(string(tc.returnedValue.NewStatus))
+				obj.Value("courier_id").String().IsEqual(tc.returnedValue.CourierID.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/services/services_mocks"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+)
+
+// TestCreatePromoCode выполняет тестирование создания промокода
+func TestCreatePromoCode(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range createPromoCodeTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockPromoCodeService := services_mocks.NewMockPromoCodeServiceInterface(t)
+
+			h := handlers.NewPromoCodeHandler(mockPromoCodeService, discardLogger)
+			mux := setupTestPromoCodeRoutes(h)
+
+			server := httptest.NewServer(mux)
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockPromoCodeService.On("CreatePromoCode", tc.payload).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+
+			obj := e.POST("/api/promo-codes").WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode).JSON().Object()
+			if tc.expectedStatusCode == http.StatusCreated {
+				obj.Value("id").String().NotEmpty()
+				obj.Value("code").String().IsEqual(tc.returnedValue.Code)
+				obj.Value("discount_type").String().IsEqual(string(tc.payload.DiscountType))
+				obj.Value("discount_value").Number().IsEqual(tc.payload.DiscountValue)
+				obj.Value("is_active").Boolean().IsTrue()
+			}
+			mockPromoCodeService.AssertExpectations(t)
+			server.Close()
+		})
+	}
+}
+
+// TestGetPromoCode выполняет тестирование получения промокода
+func TestGetPromoCode(t *testing.T) {
+	mockPromoCodeService := services_mocks.NewMockPromoCodeServiceInterface(t)
+	discardLogger := logger.NewTest()
+
+	h := handlers.NewPromoCodeHandler(mockPromoCodeService, discardLogger)
+	mux := setupTestPromoCodeRoutes(h)
+
+	for _, tc := range getPromoCodeTestCases {
+		tc := tc
+		mockPromoCodeService.
+			On("GetPromoCode", tc.id).
+			Return(tc.returnedValue, tc.returnedError)
+
+		server := httptest.NewServer(mux)
+
+		e := httpexpect.Default(t, server.URL)
+		e.GET(fmt.Sprintf("/api/promo-codes/%s", tc.id)).Expect().Status(tc.expectedStatusCode)
+
+		server.Close()
+	}
+	mockPromoCodeService.AssertExpectations(t)
+}
+
+// TestGetPromoCodes выполняет тестирование получения списка промокодов
+func TestGetPromoCodes(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getPromoCodesTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockPromoCodeService := services_mocks.NewMockPromoCodeServiceInterface(t)
+
+			h := handlers.NewPromoCodeHandler(mockPromoCodeService, discardLogger)
+			mux := setupTestPromoCodeRoutes(h)
+
+			server := httptest.NewServer(mux)
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockPromoCodeService.
+					On("GetPromoCodes", tc.activeOnly, 50, 0).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			req := e.GET("/api/promo-codes")
+			if tc.activeParam != "" {
+				req.WithQuery("active", tc.activeParam)
+			}
+			resp := req.Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				resp.JSON().Array().Length().IsEqual(len(tc.returnedValue))
+			}
+
+			mockPromoCodeService.AssertExpectations(t)
+			server.Close()
+		})
+	}
+}
+
+// TestUpdatePromoCode выполняет тестирование обновления промокода
+func TestUpdatePromoCode(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range updatePromoCodeTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockPromoCodeService := services_mocks.NewMockPromoCodeServiceInterface(t)
+
+			h := handlers.NewPromoCodeHandler(mockPromoCodeService, discardLogger)
+			mux := setupTestPromoCodeRoutes(h)
+
+			server := httptest.NewServer(mux)
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockPromoCodeService.
+					On("UpdatePromoCode", tc.id, tc.payload).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			e.PUT(fmt.Sprintf("/api/promo-codes/%s", tc.id)).WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode)
+
+			mockPromoCodeService.AssertExpectations(t)
+			server.Close()
+		})
+	}
+}
+
+// TestDeletePromoCode выполняет тестирование деактивации промокода
+func TestDeletePromoCode(t *testing.T) {
+	mockPromoCodeService := services_mocks.NewMockPromoCodeServiceInterface(t)
+	discardLogger := logger.NewTest()
+
+	h := handlers.NewPromoCodeHandler(mockPromoCodeService, discardLogger)
+	mux := setupTestPromoCodeRoutes(h)
+
+	for _, tc := range deletePromoCodeTestCases {
+		tc := tc
+		mockPromoCodeService.
+			On("DeletePromoCode", tc.id).
+			Return(tc.returnedError)
+
+		server := httptest.NewServer(mux)
+
+		e := httpexpect.Default(t, server.URL)
+		e.DELETE(fmt.Sprintf("/api/promo-codes/%s", tc.id)).Expect().Status(tc.expectedStatusCode)
+
+		server.Close()
+	}
+	mockPromoCodeService.AssertExpectations(t)
+}
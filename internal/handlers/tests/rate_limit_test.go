@@ -0,0 +1,109 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/services/services_mocks"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestRateLimitMiddleware выполняет тестирование ограничения частоты запросов
+func TestRateLimitMiddleware(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range rateLimitTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockRateLimitService := services_mocks.NewMockRateLimitServiceInterface(t)
+
+			h := handlers.NewRateLimitHandler(mockRateLimitService, discardLogger)
+			mux := setupTestRateLimitRoutes(h, tc.apiKey)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			// Лимит ключа применяется только к ключу, которым аутентифицирован запрос
+			expectedKeyID, expectedTier := "", models.RateLimitTier("")
+			if tc.apiKey != nil {
+				expectedKeyID, expectedTier = tc.apiKey.ID.String(), tc.apiKey.RateLimitTier
+			}
+			mockRateLimitService.
+				On("Allow", mock.MatchedBy(func(client models.RateLimitClient) bool {
+					return client.APIKeyID == expectedKeyID && client.Tier == expectedTier && client.RemoteAddr != ""
+				})).
+				Return(tc.returnedValue).Once()
+
+			e := httpexpect.Default(t, server.URL)
+			req := e.GET("/api/ping")
+			if tc.apiKeyHeader != "" {
+				req.WithHeader("X-API-Key", tc.apiKeyHeader)
+			}
+			resp := req.Expect().Status(tc.expectedStatusCode)
+
+			resp.Header("X-RateLimit-Limit").IsEqual(strconv.Itoa(tc.returnedValue.Limit))
+			resp.Header("X-RateLimit-Remaining").IsEqual(strconv.Itoa(tc.returnedValue.Remaining))
+			resp.Header("X-RateLimit-Reset").IsEqual(strconv.FormatInt(tc.returnedValue.ResetAt.Unix(), 10))
+			if tc.expectedStatusCode == http.StatusTooManyRequests {
+				resp.Header("Retry-After").IsEqual(strconv.Itoa(tc.returnedValue.RetryAfter))
+				resp.JSON().Object().Value("message").String().IsEqual(tc.expectedMessage)
+			} else {
+				resp.Header("Retry-After").IsEmpty()
+			}
+
+			mockRateLimitService.AssertExpectations(t)
+		})
+	}
+}
+
+// TestRateLimitMiddlewareSkipsPreflight проверяет, что preflight-запросы не учитываются в лимите
+func TestRateLimitMiddlewareSkipsPreflight(t *testing.T) {
+	mockRateLimitService := services_mocks.NewMockRateLimitServiceInterface(t)
+	discardLogger := logger.NewTest()
+
+	h := handlers.NewRateLimitHandler(mockRateLimitService, discardLogger)
+	server := httptest.NewServer(setupTestRateLimitRoutes(h, nil))
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+	e.OPTIONS("/api/ping").Expect().Status(http.StatusOK)
+
+	mockRateLimitService.AssertNotCalled(t, "Allow", mock.Anything)
+}
+
+// TestGetRateLimitStatus выполняет тестирование получения оставшегося лимита запросов
+func TestGetRateLimitStatus(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range rateLimitStatusTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockRateLimitService := services_mocks.NewMockRateLimitServiceInterface(t)
+
+			h := handlers.NewRateLimitHandler(mockRateLimitService, discardLogger)
+			server := httptest.NewServer(setupTestRateLimitRoutes(h, nil))
+			defer server.Close()
+
+			mockRateLimitService.On("GetStatus", mock.Anything).Return(tc.returnedValue, tc.returnedError).Once()
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.GET("/api/rate-limit/status").Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				obj := resp.JSON().Object()
+				obj.Value("tier").String().IsEqual(string(tc.returnedValue.Tier))
+				obj.Value("limit").Number().IsEqual(tc.returnedValue.Limit)
+				obj.Value("remaining").Number().IsEqual(tc.returnedValue.Remaining)
+				obj.Value("banned").Boolean().IsFalse()
+				resp.Header("X-RateLimit-Remaining").IsEqual(strconv.Itoa(tc.returnedValue.Remaining))
+			}
+
+			mockRateLimitService.AssertExpectations(t)
+		})
+	}
+}
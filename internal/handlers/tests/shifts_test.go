@@ -0,0 +1,155 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/services/services_mocks"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestScheduleShift выполняет тестирование планирования смены курьера
+func TestScheduleShift(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range scheduleShiftTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockShiftService := services_mocks.NewMockShiftServiceInterface(t)
+
+			h := handlers.NewShiftHandler(mockShiftService, discardLogger)
+			mux := setupTestShiftRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockShiftService.
+					On("ScheduleShift", tc.id, mock.AnythingOfType("*models.ScheduleShiftRequest")).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			obj := e.POST(fmt.Sprintf("/api/couriers/%s/shifts", tc.id)).WithJSON(tc.payload).
+				Expect().Status(tc.expectedStatusCode).JSON().Object()
+			if tc.expectedStatusCode == http.StatusCreated {
+				obj.Value("id").String().IsEqual(tc.returnedValue.ID.String())
+				obj.Value("status").String().IsEqual(string(tc.returnedValue.Status))
+			}
+		})
+	}
+}
+
+// TestCourierShiftActions выполняет тестирование начала и завершения смены и перерыва курьера
+func TestCourierShiftActions(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range courierShiftActionTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockShiftService := services_mocks.NewMockShiftServiceInterface(t)
+
+			h := handlers.NewShiftHandler(mockShiftService, discardLogger)
+			mux := setupTestShiftRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockShiftService.On(tc.serviceMethod, tc.id).Return(tc.returnedValue, tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			obj := e.POST(fmt.Sprintf("/api/couriers/%s/%s", tc.id, tc.path)).
+				Expect().Status(tc.expectedStatusCode).JSON().Object()
+			if tc.expectedStatusCode == http.StatusOK {
+				obj.Value("status").String().IsEqual(string(tc.returnedValue.Status))
+				obj.Value("stats").Object().Value("orders_delivered").Number().IsEqual(tc.returnedValue.Stats.OrdersDelivered)
+				obj.Value("breaks").Array().Length().IsEqual(len(tc.returnedValue.Breaks))
+			}
+		})
+	}
+}
+
+// TestGetCourierShifts выполняет тестирование получения смен курьера
+func TestGetCourierShifts(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getCourierShiftsTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockShiftService := services_mocks.NewMockShiftServiceInterface(t)
+
+			h := handlers.NewShiftHandler(mockShiftService, discardLogger)
+			mux := setupTestShiftRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockShiftService.
+					On("GetCourierShifts", courierID, mock.Anything, mock.Anything, tc.limit, tc.offset).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			req := e.GET(fmt.Sprintf("/api/couriers/%s/shifts", courierID))
+			for key, value := range tc.query {
+				req = req.WithQuery(key, value)
+			}
+			resp := req.Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				resp.JSON().Array().Length().IsEqual(len(tc.returnedValue))
+			}
+		})
+	}
+}
+
+// TestGetShift выполняет тестирование получения смены
+func TestGetShift(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getShiftTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockShiftService := services_mocks.NewMockShiftServiceInterface(t)
+
+			h := handlers.NewShiftHandler(mockShiftService, discardLogger)
+			mux := setupTestShiftRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockShiftService.On("GetShift", tc.id).Return(tc.returnedValue, tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			e.GET(fmt.Sprintf("/api/shifts/%s", tc.id)).Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
+
+// TestCancelShift выполняет тестирование отмены запланированной смены
+func TestCancelShift(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range cancelShiftTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockShiftService := services_mocks.NewMockShiftServiceInterface(t)
+
+			h := handlers.NewShiftHandler(mockShiftService, discardLogger)
+			mux := setupTestShiftRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockShiftService.On("CancelShift", tc.id).Return(tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			e.DELETE(fmt.Sprintf("/api/shifts/%s", tc.id)).Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
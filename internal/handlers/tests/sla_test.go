@@ -0,0 +1,47 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/services/services_mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+)
+
+// TestGetAtRiskOrders выполняет тестирование получения заказов под угрозой нарушения SLA
+func TestGetAtRiskOrders(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getAtRiskOrdersTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockETAService := services_mocks.NewMockETAServiceInterface(t)
+
+			h := handlers.NewSLAHandler(mockETAService, discardLogger)
+			mux := setupTestSLARoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockETAService.On("GetAtRiskOrders", tc.limit).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.GET("/api/orders/at-risk").WithQueryString(tc.query).Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				arr := resp.JSON().Array()
+				arr.Length().IsEqual(len(tc.returnedValue))
+				for i, order := range tc.returnedValue {
+					obj := arr.Value(i).Object()
+					obj.Value("order_id").String().IsEqual(order.OrderID.String())
+					obj.Value("breached").Boolean().IsEqual(order.Breached)
+					obj.Value("delay_minutes").Number().IsEqual(order.DelayMinutes)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/services/services_mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+)
+
+// TestGetSurgeMultipliers выполняет тестирование получения динамических коэффициентов зон
+func TestGetSurgeMultipliers(t *testing.T) {
+	for _, tc := range getSurgeMultipliersTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockSurgeService := services_mocks.NewMockSurgeServiceInterface(t)
+			discardLogger := logger.NewTest()
+
+			h := handlers.NewSurgeHandler(mockSurgeService, discardLogger)
+			mux := setupTestSurgeRoute(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockSurgeService.On("GetSurgeMultipliers").Return(tc.returnedValue, tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			resp := e.GET("/api/delivery/surge").Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				arr := resp.JSON().Array()
+				arr.Length().IsEqual(len(tc.returnedValue))
+				arr.Value(0).Object().Value("zone").String().IsEqual(tc.returnedValue[0].Zone)
+				arr.Value(0).Object().Value("multiplier").Number().IsEqual(tc.returnedValue[0].Multiplier)
+			}
+		})
+	}
+}
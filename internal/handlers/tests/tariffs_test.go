@@ -0,0 +1,155 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/services/services_mocks"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+)
+
+// TestCreateTariff выполняет тестирование создания версии тарифа
+func TestCreateTariff(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range createTariffTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockTariffService := services_mocks.NewMockTariffServiceInterface(t)
+
+			h := handlers.NewTariffHandler(mockTariffService, discardLogger)
+			mux := setupTestTariffRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockTariffService.On("CreateTariff", tc.payload).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			obj := e.POST("/api/tariffs").WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode).JSON().Object()
+			if tc.expectedStatusCode == http.StatusCreated {
+				obj.Value("id").String().IsEqual(tc.returnedValue.ID.String())
+				obj.Value("version").Number().IsEqual(tc.returnedValue.Version)
+				obj.Value("distance_bands").Array().Length().IsEqual(len(tc.payload.DistanceBands))
+			}
+		})
+	}
+}
+
+// TestGetTariff выполняет тестирование получения версии тарифа
+func TestGetTariff(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getTariffTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockTariffService := services_mocks.NewMockTariffServiceInterface(t)
+
+			h := handlers.NewTariffHandler(mockTariffService, discardLogger)
+			mux := setupTestTariffRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockTariffService.On("GetTariff", tc.id).Return(tc.returnedValue, tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			e.GET(fmt.Sprintf("/api/tariffs/%s", tc.id)).Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
+
+// TestGetTariffs выполняет тестирование получения списка тарифов
+func TestGetTariffs(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getTariffsTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockTariffService := services_mocks.NewMockTariffServiceInterface(t)
+
+			h := handlers.NewTariffHandler(mockTariffService, discardLogger)
+			mux := setupTestTariffRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockTariffService.
+					On("GetTariffs", tc.code, tc.activeOnly, 50, 0).
+					Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			req := e.GET("/api/tariffs")
+			for key, value := range tc.query {
+				req = req.WithQuery(key, value)
+			}
+
+			resp := req.Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				resp.JSON().Array().Length().IsEqual(len(tc.returnedValue))
+			}
+		})
+	}
+}
+
+// TestRetireTariff выполняет тестирование завершения действия версии тарифа
+func TestRetireTariff(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range retireTariffTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockTariffService := services_mocks.NewMockTariffServiceInterface(t)
+
+			h := handlers.NewTariffHandler(mockTariffService, discardLogger)
+			mux := setupTestTariffRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockTariffService.On("RetireTariff", tc.id).Return(tc.returnedValue, tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			e.DELETE(fmt.Sprintf("/api/tariffs/%s", tc.id)).Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
+
+// TestQuoteDelivery выполняет тестирование расчёта стоимости доставки без создания заказа
+func TestQuoteDelivery(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range quoteDeliveryTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockTariffService := services_mocks.NewMockTariffServiceInterface(t)
+
+			h := handlers.NewTariffHandler(mockTariffService, discardLogger)
+			mux := setupTestTariffRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockTariffService.On("QuoteDelivery", tc.payload).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			obj := e.POST("/api/delivery/quote").WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode).JSON().Object()
+			if tc.expectedStatusCode == http.StatusOK {
+				obj.Value("tariff_code").String().IsEqual(tc.returnedValue.TariffCode)
+				obj.Value("total").Number().IsEqual(tc.returnedValue.Total)
+				obj.Value("surge_multiplier").Number().IsEqual(tc.returnedValue.SurgeMultiplier)
+				obj.Value("lines").Array().Length().IsEqual(len(tc.returnedValue.Lines))
+			}
+		})
+	}
+}
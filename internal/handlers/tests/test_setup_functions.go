@@ -2,6 +2,7 @@ package handler_tests
 
 import (
 	"delivery-system/internal/handlers"
+	"delivery-system/internal/models"
 	"net/http"
 	"strings"
 )
@@ -10,7 +11,7 @@ func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Actor-Role, X-Actor-ID, X-API-Key")
 
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusOK)
@@ -21,6 +22,18 @@ func corsMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
+// authenticatedMiddleware имитирует аутентификацию: инициатор из заголовков X-Actor-Role и X-Actor-ID
+// попадает в контекст запроса так же, как после проверки токена
+func authenticatedMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		actor := models.Actor{Role: models.Role(r.Header.Get("X-Actor-Role")), ID: r.Header.Get("X-Actor-ID")}
+		if actor.Role != "" {
+			r = r.WithContext(handlers.ContextWithActor(r.Context(), actor))
+		}
+		next(w, r)
+	}
+}
+
 // setupTestOrderRoutes настраивает HTTP-маршруты для функционала заказов
 func setupTestOrderRoutes(h *handlers.OrderHandler) *http.ServeMux {
 	mux := http.NewServeMux()
@@ -55,12 +68,47 @@ func handleOrderRoute(handler *handlers.OrderHandler) http.HandlerFunc {
 			} else {
 				handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
 			}
+		} else if strings.HasSuffix(r.URL.Path, "/cancel") {
+			// Отмена заказа
+			if r.Method == http.MethodPost {
+				handler.CancelOrder(w, r)
+			} else {
+				handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/cancellation") {
+			// Сведения об отмене заказа
+			if r.Method == http.MethodGet {
+				handler.GetOrderCancellation(w, r)
+			} else {
+				handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
 		} else if strings.HasSuffix(r.URL.Path, "/review") {
 			if r.Method == http.MethodPost {
 				handler.CreateReview(w, r)
 			} else {
 				handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
 			}
+		} else if strings.HasSuffix(r.URL.Path, "/auto-assign") {
+			// Автоназначение курьера на заказ
+			if r.Method == http.MethodPost {
+				handler.AutoAssignOrder(w, r)
+			} else {
+				handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/events") {
+			// Журнал событий заказа
+			if r.Method == http.MethodGet {
+				handler.GetOrderEvents(w, r)
+			} else {
+				handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/replay") {
+			// Восстановление состояния заказа из журнала событий
+			if r.Method == http.MethodGet {
+				handler.ReplayOrder(w, r)
+			} else {
+				handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
 		} else {
 			// Получение заказа по ID
 			if r.Method == http.MethodGet {
@@ -114,6 +162,20 @@ func handleCourierRoute(handler *handlers.CourierHandler) http.HandlerFunc {
 			} else {
 				handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
 			}
+		} else if strings.HasSuffix(r.URL.Path, "/zone") {
+			// Привязка курьера к зоне доставки
+			if r.Method == http.MethodPut {
+				handler.UpdateCourierZone(w, r)
+			} else {
+				handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/capacity") {
+			// Изменение вместимости курьера
+			if r.Method == http.MethodPut {
+				handler.UpdateCourierCapacity(w, r)
+			} else {
+				handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
 		} else if strings.HasSuffix(r.URL.Path, "/reviews") {
 			if r.Method == http.MethodGet {
 				handler.GetCourierReviews(w, r)
@@ -132,6 +194,126 @@ func handleCourierRoute(handler *handlers.CourierHandler) http.HandlerFunc {
 	}
 }
 
+// setupTestPromoCodeRoutes настраивает HTTP-маршруты для функционала промокодов
+func setupTestPromoCodeRoutes(h *handlers.PromoCodeHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/promo-codes", corsMiddleware(handlePromoCodesRoute(h)))
+	mux.HandleFunc("/api/promo-codes/", corsMiddleware(handlePromoCodeRoute(h)))
+
+	return mux
+}
+
+// setupTestTariffRoutes настраивает HTTP-маршруты для функционала тарифов доставки
+func setupTestTariffRoutes(h *handlers.TariffHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/tariffs", corsMiddleware(handleTariffsRoute(h)))
+	mux.HandleFunc("/api/tariffs/", corsMiddleware(handleTariffRoute(h)))
+	mux.HandleFunc("/api/delivery/quote", corsMiddleware(h.QuoteDelivery))
+
+	return mux
+}
+
+// handlePromoCodesRoute обрабатывает маршруты для коллекции промокодов
+func handlePromoCodesRoute(handler *handlers.PromoCodeHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetPromoCodes(w, r)
+		case http.MethodPost:
+			handler.CreatePromoCode(w, r)
+		default:
+			handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handlePromoCodeRoute обрабатывает маршруты для отдельного промокода
+func handlePromoCodeRoute(handler *handlers.PromoCodeHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetPromoCode(w, r)
+		case http.MethodPut:
+			handler.UpdatePromoCode(w, r)
+		case http.MethodDelete:
+			handler.DeletePromoCode(w, r)
+		default:
+			handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handleTariffsRoute обрабатывает маршруты для коллекции тарифов
+func handleTariffsRoute(handler *handlers.TariffHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetTariffs(w, r)
+		case http.MethodPost:
+			handler.CreateTariff(w, r)
+		default:
+			handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handleTariffRoute обрабатывает маршруты для отдельной версии тарифа
+func handleTariffRoute(handler *handlers.TariffHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetTariff(w, r)
+		case http.MethodDelete:
+			handler.RetireTariff(w, r)
+		default:
+			handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handleZonesRoute обрабатывает маршруты для коллекции зон доставки
+func handleZonesRoute(handler *handlers.ZoneHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetZones(w, r)
+		case http.MethodPost:
+			handler.CreateZone(w, r)
+		default:
+			handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handleZoneRoute обрабатывает маршруты для отдельной зоны доставки
+func handleZoneRoute(handler *handlers.ZoneHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetZone(w, r)
+		case http.MethodPut:
+			handler.UpdateZone(w, r)
+		case http.MethodDelete:
+			handler.DeleteZone(w, r)
+		default:
+			handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// setupTestAnalyticsRoutes настраивает HTTP-маршруты для функционала аналитики
+func setupTestAnalyticsRoutes(h *handlers.AnalyticsHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/analytics/summary", corsMiddleware(h.GetSummary))
+	mux.HandleFunc("/api/analytics/top-items", corsMiddleware(h.GetTopItems))
+	mux.HandleFunc("/api/analytics/couriers", corsMiddleware(h.GetCourierReport))
+
+	return mux
+}
+
 // setupTestKafkaMetricsRoute настраивает HTTP-маршрут для функционала получения статистики Kafka
 func setupTestKafkaMetricsRoute(h *handlers.KafkaMetricsHandler) *http.ServeMux {
 	mux := http.NewServeMux()
@@ -141,6 +323,302 @@ func setupTestKafkaMetricsRoute(h *handlers.KafkaMetricsHandler) *http.ServeMux
 	return mux
 }
 
+// setupTestOutboxMetricsRoute настраивает HTTP-маршрут для функционала получения метрик outbox
+func setupTestOutboxMetricsRoute(h *handlers.OutboxMetricsHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/outbox/metrics", corsMiddleware(h.GetMetrics))
+
+	return mux
+}
+
+// setupTestSurgeRoute настраивает HTTP-маршрут для функционала получения динамических коэффициентов
+func setupTestSurgeRoute(h *handlers.SurgeHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/delivery/surge", corsMiddleware(h.GetSurgeMultipliers))
+
+	return mux
+}
+
+// setupTestZoneRoutes настраивает HTTP-маршруты для функционала зон доставки
+func setupTestZoneRoutes(h *handlers.ZoneHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/zones", corsMiddleware(handleZonesRoute(h)))
+	mux.HandleFunc("/api/zones/", corsMiddleware(handleZoneRoute(h)))
+
+	return mux
+}
+
+// setupTestShiftRoutes настраивает HTTP-маршруты для функционала смен курьеров
+func setupTestShiftRoutes(h *handlers.ShiftHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/couriers/", corsMiddleware(handleCourierShiftRoute(h)))
+	mux.HandleFunc("/api/shifts/", corsMiddleware(handleShiftRoute(h)))
+
+	return mux
+}
+
+// handleCourierShiftRoute обрабатывает маршруты смен отдельного курьера
+func handleCourierShiftRoute(handler *handlers.ShiftHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		switch {
+		case strings.HasSuffix(path, "/shifts/break/start"):
+			handler.StartBreak(w, r)
+		case strings.HasSuffix(path, "/shifts/break/end"):
+			handler.EndBreak(w, r)
+		case strings.HasSuffix(path, "/shifts/start"):
+			handler.StartShift(w, r)
+		case strings.HasSuffix(path, "/shifts/end"):
+			handler.EndShift(w, r)
+		case strings.HasSuffix(path, "/shifts"):
+			switch r.Method {
+			case http.MethodGet:
+				handler.GetCourierShifts(w, r)
+			case http.MethodPost:
+				handler.ScheduleShift(w, r)
+			default:
+				handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		default:
+			handlers.WriteErrorResponse(w, http.StatusNotFound, "Not found")
+		}
+	}
+}
+
+// handleShiftRoute обрабатывает маршруты для отдельной смены
+func handleShiftRoute(handler *handlers.ShiftHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetShift(w, r)
+		case http.MethodDelete:
+			handler.CancelShift(w, r)
+		default:
+			handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// setupTestBatchRoutes настраивает HTTP-маршруты для функционала пакетной доставки заказов
+func setupTestBatchRoutes(h *handlers.BatchHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/orders/batches", corsMiddleware(h.GetBatches))
+	mux.HandleFunc("/api/couriers/", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/batch") {
+			h.AssignBatch(w, r)
+		} else {
+			h.GetCourierRoute(w, r)
+		}
+	}))
+
+	return mux
+}
+
+// setupTestSLARoutes настраивает HTTP-маршруты для функционала контроля сроков доставки
+func setupTestSLARoutes(h *handlers.SLAHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/orders/at-risk", corsMiddleware(h.GetAtRiskOrders))
+
+	return mux
+}
+
+// setupTestDeliveryProofRoutes настраивает HTTP-маршруты для функционала подтверждения доставки заказов
+func setupTestDeliveryProofRoutes(h *handlers.DeliveryProofHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/orders/", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/delivery-proof") {
+			switch r.Method {
+			case http.MethodPost:
+				h.ConfirmDelivery(w, r)
+			case http.MethodGet:
+				h.GetDeliveryProof(w, r)
+			default:
+				handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		} else {
+			h.GetDeliveryProofFile(w, r)
+		}
+	}))
+
+	return mux
+}
+
+// setupTestPreparationRoutes настраивает HTTP-маршруты для функционала приготовления заказов продавцами
+func setupTestPreparationRoutes(h *handlers.PreparationHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/orders/", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			return
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accept"):
+			h.AcceptOrder(w, r)
+		case strings.HasSuffix(r.URL.Path, "/reject"):
+			h.RejectOrder(w, r)
+		case strings.HasSuffix(r.URL.Path, "/preparing"):
+			h.StartPreparing(w, r)
+		case strings.HasSuffix(r.URL.Path, "/ready"):
+			h.MarkReady(w, r)
+		default:
+			handlers.WriteErrorResponse(w, http.StatusNotFound, "Not found")
+		}
+	}))
+
+	return mux
+}
+
+// setupTestAuthRoutes настраивает HTTP-маршруты для проверки аутентификации и прав доступа.
+// Защищённые маршруты возвращают инициатора запроса, определённого обработчиком
+func setupTestAuthRoutes(h *handlers.AuthHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/auth/token", corsMiddleware(h.IssueToken))
+	mux.HandleFunc("/api/", corsMiddleware(h.Middleware(func(w http.ResponseWriter, r *http.Request) {
+		actor, err := handlers.ActorFromRequest(r)
+		if err != nil {
+			handlers.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		handlers.WriteJSONResponse(w, http.StatusOK, actor)
+	})))
+
+	return mux
+}
+
+// setupTestAPIKeyRoutes настраивает HTTP-маршруты для тестирования API-ключей
+func setupTestAPIKeyRoutes(h *handlers.APIKeyHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/api-keys", corsMiddleware(authenticatedMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			h.GetAPIKeys(w, r)
+		case http.MethodPost:
+			h.IssueAPIKey(w, r)
+		default:
+			handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	})))
+
+	mux.HandleFunc("/api/api-keys/", corsMiddleware(authenticatedMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/rotate") {
+			h.RotateAPIKey(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/usage") {
+			h.GetUsage(w, r)
+		} else {
+			switch r.Method {
+			case http.MethodGet:
+				h.GetAPIKey(w, r)
+			case http.MethodDelete:
+				h.RevokeAPIKey(w, r)
+			default:
+				handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+			}
+		}
+	})))
+
+	return mux
+}
+
+// setupTestCustomerRoutes настраивает HTTP-маршруты для функционала клиентов
+func setupTestCustomerRoutes(h *handlers.CustomerHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/customers", corsMiddleware(handleCustomersRoute(h)))
+	mux.HandleFunc("/api/customers/", corsMiddleware(handleCustomerRoute(h)))
+
+	return mux
+}
+
+// handleCustomersRoute обрабатывает маршруты для коллекции клиентов
+func handleCustomersRoute(handler *handlers.CustomerHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetCustomers(w, r)
+		case http.MethodPost:
+			handler.CreateCustomer(w, r)
+		default:
+			handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handleCustomerRoute обрабатывает маршруты для отдельного клиента
+func handleCustomerRoute(handler *handlers.CustomerHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		switch {
+		case strings.HasSuffix(path, "/orders"):
+			handler.GetCustomerOrders(w, r)
+		case strings.HasSuffix(path, "/addresses"):
+			handler.AddCustomerAddress(w, r)
+		case strings.Contains(path, "/addresses/"):
+			handler.DeleteCustomerAddress(w, r)
+		case r.Method == http.MethodPut:
+			handler.UpdateCustomer(w, r)
+		default:
+			handler.GetCustomer(w, r)
+		}
+	}
+}
+
+// setupTestMerchantRoutes настраивает HTTP-маршруты для функционала продавцов
+func setupTestMerchantRoutes(h *handlers.MerchantHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/merchants", corsMiddleware(handleMerchantsRoute(h)))
+	mux.HandleFunc("/api/merchants/", corsMiddleware(handleMerchantRoute(h)))
+
+	return mux
+}
+
+// handleMerchantsRoute обрабатывает маршруты для коллекции продавцов
+func handleMerchantsRoute(handler *handlers.MerchantHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handler.GetMerchants(w, r)
+		case http.MethodPost:
+			handler.CreateMerchant(w, r)
+		default:
+			handlers.WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		}
+	}
+}
+
+// handleMerchantRoute обрабатывает маршруты для отдельного продавца и его каталога
+func handleMerchantRoute(handler *handlers.MerchantHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		switch {
+		case strings.HasSuffix(path, "/products") && r.Method == http.MethodPost:
+			handler.CreateProduct(w, r)
+		case strings.HasSuffix(path, "/products"):
+			handler.GetProducts(w, r)
+		case strings.Contains(path, "/products/") && r.Method == http.MethodDelete:
+			handler.DeleteProduct(w, r)
+		case strings.Contains(path, "/products/"):
+			handler.UpdateProduct(w, r)
+		case r.Method == http.MethodPut:
+			handler.UpdateMerchant(w, r)
+		case r.Method == http.MethodDelete:
+			handler.DeleteMerchant(w, r)
+		default:
+			handler.GetMerchant(w, r)
+		}
+	}
+}
+
 // setupTestRedisMetricsRoute настраивает HTTP-маршрут для функционала получения статистики Redis
 func setupTestRedisMetricsRoute(h *handlers.RedisMetricsHandler) *http.ServeMux {
 	mux := http.NewServeMux()
@@ -149,3 +627,43 @@ func setupTestRedisMetricsRoute(h *handlers.RedisMetricsHandler) *http.ServeMux
 
 	return mux
 }
+
+// setupTestRateLimitRoutes настраивает HTTP-маршруты для проверки ограничения частоты запросов.
+// /api/ping - произвольный эндпоинт под rate limiting middleware. Если apiKey задан, запросы
+// считаются аутентифицированными этим ключом
+func setupTestRateLimitRoutes(h *handlers.RateLimitHandler, apiKey *models.APIKey) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	authenticated := func(next http.HandlerFunc) http.HandlerFunc {
+		if apiKey == nil {
+			return next
+		}
+		return func(w http.ResponseWriter, r *http.Request) {
+			next(w, r.WithContext(handlers.ContextWithAPIKey(r.Context(), apiKey)))
+		}
+	}
+
+	mux.HandleFunc("/api/ping", corsMiddleware(authenticated(h.Middleware(func(w http.ResponseWriter, r *http.Request) {
+		handlers.WriteJSONResponse(w, http.StatusOK, map[string]string{"status": "ok"})
+	}))))
+	mux.HandleFunc("/api/rate-limit/status", corsMiddleware(authenticated(h.GetStatus)))
+
+	return mux
+}
+
+// setupTestCourierLocationRoutes настраивает HTTP-маршруты для функционала местоположения курьеров
+func setupTestCourierLocationRoutes(h *handlers.CourierLocationHandler) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/api/couriers/nearby", corsMiddleware(h.GetNearbyCouriers))
+	mux.HandleFunc("/api/couriers/", corsMiddleware(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/location") {
+			h.UpdateCourierLocation(w, r)
+		} else {
+			h.GetCourierTrack(w, r)
+		}
+	}))
+	mux.HandleFunc("/api/orders/", corsMiddleware(h.StreamOrderCourierLocation))
+
+	return mux
+}
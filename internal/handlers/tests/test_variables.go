@@ -3,6 +3,7 @@ package handler_tests
 import (
 	"context"
 	"delivery-system/internal/models"
+	"delivery-system/internal/services"
 	"errors"
 	"fmt"
 	"net/http"
@@ -22,6 +23,48 @@ var statusOrderCreated = models.OrderStatusCreated
 var courierRating1 = 5.0
 var courierRating2 = 4.0
 var courierStatusOffline = models.CourierStatusOffline
+var dispatcherActor = models.Actor{Role: models.RoleDispatcher}
+var promoCodeID = uuid.New()
+var promoCodeMaxUses = 100
+var tariffID = uuid.New()
+var tariffBandUpToKm = 3.0
+var tariffMinPrice = 150.0
+var tariffLowMaxPrice = 100.0
+var tariffInvalidHour = 24
+var tariffItemWeight = 2.5
+var zoneID = uuid.New()
+var zoneCode = "center"
+var zoneMaxRouteDistance = 15000
+var zoneSLAMinutes = 45
+var slaDeadline = time.Now().Add(5 * time.Minute)
+var lateETA = slaDeadline.Add(12 * time.Minute)
+var orderETA = time.Now().Add(40 * time.Minute)
+var orderSLADeadline = time.Now().Add(time.Hour)
+var shiftID = uuid.New()
+var shiftStart = time.Now().Add(time.Hour)
+var shiftEnd = shiftStart.Add(8 * time.Hour)
+var shiftStartedAt = time.Now().Add(-2 * time.Hour)
+var courierCapacity = 3
+var invalidCourierCapacity = 0
+var batchOrderID = uuid.New()
+var courierActor = models.Actor{Role: models.RoleCourier, ID: courierID.String()}
+var merchantActor = models.Actor{Role: models.RoleMerchant, ID: merchantID.String()}
+var adminActor = models.Actor{Role: models.RoleAdmin, ID: "admin-1"}
+var customerActor = models.Actor{Role: models.RoleCustomer, ID: customerID.String()}
+var accessToken = "header.payload.signature"
+var proofPhotoKey = "delivery-proofs/" + orderID.String() + "/photo.png"
+var proofImage = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+var customerID = uuid.New()
+var customerAddressID = uuid.New()
+var customerAddressLabel = "home"
+var merchantID = uuid.New()
+var productID = uuid.New()
+var invalidWeight = -0.5
+var scheduledFor = time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+var scheduledDispatchAt = scheduledFor.Add(-50 * time.Minute)
+var pastScheduledFor = time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+var prepMinutes = 25
+var orderReadyAt = time.Now().Add(25 * time.Minute).UTC().Truncate(time.Second)
 
 // Экземпляры моделей приложения
 // // Заказы
@@ -39,6 +82,8 @@ var order1 = &models.Order{
 	Status:       models.OrderStatusCreated,
 	CreatedAt:    time.Now(),
 	UpdatedAt:    time.Now(),
+	ETA:          &orderETA,
+	SLADeadline:  &orderSLADeadline,
 }
 var order2 = &models.Order{
 	ID:              uuid.New(),
@@ -73,6 +118,313 @@ var order3 = &models.Order{
 	CreatedAt:    time.Now(),
 	UpdatedAt:    time.Now(),
 }
+var scheduledOrder = &models.Order{
+	ID:              orderID,
+	CustomerName:    order1.CustomerName,
+	CustomerPhone:   order1.CustomerPhone,
+	PickupAddress:   order1.PickupAddress,
+	DeliveryAddress: order1.DeliveryAddress,
+	Items:           order1.Items,
+	TotalAmount:     order1.TotalAmount,
+	DeliveryCost:    order1.DeliveryCost,
+	Status:          models.OrderStatusScheduled,
+	CreatedAt:       time.Now(),
+	UpdatedAt:       time.Now(),
+	ETA:             &orderETA,
+	SLADeadline:     &scheduledFor,
+	ScheduledFor:    &scheduledFor,
+	DispatchAt:      &scheduledDispatchAt,
+}
+var merchantOrder = &models.Order{
+	ID:              orderID,
+	MerchantID:      &merchantID,
+	CustomerName:    order1.CustomerName,
+	CustomerPhone:   order1.CustomerPhone,
+	PickupAddress:   "merchant_pickup_location",
+	DeliveryAddress: order1.DeliveryAddress,
+	Items: []models.OrderItem{
+		{ID: itemID, OrderID: orderID, ProductID: &productID, Name: "catalog_item", Quantity: 2, Price: 120.0},
+	},
+	TotalAmount:  float64(2) * 120.0,
+	DeliveryCost: order1.DeliveryCost,
+	Status:       models.OrderStatusCreated,
+	CreatedAt:    time.Now(),
+	UpdatedAt:    time.Now(),
+	ETA:          &orderETA,
+	SLADeadline:  &orderSLADeadline,
+}
+
+// // Журнал событий заказа
+var orderEvents = []*models.OrderEvent{
+	{
+		ID: uuid.New(), OrderID: orderID, Version: 1, Type: models.EventTypeOrderCreated,
+		Payload: []byte(`{"id":"` + orderID.String() + `","status":"created"}`),
+		Actor:   models.Actor{Role: models.RoleCustomer}, CreatedAt: time.Now(),
+	},
+	{
+		ID: uuid.New(), OrderID: orderID, Version: 2, Type: models.EventTypeCourierAssigned,
+		Payload: []byte(`{"order_id":"` + orderID.String() + `","old_status":"created","new_status":"accepted"}`),
+		Actor:   models.Actor{Role: models.RoleDispatcher, ID: "dispatcher-1"}, CreatedAt: time.Now(),
+	},
+}
+var orderReplay = &models.OrderReplayResult{Order: order1, Version: 12, SnapshotVersion: 10, EventsApplied: 2}
+
+// // Отмены заказов
+var orderCancellation = &models.OrderCancellation{
+	OrderID:      orderID,
+	Reason:       models.CancellationReasonCustomerRequest,
+	Stage:        models.OrderStatusPreparing,
+	CancelledBy:  models.Actor{Role: models.RoleCustomer},
+	PaidAmount:   1500,
+	Fee:          450,
+	RefundAmount: 1050,
+	CourierID:    &courierID,
+	CancelledAt:  time.Now(),
+}
+
+// // Приготовление заказов продавцами
+var orderAccepted = &models.OrderPreparationEvent{
+	OrderID:     orderID,
+	MerchantID:  merchantID,
+	OldStatus:   models.OrderStatusCreated,
+	NewStatus:   models.OrderStatusAccepted,
+	PrepMinutes: &prepMinutes,
+	ReadyAt:     &orderReadyAt,
+	Timestamp:   time.Now(),
+}
+var orderPreparing = &models.OrderPreparationEvent{
+	OrderID:     orderID,
+	MerchantID:  merchantID,
+	OldStatus:   models.OrderStatusAccepted,
+	NewStatus:   models.OrderStatusPreparing,
+	PrepMinutes: &prepMinutes,
+	ReadyAt:     &orderReadyAt,
+	CourierID:   &courierID,
+	Timestamp:   time.Now(),
+}
+var orderReady = &models.OrderPreparationEvent{
+	OrderID:     orderID,
+	MerchantID:  merchantID,
+	OldStatus:   models.OrderStatusPreparing,
+	NewStatus:   models.OrderStatusReady,
+	PrepMinutes: &prepMinutes,
+	ReadyAt:     &orderReadyAt,
+	CourierID:   &courierID,
+	Timestamp:   time.Now(),
+}
+var orderRejection = &models.OrderCancellation{
+	OrderID:      orderID,
+	Reason:       models.CancellationReasonOutOfStock,
+	Stage:        models.OrderStatusCreated,
+	CancelledBy:  merchantActor,
+	PaidAmount:   1500,
+	RefundAmount: 1500,
+	CancelledAt:  time.Now(),
+}
+
+// // Аутентификация
+var customerOrder = &models.Order{ID: orderID, CustomerID: &customerID, CourierID: &courierID, MerchantID: &merchantID}
+var foreignOrder = &models.Order{ID: orderID, CustomerID: &customerAddressID}
+var authToken = &models.AuthToken{
+	AccessToken: accessToken,
+	TokenType:   "Bearer",
+	ExpiresAt:   time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+	Actor:       courierActor,
+}
+
+// // API-ключи
+var apiKeyID = uuid.New()
+var apiKeyValue = "dsk_Zm9vYmFyYmF6cXV4cXV1eGNvcmdlZ3JhdWx0"
+var apiKeyExpiresAt = time.Now().Add(30 * 24 * time.Hour).UTC().Truncate(time.Second)
+var apiKeyExpiredAt = time.Now().Add(-time.Hour)
+var merchantAPIKey = &models.APIKey{
+	ID:        apiKeyID,
+	Name:      "POS",
+	Prefix:    apiKeyValue[:12],
+	Scopes:    []string{"orders:read", "orders:write"},
+	Owner:     merchantActor,
+	CreatedAt: time.Now(),
+	UpdatedAt: time.Now(),
+}
+var analyticsAPIKey = &models.APIKey{
+	ID:     uuid.New(),
+	Name:   "Analytics export",
+	Scopes: []string{"analytics:read"},
+	Owner:  adminActor,
+}
+var vipAPIKey = &models.APIKey{
+	ID:            uuid.New(),
+	Name:          "Partner integration",
+	Scopes:        []string{"*"},
+	Owner:         merchantActor,
+	RateLimitTier: models.RateLimitTierVIP,
+}
+var readOnlyAPIKey = &models.APIKey{
+	ID:     uuid.New(),
+	Name:   "Order status",
+	Scopes: []string{"orders:read"},
+	Owner:  merchantActor,
+}
+var fullAccessAPIKey = &models.APIKey{
+	ID:     uuid.New(),
+	Name:   "Full access",
+	Scopes: []string{models.APIKeyScopeAll},
+	Owner:  merchantActor,
+}
+var issuedAPIKey = &models.IssuedAPIKey{APIKey: merchantAPIKey, Key: apiKeyValue}
+var apiKeyUsage = &models.APIKeyUsage{
+	KeyID: apiKeyID,
+	Days:  2,
+	Total: 15,
+	Daily: []models.APIKeyDailyUsage{
+		{Date: time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02"), Requests: 10},
+		{Date: time.Now().UTC().Format("2006-01-02"), Requests: 5},
+	},
+}
+
+// // Подтверждения доставки
+var deliveryProof = &models.DeliveryProof{
+	OrderID:        orderID,
+	CourierID:      &courierID,
+	PinVerified:    true,
+	Lat:            55.7558,
+	Lon:            37.6173,
+	DistanceMeters: 42.5,
+	PhotoKey:       &proofPhotoKey,
+	DeliveredAt:    time.Now(),
+}
+
+// // Сроки доставки
+var atRiskOrders = []*models.AtRiskOrder{
+	{
+		OrderID: orderID, Status: models.OrderStatusInDelivery, CourierID: &courierID, Zone: &zoneCode,
+		ETA: &lateETA, SLADeadline: slaDeadline, MinutesLeft: 5, DelayMinutes: 12,
+	},
+	{
+		OrderID: order3.ID, Status: models.OrderStatusPreparing, SLADeadline: slaDeadline.Add(-time.Hour),
+		MinutesLeft: -55, Breached: true, BreachedAt: &slaDeadline,
+	},
+}
+
+// // Промокоды
+var promoCode1 = &models.PromoCode{
+	ID:             promoCodeID,
+	Code:           "WELCOME10",
+	DiscountType:   models.DiscountTypePercent,
+	DiscountValue:  10,
+	MinOrderAmount: 500,
+	MaxUses:        &promoCodeMaxUses,
+	IsActive:       true,
+	CreatedAt:      time.Now(),
+	UpdatedAt:      time.Now(),
+}
+var promoCode2 = &models.PromoCode{
+	ID:           uuid.New(),
+	Code:         "FREESHIP",
+	DiscountType: models.DiscountTypeFreeDelivery,
+	IsActive:     true,
+	CreatedAt:    time.Now(),
+	UpdatedAt:    time.Now(),
+}
+
+// // Тарифы
+var tariff1 = &models.Tariff{
+	ID:      tariffID,
+	Code:    "standard",
+	Version: 2,
+	BaseFee: 50,
+	DistanceBands: []models.DistanceBand{
+		{UpToKm: &tariffBandUpToKm, PerKm: 30},
+		{PerKm: 20},
+	},
+	MinPrice:          &tariffMinPrice,
+	WeightSurcharges:  []models.Surcharge{{Over: 10, Amount: 100}},
+	NightMultiplier:   1.5,
+	NightStartHour:    23,
+	NightEndHour:      6,
+	WeekendMultiplier: 1,
+	EffectiveFrom:     time.Now(),
+	CreatedAt:         time.Now(),
+}
+var deliveryBreakdown = &models.DeliveryBreakdown{
+	TariffID:       tariffID,
+	TariffCode:     tariff1.Code,
+	TariffVersion:  tariff1.Version,
+	DistanceMeters: 5000,
+	ItemCount:      2,
+	WeightKg:       5,
+	Lines: []models.PriceLine{
+		{Type: models.PriceLineBaseFee, Description: "Base fee", Amount: 50},
+		{Type: models.PriceLineDistance, Description: "3.00 km x 30.00", Amount: 90},
+		{Type: models.PriceLineDistance, Description: "2.00 km x 20.00", Amount: 40},
+	},
+	Total:           180,
+	SurgeMultiplier: 1,
+	CalculatedAt:    time.Now(),
+}
+var surgeMultipliers = []*models.SurgeMultiplier{
+	{Zone: "center", Multiplier: 1.35, TargetMultiplier: 1.5, OpenOrders: 12, AvailableCouriers: 6, UpdatedAt: time.Now()},
+	{Zone: "", Multiplier: 1, TargetMultiplier: 1, OpenOrders: 2, AvailableCouriers: 6, UpdatedAt: time.Now()},
+}
+
+// // Зоны доставки
+var zonePolygon = &models.GeoJSONPolygon{
+	Type:        models.GeoJSONTypePolygon,
+	Coordinates: [][][2]float64{{{37.5, 55.7}, {37.7, 55.7}, {37.7, 55.8}, {37.5, 55.8}, {37.5, 55.7}}},
+}
+var zone1 = &models.DeliveryZone{
+	ID:               zoneID,
+	Code:             zoneCode,
+	Name:             "Center",
+	Polygon:          zonePolygon,
+	MaxRouteDistance: &zoneMaxRouteDistance,
+	SLAMinutes:       &zoneSLAMinutes,
+	IsActive:         true,
+	CreatedAt:        time.Now(),
+	UpdatedAt:        time.Now(),
+}
+
+// // Смены курьеров
+var scheduledShift = &models.CourierShift{
+	ID:             shiftID,
+	CourierID:      courierID,
+	Status:         models.ShiftStatusScheduled,
+	ScheduledStart: &shiftStart,
+	ScheduledEnd:   &shiftEnd,
+	Breaks:         []models.ShiftBreak{},
+	CreatedAt:      time.Now(),
+	UpdatedAt:      time.Now(),
+}
+var activeShift = &models.CourierShift{
+	ID:        uuid.New(),
+	CourierID: courierID,
+	Status:    models.ShiftStatusActive,
+	StartedAt: &shiftStartedAt,
+	Breaks:    []models.ShiftBreak{{ID: uuid.New(), StartedAt: shiftStartedAt.Add(time.Hour)}},
+	Stats: &models.ShiftStats{
+		OrdersDelivered: 3,
+		DistanceMeters:  5230.5,
+		OnlineSeconds:   5400,
+		BreakSeconds:    1800,
+	},
+	CreatedAt: shiftStartedAt,
+	UpdatedAt: time.Now(),
+}
+
+// // Пакетная доставка
+var courierRoute = &models.CourierRoute{
+	CourierID: &courierID,
+	Stops: []models.RouteStop{
+		{OrderID: orderID, Type: models.RouteStopPickup, Address: "pickup_address_1", Lat: 55.7558, Lon: 37.6173},
+		{OrderID: batchOrderID, Type: models.RouteStopPickup, Address: "pickup_address_2", Lat: 55.7561, Lon: 37.6180},
+		{OrderID: orderID, Type: models.RouteStopDelivery, Address: "delivery_address_1", Lat: 55.7652, Lon: 37.6051},
+		{OrderID: batchOrderID, Type: models.RouteStopDelivery, Address: "delivery_address_2", Lat: 55.7660, Lon: 37.6040},
+	},
+	DistanceMeters: 2150.4,
+}
+var orderBatches = []*models.OrderBatch{
+	{OrderIDs: []uuid.UUID{orderID, batchOrderID}, Route: &models.CourierRoute{Stops: courierRoute.Stops, DistanceMeters: 1830.2}},
+}
 
 // // Курьеры
 var courier1 = &models.Courier{
@@ -115,6 +467,49 @@ var review = &models.Review{
 	Text:      createReviewRequest.Text,
 }
 
+// // Клиенты
+var customerAddress1 = models.CustomerAddress{
+	ID:         customerAddressID,
+	CustomerID: customerID,
+	Label:      &customerAddressLabel,
+	Address:    order1.DeliveryAddress,
+	Lat:        55.7558,
+	Lon:        37.6173,
+	CreatedAt:  time.Now(),
+}
+var customer1 = &models.Customer{
+	ID:        customerID,
+	Name:      order1.CustomerName,
+	Phone:     order1.CustomerPhone,
+	Addresses: []models.CustomerAddress{customerAddress1},
+	CreatedAt: time.Now(),
+	UpdatedAt: time.Now(),
+}
+
+// // Продавцы
+var merchant1 = &models.Merchant{
+	ID:            merchantID,
+	Name:          "test_merchant",
+	PickupAddress: merchantOrder.PickupAddress,
+	Lat:           55.7601,
+	Lon:           37.6186,
+	OpeningHours: []models.OpeningHours{
+		{Weekday: time.Friday, Open: "10:00", Close: "02:00"},
+	},
+	IsActive:  true,
+	CreatedAt: time.Now(),
+	UpdatedAt: time.Now(),
+}
+var product1 = &models.Product{
+	ID:          productID,
+	MerchantID:  merchantID,
+	Name:        merchantOrder.Items[0].Name,
+	Price:       merchantOrder.Items[0].Price,
+	IsAvailable: true,
+	CreatedAt:   time.Now(),
+	UpdatedAt:   time.Now(),
+}
+
 // // Запросы
 var createOrderRequest = models.CreateOrderRequest{
 	CustomerName:    order1.CustomerName,
@@ -128,20 +523,174 @@ var createOrderRequest = models.CreateOrderRequest{
 var updateOrderRequest = models.UpdateOrderStatusRequest{
 	Status: models.OrderStatusAccepted, CourierID: &courierID,
 }
+var orderStatusChange = &models.OrderStatusChangedEvent{
+	OrderID:   orderID,
+	OldStatus: models.OrderStatusCreated,
+	NewStatus: models.OrderStatusAccepted,
+	CourierID: &courierID,
+	Timestamp: time.Now(),
+}
+var createOrderAutoAssignRequest = models.CreateOrderRequest{
+	CustomerName:    order3.CustomerName,
+	CustomerPhone:   order3.CustomerPhone,
+	PickupAddress:   order3.PickupAddress,
+	DeliveryAddress: order3.DeliveryAddress,
+	Items: []models.CreateOrderItemRequest{
+		{Name: order3.Items[0].Name, Quantity: order3.Items[0].Quantity, Price: order3.Items[0].Price},
+	},
+	AutoAssign: true,
+}
+var createScheduledOrderRequest = models.CreateOrderRequest{
+	CustomerName:    order1.CustomerName,
+	CustomerPhone:   order1.CustomerPhone,
+	PickupAddress:   order1.PickupAddress,
+	DeliveryAddress: order1.DeliveryAddress,
+	Items: []models.CreateOrderItemRequest{
+		{Name: order1.Items[0].Name, Quantity: order1.Items[0].Quantity, Price: order1.Items[0].Price},
+	},
+	ScheduledFor: &scheduledFor,
+}
+var createCustomerOrderRequest = models.CreateOrderRequest{
+	CustomerID:        &customerID,
+	DeliveryAddressID: &customerAddressID,
+	PickupAddress:     order1.PickupAddress,
+	Items: []models.CreateOrderItemRequest{
+		{Name: order1.Items[0].Name, Quantity: order1.Items[0].Quantity, Price: order1.Items[0].Price},
+	},
+}
+var createMerchantOrderRequest = models.CreateOrderRequest{
+	MerchantID:      &merchantID,
+	CustomerName:    order1.CustomerName,
+	CustomerPhone:   order1.CustomerPhone,
+	DeliveryAddress: order1.DeliveryAddress,
+	Items: []models.CreateOrderItemRequest{
+		{ProductID: &productID, Quantity: merchantOrder.Items[0].Quantity},
+	},
+}
+var merchantRequest = models.MerchantRequest{
+	Name:          merchant1.Name,
+	Phone:         "+7 (495) 000-00-00",
+	PickupAddress: merchant1.PickupAddress,
+	OpeningHours:  merchant1.OpeningHours,
+}
+var productRequest = models.ProductRequest{
+	Name:  product1.Name,
+	Price: product1.Price,
+}
+var customerRequest = models.CustomerRequest{
+	Name:  customer1.Name,
+	Phone: "+7 (111) 111-11-11",
+}
+var customerAddressRequest = models.CustomerAddressRequest{
+	Label:   customerAddressLabel,
+	Address: customerAddress1.Address,
+}
+var createOrderPromoCodeRequest = models.CreateOrderRequest{
+	CustomerName:    order1.CustomerName,
+	CustomerPhone:   order1.CustomerPhone,
+	PickupAddress:   order1.PickupAddress,
+	DeliveryAddress: order1.DeliveryAddress,
+	Items: []models.CreateOrderItemRequest{
+		{Name: order1.Items[0].Name, Quantity: order1.Items[0].Quantity, Price: order1.Items[0].Price},
+	},
+	PromoCode: "EXPIRED",
+}
+var promoCodeRequest = models.PromoCodeRequest{
+	Code:           promoCode1.Code,
+	DiscountType:   promoCode1.DiscountType,
+	DiscountValue:  promoCode1.DiscountValue,
+	MinOrderAmount: promoCode1.MinOrderAmount,
+	MaxUses:        promoCode1.MaxUses,
+}
+var tariffRequest = models.TariffRequest{
+	Code:             tariff1.Code,
+	BaseFee:          tariff1.BaseFee,
+	DistanceBands:    tariff1.DistanceBands,
+	MinPrice:         tariff1.MinPrice,
+	WeightSurcharges: tariff1.WeightSurcharges,
+	NightMultiplier:  &tariff1.NightMultiplier,
+}
+var deliveryQuoteRequest = models.DeliveryQuoteRequest{
+	PickupAddress:   order1.PickupAddress,
+	DeliveryAddress: order1.DeliveryAddress,
+	Items: []models.CreateOrderItemRequest{
+		{Name: "box", Quantity: 2, Price: 100, WeightKg: &tariffItemWeight},
+	},
+}
+var cancelOrderRequest = models.CancelOrderRequest{Reason: models.CancellationReasonCustomerRequest}
+var deliveryProofForm = deliveryProofFormType{Pin: "1234", Lat: "55.7558", Lon: "37.6173", Photo: proofImage}
+var deliveryProofRequest = models.DeliveryProofRequest{
+	Pin:   "1234",
+	Lat:   55.7558,
+	Lon:   37.6173,
+	Photo: &models.ProofFile{ContentType: "image/png", Data: proofImage},
+}
 var createReviewRequest = models.CreateReviewRequest{Rating: 4, Text: "text_review"}
 var createCourierRequest = models.CreateCourierRequest{
 	Name:  courier1.Name,
 	Phone: courier1.Phone,
 }
 var updateCourierStatusRequest = models.UpdateCourierStatusRequest{Status: models.CourierStatusOffline}
+var updateCourierZoneRequest = models.UpdateCourierZoneRequest{Zone: &zoneCode}
+var updateCourierCapacityRequest = models.UpdateCourierCapacityRequest{Capacity: &courierCapacity}
+var assignBatchRequest = models.AssignBatchRequest{OrderIDs: []uuid.UUID{orderID, batchOrderID}}
+var zoneRequest = models.DeliveryZoneRequest{
+	Code:             zone1.Code,
+	Name:             zone1.Name,
+	Polygon:          zonePolygon,
+	MaxRouteDistance: &zoneMaxRouteDistance,
+	SLAMinutes:       &zoneSLAMinutes,
+}
 var assignOrderRequest = assignOrderRequestType{OrderID: order1.ID}
+var scheduleShiftRequest = models.ScheduleShiftRequest{ScheduledStart: shiftStart, ScheduledEnd: shiftEnd}
+
+// // Автоназначение
+var assignmentResult = &models.AssignmentResult{
+	OrderID:   order1.ID,
+	CourierID: courier1.ID,
+	Score:     0.9,
+	Candidates: []models.CandidateScore{
+		{CourierID: courier1.ID, DistanceKm: 1.2, Rating: 5, DistanceScore: 0.88, RatingScore: 1, LoadScore: 1, TotalScore: 0.9},
+		{CourierID: courier3.ID, DistanceKm: 4.5, Rating: 3, DistanceScore: 0.55, RatingScore: 0.5, LoadScore: 1, TotalScore: 0.67},
+	},
+}
+
+// // Аналитика
+var analyticsFrom = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+var analyticsTo = time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+var courierAvgRating = 4.5
+var summaryReport = &models.SummaryReport{
+	Filter: models.AnalyticsFilter{From: analyticsFrom, To: analyticsTo, GroupBy: models.AnalyticsGroupByWeek},
+	Totals: models.SalesMetrics{
+		OrdersCount: 10, DeliveredCount: 8, CancelledCount: 2, Revenue: 8000,
+		AverageOrderValue: 1000, AvgDeliveryTimeMinutes: 42.5, CancellationRate: 0.2,
+	},
+	Periods: []models.SalesMetrics{
+		{Period: &analyticsFrom, OrdersCount: 10, DeliveredCount: 8, CancelledCount: 2, Revenue: 8000,
+			AverageOrderValue: 1000, AvgDeliveryTimeMinutes: 42.5, CancellationRate: 0.2},
+	},
+}
+var topItemsReport = &models.TopItemsReport{
+	Filter: models.AnalyticsFilter{From: analyticsFrom, To: analyticsTo, GroupBy: models.AnalyticsGroupByDay, Limit: 5},
+	Items: []models.TopItem{
+		{Name: "test_item_1", Quantity: 20, Revenue: 1000, OrdersCount: 10},
+		{Name: "test_item_2", Quantity: 3, Revenue: 3000, OrdersCount: 3},
+	},
+}
+var courierReport = &models.CourierReport{
+	Filter: models.AnalyticsFilter{From: analyticsFrom, To: analyticsTo, GroupBy: models.AnalyticsGroupByDay},
+	Couriers: []models.CourierMetrics{
+		{CourierID: courier1.ID, Name: courier1.Name, Deliveries: 8, Earnings: 2400,
+			AvgDeliveryTimeMinutes: 42.5, AvgRating: &courierAvgRating, ReviewsCount: 2},
+	},
+}
 
 // // Метрики
 var kafkaMetrics = &models.KafkaMetricsResponse{
 	TotalLag: 123,
 	Statistics: []models.KafkaTopicMetricsResponse{
-		{"test_topic_1", 1000, 20, "10 ms"},
-		{"test_topic_2", 1500, 100, "20 ms"},
+		{Topic: "test_topic_1", TotalProcessedEvents: 1000, Errors: 20, AvgProcessingDuration: "10 ms"},
+		{Topic: "test_topic_2", TotalProcessedEvents: 1500, Errors: 100, AvgProcessingDuration: "20 ms"},
 	},
 }
 var redisMetrics = &models.RedisMetricsResponse{
@@ -149,17 +698,114 @@ var redisMetrics = &models.RedisMetricsResponse{
 	MissRate:  5.0,
 	CacheSize: 1000,
 }
+var courierLocation = &models.CourierLocation{CourierID: courierID, Lat: 55.7558, Lon: 37.6173, Timestamp: time.Now()}
+var courierTrack = &models.GeoJSONFeature{
+	Type: models.GeoJSONTypeFeature,
+	Geometry: &models.GeoJSONGeometry{
+		Type:        models.GeoJSONTypeLineString,
+		Coordinates: [][2]float64{{37.6173, 55.7558}, {37.6200, 55.7570}},
+	},
+	Properties: map[string]interface{}{"courier_id": courierID, "points": 2},
+}
+var nearbyCouriers = []*models.NearbyCourier{
+	{Courier: courier1, DistanceMeters: 350.5},
+	{Courier: courier2, DistanceMeters: 1200},
+}
+var orderWithCourier = &models.Order{ID: orderID, CustomerID: &customerID, CustomerPhone: "+79991234567", CourierID: &courierID}
+var order1WithCourier = &models.Order{ID: orderID, CustomerPhone: order1.CustomerPhone, CourierID: &courierID}
+var deliveredOrderWithCourier = &models.Order{
+	ID: orderID, CustomerID: &customerID, CustomerPhone: "+79991234567", CourierID: &courierID,
+	Status: models.OrderStatusDelivered,
+}
 
 // Ошибки
 var errorNotFound = errors.New("not found")
 var errorInternalServerError = errors.New("internal Server Error")
+var errorInvalidTransition = &services.InvalidTransitionError{
+	From: models.OrderStatusDelivered,
+	To:   models.OrderStatusCreated,
+	Role: models.RoleDispatcher,
+}
+var errorPromoCodeExpired = &services.PromoCodeError{Code: "EXPIRED", Reason: "expired"}
+var errorCancelNotAllowed = &services.InvalidTransitionError{
+	From: models.OrderStatusInDelivery, To: models.OrderStatusCancelled, Role: models.RoleCustomer,
+}
+var errorAlreadyExists = errors.New("promo code already exists")
+var errorTariffVersionExists = errors.New("tariff version already exists")
+var errorZoneExists = errors.New("delivery zone already exists")
+var errorZoneNotFound = errors.New("delivery zone not found")
+var errorShiftNotFound = errors.New("shift not found")
+var errorNotOnShift = &services.ShiftError{CourierID: courierID, Reason: "courier is not on shift"}
+var errorShiftOverlaps = &services.ShiftError{CourierID: courierID, Reason: "shift overlaps with another shift"}
+var errorCourierHasOrders = &services.ShiftError{CourierID: courierID, Reason: "courier has active orders"}
+var errorCourierOverCapacity = errors.New("courier is not available: 2 active orders, capacity 3")
+var errorOrderAlreadyAssigned = fmt.Errorf("%w: %s", services.ErrOrderAlreadyAssigned, orderID)
+var errorInvalidPin = &services.DeliveryProofError{OrderID: orderID, Reason: "invalid PIN, 4 attempts left"}
+var errorScheduleTooFar = fmt.Errorf("%w: at most 7 days ahead", services.ErrScheduleTooFar)
+var errorCustomerExists = errors.New("customer already exists")
+var errorCustomerNotFound = errors.New("customer not found")
+var errorMerchantNotFound = errors.New("merchant not found")
+var errorProductNotFound = errors.New("product not found")
+var errorMerchantClosed = fmt.Errorf("%w: merchant is closed at Mon 09:00", services.ErrMerchantUnavailable)
+var errorPrepTimeTooLong = fmt.Errorf("%w: at most 180 minutes", services.ErrPrepTimeTooLong)
+var errorForeignMerchant = &services.InvalidTransitionError{
+	From: models.OrderStatusCreated, To: models.OrderStatusAccepted, Role: models.RoleMerchant,
+	Reason: "order belongs to another merchant",
+}
+var errorAlreadyAccepted = &services.InvalidTransitionError{
+	From: models.OrderStatusAccepted, To: models.OrderStatusCancelled, Role: models.RoleMerchant,
+	Reason: "order has already been accepted",
+}
+var errorNotPreparing = &services.InvalidTransitionError{
+	From: models.OrderStatusAccepted, To: models.OrderStatusReady, Role: models.RoleMerchant,
+}
+var errorInvalidAPIKey = fmt.Errorf("%w: unknown key", services.ErrInvalidAPIKey)
+var errorAPIKeyInactive = services.ErrAPIKeyInactive
+var errorInvalidToken = fmt.Errorf("%w: token is expired", services.ErrInvalidToken)
+var errorDeliverNotAllowed = &services.InvalidTransitionError{
+	From: models.OrderStatusReady, To: models.OrderStatusDelivered, Role: models.RoleCourier,
+}
 
 // Модели
 type assignOrderRequestType struct {
 	OrderID uuid.UUID `json:"order_id"`
 }
+type deliveryProofFormType struct {
+	Pin       string
+	Lat       string
+	Lon       string
+	Photo     []byte
+	Signature []byte
+}
 
 // Тесткейсы для /api/orders
+var customerDeliveryCost = 150.0
+
+var createOrderAsCustomerTestCases = []struct {
+	name               string
+	payload            models.CreateOrderRequest
+	actorID            string
+	expectedStatusCode int
+}{
+	{"test_customer_id_from_actor", createOrderRequest, customerID.String(), http.StatusCreated},
+	{"test_own_customer_id", withCustomerID(createOrderRequest, customerID), customerID.String(), http.StatusCreated},
+	{"test_another_customer_id", withCustomerID(createOrderRequest, uuid.New()), customerID.String(), http.StatusForbidden},
+	{"test_actor_id_not_customer", createOrderRequest, order1.CustomerPhone, http.StatusForbidden},
+	{"test_delivery_cost", withDeliveryCost(createOrderRequest, customerDeliveryCost), customerID.String(), http.StatusForbidden},
+}
+
+// withCustomerID возвращает копию запроса на создание заказа с указанным клиентом
+func withCustomerID(req models.CreateOrderRequest, id uuid.UUID) models.CreateOrderRequest {
+	req.CustomerID = &id
+	return req
+}
+
+// withDeliveryCost возвращает копию запроса на создание заказа с заданной стоимостью доставки
+func withDeliveryCost(req models.CreateOrderRequest, cost float64) models.CreateOrderRequest {
+	req.DeliveryCost = &cost
+	return req
+}
+
 var createOrderTestCases = []struct {
 	name               string
 	payload            *models.CreateOrderRequest
@@ -189,129 +835,375 @@ var createOrderTestCases = []struct {
 		http.StatusInternalServerError,
 	},
 	{
-		"validate_customer_name",
-		&models.CreateOrderRequest{},
+		"test_promo_code_not_applicable",
+		&createOrderPromoCodeRequest,
 		nil,
+		errorPromoCodeExpired,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"test_address_not_found",
+		&createOrderRequest,
 		nil,
-		http.StatusBadRequest,
+		fmt.Errorf("%w: unknown street", services.ErrAddressNotFound),
+		http.StatusUnprocessableEntity,
 	},
 	{
-		"validate_customer_phone",
+		"test_geo_unavailable",
+		&createOrderRequest,
+		nil,
+		fmt.Errorf("failed to calculate delivery cost. Error: %w", services.ErrGeoUnavailable),
+		http.StatusServiceUnavailable,
+	},
+	{
+		"test_no_active_tariff",
+		&createOrderRequest,
+		nil,
+		fmt.Errorf("failed to calculate delivery cost: %w", services.ErrNoActiveTariff),
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"test_outside_delivery_area",
+		&createOrderRequest,
+		nil,
+		fmt.Errorf("delivery point: %w", services.ErrOutsideDeliveryArea),
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"test_route_too_long",
+		&createOrderRequest,
+		nil,
+		fmt.Errorf("%w: 20000 m exceeds the limit of 15000 m", services.ErrRouteTooLong),
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"test_customer_saved_address",
+		&createCustomerOrderRequest,
+		order1,
+		nil,
+		http.StatusCreated,
+	},
+	{
+		"test_customer_address_not_found",
+		&createCustomerOrderRequest,
+		nil,
+		services.ErrCustomerAddressNotFound,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"validate_delivery_address_id_without_customer",
 		&models.CreateOrderRequest{
-			CustomerName: "test_name",
+			CustomerName:      "test_name",
+			CustomerPhone:     "79999999999",
+			PickupAddress:     "pickup_location",
+			DeliveryAddressID: &customerAddressID,
+			Items:             []models.CreateOrderItemRequest{{Name: "test", Quantity: 1, Price: 1}},
 		},
 		nil,
 		nil,
 		http.StatusBadRequest,
 	},
 	{
-		"validate_pickup_address",
+		"test_merchant",
+		&createMerchantOrderRequest,
+		merchantOrder,
+		nil,
+		http.StatusCreated,
+	},
+	{
+		"test_merchant_closed",
+		&createMerchantOrderRequest,
+		nil,
+		errorMerchantClosed,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"test_product_unavailable",
+		&createMerchantOrderRequest,
+		nil,
+		fmt.Errorf("%w: catalog_item is out of stock", services.ErrProductUnavailable),
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"validate_merchant_item_without_product",
 		&models.CreateOrderRequest{
-			CustomerName:  "test_name",
-			CustomerPhone: "79999999999",
+			MerchantID:      &merchantID,
+			CustomerName:    "test_name",
+			CustomerPhone:   "79999999999",
+			DeliveryAddress: "delivery_location",
+			Items:           []models.CreateOrderItemRequest{{Name: "test", Quantity: 1, Price: 1}},
 		},
 		nil,
 		nil,
 		http.StatusBadRequest,
 	},
 	{
-		"validate_delivery_address",
+		"validate_merchant_auto_assign",
 		&models.CreateOrderRequest{
-			CustomerName:  "test_name",
-			CustomerPhone: "79999999999",
-			PickupAddress: "pickup_location",
+			MerchantID:      &merchantID,
+			CustomerName:    "test_name",
+			CustomerPhone:   "79999999999",
+			DeliveryAddress: "delivery_location",
+			Items:           []models.CreateOrderItemRequest{{ProductID: &productID, Quantity: 1}},
+			AutoAssign:      true,
 		},
 		nil,
 		nil,
 		http.StatusBadRequest,
 	},
 	{
-		"validate_order_items_absent",
+		"validate_product_without_merchant",
 		&models.CreateOrderRequest{
 			CustomerName:    "test_name",
 			CustomerPhone:   "79999999999",
 			PickupAddress:   "pickup_location",
 			DeliveryAddress: "delivery_location",
+			Items:           []models.CreateOrderItemRequest{{ProductID: &productID, Name: "test", Quantity: 1, Price: 1}},
 		},
 		nil,
 		nil,
 		http.StatusBadRequest,
 	},
 	{
-		"validate_order_item_name",
+		"test_scheduled",
+		&createScheduledOrderRequest,
+		scheduledOrder,
+		nil,
+		http.StatusCreated,
+	},
+	{
+		"test_schedule_too_far",
+		&createScheduledOrderRequest,
+		nil,
+		errorScheduleTooFar,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"validate_scheduled_for_past",
 		&models.CreateOrderRequest{
 			CustomerName:    "test_name",
 			CustomerPhone:   "79999999999",
 			PickupAddress:   "pickup_location",
 			DeliveryAddress: "delivery_location",
-			Items:           []models.CreateOrderItemRequest{{Name: ""}},
+			Items:           []models.CreateOrderItemRequest{{Name: "test", Quantity: 1, Price: 1}},
+			ScheduledFor:    &pastScheduledFor,
 		},
 		nil,
 		nil,
 		http.StatusBadRequest,
 	},
 	{
-		"validate_order_item_quantity",
+		"validate_scheduled_auto_assign",
 		&models.CreateOrderRequest{
 			CustomerName:    "test_name",
 			CustomerPhone:   "79999999999",
 			PickupAddress:   "pickup_location",
 			DeliveryAddress: "delivery_location",
-			Items:           []models.CreateOrderItemRequest{{Name: "test", Quantity: 0}},
+			Items:           []models.CreateOrderItemRequest{{Name: "test", Quantity: 1, Price: 1}},
+			AutoAssign:      true,
+			ScheduledFor:    &scheduledFor,
 		},
 		nil,
 		nil,
 		http.StatusBadRequest,
 	},
 	{
-		"validate_order_item_price",
+		"validate_promo_code_length",
 		&models.CreateOrderRequest{
 			CustomerName:    "test_name",
 			CustomerPhone:   "79999999999",
 			PickupAddress:   "pickup_location",
 			DeliveryAddress: "delivery_location",
-			Items:           []models.CreateOrderItemRequest{{Name: "test", Quantity: 2, Price: -1}},
+			Items:           []models.CreateOrderItemRequest{{Name: "test", Quantity: 1, Price: 1}},
+			PromoCode:       strings.Repeat("A", 65),
 		},
 		nil,
 		nil,
 		http.StatusBadRequest,
 	},
-}
-
-var getOrderTestCases = []struct {
-	name               string
-	id                 uuid.UUID
-	returnedValue      *models.Order
-	returnedError      error
-	expectedStatusCode int
-}{
-	{"test_ok", orderID, order1, nil, http.StatusOK},
-	{"test_not_found", uuid.New(), nil, errorNotFound, http.StatusNotFound},
-	{"test_server_error", uuid.New(), nil, errorInternalServerError, http.StatusInternalServerError},
-}
-
-var updateOrderStatusTestCases = []struct {
-	name               string
-	id                 uuid.UUID
-	payload            *models.UpdateOrderStatusRequest
-	returnedError      error
-	expectedStatusCode int
-}{
-	{"test_ok", orderID, &updateOrderRequest, nil, http.StatusOK},
-	{"test_not_found", uuid.New(), &updateOrderRequest, errorNotFound, http.StatusNotFound},
-	{"test_server_error", uuid.New(), &updateOrderRequest, errorInternalServerError, http.StatusInternalServerError},
-}
-
-var getOrdersTestCases = []struct {
-	name               string
-	status             *models.OrderStatus
-	courierID          *uuid.UUID
-	limit              int
-	offset             int
-	queryParam         string
-	returnedValue      []*models.Order
-	returnedError      error
-	expectedStatusCode int
+	{
+		"validate_customer_name",
+		&models.CreateOrderRequest{},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_customer_phone",
+		&models.CreateOrderRequest{
+			CustomerName: "test_name",
+		},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_pickup_address",
+		&models.CreateOrderRequest{
+			CustomerName:  "test_name",
+			CustomerPhone: "79999999999",
+		},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_delivery_address",
+		&models.CreateOrderRequest{
+			CustomerName:  "test_name",
+			CustomerPhone: "79999999999",
+			PickupAddress: "pickup_location",
+		},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_order_items_absent",
+		&models.CreateOrderRequest{
+			CustomerName:    "test_name",
+			CustomerPhone:   "79999999999",
+			PickupAddress:   "pickup_location",
+			DeliveryAddress: "delivery_location",
+		},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_order_item_name",
+		&models.CreateOrderRequest{
+			CustomerName:    "test_name",
+			CustomerPhone:   "79999999999",
+			PickupAddress:   "pickup_location",
+			DeliveryAddress: "delivery_location",
+			Items:           []models.CreateOrderItemRequest{{Name: ""}},
+		},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_order_item_quantity",
+		&models.CreateOrderRequest{
+			CustomerName:    "test_name",
+			CustomerPhone:   "79999999999",
+			PickupAddress:   "pickup_location",
+			DeliveryAddress: "delivery_location",
+			Items:           []models.CreateOrderItemRequest{{Name: "test", Quantity: 0}},
+		},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_order_item_price",
+		&models.CreateOrderRequest{
+			CustomerName:    "test_name",
+			CustomerPhone:   "79999999999",
+			PickupAddress:   "pickup_location",
+			DeliveryAddress: "delivery_location",
+			Items:           []models.CreateOrderItemRequest{{Name: "test", Quantity: 2, Price: -1}},
+		},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+}
+
+var getOrderTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	returnedValue      *models.Order
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", orderID, order1, nil, http.StatusOK},
+	{"test_not_found", uuid.New(), nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", uuid.New(), nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var updateOrderStatusTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	payload            *models.UpdateOrderStatusRequest
+	actorRole          string
+	order              *models.Order
+	orderError         error
+	returnedValue      *models.OrderStatusChangedEvent
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", orderID, &updateOrderRequest, "dispatcher", order1, nil, orderStatusChange, nil, http.StatusOK},
+	{"test_admin_role_from_header", uuid.New(), &updateOrderRequest, "admin", nil, nil, nil, nil, http.StatusBadRequest},
+	{"test_system_role_from_header", uuid.New(), &updateOrderRequest, "system", nil, nil, nil, nil, http.StatusBadRequest},
+	{"test_not_found", uuid.New(), &updateOrderRequest, "dispatcher", order1, nil, nil, errorNotFound, http.StatusNotFound},
+	{"test_invalid_transition", uuid.New(), &updateOrderRequest, "dispatcher", order1, nil, nil, errorInvalidTransition, http.StatusConflict},
+	{"test_server_error", uuid.New(), &updateOrderRequest, "dispatcher", order1, nil, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"test_invalid_status", uuid.New(), &models.UpdateOrderStatusRequest{Status: "unknown"}, "dispatcher", nil, nil, nil, nil, http.StatusBadRequest},
+	{"test_invalid_role", uuid.New(), &updateOrderRequest, "unknown", nil, nil, nil, nil, http.StatusBadRequest},
+	{"test_cancel_via_status", uuid.New(), &models.UpdateOrderStatusRequest{Status: models.OrderStatusCancelled}, "dispatcher", nil, nil, nil, nil, http.StatusBadRequest},
+	{"test_actor_required", uuid.New(), &updateOrderRequest, "", nil, nil, nil, nil, http.StatusBadRequest},
+	{"test_merchant_order_accept", uuid.New(), &updateOrderRequest, "dispatcher", merchantOrder, nil, nil, nil, http.StatusBadRequest},
+	{"test_merchant_order_ready", uuid.New(), &models.UpdateOrderStatusRequest{Status: models.OrderStatusReady}, "dispatcher", merchantOrder, nil, nil, nil, http.StatusBadRequest},
+	{"test_merchant_order_in_delivery", uuid.New(), &models.UpdateOrderStatusRequest{Status: models.OrderStatusInDelivery}, "dispatcher", nil, nil, orderStatusChange, nil, http.StatusOK},
+	{"test_order_lookup_not_found", uuid.New(), &updateOrderRequest, "dispatcher", nil, errorNotFound, nil, nil, http.StatusNotFound},
+}
+
+var autoAssignOrderTestCases = []struct {
+	name               string
+	orderID            string
+	returnedValue      *models.AssignmentResult
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", order1.ID.String(), assignmentResult, nil, http.StatusOK},
+	{"test_not_found", uuid.New().String(), nil, errorNotFound, http.StatusNotFound},
+	{"test_no_couriers", order3.ID.String(), nil, errors.New("no available couriers for order"), http.StatusConflict},
+	{"test_already_assigned", order2.ID.String(), nil, services.ErrOrderAlreadyAssigned, http.StatusConflict},
+	{"test_assigned_concurrently", order1.ID.String(), nil, errorOrderAlreadyAssigned, http.StatusConflict},
+	{"test_server_error", order1.ID.String(), nil, errorInternalServerError, http.StatusInternalServerError},
+	{"test_invalid_order_id", "1", nil, nil, http.StatusBadRequest},
+}
+
+var getOrderEventsTestCases = []struct {
+	name               string
+	orderID            string
+	returnedValue      []*models.OrderEvent
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", orderID.String(), orderEvents, nil, http.StatusOK},
+	{"test_not_found", uuid.New().String(), nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", orderID.String(), nil, errorInternalServerError, http.StatusInternalServerError},
+	{"test_invalid_order_id", "1", nil, nil, http.StatusBadRequest},
+}
+
+var replayOrderTestCases = []struct {
+	name               string
+	version            string
+	expectedVersion    int
+	returnedValue      *models.OrderReplayResult
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_latest", "", 0, orderReplay, nil, http.StatusOK},
+	{"test_version", "12", 12, orderReplay, nil, http.StatusOK},
+	{"test_not_found", "", 0, nil, errors.New("order events not found"), http.StatusNotFound},
+	{"test_server_error", "", 0, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"test_invalid_version", "-1", 0, nil, nil, http.StatusBadRequest},
+}
+
+var getOrdersTestCases = []struct {
+	name               string
+	status             *models.OrderStatus
+	courierID          *uuid.UUID
+	limit              int
+	offset             int
+	queryParam         string
+	returnedValue      []*models.Order
+	returnedError      error
+	expectedStatusCode int
 }{
 	{
 		"test_w/o_filters",
@@ -392,6 +1284,40 @@ var getOrdersTestCases = []struct {
 	},
 }
 
+var cancelOrderTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	payload            *models.CancelOrderRequest
+	actorRole          string
+	actorID            string
+	returnedValue      *models.OrderCancellation
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", orderID, &cancelOrderRequest, "customer", customerID.String(), orderCancellation, nil, http.StatusOK},
+	{"test_not_allowed", orderID, &cancelOrderRequest, "customer", customerID.String(), nil, errorCancelNotAllowed, http.StatusConflict},
+	{"test_not_found", uuid.New(), &cancelOrderRequest, "dispatcher", "", nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", orderID, &cancelOrderRequest, "dispatcher", "", nil, errorInternalServerError, http.StatusInternalServerError},
+	{"test_actor_required", orderID, &cancelOrderRequest, "", "", nil, nil, http.StatusBadRequest},
+	{"test_customer_id_required", orderID, &cancelOrderRequest, "customer", "", nil, nil, http.StatusBadRequest},
+	{"test_reason_required", orderID, &models.CancelOrderRequest{}, "dispatcher", "", nil, nil, http.StatusBadRequest},
+	{"test_unknown_reason", orderID, &models.CancelOrderRequest{Reason: "changed_mind"}, "dispatcher", "", nil, nil, http.StatusBadRequest},
+	{"test_other_without_comment", orderID, &models.CancelOrderRequest{Reason: models.CancellationReasonOther}, "dispatcher", "", nil, nil, http.StatusBadRequest},
+	{"test_invalid_role", orderID, &cancelOrderRequest, "unknown", "", nil, nil, http.StatusBadRequest},
+}
+
+var getOrderCancellationTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	returnedValue      *models.OrderCancellation
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", orderID, orderCancellation, nil, http.StatusOK},
+	{"test_not_cancelled", uuid.New(), nil, errors.New("order cancellation not found"), http.StatusNotFound},
+	{"test_server_error", orderID, nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
 var createOrderReviewTestCases = []struct {
 	name               string
 	payload            *models.CreateReviewRequest
@@ -457,143 +1383,357 @@ var createOrderReviewTestCases = []struct {
 	},
 }
 
-// Тесткейсы для /api/couriers
-var createCourierTestCases = []struct {
+// Тесткейсы для /api/promo-codes
+var createPromoCodeTestCases = []struct {
 	name               string
-	payload            *models.CreateCourierRequest
-	returnedValue      *models.Courier
+	payload            *models.PromoCodeRequest
+	returnedValue      *models.PromoCode
 	returnedError      error
 	expectedStatusCode int
 }{
+	{"test_created", &promoCodeRequest, promoCode1, nil, http.StatusCreated},
+	{"test_bad_request", nil, nil, nil, http.StatusBadRequest},
+	{"test_conflict", &promoCodeRequest, nil, errorAlreadyExists, http.StatusConflict},
+	{"test_server_error", &promoCodeRequest, nil, errorInternalServerError, http.StatusInternalServerError},
 	{
-		"test_created",
-		&createCourierRequest,
-		courier1,
+		"validate_code",
+		&models.PromoCodeRequest{Code: "  ", DiscountType: models.DiscountTypeFixed, DiscountValue: 100},
 		nil,
-		http.StatusCreated,
+		nil,
+		http.StatusBadRequest,
 	},
 	{
-		"test_bad_request",
+		"validate_discount_type",
+		&models.PromoCodeRequest{Code: "CODE", DiscountType: "unknown", DiscountValue: 100},
+		nil,
 		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_discount_value",
+		&models.PromoCodeRequest{Code: "CODE", DiscountType: models.DiscountTypeFixed},
 		nil,
 		nil,
 		http.StatusBadRequest,
 	},
 	{
-		"test_server_error",
-		&createCourierRequest,
+		"validate_percent_value",
+		&models.PromoCodeRequest{Code: "CODE", DiscountType: models.DiscountTypePercent, DiscountValue: 150},
 		nil,
-		errorInternalServerError,
-		http.StatusInternalServerError,
+		nil,
+		http.StatusBadRequest,
 	},
 	{
-		"validate_courier_name",
-		&models.CreateCourierRequest{Name: ""},
+		"validate_validity_window",
+		&models.PromoCodeRequest{
+			Code:          "CODE",
+			DiscountType:  models.DiscountTypeFixed,
+			DiscountValue: 100,
+			ValidFrom:     &deliveredTime,
+			ValidUntil:    &promoCode1.CreatedAt,
+		},
 		nil,
 		nil,
 		http.StatusBadRequest,
 	},
 	{
-		"validate_courier_phone",
-		&models.CreateCourierRequest{Name: courier1.Name, Phone: ""},
+		"validate_max_uses",
+		&models.PromoCodeRequest{
+			Code:         "CODE",
+			DiscountType: models.DiscountTypeFreeDelivery,
+			MaxUses:      new(int),
+		},
 		nil,
 		nil,
 		http.StatusBadRequest,
 	},
 }
 
-var getCourierTestCases = []struct {
+var getPromoCodeTestCases = []struct {
 	name               string
 	id                 uuid.UUID
-	returnedValue      *models.Courier
+	returnedValue      *models.PromoCode
 	returnedError      error
 	expectedStatusCode int
 }{
-	{"test_ok", courierID, courier1, nil, http.StatusOK},
+	{"test_ok", promoCodeID, promoCode1, nil, http.StatusOK},
 	{"test_not_found", uuid.New(), nil, errorNotFound, http.StatusNotFound},
 	{"test_server_error", uuid.New(), nil, errorInternalServerError, http.StatusInternalServerError},
 }
 
-var updateCourierStatusTestCases = []struct {
+var getPromoCodesTestCases = []struct {
+	name               string
+	activeOnly         bool
+	activeParam        string
+	returnedValue      []*models.PromoCode
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_all", false, "", []*models.PromoCode{promoCode1, promoCode2}, nil, http.StatusOK},
+	{"test_active_only", true, "true", []*models.PromoCode{promoCode1}, nil, http.StatusOK},
+	{"test_invalid_active", false, "maybe", nil, nil, http.StatusBadRequest},
+	{"test_server_error", false, "", nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var updatePromoCodeTestCases = []struct {
 	name               string
 	id                 uuid.UUID
-	payload            *models.UpdateCourierStatusRequest
+	payload            *models.PromoCodeRequest
+	returnedValue      *models.PromoCode
 	returnedError      error
 	expectedStatusCode int
 }{
-	{"test_ok", orderID, &updateCourierStatusRequest, nil, http.StatusOK},
-	{"test_not_found", uuid.New(), &updateCourierStatusRequest, errorNotFound, http.StatusNotFound},
-	{"test_server_error", uuid.New(), &updateCourierStatusRequest, errorInternalServerError, http.StatusInternalServerError},
+	{"test_ok", promoCodeID, &promoCodeRequest, promoCode1, nil, http.StatusOK},
+	{"test_bad_request", promoCodeID, &models.PromoCodeRequest{}, nil, nil, http.StatusBadRequest},
+	{"test_not_found", uuid.New(), &promoCodeRequest, nil, errorNotFound, http.StatusNotFound},
+	{"test_conflict", promoCodeID, &promoCodeRequest, nil, errorAlreadyExists, http.StatusConflict},
+	{"test_server_error", promoCodeID, &promoCodeRequest, nil, errorInternalServerError, http.StatusInternalServerError},
 }
 
-var getCouriersTestCases = []struct {
+var deletePromoCodeTestCases = []struct {
 	name               string
-	status             *models.CourierStatus
-	ratingSort         bool
-	limit              int
-	offset             int
-	queryParam         string
-	returnedValue      []*models.Courier
+	id                 uuid.UUID
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_no_content", promoCodeID, nil, http.StatusNoContent},
+	{"test_not_found", uuid.New(), errorNotFound, http.StatusNotFound},
+	{"test_server_error", uuid.New(), errorInternalServerError, http.StatusInternalServerError},
+}
+
+// Тесткейсы для /api/analytics
+var analyticsSummaryTestCases = []struct {
+	name               string
+	query              map[string]string
+	expectedFilter     *models.AnalyticsFilter
+	returnedValue      *models.SummaryReport
 	returnedError      error
 	expectedStatusCode int
+	expectedCSV        bool
 }{
 	{
-		"test_w/o_filters",
-		nil,
-		false,
-		50,
-		0,
-		"",
-		[]*models.Courier{courier1, courier2, courier3},
+		"test_json",
+		map[string]string{"from": "2025-01-01", "to": "2025-01-31", "group": "week"},
+		&summaryReport.Filter,
+		summaryReport,
 		nil,
 		http.StatusOK,
-	},
-	{
-		"test_with_status_filter",
-		&courierStatusOffline,
 		false,
-		50,
-		0,
-		"status",
-		[]*models.Courier{courier2},
-		nil,
-		http.StatusOK,
 	},
 	{
-		"test_with_rating_sort_filter",
-		nil,
-		true,
-		50,
-		0,
-		"rating_sort",
-		[]*models.Courier{courier1, courier2, courier3},
+		"test_csv",
+		map[string]string{"from": "2025-01-01T00:00:00Z", "to": "2025-02-01T00:00:00Z", "group": "week", "format": "csv"},
+		&summaryReport.Filter,
+		summaryReport,
 		nil,
 		http.StatusOK,
+		true,
 	},
 	{
-		"test_with_limit_filter",
+		"test_server_error",
+		map[string]string{"from": "2025-01-01", "to": "2025-01-31", "group": "week"},
+		&summaryReport.Filter,
 		nil,
+		errorInternalServerError,
+		http.StatusInternalServerError,
 		false,
-		1,
-		0,
-		"limit",
-		[]*models.Courier{courier1},
-		nil,
-		http.StatusOK,
 	},
+	{"validate_group", map[string]string{"group": "year"}, nil, nil, nil, http.StatusBadRequest, false},
+	{"validate_format", map[string]string{"format": "xml"}, nil, nil, nil, http.StatusBadRequest, false},
+	{"validate_from", map[string]string{"from": "yesterday"}, nil, nil, nil, http.StatusBadRequest, false},
 	{
-		"test_with_offset_filter",
+		"validate_range",
+		map[string]string{"from": "2025-02-01", "to": "2025-01-01"},
 		nil,
-		false,
-		50,
-		1,
-		"offset",
-		[]*models.Courier{courier2, courier3},
 		nil,
-		http.StatusOK,
-	},
-	{
-		"test_server_error",
+		nil,
+		http.StatusBadRequest,
+		false,
+	},
+}
+
+// Тесткейсы для /api/couriers
+var createCourierTestCases = []struct {
+	name               string
+	payload            *models.CreateCourierRequest
+	returnedValue      *models.Courier
+	returnedError      error
+	expectedStatusCode int
+}{
+	{
+		"test_created",
+		&createCourierRequest,
+		courier1,
+		nil,
+		http.StatusCreated,
+	},
+	{
+		"test_bad_request",
+		nil,
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"test_server_error",
+		&createCourierRequest,
+		nil,
+		errorInternalServerError,
+		http.StatusInternalServerError,
+	},
+	{
+		"validate_courier_name",
+		&models.CreateCourierRequest{Name: ""},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_courier_phone",
+		&models.CreateCourierRequest{Name: courier1.Name, Phone: ""},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"test_zone_not_found",
+		&models.CreateCourierRequest{Name: courier1.Name, Phone: courier1.Phone, Zone: &zoneCode},
+		nil,
+		errorZoneNotFound,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"validate_courier_capacity",
+		&models.CreateCourierRequest{Name: courier1.Name, Phone: courier1.Phone, Capacity: &invalidCourierCapacity},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+}
+
+var getCourierTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	returnedValue      *models.Courier
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", courierID, courier1, nil, http.StatusOK},
+	{"test_not_found", uuid.New(), nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", uuid.New(), nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var updateCourierStatusTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	payload            *models.UpdateCourierStatusRequest
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", orderID, &updateCourierStatusRequest, nil, http.StatusOK},
+	{"test_not_found", uuid.New(), &updateCourierStatusRequest, errorNotFound, http.StatusNotFound},
+	{"test_not_on_shift", uuid.New(), &models.UpdateCourierStatusRequest{Status: models.CourierStatusAvailable}, errorNotOnShift, http.StatusConflict},
+	{"test_server_error", uuid.New(), &updateCourierStatusRequest, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var updateCourierZoneTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	payload            *models.UpdateCourierZoneRequest
+	zone               *string
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", courierID, &updateCourierZoneRequest, &zoneCode, nil, http.StatusOK},
+	{"test_detach", courierID, &models.UpdateCourierZoneRequest{}, nil, nil, http.StatusOK},
+	{"test_zone_not_found", courierID, &updateCourierZoneRequest, &zoneCode, errorZoneNotFound, http.StatusUnprocessableEntity},
+	{"test_not_found", uuid.New(), &updateCourierZoneRequest, &zoneCode, errorNotFound, http.StatusNotFound},
+	{"test_server_error", courierID, &updateCourierZoneRequest, &zoneCode, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var updateCourierCapacityTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	payload            *models.UpdateCourierCapacityRequest
+	capacity           *int
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", courierID, &updateCourierCapacityRequest, &courierCapacity, nil, http.StatusOK},
+	{"test_reset_to_default", courierID, &models.UpdateCourierCapacityRequest{}, nil, nil, http.StatusOK},
+	{"test_invalid_capacity", courierID, &models.UpdateCourierCapacityRequest{Capacity: &invalidCourierCapacity}, nil, nil, http.StatusBadRequest},
+	{"test_not_found", uuid.New(), &updateCourierCapacityRequest, &courierCapacity, errorNotFound, http.StatusNotFound},
+	{"test_server_error", courierID, &updateCourierCapacityRequest, &courierCapacity, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var getCouriersTestCases = []struct {
+	name               string
+	status             *models.CourierStatus
+	ratingSort         bool
+	limit              int
+	offset             int
+	queryParam         string
+	returnedValue      []*models.Courier
+	returnedError      error
+	expectedStatusCode int
+}{
+	{
+		"test_w/o_filters",
+		nil,
+		false,
+		50,
+		0,
+		"",
+		[]*models.Courier{courier1, courier2, courier3},
+		nil,
+		http.StatusOK,
+	},
+	{
+		"test_with_status_filter",
+		&courierStatusOffline,
+		false,
+		50,
+		0,
+		"status",
+		[]*models.Courier{courier2},
+		nil,
+		http.StatusOK,
+	},
+	{
+		"test_with_rating_sort_filter",
+		nil,
+		true,
+		50,
+		0,
+		"rating_sort",
+		[]*models.Courier{courier1, courier2, courier3},
+		nil,
+		http.StatusOK,
+	},
+	{
+		"test_with_limit_filter",
+		nil,
+		false,
+		1,
+		0,
+		"limit",
+		[]*models.Courier{courier1},
+		nil,
+		http.StatusOK,
+	},
+	{
+		"test_with_offset_filter",
+		nil,
+		false,
+		50,
+		1,
+		"offset",
+		[]*models.Courier{courier2, courier3},
+		nil,
+		http.StatusOK,
+	},
+	{
+		"test_server_error",
 		nil,
 		false,
 		50,
@@ -636,6 +1776,13 @@ var assignOrderToCourierTestCases = []struct {
 		errorNotFound,
 		http.StatusNotFound,
 	},
+	{
+		"test_already_assigned",
+		&assignOrderRequest,
+		courier1.ID,
+		errorOrderAlreadyAssigned,
+		http.StatusConflict,
+	},
 	{
 		"test_courier_not_available",
 		&assignOrderRequest,
@@ -720,3 +1867,1182 @@ var extractUUIDFromPathTestCases = []struct {
 	{"test_missing_uuid", "/api/status", "/api/", true},
 	{"test_invalid_uuid", fmt.Sprintf("/api/%d/status", 1234), "/api/", true},
 }
+
+// // Ограничение частоты запросов
+var rateLimitResetAt = time.Now().Add(30 * time.Second).UTC()
+
+var rateLimitTestCases = []struct {
+	name               string
+	apiKeyHeader       string
+	apiKey             *models.APIKey
+	returnedValue      *models.RateLimitStatus
+	expectedStatusCode int
+	expectedMessage    string
+}{
+	{
+		name: "test_allowed",
+		returnedValue: &models.RateLimitStatus{
+			Client: "ip:127.0.0.1", Tier: models.RateLimitTierDefault, Limit: 100, Remaining: 99,
+			Window: 60, ResetAt: rateLimitResetAt, Allowed: true,
+		},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:         "test_vip_allowed",
+		apiKeyHeader: apiKeyValue,
+		apiKey:       vipAPIKey,
+		returnedValue: &models.RateLimitStatus{
+			Client: "key:" + vipAPIKey.ID.String(), Tier: models.RateLimitTierVIP, Limit: 1000, Remaining: 999,
+			Window: 60, ResetAt: rateLimitResetAt, Allowed: true,
+		},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:         "test_unauthenticated_key_ignored",
+		apiKeyHeader: apiKeyValue,
+		returnedValue: &models.RateLimitStatus{
+			Client: "ip:127.0.0.1", Tier: models.RateLimitTierDefault, Limit: 100, Remaining: 99,
+			Window: 60, ResetAt: rateLimitResetAt, Allowed: true,
+		},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name: "test_limit_exceeded",
+		returnedValue: &models.RateLimitStatus{
+			Client: "ip:127.0.0.1", Tier: models.RateLimitTierDefault, Limit: 100, Remaining: 0,
+			Window: 60, ResetAt: rateLimitResetAt, RetryAfter: 30,
+		},
+		expectedStatusCode: http.StatusTooManyRequests,
+		expectedMessage:    "Rate limit exceeded",
+	},
+	{
+		name: "test_banned",
+		returnedValue: &models.RateLimitStatus{
+			Client: "ip:127.0.0.1", Tier: models.RateLimitTierDefault, Limit: 100, Remaining: 0,
+			Window: 60, ResetAt: time.Now().Add(5 * time.Minute), Banned: true, RetryAfter: 300,
+		},
+		expectedStatusCode: http.StatusTooManyRequests,
+		expectedMessage:    "Too many requests, client is temporarily banned",
+	},
+}
+
+var rateLimitStatusTestCases = []struct {
+	name               string
+	returnedValue      *models.RateLimitStatus
+	returnedError      error
+	expectedStatusCode int
+}{
+	{
+		name: "test_ok",
+		returnedValue: &models.RateLimitStatus{
+			Client: "ip:127.0.0.1", Tier: models.RateLimitTierDefault, Limit: 100, Remaining: 42,
+			Window: 60, ResetAt: rateLimitResetAt, Allowed: true,
+		},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "test_server_error",
+		returnedError:      errorInternalServerError,
+		expectedStatusCode: http.StatusInternalServerError,
+	},
+}
+
+// // Метрики outbox
+var getOutboxMetricsTestCases = []struct {
+	name               string
+	returnedValue      *models.OutboxMetrics
+	returnedError      error
+	expectedStatusCode int
+}{
+	{
+		name: "test_ok",
+		returnedValue: &models.OutboxMetrics{
+			Pending: 12, Retrying: 3, OldestPendingAgeSeconds: 42.5,
+			Published: 1000, PublishErrors: 7, LastPublishAgeSeconds: 0.8, AvgPublishAgeSeconds: 1.2,
+		},
+		expectedStatusCode: http.StatusOK,
+	},
+	{
+		name:               "test_server_error",
+		returnedError:      errorInternalServerError,
+		expectedStatusCode: http.StatusInternalServerError,
+	},
+}
+
+// Тесткейсы для UpdateCourierLocation
+var updateCourierLocationTestCases = []struct {
+	name               string
+	payload            interface{}
+	returnedValue      *models.CourierLocation
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", models.UpdateLocationRequest{Lat: 55.7558, Lon: 37.6173}, courierLocation, nil, http.StatusOK},
+	{"test_invalid_lat", models.UpdateLocationRequest{Lat: 91, Lon: 37.6173}, nil, nil, http.StatusBadRequest},
+	{"test_invalid_lon", models.UpdateLocationRequest{Lat: 55.7558, Lon: -181}, nil, nil, http.StatusBadRequest},
+	{"test_invalid_body", "not a location", nil, nil, http.StatusBadRequest},
+	{"test_not_found", models.UpdateLocationRequest{Lat: 55.7558, Lon: 37.6173}, nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", models.UpdateLocationRequest{Lat: 55.7558, Lon: 37.6173}, nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+// Тесткейсы для GetCourierTrack
+var getCourierTrackTestCases = []struct {
+	name               string
+	query              map[string]string
+	returnedValue      *models.GeoJSONFeature
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", map[string]string{"from": "2025-01-01T10:00:00Z", "to": "2025-01-01T12:00:00Z"}, courierTrack, nil, http.StatusOK},
+	{"test_ok_default_period", map[string]string{}, courierTrack, nil, http.StatusOK},
+	{"test_invalid_from", map[string]string{"from": "yesterday"}, nil, nil, http.StatusBadRequest},
+	{"test_from_after_to", map[string]string{"from": "2025-01-02T00:00:00Z", "to": "2025-01-01T00:00:00Z"}, nil, nil, http.StatusBadRequest},
+	{"test_not_found", map[string]string{}, nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", map[string]string{}, nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+// Тесткейсы для StreamOrderCourierLocation
+var streamOrderCourierLocationTestCases = []struct {
+	name               string
+	actorRole          string
+	actorID            string
+	returnedOrder      *models.Order
+	completedOrder     *models.Order
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", "customer", customerID.String(), orderWithCourier, nil, nil, http.StatusOK},
+	{"test_completed_during_stream", "dispatcher", "", orderWithCourier, deliveredOrderWithCourier, nil, http.StatusOK},
+	{"test_another_customer", "customer", uuid.New().String(), orderWithCourier, nil, nil, http.StatusForbidden},
+	{"test_customer_phone_as_id", "customer", orderWithCourier.CustomerPhone, orderWithCourier, nil, nil, http.StatusForbidden},
+	{"test_order_without_customer", "customer", customerID.String(), order1WithCourier, nil, nil, http.StatusForbidden},
+	{"test_already_delivered", "dispatcher", "", deliveredOrderWithCourier, nil, nil, http.StatusConflict},
+	{"test_no_courier", "dispatcher", "", order1, nil, nil, http.StatusConflict},
+	{"test_not_found", "dispatcher", "", nil, nil, errorNotFound, http.StatusNotFound},
+	{"test_invalid_role", "hacker", "", nil, nil, nil, http.StatusBadRequest},
+	{"test_actor_required", "", "", nil, nil, nil, http.StatusBadRequest},
+	{"test_customer_id_required", "customer", "", nil, nil, nil, http.StatusBadRequest},
+}
+
+// Тесткейсы для GetNearbyCouriers
+var getNearbyCouriersTestCases = []struct {
+	name               string
+	query              map[string]string
+	expectedRadius     float64
+	expectedLimit      int
+	returnedValue      []*models.NearbyCourier
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", map[string]string{"lat": "55.7558", "lon": "37.6173", "radius_m": "1500", "limit": "5"}, 1500, 5, nearbyCouriers, nil, http.StatusOK},
+	{"test_ok_defaults", map[string]string{"lat": "55.7558", "lon": "37.6173"}, 0, 20, nearbyCouriers, nil, http.StatusOK},
+	{"test_missing_lat", map[string]string{"lon": "37.6173"}, 0, 0, nil, nil, http.StatusBadRequest},
+	{"test_invalid_lon", map[string]string{"lat": "55.7558", "lon": "200"}, 0, 0, nil, nil, http.StatusBadRequest},
+	{"test_invalid_radius", map[string]string{"lat": "55.7558", "lon": "37.6173", "radius_m": "-5"}, 0, 0, nil, nil, http.StatusBadRequest},
+	{"test_server_error", map[string]string{"lat": "55.7558", "lon": "37.6173"}, 0, 20, nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+// Тесткейсы для /api/tariffs
+var createTariffTestCases = []struct {
+	name               string
+	payload            *models.TariffRequest
+	returnedValue      *models.Tariff
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_created", &tariffRequest, tariff1, nil, http.StatusCreated},
+	{"test_bad_request", nil, nil, nil, http.StatusBadRequest},
+	{"test_conflict", &tariffRequest, nil, errorTariffVersionExists, http.StatusConflict},
+	{"test_server_error", &tariffRequest, nil, errorInternalServerError, http.StatusInternalServerError},
+	{
+		"validate_code",
+		&models.TariffRequest{Code: " ", DistanceBands: []models.DistanceBand{{PerKm: 10}}},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_distance_bands_required",
+		&models.TariffRequest{Code: "standard"},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_open_band_not_last",
+		&models.TariffRequest{
+			Code:          "standard",
+			DistanceBands: []models.DistanceBand{{PerKm: 10}, {UpToKm: &tariffBandUpToKm, PerKm: 20}},
+		},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_min_max_price",
+		&models.TariffRequest{
+			Code:          "standard",
+			DistanceBands: []models.DistanceBand{{PerKm: 10}},
+			MinPrice:      &tariffMinPrice,
+			MaxPrice:      &tariffLowMaxPrice,
+		},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_surcharge",
+		&models.TariffRequest{
+			Code:                "standard",
+			DistanceBands:       []models.DistanceBand{{PerKm: 10}},
+			ItemCountSurcharges: []models.Surcharge{{Over: 5, Amount: -10}},
+		},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_night_hours",
+		&models.TariffRequest{
+			Code:           "standard",
+			DistanceBands:  []models.DistanceBand{{PerKm: 10}},
+			NightStartHour: &tariffInvalidHour,
+		},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+}
+
+var getTariffTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	returnedValue      *models.Tariff
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", tariffID, tariff1, nil, http.StatusOK},
+	{"test_not_found", uuid.New(), nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", uuid.New(), nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var getTariffsTestCases = []struct {
+	name               string
+	query              map[string]string
+	code               string
+	activeOnly         bool
+	returnedValue      []*models.Tariff
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", map[string]string{}, "", false, []*models.Tariff{tariff1}, nil, http.StatusOK},
+	{"test_ok_filters", map[string]string{"code": "standard", "active": "true"}, "standard", true, []*models.Tariff{tariff1}, nil, http.StatusOK},
+	{"test_invalid_active", map[string]string{"active": "maybe"}, "", false, nil, nil, http.StatusBadRequest},
+	{"test_server_error", map[string]string{}, "", false, nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var retireTariffTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	returnedValue      *models.Tariff
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", tariffID, tariff1, nil, http.StatusOK},
+	{"test_not_found", uuid.New(), nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", uuid.New(), nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+// Тесткейсы для /api/delivery/quote
+var quoteDeliveryTestCases = []struct {
+	name               string
+	payload            *models.DeliveryQuoteRequest
+	returnedValue      *models.DeliveryBreakdown
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", &deliveryQuoteRequest, deliveryBreakdown, nil, http.StatusOK},
+	{"test_bad_request", nil, nil, nil, http.StatusBadRequest},
+	{
+		"validate_addresses",
+		&models.DeliveryQuoteRequest{PickupAddress: order1.PickupAddress},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"test_address_not_found",
+		&deliveryQuoteRequest,
+		nil,
+		fmt.Errorf("%w: unknown street", services.ErrAddressNotFound),
+		http.StatusUnprocessableEntity,
+	},
+	{"test_no_active_tariff", &deliveryQuoteRequest, nil, services.ErrNoActiveTariff, http.StatusUnprocessableEntity},
+	{
+		"test_outside_delivery_area",
+		&deliveryQuoteRequest,
+		nil,
+		fmt.Errorf("pickup point: %w", services.ErrOutsideDeliveryArea),
+		http.StatusUnprocessableEntity,
+	},
+	{"test_geo_unavailable", &deliveryQuoteRequest, nil, services.ErrGeoUnavailable, http.StatusServiceUnavailable},
+	{"test_server_error", &deliveryQuoteRequest, nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+// Тесткейсы для /api/delivery/surge
+var getSurgeMultipliersTestCases = []struct {
+	name               string
+	returnedValue      []*models.SurgeMultiplier
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", surgeMultipliers, nil, http.StatusOK},
+	{"test_server_error", nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+// Тесткейсы для /api/zones
+var createZoneTestCases = []struct {
+	name               string
+	payload            *models.DeliveryZoneRequest
+	returnedValue      *models.DeliveryZone
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_created", &zoneRequest, zone1, nil, http.StatusCreated},
+	{"test_bad_request", nil, nil, nil, http.StatusBadRequest},
+	{"test_conflict", &zoneRequest, nil, errorZoneExists, http.StatusConflict},
+	{"test_server_error", &zoneRequest, nil, errorInternalServerError, http.StatusInternalServerError},
+	{
+		"validate_code",
+		&models.DeliveryZoneRequest{Code: " ", Name: zone1.Name, Polygon: zonePolygon},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_polygon_type",
+		&models.DeliveryZoneRequest{
+			Code:    zone1.Code,
+			Name:    zone1.Name,
+			Polygon: &models.GeoJSONPolygon{Type: models.GeoJSONTypePoint, Coordinates: zonePolygon.Coordinates},
+		},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_polygon_closed",
+		&models.DeliveryZoneRequest{
+			Code: zone1.Code,
+			Name: zone1.Name,
+			Polygon: &models.GeoJSONPolygon{
+				Type:        models.GeoJSONTypePolygon,
+				Coordinates: [][][2]float64{{{37.5, 55.7}, {37.7, 55.7}, {37.7, 55.8}, {37.5, 55.8}}},
+			},
+		},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_coordinates",
+		&models.DeliveryZoneRequest{
+			Code: zone1.Code,
+			Name: zone1.Name,
+			Polygon: &models.GeoJSONPolygon{
+				Type:        models.GeoJSONTypePolygon,
+				Coordinates: [][][2]float64{{{55.7, 37.5}, {55.7, 200}, {55.8, 37.7}, {55.7, 37.5}}},
+			},
+		},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_max_route_distance",
+		&models.DeliveryZoneRequest{Code: zone1.Code, Name: zone1.Name, Polygon: zonePolygon, MaxRouteDistance: new(int)},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"validate_sla_minutes",
+		&models.DeliveryZoneRequest{Code: zone1.Code, Name: zone1.Name, Polygon: zonePolygon, SLAMinutes: new(int)},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+}
+
+var getZoneTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	returnedValue      *models.DeliveryZone
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", zoneID, zone1, nil, http.StatusOK},
+	{"test_not_found", uuid.New(), nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", uuid.New(), nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var getZonesTestCases = []struct {
+	name               string
+	activeOnly         bool
+	activeParam        string
+	returnedValue      []*models.DeliveryZone
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_all", false, "", []*models.DeliveryZone{zone1}, nil, http.StatusOK},
+	{"test_active_only", true, "true", []*models.DeliveryZone{zone1}, nil, http.StatusOK},
+	{"test_invalid_active", false, "maybe", nil, nil, http.StatusBadRequest},
+	{"test_server_error", false, "", nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var updateZoneTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	payload            *models.DeliveryZoneRequest
+	returnedValue      *models.DeliveryZone
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", zoneID, &zoneRequest, zone1, nil, http.StatusOK},
+	{"test_bad_request", zoneID, &models.DeliveryZoneRequest{}, nil, nil, http.StatusBadRequest},
+	{"test_not_found", uuid.New(), &zoneRequest, nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", zoneID, &zoneRequest, nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var deleteZoneTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_no_content", zoneID, nil, http.StatusNoContent},
+	{"test_not_found", uuid.New(), errorNotFound, http.StatusNotFound},
+	{"test_server_error", uuid.New(), errorInternalServerError, http.StatusInternalServerError},
+}
+
+// Тесткейсы для /api/couriers/{id}/shifts и /api/shifts
+var scheduleShiftTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	payload            *models.ScheduleShiftRequest
+	returnedValue      *models.CourierShift
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_created", courierID, &scheduleShiftRequest, scheduledShift, nil, http.StatusCreated},
+	{"test_missing_bounds", courierID, &models.ScheduleShiftRequest{ScheduledStart: shiftStart}, nil, nil, http.StatusBadRequest},
+	{
+		"test_end_before_start",
+		courierID,
+		&models.ScheduleShiftRequest{ScheduledStart: shiftEnd, ScheduledEnd: shiftStart},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"test_too_long",
+		courierID,
+		&models.ScheduleShiftRequest{ScheduledStart: shiftStart, ScheduledEnd: shiftStart.Add(25 * time.Hour)},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{
+		"test_in_the_past",
+		courierID,
+		&models.ScheduleShiftRequest{ScheduledStart: shiftStart.Add(-48 * time.Hour), ScheduledEnd: shiftEnd.Add(-48 * time.Hour)},
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{"test_overlap", courierID, &scheduleShiftRequest, nil, errorShiftOverlaps, http.StatusConflict},
+	{"test_courier_not_found", uuid.New(), &scheduleShiftRequest, nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", courierID, &scheduleShiftRequest, nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var courierShiftActionTestCases = []struct {
+	name               string
+	path               string
+	serviceMethod      string
+	id                 uuid.UUID
+	returnedValue      *models.CourierShift
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_start_ok", "shifts/start", "StartShift", courierID, activeShift, nil, http.StatusOK},
+	{"test_start_conflict", "shifts/start", "StartShift", courierID, nil, &services.ShiftError{CourierID: courierID, Reason: "shift is already started"}, http.StatusConflict},
+	{"test_start_not_found", "shifts/start", "StartShift", uuid.New(), nil, errorNotFound, http.StatusNotFound},
+	{"test_end_ok", "shifts/end", "EndShift", courierID, activeShift, nil, http.StatusOK},
+	{"test_end_has_orders", "shifts/end", "EndShift", courierID, nil, errorCourierHasOrders, http.StatusConflict},
+	{"test_end_server_error", "shifts/end", "EndShift", courierID, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"test_break_start_ok", "shifts/break/start", "StartBreak", courierID, activeShift, nil, http.StatusOK},
+	{"test_break_start_has_orders", "shifts/break/start", "StartBreak", courierID, nil, errorCourierHasOrders, http.StatusConflict},
+	{"test_break_end_ok", "shifts/break/end", "EndBreak", courierID, activeShift, nil, http.StatusOK},
+	{"test_break_end_no_break", "shifts/break/end", "EndBreak", courierID, nil, &services.ShiftError{CourierID: courierID, Reason: "no break in progress"}, http.StatusConflict},
+}
+
+var getCourierShiftsTestCases = []struct {
+	name               string
+	query              map[string]string
+	limit              int
+	offset             int
+	returnedValue      []*models.CourierShift
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", map[string]string{}, 50, 0, []*models.CourierShift{activeShift, scheduledShift}, nil, http.StatusOK},
+	{
+		"test_period_and_pagination",
+		map[string]string{"from": "2025-01-01T00:00:00Z", "to": "2025-02-01T00:00:00Z", "limit": "10", "offset": "20"},
+		10,
+		20,
+		[]*models.CourierShift{activeShift},
+		nil,
+		http.StatusOK,
+	},
+	{"test_invalid_from", map[string]string{"from": "yesterday"}, 0, 0, nil, nil, http.StatusBadRequest},
+	{
+		"test_from_after_to",
+		map[string]string{"from": "2025-02-01T00:00:00Z", "to": "2025-01-01T00:00:00Z"},
+		0,
+		0,
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+	{"test_server_error", map[string]string{}, 50, 0, nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var getShiftTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	returnedValue      *models.CourierShift
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", activeShift.ID, activeShift, nil, http.StatusOK},
+	{"test_not_found", uuid.New(), nil, errorShiftNotFound, http.StatusNotFound},
+	{"test_server_error", uuid.New(), nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var cancelShiftTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_no_content", shiftID, nil, http.StatusNoContent},
+	{"test_not_scheduled", activeShift.ID, &services.ShiftError{CourierID: courierID, Reason: "shift is active, only scheduled shifts can be cancelled"}, http.StatusConflict},
+	{"test_not_found", uuid.New(), errorShiftNotFound, http.StatusNotFound},
+	{"test_server_error", uuid.New(), errorInternalServerError, http.StatusInternalServerError},
+}
+
+// Тесткейсы для пакетной доставки заказов
+var getBatchesTestCases = []struct {
+	name               string
+	returnedValue      []*models.OrderBatch
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", orderBatches, nil, http.StatusOK},
+	{"test_no_batches", []*models.OrderBatch{}, nil, http.StatusOK},
+	{"test_server_error", nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var assignBatchTestCases = []struct {
+	name               string
+	courierID          uuid.UUID
+	payload            *models.AssignBatchRequest
+	returnedValue      *models.CourierRoute
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", courierID, &assignBatchRequest, courierRoute, nil, http.StatusOK},
+	{"test_empty_orders", courierID, &models.AssignBatchRequest{}, nil, nil, http.StatusBadRequest},
+	{"test_nil_order_id", courierID, &models.AssignBatchRequest{OrderIDs: []uuid.UUID{uuid.Nil}}, nil, nil, http.StatusBadRequest},
+	{"test_duplicate_orders", courierID, &models.AssignBatchRequest{OrderIDs: []uuid.UUID{orderID, orderID}}, nil, nil, http.StatusBadRequest},
+	{"test_over_capacity", courierID, &assignBatchRequest, nil, errorCourierOverCapacity, http.StatusBadRequest},
+	{"test_not_found", uuid.New(), &assignBatchRequest, nil, errorNotFound, http.StatusNotFound},
+	{"test_already_assigned", courierID, &assignBatchRequest, nil, errorOrderAlreadyAssigned, http.StatusConflict},
+	{"test_server_error", courierID, &assignBatchRequest, nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var getCourierRouteTestCases = []struct {
+	name               string
+	courierID          uuid.UUID
+	returnedValue      *models.CourierRoute
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", courierID, courierRoute, nil, http.StatusOK},
+	{"test_not_found", uuid.New(), nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", courierID, nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+// Тесткейсы для подтверждения доставки заказов
+var confirmDeliveryTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	form               deliveryProofFormType
+	request            *models.DeliveryProofRequest
+	returnedValue      *models.DeliveryProof
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", orderID, deliveryProofForm, &deliveryProofRequest, deliveryProof, nil, http.StatusOK},
+	{
+		"test_ok_without_photo",
+		orderID,
+		deliveryProofFormType{Pin: "1234", Lat: "55.7558", Lon: "37.6173"},
+		&models.DeliveryProofRequest{Pin: "1234", Lat: 55.7558, Lon: 37.6173},
+		deliveryProof,
+		nil,
+		http.StatusOK,
+	},
+	{"test_invalid_pin", orderID, deliveryProofForm, &deliveryProofRequest, nil, errorInvalidPin, http.StatusUnprocessableEntity},
+	{"test_not_allowed", orderID, deliveryProofForm, &deliveryProofRequest, nil, errorDeliverNotAllowed, http.StatusConflict},
+	{"test_not_found", uuid.New(), deliveryProofForm, &deliveryProofRequest, nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", orderID, deliveryProofForm, &deliveryProofRequest, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"validate_pin_format", orderID, deliveryProofFormType{Pin: "12a4", Lat: "55.7558", Lon: "37.6173"}, nil, nil, nil, http.StatusBadRequest},
+	{"validate_pin_length", orderID, deliveryProofFormType{Pin: "123", Lat: "55.7558", Lon: "37.6173"}, nil, nil, nil, http.StatusBadRequest},
+	{"validate_lat", orderID, deliveryProofFormType{Pin: "1234", Lat: "95", Lon: "37.6173"}, nil, nil, nil, http.StatusBadRequest},
+	{"validate_lon", orderID, deliveryProofFormType{Pin: "1234", Lat: "55.7558"}, nil, nil, nil, http.StatusBadRequest},
+	{
+		"validate_photo_type",
+		orderID,
+		deliveryProofFormType{Pin: "1234", Lat: "55.7558", Lon: "37.6173", Photo: []byte("not an image")},
+		nil,
+		nil,
+		nil,
+		http.StatusBadRequest,
+	},
+}
+
+var getDeliveryProofTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	returnedValue      *models.DeliveryProof
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", orderID, deliveryProof, nil, http.StatusOK},
+	{"test_not_found", uuid.New(), nil, errors.New("delivery proof not found"), http.StatusNotFound},
+	{"test_server_error", orderID, nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var getDeliveryProofFileTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	kind               string
+	returnedValue      []byte
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", orderID, "photo", proofImage, nil, http.StatusOK},
+	{"test_no_signature", orderID, "signature", nil, errors.New("delivery proof signature not found"), http.StatusNotFound},
+	{"test_unknown_kind", orderID, "video", nil, nil, http.StatusNotFound},
+	{"test_server_error", orderID, "photo", nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+// Тесткейсы для /api/orders/at-risk
+var getAtRiskOrdersTestCases = []struct {
+	name               string
+	query              string
+	limit              int
+	returnedValue      []*models.AtRiskOrder
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", "", 50, atRiskOrders, nil, http.StatusOK},
+	{"test_limit", "limit=1", 1, atRiskOrders[:1], nil, http.StatusOK},
+	{"test_no_orders", "", 50, []*models.AtRiskOrder{}, nil, http.StatusOK},
+	{"test_server_error", "", 50, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"validate_limit", "limit=0", 0, nil, nil, http.StatusBadRequest},
+	{"validate_limit_max", "limit=501", 0, nil, nil, http.StatusBadRequest},
+}
+
+// Тесткейсы для /api/customers
+var createCustomerTestCases = []struct {
+	name               string
+	payload            *models.CustomerRequest
+	returnedValue      *models.Customer
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_created", &customerRequest, customer1, nil, http.StatusCreated},
+	{"test_conflict", &customerRequest, nil, errorCustomerExists, http.StatusConflict},
+	{"test_server_error", &customerRequest, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"validate_name", &models.CustomerRequest{Phone: customerRequest.Phone}, nil, nil, http.StatusBadRequest},
+	{"validate_phone", &models.CustomerRequest{Name: customerRequest.Name, Phone: "12-34"}, nil, nil, http.StatusBadRequest},
+}
+
+var getCustomerTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	returnedValue      *models.Customer
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", customerID, customer1, nil, http.StatusOK},
+	{"test_not_found", uuid.New(), nil, errorCustomerNotFound, http.StatusNotFound},
+	{"test_server_error", uuid.New(), nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var getCustomersTestCases = []struct {
+	name               string
+	phone              string
+	returnedValue      []*models.Customer
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", "", []*models.Customer{customer1}, nil, http.StatusOK},
+	{"test_by_phone", customerRequest.Phone, []*models.Customer{customer1}, nil, http.StatusOK},
+	{"test_server_error", "", nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var updateCustomerTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	payload            *models.CustomerRequest
+	returnedValue      *models.Customer
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", customerID, &customerRequest, customer1, nil, http.StatusOK},
+	{"test_not_found", uuid.New(), &customerRequest, nil, errorCustomerNotFound, http.StatusNotFound},
+	{"test_conflict", customerID, &customerRequest, nil, errorCustomerExists, http.StatusConflict},
+	{"validate_phone", customerID, &models.CustomerRequest{Name: customerRequest.Name}, nil, nil, http.StatusBadRequest},
+}
+
+var addCustomerAddressTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	payload            *models.CustomerAddressRequest
+	returnedValue      *models.CustomerAddress
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_created", customerID, &customerAddressRequest, &customerAddress1, nil, http.StatusCreated},
+	{"test_customer_not_found", uuid.New(), &customerAddressRequest, nil, errorCustomerNotFound, http.StatusNotFound},
+	{"test_address_not_found", customerID, &customerAddressRequest, nil,
+		fmt.Errorf("%w: unknown street", services.ErrAddressNotFound), http.StatusUnprocessableEntity},
+	{"test_geo_unavailable", customerID, &customerAddressRequest, nil, services.ErrGeoUnavailable, http.StatusServiceUnavailable},
+	{"validate_address", customerID, &models.CustomerAddressRequest{Label: "home"}, nil, nil, http.StatusBadRequest},
+}
+
+var deleteCustomerAddressTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	addressID          uuid.UUID
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_deleted", customerID, customerAddressID, nil, http.StatusNoContent},
+	{"test_not_found", customerID, uuid.New(), errors.New("customer address not found"), http.StatusNotFound},
+	{"test_server_error", customerID, customerAddressID, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var getCustomerOrdersTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	returnedValue      []*models.Order
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", customerID, []*models.Order{order1, order2}, nil, http.StatusOK},
+	{"test_not_found", uuid.New(), nil, errorCustomerNotFound, http.StatusNotFound},
+	{"test_server_error", customerID, nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+// Тесткейсы для /api/merchants
+var createMerchantTestCases = []struct {
+	name               string
+	payload            *models.MerchantRequest
+	returnedValue      *models.Merchant
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_created", &merchantRequest, merchant1, nil, http.StatusCreated},
+	{"test_address_not_found", &merchantRequest, nil,
+		fmt.Errorf("%w: unknown street", services.ErrAddressNotFound), http.StatusUnprocessableEntity},
+	{"test_geo_unavailable", &merchantRequest, nil, services.ErrGeoUnavailable, http.StatusServiceUnavailable},
+	{"test_server_error", &merchantRequest, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"validate_name", &models.MerchantRequest{PickupAddress: merchantRequest.PickupAddress}, nil, nil, http.StatusBadRequest},
+	{"validate_pickup_address", &models.MerchantRequest{Name: merchantRequest.Name}, nil, nil, http.StatusBadRequest},
+	{"validate_phone", &models.MerchantRequest{Name: merchantRequest.Name, Phone: "12-34",
+		PickupAddress: merchantRequest.PickupAddress}, nil, nil, http.StatusBadRequest},
+	{"validate_weekday", &models.MerchantRequest{Name: merchantRequest.Name, PickupAddress: merchantRequest.PickupAddress,
+		OpeningHours: []models.OpeningHours{{Weekday: 7, Open: "10:00", Close: "22:00"}}}, nil, nil, http.StatusBadRequest},
+	{"validate_clock", &models.MerchantRequest{Name: merchantRequest.Name, PickupAddress: merchantRequest.PickupAddress,
+		OpeningHours: []models.OpeningHours{{Weekday: time.Monday, Open: "10:00", Close: "25:00"}}}, nil, nil, http.StatusBadRequest},
+	{"validate_empty_interval", &models.MerchantRequest{Name: merchantRequest.Name, PickupAddress: merchantRequest.PickupAddress,
+		OpeningHours: []models.OpeningHours{{Weekday: time.Monday, Open: "10:00", Close: "10:00"}}}, nil, nil, http.StatusBadRequest},
+}
+
+var getMerchantTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	returnedValue      *models.Merchant
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", merchantID, merchant1, nil, http.StatusOK},
+	{"test_not_found", uuid.New(), nil, errorMerchantNotFound, http.StatusNotFound},
+	{"test_server_error", uuid.New(), nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var getMerchantsTestCases = []struct {
+	name               string
+	active             string
+	activeOnly         bool
+	returnedValue      []*models.Merchant
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", "", false, []*models.Merchant{merchant1}, nil, http.StatusOK},
+	{"test_active", "true", true, []*models.Merchant{merchant1}, nil, http.StatusOK},
+	{"test_server_error", "", false, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"validate_active", "yes", false, nil, nil, http.StatusBadRequest},
+}
+
+var updateMerchantTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	payload            *models.MerchantRequest
+	returnedValue      *models.Merchant
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", merchantID, &merchantRequest, merchant1, nil, http.StatusOK},
+	{"test_not_found", uuid.New(), &merchantRequest, nil, errorMerchantNotFound, http.StatusNotFound},
+	{"test_address_not_found", merchantID, &merchantRequest, nil,
+		fmt.Errorf("%w: unknown street", services.ErrAddressNotFound), http.StatusUnprocessableEntity},
+	{"validate_name", merchantID, &models.MerchantRequest{PickupAddress: merchantRequest.PickupAddress}, nil, nil, http.StatusBadRequest},
+}
+
+var deleteMerchantTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_deleted", merchantID, nil, http.StatusNoContent},
+	{"test_not_found", uuid.New(), errorMerchantNotFound, http.StatusNotFound},
+	{"test_server_error", merchantID, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var createProductTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	payload            *models.ProductRequest
+	returnedValue      *models.Product
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_created", merchantID, &productRequest, product1, nil, http.StatusCreated},
+	{"test_merchant_not_found", uuid.New(), &productRequest, nil, errorMerchantNotFound, http.StatusNotFound},
+	{"test_server_error", merchantID, &productRequest, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"validate_name", merchantID, &models.ProductRequest{Price: productRequest.Price}, nil, nil, http.StatusBadRequest},
+	{"validate_price", merchantID, &models.ProductRequest{Name: productRequest.Name, Price: -1}, nil, nil, http.StatusBadRequest},
+	{"validate_weight", merchantID, &models.ProductRequest{Name: productRequest.Name, WeightKg: &invalidWeight},
+		nil, nil, http.StatusBadRequest},
+}
+
+var getProductsTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	returnedValue      []*models.Product
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", merchantID, []*models.Product{product1}, nil, http.StatusOK},
+	{"test_merchant_not_found", uuid.New(), nil, errorMerchantNotFound, http.StatusNotFound},
+	{"test_server_error", merchantID, nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var updateProductTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	productID          uuid.UUID
+	payload            *models.ProductRequest
+	returnedValue      *models.Product
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", merchantID, productID, &productRequest, product1, nil, http.StatusOK},
+	{"test_not_found", merchantID, uuid.New(), &productRequest, nil, errorProductNotFound, http.StatusNotFound},
+	{"validate_price", merchantID, productID, &models.ProductRequest{Name: productRequest.Name, Price: -1}, nil, nil, http.StatusBadRequest},
+}
+
+var deleteProductTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	productID          uuid.UUID
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_deleted", merchantID, productID, nil, http.StatusNoContent},
+	{"test_not_found", merchantID, uuid.New(), errorProductNotFound, http.StatusNotFound},
+	{"test_server_error", merchantID, productID, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var acceptOrderTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	payload            *models.AcceptOrderRequest
+	returnedValue      *models.OrderPreparationEvent
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", orderID, &models.AcceptOrderRequest{PrepMinutes: prepMinutes}, orderAccepted, nil, http.StatusOK},
+	{"test_prep_time_too_long", orderID, &models.AcceptOrderRequest{PrepMinutes: 240}, nil, errorPrepTimeTooLong, http.StatusUnprocessableEntity},
+	{"test_foreign_merchant", orderID, &models.AcceptOrderRequest{PrepMinutes: prepMinutes}, nil, errorForeignMerchant, http.StatusConflict},
+	{"test_not_found", uuid.New(), &models.AcceptOrderRequest{PrepMinutes: prepMinutes}, nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", orderID, &models.AcceptOrderRequest{PrepMinutes: prepMinutes}, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"test_prep_minutes_required", orderID, &models.AcceptOrderRequest{}, nil, nil, http.StatusBadRequest},
+	{"test_negative_prep_minutes", orderID, &models.AcceptOrderRequest{PrepMinutes: -5}, nil, nil, http.StatusBadRequest},
+}
+
+var rejectOrderTestCases = []struct {
+	name               string
+	id                 uuid.UUID
+	payload            *models.CancelOrderRequest
+	returnedValue      *models.OrderCancellation
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", orderID, &models.CancelOrderRequest{Reason: models.CancellationReasonOutOfStock}, orderRejection, nil, http.StatusOK},
+	{"test_already_accepted", orderID, &models.CancelOrderRequest{Reason: models.CancellationReasonOutOfStock}, nil, errorAlreadyAccepted, http.StatusConflict},
+	{"test_not_found", uuid.New(), &models.CancelOrderRequest{Reason: models.CancellationReasonMerchantClosed}, nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", orderID, &models.CancelOrderRequest{Reason: models.CancellationReasonOutOfStock}, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"test_reason_required", orderID, &models.CancelOrderRequest{}, nil, nil, http.StatusBadRequest},
+	{"test_unknown_reason", orderID, &models.CancelOrderRequest{Reason: "too_busy"}, nil, nil, http.StatusBadRequest},
+}
+
+var preparationStepTestCases = []struct {
+	name               string
+	path               string
+	method             string
+	id                 uuid.UUID
+	returnedValue      *models.OrderPreparationEvent
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_preparing_ok", "preparing", "StartPreparing", orderID, orderPreparing, nil, http.StatusOK},
+	{"test_preparing_not_found", "preparing", "StartPreparing", uuid.New(), nil, errorNotFound, http.StatusNotFound},
+	{"test_ready_ok", "ready", "MarkReady", orderID, orderReady, nil, http.StatusOK},
+	{"test_ready_not_preparing", "ready", "MarkReady", orderID, nil, errorNotPreparing, http.StatusConflict},
+	{"test_ready_server_error", "ready", "MarkReady", orderID, nil, errorInternalServerError, http.StatusInternalServerError},
+}
+
+var authMiddlewareTestCases = []struct {
+	name               string
+	method             string
+	path               string
+	authorization      string
+	actor              models.Actor
+	authError          error
+	order              *models.Order
+	orderError         error
+	expectedStatusCode int
+}{
+	{"test_no_token", http.MethodGet, "/api/orders", "", models.Actor{}, nil, nil, nil, http.StatusUnauthorized},
+	{"test_not_bearer", http.MethodGet, "/api/orders", "Basic dXNlcjpwYXNz", models.Actor{}, nil, nil, nil, http.StatusUnauthorized},
+	{"test_invalid_token", http.MethodGet, "/api/orders", "Bearer " + accessToken, models.Actor{}, errorInvalidToken, nil, nil, http.StatusUnauthorized},
+	{"test_auth_error", http.MethodGet, "/api/orders", "Bearer " + accessToken, models.Actor{}, errorInternalServerError, nil, nil, http.StatusInternalServerError},
+	{"test_admin_creates_courier", http.MethodPost, "/api/couriers", "Bearer " + accessToken, adminActor, nil, nil, nil, http.StatusOK},
+	{"test_dispatcher_creates_courier", http.MethodPost, "/api/couriers", "Bearer " + accessToken, dispatcherActor, nil, nil, nil, http.StatusForbidden},
+	{"test_dispatcher_lists_orders", http.MethodGet, "/api/orders", "Bearer " + accessToken, dispatcherActor, nil, nil, nil, http.StatusOK},
+	{"test_dispatcher_without_rule", http.MethodGet, "/api/analytics/summary", "Bearer " + accessToken, dispatcherActor, nil, nil, nil, http.StatusForbidden},
+	{"test_courier_own_status", http.MethodPut, "/api/couriers/" + courierID.String() + "/status", "Bearer " + accessToken, courierActor, nil, nil, nil, http.StatusOK},
+	{"test_courier_foreign_status", http.MethodPut, "/api/couriers/" + uuid.New().String() + "/status", "Bearer " + accessToken, courierActor, nil, nil, nil, http.StatusForbidden},
+	{"test_courier_own_location", http.MethodPost, "/api/couriers/" + courierID.String() + "/location", "Bearer " + accessToken, courierActor, nil, nil, nil, http.StatusOK},
+	{"test_dispatcher_courier_location", http.MethodPost, "/api/couriers/" + courierID.String() + "/location", "Bearer " + accessToken, dispatcherActor, nil, nil, nil, http.StatusForbidden},
+	{"test_courier_zone", http.MethodPut, "/api/couriers/" + courierID.String() + "/zone", "Bearer " + accessToken, courierActor, nil, nil, nil, http.StatusForbidden},
+	{"test_customer_own_order", http.MethodGet, "/api/orders/" + orderID.String(), "Bearer " + accessToken, customerActor, nil, customerOrder, nil, http.StatusOK},
+	{"test_customer_foreign_order", http.MethodGet, "/api/orders/" + orderID.String(), "Bearer " + accessToken, customerActor, nil, foreignOrder, nil, http.StatusForbidden},
+	{"test_customer_order_not_found", http.MethodGet, "/api/orders/" + orderID.String(), "Bearer " + accessToken, customerActor, nil, nil, errorNotFound, http.StatusNotFound},
+	{"test_customer_order_error", http.MethodGet, "/api/orders/" + orderID.String(), "Bearer " + accessToken, customerActor, nil, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"test_customer_own_review", http.MethodPost, "/api/orders/" + orderID.String() + "/review", "Bearer " + accessToken, customerActor, nil, customerOrder, nil, http.StatusOK},
+	{"test_customer_foreign_review", http.MethodPost, "/api/orders/" + orderID.String() + "/review", "Bearer " + accessToken, customerActor, nil, foreignOrder, nil, http.StatusForbidden},
+	{"test_customer_lists_orders", http.MethodGet, "/api/orders", "Bearer " + accessToken, customerActor, nil, nil, nil, http.StatusForbidden},
+	{"test_customer_own_history", http.MethodGet, "/api/customers/" + customerID.String() + "/orders", "Bearer " + accessToken, customerActor, nil, nil, nil, http.StatusOK},
+	{"test_merchant_accepts_own_order", http.MethodPost, "/api/orders/" + orderID.String() + "/accept", "Bearer " + accessToken, merchantActor, nil, customerOrder, nil, http.StatusOK},
+	{"test_merchant_updates_foreign_catalog", http.MethodPost, "/api/merchants/" + uuid.New().String() + "/products", "Bearer " + accessToken, merchantActor, nil, nil, nil, http.StatusForbidden},
+	{"test_stream_query_token", http.MethodGet, "/api/orders/" + orderID.String() + "/courier/stream?access_token=" + accessToken, "", customerActor, nil, customerOrder, nil, http.StatusOK},
+}
+
+var issueTokenTestCases = []struct {
+	name               string
+	payload            *models.TokenRequest
+	returnedValue      *models.AuthToken
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", &models.TokenRequest{Role: models.RoleCourier, Subject: courierID.String()}, authToken, nil, http.StatusCreated},
+	{"test_admin_without_subject", &models.TokenRequest{Role: models.RoleAdmin, TTLMinutes: 30}, authToken, nil, http.StatusCreated},
+	{"test_server_error", &models.TokenRequest{Role: models.RoleDispatcher}, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"test_unknown_role", &models.TokenRequest{Role: "root"}, nil, nil, http.StatusBadRequest},
+	{"test_system_role", &models.TokenRequest{Role: models.RoleSystem}, nil, nil, http.StatusBadRequest},
+	{"test_subject_required", &models.TokenRequest{Role: models.RoleCustomer}, nil, nil, http.StatusBadRequest},
+	{"test_ttl_too_long", &models.TokenRequest{Role: models.RoleAdmin, TTLMinutes: 100000}, nil, nil, http.StatusBadRequest},
+}
+
+var apiKeyMiddlewareTestCases = []struct {
+	name               string
+	method             string
+	path               string
+	apiKey             *models.APIKey
+	authError          error
+	order              *models.Order
+	expectedStatusCode int
+}{
+	{"test_invalid_key", http.MethodGet, "/api/orders/" + orderID.String(), nil, errorInvalidAPIKey, nil, http.StatusUnauthorized},
+	{"test_auth_error", http.MethodGet, "/api/orders/" + orderID.String(), nil, errorInternalServerError, nil, http.StatusInternalServerError},
+	{"test_merchant_accepts_own_order", http.MethodPost, "/api/orders/" + orderID.String() + "/accept", merchantAPIKey, nil, customerOrder, http.StatusOK},
+	{"test_merchant_foreign_order", http.MethodGet, "/api/orders/" + orderID.String(), merchantAPIKey, nil, foreignOrder, http.StatusForbidden},
+	{"test_scope_not_granted", http.MethodPost, "/api/orders/" + orderID.String() + "/accept", readOnlyAPIKey, nil, nil, http.StatusForbidden},
+	{"test_other_resource", http.MethodGet, "/api/merchants", merchantAPIKey, nil, nil, http.StatusForbidden},
+	{"test_full_access_scope", http.MethodGet, "/api/merchants", fullAccessAPIKey, nil, nil, http.StatusOK},
+	{"test_full_access_role_policy", http.MethodGet, "/api/couriers", fullAccessAPIKey, nil, nil, http.StatusForbidden},
+	{"test_analytics_export", http.MethodGet, "/api/analytics/summary", analyticsAPIKey, nil, nil, http.StatusOK},
+	{"test_analytics_write", http.MethodPost, "/api/analytics/summary", analyticsAPIKey, nil, nil, http.StatusForbidden},
+}
+
+var issueAPIKeyTestCases = []struct {
+	name               string
+	payload            *models.APIKeyRequest
+	returnedValue      *models.IssuedAPIKey
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", &models.APIKeyRequest{Name: "POS", Scopes: []string{"orders:read", "orders:write"}, OwnerRole: models.RoleMerchant, OwnerID: merchantID.String(), ExpiresAt: &apiKeyExpiresAt}, issuedAPIKey, nil, http.StatusCreated},
+	{"test_dispatcher_without_owner_id", &models.APIKeyRequest{Name: "Analytics", Scopes: []string{"*"}, OwnerRole: models.RoleDispatcher}, issuedAPIKey, nil, http.StatusCreated},
+	{"test_vip_tier", &models.APIKeyRequest{Name: "Partner", Scopes: []string{"*"}, OwnerRole: models.RoleMerchant, OwnerID: merchantID.String(), RateLimitTier: models.RateLimitTierVIP}, issuedAPIKey, nil, http.StatusCreated},
+	{"test_server_error", &models.APIKeyRequest{Name: "POS", Scopes: []string{"orders:read"}, OwnerRole: models.RoleMerchant, OwnerID: merchantID.String()}, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"test_name_required", &models.APIKeyRequest{Name: "  ", Scopes: []string{"orders:read"}, OwnerRole: models.RoleMerchant, OwnerID: merchantID.String()}, nil, nil, http.StatusBadRequest},
+	{"test_scopes_required", &models.APIKeyRequest{Name: "POS", OwnerRole: models.RoleMerchant, OwnerID: merchantID.String()}, nil, nil, http.StatusBadRequest},
+	{"test_unknown_scope", &models.APIKeyRequest{Name: "POS", Scopes: []string{"orders:delete"}, OwnerRole: models.RoleMerchant, OwnerID: merchantID.String()}, nil, nil, http.StatusBadRequest},
+	{"test_unknown_resource", &models.APIKeyRequest{Name: "POS", Scopes: []string{"payments:read"}, OwnerRole: models.RoleMerchant, OwnerID: merchantID.String()}, nil, nil, http.StatusBadRequest},
+	{"test_system_owner", &models.APIKeyRequest{Name: "POS", Scopes: []string{"orders:read"}, OwnerRole: models.RoleSystem}, nil, nil, http.StatusBadRequest},
+	{"test_owner_id_required", &models.APIKeyRequest{Name: "POS", Scopes: []string{"orders:read"}, OwnerRole: models.RoleMerchant}, nil, nil, http.StatusBadRequest},
+	{"test_expired", &models.APIKeyRequest{Name: "POS", Scopes: []string{"orders:read"}, OwnerRole: models.RoleMerchant, OwnerID: merchantID.String(), ExpiresAt: &apiKeyExpiredAt}, nil, nil, http.StatusBadRequest},
+	{"test_unknown_tier", &models.APIKeyRequest{Name: "POS", Scopes: []string{"orders:read"}, OwnerRole: models.RoleMerchant, OwnerID: merchantID.String(), RateLimitTier: "gold"}, nil, nil, http.StatusBadRequest},
+}
+
+var getAPIKeysTestCases = []struct {
+	name               string
+	actor              models.Actor
+	query              string
+	owner              *models.Actor
+	returnedValue      []*models.APIKey
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_admin_all", adminActor, "", nil, []*models.APIKey{merchantAPIKey, analyticsAPIKey}, nil, http.StatusOK},
+	{"test_admin_by_owner", adminActor, "owner_role=merchant&owner_id=" + merchantID.String(), &merchantActor, []*models.APIKey{merchantAPIKey}, nil, http.StatusOK},
+	{"test_owner", merchantActor, "owner_role=admin", &merchantActor, []*models.APIKey{merchantAPIKey}, nil, http.StatusOK},
+	{"test_server_error", adminActor, "", nil, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"test_invalid_owner_role", adminActor, "owner_role=system", nil, nil, nil, http.StatusBadRequest},
+}
+
+var getAPIKeyUsageTestCases = []struct {
+	name               string
+	actor              models.Actor
+	query              string
+	apiKey             *models.APIKey
+	getError           error
+	days               int
+	returnedValue      *models.APIKeyUsage
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_owner", merchantActor, "days=2", merchantAPIKey, nil, 2, apiKeyUsage, nil, http.StatusOK},
+	{"test_admin_default_days", adminActor, "", merchantAPIKey, nil, 7, apiKeyUsage, nil, http.StatusOK},
+	{"test_foreign_owner", models.Actor{Role: models.RoleMerchant, ID: uuid.New().String()}, "", merchantAPIKey, nil, 0, nil, nil, http.StatusForbidden},
+	{"test_other_role", customerActor, "", merchantAPIKey, nil, 0, nil, nil, http.StatusForbidden},
+	{"test_not_found", adminActor, "", nil, errorNotFound, 0, nil, nil, http.StatusNotFound},
+	{"test_usage_error", adminActor, "", merchantAPIKey, nil, 7, nil, errorInternalServerError, http.StatusInternalServerError},
+	{"test_invalid_days", adminActor, "days=abc", nil, nil, 0, nil, nil, http.StatusBadRequest},
+	{"test_too_many_days", adminActor, "days=91", nil, nil, 0, nil, nil, http.StatusBadRequest},
+}
+
+var rotateAPIKeyTestCases = []struct {
+	name               string
+	id                 string
+	returnedValue      *models.IssuedAPIKey
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", apiKeyID.String(), issuedAPIKey, nil, http.StatusOK},
+	{"test_revoked", apiKeyID.String(), nil, errorAPIKeyInactive, http.StatusUnprocessableEntity},
+	{"test_not_found", apiKeyID.String(), nil, errorNotFound, http.StatusNotFound},
+	{"test_server_error", apiKeyID.String(), nil, errorInternalServerError, http.StatusInternalServerError},
+	{"test_invalid_id", "invalid-uuid", nil, nil, http.StatusBadRequest},
+}
+
+var revokeAPIKeyTestCases = []struct {
+	name               string
+	id                 string
+	returnedError      error
+	expectedStatusCode int
+}{
+	{"test_ok", apiKeyID.String(), nil, http.StatusNoContent},
+	{"test_not_found", apiKeyID.String(), errorNotFound, http.StatusNotFound},
+	{"test_server_error", apiKeyID.String(), errorInternalServerError, http.StatusInternalServerError},
+	{"test_invalid_id", "invalid-uuid", nil, http.StatusBadRequest},
+}
+
+// Офлайн-геосервисы
+var localAddressBook = map[string][2]float64{
+	"Москва, Тверская улица, 1": {37.6117, 55.7574},
+	"Москва, Арбат, 10":         {37.5963, 55.7510},
+}
+
+// oneDegreeMeters - длина дуги в один градус большого круга
+const oneDegreeMeters = 111194.93
+
+var localGeocoderTestCases = []struct {
+	name          string
+	address       string
+	expectedLng   float64
+	expectedLat   float64
+	expectedError error
+}{
+	{"test_ok", "Москва, Тверская улица, 1", 37.6117, 55.7574, nil},
+	{"test_case_and_spaces", "  москва,   АРБАТ,  10 ", 37.5963, 55.7510, nil},
+	{"test_not_found", "Москва, Ленинский проспект, 1", 0, 0, services.ErrAddressNotFound},
+	{"test_empty_address", "", 0, 0, services.ErrAddressNotFound},
+}
+
+var loadAddressBookTestCases = []struct {
+	name     string
+	content  string
+	noFile   bool
+	hasError bool
+}{
+	{"test_ok", `{"Москва, Тверская улица, 1": [37.6117, 55.7574]}`, false, false},
+	{"test_empty_book", `{}`, false, false},
+	{"test_invalid_json", `{"Москва, Тверская улица, 1": [37.6117`, false, true},
+	{"test_invalid_point", `{"Москва, Тверская улица, 1": "37.6117, 55.7574"}`, false, true},
+	{"test_missing_file", "", true, true},
+}
+
+var haversineRouterTestCases = []struct {
+	name             string
+	coordinates      [][2]float64
+	expectedDistance float64
+	expectedError    error
+}{
+	{"test_meridian_degree", [][2]float64{{37, 55}, {37, 56}}, oneDegreeMeters, nil},
+	{"test_equator_degree", [][2]float64{{0, 0}, {1, 0}}, oneDegreeMeters, nil},
+	{"test_polyline", [][2]float64{{0, 0}, {0, 1}, {1, 1}, {1, 0}}, oneDegreeMeters + 111177.99 + oneDegreeMeters, nil},
+	{"test_same_point", [][2]float64{{37.6117, 55.7574}, {37.6117, 55.7574}}, 0, nil},
+	{"test_single_point", [][2]float64{{37.6117, 55.7574}}, 0, services.ErrRouteNotFound},
+	{"test_no_points", nil, 0, services.ErrRouteNotFound},
+}
@@ -0,0 +1,146 @@
+package handler_tests
+
+import (
+	"delivery-system/internal/handlers"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/services/services_mocks"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+)
+
+// TestCreateZone выполняет тестирование создания зоны доставки
+func TestCreateZone(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range createZoneTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockZoneService := services_mocks.NewMockZoneServiceInterface(t)
+
+			h := handlers.NewZoneHandler(mockZoneService, discardLogger)
+			mux := setupTestZoneRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockZoneService.On("CreateZone", tc.payload).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			obj := e.POST("/api/zones").WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode).JSON().Object()
+			if tc.expectedStatusCode == http.StatusCreated {
+				obj.Value("id").String().IsEqual(tc.returnedValue.ID.String())
+				obj.Value("code").String().IsEqual(tc.payload.Code)
+				obj.Value("polygon").Object().Value("type").String().IsEqual(tc.payload.Polygon.Type)
+			}
+		})
+	}
+}
+
+// TestGetZone выполняет тестирование получения зоны доставки
+func TestGetZone(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getZoneTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockZoneService := services_mocks.NewMockZoneServiceInterface(t)
+
+			h := handlers.NewZoneHandler(mockZoneService, discardLogger)
+			mux := setupTestZoneRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockZoneService.On("GetZone", tc.id).Return(tc.returnedValue, tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			e.GET(fmt.Sprintf("/api/zones/%s", tc.id)).Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
+
+// TestGetZones выполняет тестирование получения списка зон доставки
+func TestGetZones(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range getZonesTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockZoneService := services_mocks.NewMockZoneServiceInterface(t)
+
+			h := handlers.NewZoneHandler(mockZoneService, discardLogger)
+			mux := setupTestZoneRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockZoneService.On("GetZones", tc.activeOnly).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			req := e.GET("/api/zones")
+			if tc.activeParam != "" {
+				req.WithQuery("active", tc.activeParam)
+			}
+			resp := req.Expect().Status(tc.expectedStatusCode)
+			if tc.expectedStatusCode == http.StatusOK {
+				resp.JSON().Array().Length().IsEqual(len(tc.returnedValue))
+			}
+		})
+	}
+}
+
+// TestUpdateZone выполняет тестирование обновления зоны доставки
+func TestUpdateZone(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range updateZoneTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockZoneService := services_mocks.NewMockZoneServiceInterface(t)
+
+			h := handlers.NewZoneHandler(mockZoneService, discardLogger)
+			mux := setupTestZoneRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			if tc.expectedStatusCode != http.StatusBadRequest {
+				mockZoneService.On("UpdateZone", tc.id, tc.payload).Return(tc.returnedValue, tc.returnedError)
+			}
+
+			e := httpexpect.Default(t, server.URL)
+			e.PUT(fmt.Sprintf("/api/zones/%s", tc.id)).WithJSON(tc.payload).Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
+
+// TestDeleteZone выполняет тестирование деактивации зоны доставки
+func TestDeleteZone(t *testing.T) {
+	discardLogger := logger.NewTest()
+
+	for _, tc := range deleteZoneTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			mockZoneService := services_mocks.NewMockZoneServiceInterface(t)
+
+			h := handlers.NewZoneHandler(mockZoneService, discardLogger)
+			mux := setupTestZoneRoutes(h)
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mockZoneService.On("DeleteZone", tc.id).Return(tc.returnedError)
+
+			e := httpexpect.Default(t, server.URL)
+			e.DELETE(fmt.Sprintf("/api/zones/%s", tc.id)).Expect().Status(tc.expectedStatusCode)
+		})
+	}
+}
@@ -1,20 +1,30 @@
 package handlers
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	"delivery-system/internal/models"
+
 	"github.com/google/uuid"
 )
 
 // Константы
 const (
-	defaultCacheTTL         = 5 * time.Minute
-	apiOrderPrefix   string = "/api/orders/"
-	apiCourierPrefix string = "/api/couriers/"
+	defaultCacheTTL           = 5 * time.Minute
+	apiOrderPrefix     string = "/api/orders/"
+	apiCourierPrefix   string = "/api/couriers/"
+	apiPromoCodePrefix string = "/api/promo-codes/"
+)
+
+// Заголовки, идентифицирующие инициатора запроса
+const (
+	headerActorRole = "X-Actor-Role"
+	headerActorID   = "X-Actor-ID"
 )
 
 // ErrorResponse представляет структуру ответа с ошибкой
@@ -42,6 +52,18 @@ func WriteErrorResponse(w http.ResponseWriter, statusCode int, message string) {
 	WriteJSONResponse(w, statusCode, response)
 }
 
+// WriteCSVResponse отправляет ответ в формате CSV как вложение с указанным именем файла
+func WriteCSVResponse(w http.ResponseWriter, filename string, records [][]string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+
+	writer := csv.NewWriter(w)
+	if err := writer.WriteAll(records); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
 // ExtractUUIDFromPath извлекает UUID из пути URL
 func ExtractUUIDFromPath(path, prefix string) (uuid.UUID, error) {
 	if !strings.HasPrefix(path, prefix) {
@@ -65,11 +87,39 @@ func ExtractUUIDFromPath(path, prefix string) (uuid.UUID, error) {
 	return id, nil
 }
 
+// ActorFromRequest определяет инициатора запроса. Если запрос аутентифицирован, инициатор берётся
+// из токена доступа, иначе - из заголовков X-Actor-Role и X-Actor-ID. Запрос без роли не получает
+// роль по умолчанию, а курьер, клиент и продавец обязаны передать свой идентификатор.
+// Роли admin и system заголовками не назначаются: их можно получить только через аутентификацию
+func ActorFromRequest(r *http.Request) (models.Actor, error) {
+	if actor, ok := r.Context().Value(actorContextKey{}).(models.Actor); ok {
+		return actor, nil
+	}
+
+	actor := models.Actor{
+		Role: models.Role(r.Header.Get(headerActorRole)),
+		ID:   strings.TrimSpace(r.Header.Get(headerActorID)),
+	}
+	if actor.Role == "" {
+		return models.Actor{}, fmt.Errorf("%s header is required", headerActorRole)
+	}
+	if !actor.Role.IsValid() {
+		return models.Actor{}, fmt.Errorf("unknown actor role: %s", actor.Role)
+	}
+	if actor.Role == models.RoleAdmin || actor.Role == models.RoleSystem {
+		return models.Actor{}, fmt.Errorf("role %s cannot be set by %s header", actor.Role, headerActorRole)
+	}
+	if actor.ID == "" && roleRequiresID(actor.Role) {
+		return models.Actor{}, fmt.Errorf("%s header is required for role %s", headerActorID, actor.Role)
+	}
+	return actor, nil
+}
+
 // enableCORS включает CORS заголовки
 func enableCORS(w http.ResponseWriter) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Actor-Role, X-Actor-ID, X-API-Key")
 }
 
 // corsMiddleware добавляет CORS заголовки
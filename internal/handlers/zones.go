@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/services"
+)
+
+// maxZoneCodeLength соответствует размеру колонок с кодом зоны (delivery_zones.code, couriers.zone, tariffs.zone)
+const maxZoneCodeLength = 64
+
+// ZoneHandler представляет обработчик зон доставки
+type ZoneHandler struct {
+	zoneService services.ZoneServiceInterface
+	log         *logger.Logger
+}
+
+// NewZoneHandler создает новый обработчик зон доставки
+func NewZoneHandler(zoneService services.ZoneServiceInterface, log *logger.Logger) *ZoneHandler {
+	return &ZoneHandler{
+		zoneService: zoneService,
+		log:         log,
+	}
+}
+
+// CreateZone создает новую зону доставки
+func (h *ZoneHandler) CreateZone(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	var req models.DeliveryZoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	req.Code = strings.TrimSpace(req.Code)
+	if req.Code == "" {
+		WriteErrorResponse(w, http.StatusBadRequest, "code is required")
+		return
+	}
+	if len(req.Code) > maxZoneCodeLength {
+		WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("code must be no longer than %d characters", maxZoneCodeLength))
+		return
+	}
+	if err := h.validateZoneRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	zone, err := h.zoneService.CreateZone(&req)
+	if err != nil {
+		if strings.Contains(err.Error(), "already exists") {
+			WriteErrorResponse(w, http.StatusConflict, "Delivery zone already exists")
+			return
+		}
+		h.log.WithError(err).Error("Failed to create delivery zone")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to create delivery zone")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusCreated, zone)
+}
+
+// GetZone получает зону доставки по ID
+func (h *ZoneHandler) GetZone(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	zoneID, err := ExtractUUIDFromPath(r.URL.Path, "/api/zones/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid zone ID")
+		return
+	}
+
+	zone, err := h.zoneService.GetZone(zoneID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Delivery zone not found")
+		} else {
+			h.log.WithError(err).Error("Failed to get delivery zone")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get delivery zone")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, zone)
+}
+
+// GetZones получает список зон доставки
+func (h *ZoneHandler) GetZones(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	activeOnly := false
+	if activeStr := r.URL.Query().Get("active"); activeStr != "" {
+		active, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			WriteErrorResponse(w, http.StatusBadRequest, "Invalid active parameter")
+			return
+		}
+		activeOnly = active
+	}
+
+	zones, err := h.zoneService.GetZones(activeOnly)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get delivery zones")
+		WriteErrorResponse(w, http.StatusInternalServerError, "Failed to get delivery zones")
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, zones)
+}
+
+// UpdateZone обновляет границу и параметры зоны доставки
+func (h *ZoneHandler) UpdateZone(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	zoneID, err := ExtractUUIDFromPath(r.URL.Path, "/api/zones/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid zone ID")
+		return
+	}
+
+	var req models.DeliveryZoneRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.validateZoneRequest(&req); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	zone, err := h.zoneService.UpdateZone(zoneID, &req)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Delivery zone not found")
+		} else {
+			h.log.WithError(err).Error("Failed to update delivery zone")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to update delivery zone")
+		}
+		return
+	}
+
+	WriteJSONResponse(w, http.StatusOK, zone)
+}
+
+// DeleteZone деактивирует зону доставки
+func (h *ZoneHandler) DeleteZone(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		WriteErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	zoneID, err := ExtractUUIDFromPath(r.URL.Path, "/api/zones/")
+	if err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, "Invalid zone ID")
+		return
+	}
+
+	if err := h.zoneService.DeleteZone(zoneID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			WriteErrorResponse(w, http.StatusNotFound, "Delivery zone not found")
+		} else {
+			h.log.WithError(err).Error("Failed to delete delivery zone")
+			WriteErrorResponse(w, http.StatusInternalServerError, "Failed to delete delivery zone")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// validateZoneRequest валидирует название, границу, ограничения и SLA зоны доставки
+func (h *ZoneHandler) validateZoneRequest(req *models.DeliveryZoneRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if req.MaxRouteDistance != nil && *req.MaxRouteDistance <= 0 {
+		return fmt.Errorf("max route distance must be positive")
+	}
+	if req.SLAMinutes != nil && *req.SLAMinutes <= 0 {
+		return fmt.Errorf("sla minutes must be positive")
+	}
+	return validatePolygon(req.Polygon)
+}
+
+// validatePolygon проверяет, что граница зоны - GeoJSON Polygon из замкнутых колец
+// не менее чем из четырёх точек с корректными координатами
+func validatePolygon(polygon *models.GeoJSONPolygon) error {
+	if polygon == nil {
+		return fmt.Errorf("polygon is required")
+	}
+	if polygon.Type != models.GeoJSONTypePolygon {
+		return fmt.Errorf("polygon type must be %s", models.GeoJSONTypePolygon)
+	}
+	if len(polygon.Coordinates) == 0 {
+		return fmt.Errorf("polygon must have an outer ring")
+	}
+	for i, ring := range polygon.Coordinates {
+		if len(ring) < 4 {
+			return fmt.Errorf("ring %d: at least 4 positions are required", i+1)
+		}
+		if ring[0] != ring[len(ring)-1] {
+			return fmt.Errorf("ring %d: first and last positions must be equal", i+1)
+		}
+		for _, position := range ring {
+			if position[0] < -180 || position[0] > 180 || position[1] < -90 || position[1] > 90 {
+				return fmt.Errorf("ring %d: coordinates must be [longitude, latitude] within valid ranges", i+1)
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeZoneCode убирает пробелы по краям кода зоны и заменяет пустой код на nil
+func normalizeZoneCode(zone *string) *string {
+	if zone == nil {
+		return nil
+	}
+	code := strings.TrimSpace(*zone)
+	if code == "" {
+		return nil
+	}
+	return &code
+}
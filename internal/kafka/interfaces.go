@@ -1,6 +1,8 @@
 package kafka
 
 import (
+	"time"
+
 	"delivery-system/internal/models"
 
 	"github.com/google/uuid"
@@ -13,4 +15,5 @@ type ProducerInterface interface {
 	PublishCourierAssigned(orderID, courierID uuid.UUID) error
 	PublishCourierStatusChanged(courierID uuid.UUID, oldStatus, newStatus models.CourierStatus) error
 	PublishLocationUpdated(courierID uuid.UUID, lat, lon float64) error
+	PublishMessage(topic, key string, eventType models.EventType, timestamp time.Time, data []byte) error
 }
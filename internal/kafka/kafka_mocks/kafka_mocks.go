@@ -6,6 +6,7 @@ package kafka_mocks
 
 import (
 	"delivery-system/internal/models"
+	"time"
 
 	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
@@ -265,6 +266,81 @@ func (_c *MockProducerInterface_PublishLocationUpdated_Call) RunAndReturn(run fu
 	return _c
 }
 
+// PublishMessage provides a mock function for the type MockProducerInterface
+func (_mock *MockProducerInterface) PublishMessage(topic string, key string, eventType models.EventType, timestamp time.Time, data []byte) error {
+	ret := _mock.Called(topic, key, eventType, timestamp, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishMessage")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, models.EventType, time.Time, []byte) error); ok {
+		r0 = returnFunc(topic, key, eventType, timestamp, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProducerInterface_PublishMessage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishMessage'
+type MockProducerInterface_PublishMessage_Call struct {
+	*mock.Call
+}
+
+// PublishMessage is a helper method to define mock.On call
+//   - topic string
+//   - key string
+//   - eventType models.EventType
+//   - timestamp time.Time
+//   - data []byte
+func (_e *MockProducerInterface_Expecter) PublishMessage(topic interface{}, key interface{}, eventType interface{}, timestamp interface{}, data interface{}) *MockProducerInterface_PublishMessage_Call {
+	return &MockProducerInterface_PublishMessage_Call{Call: _e.mock.On("PublishMessage", topic, key, eventType, timestamp, data)}
+}
+
+func (_c *MockProducerInterface_PublishMessage_Call) Run(run func(topic string, key string, eventType models.EventType, timestamp time.Time, data []byte)) *MockProducerInterface_PublishMessage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 models.EventType
+		if args[2] != nil {
+			arg2 = args[2].(models.EventType)
+		}
+		var arg3 time.Time
+		if args[3] != nil {
+			arg3 = args[3].(time.Time)
+		}
+		var arg4 []byte
+		if args[4] != nil {
+			arg4 = args[4].([]byte)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProducerInterface_PublishMessage_Call) Return(err error) *MockProducerInterface_PublishMessage_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProducerInterface_PublishMessage_Call) RunAndReturn(run func(topic string, key string, eventType models.EventType, timestamp time.Time, data []byte) error) *MockProducerInterface_PublishMessage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PublishOrderCreated provides a mock function for the type MockProducerInterface
 func (_mock *MockProducerInterface) PublishOrderCreated(order *models.Order) error {
 	ret := _mock.Called(order)
@@ -59,6 +59,9 @@ func (p *Producer) PublishOrderCreated(order *models.Order) error {
 			CustomerPhone:   order.CustomerPhone,
 			DeliveryAddress: order.DeliveryAddress,
 			TotalAmount:     order.TotalAmount,
+			DeliveryCost:    order.DeliveryCost,
+			PromoCode:       order.PromoCode,
+			DiscountAmount:  order.DiscountAmount,
 		},
 	}
 
@@ -140,15 +143,21 @@ func (p *Producer) publishEvent(topic string, event models.Event) error {
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
+	return p.PublishMessage(topic, event.ID.String(), event.Type, event.Timestamp, data)
+}
+
+// PublishMessage публикует уже сериализованное событие в указанный топик.
+// Используется релеем outbox, который хранит события в сериализованном виде
+func (p *Producer) PublishMessage(topic, key string, eventType models.EventType, timestamp time.Time, data []byte) error {
 	correlationID := uuid.New().String()
 
 	message := &sarama.ProducerMessage{
 		Topic: topic,
-		Key:   sarama.StringEncoder(event.ID.String()),
+		Key:   sarama.StringEncoder(key),
 		Value: sarama.ByteEncoder(data),
 		Headers: []sarama.RecordHeader{
-			{Key: []byte("event_type"), Value: []byte(event.Type)},
-			{Key: []byte("timestamp"), Value: []byte(event.Timestamp.Format(time.RFC3339))},
+			{Key: []byte("event_type"), Value: []byte(eventType)},
+			{Key: []byte("timestamp"), Value: []byte(timestamp.Format(time.RFC3339))},
 			{Key: []byte("correlation_id"), Value: []byte(correlationID)},
 		},
 	}
@@ -166,8 +175,8 @@ func (p *Producer) publishEvent(topic string, event models.Event) error {
 		"topic":          topic,
 		"partition":      partition,
 		"offset":         offset,
-		"event_type":     event.Type,
-		"event_id":       event.ID,
+		"event_type":     eventType,
+		"key":            key,
 	}).Debug("Event published successfully")
 
 	return nil
@@ -0,0 +1,45 @@
+package models
+
+// Role представляет роль участника системы
+type Role string
+
+const (
+	RoleSystem     Role = "system"
+	RoleAdmin      Role = "admin"
+	RoleDispatcher Role = "dispatcher"
+	RoleCourier    Role = "courier"
+	RoleCustomer   Role = "customer"
+	RoleMerchant   Role = "merchant"
+)
+
+// IsValid проверяет, что роль известна системе
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleSystem, RoleAdmin, RoleDispatcher, RoleCourier, RoleCustomer, RoleMerchant:
+		return true
+	}
+	return false
+}
+
+// IsExternal проверяет, что роль может быть выдана пользователю API. Роль system зарезервирована
+// для изменений, выполняемых самой системой
+func (r Role) IsExternal() bool {
+	return r.IsValid() && r != RoleSystem
+}
+
+// Actor представляет инициатора изменения (роль и, при наличии, идентификатор)
+type Actor struct {
+	Role Role   `json:"role"`
+	ID   string `json:"id,omitempty"`
+}
+
+// SystemActor - инициатор для изменений, выполняемых самой системой
+var SystemActor = Actor{Role: RoleSystem}
+
+// String возвращает инициатора в виде "роль" или "роль:идентификатор"
+func (a Actor) String() string {
+	if a.ID == "" {
+		return string(a.Role)
+	}
+	return string(a.Role) + ":" + a.ID
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalyticsGroupBy представляет период группировки аналитических метрик
+type AnalyticsGroupBy string
+
+const (
+	AnalyticsGroupByDay   AnalyticsGroupBy = "day"
+	AnalyticsGroupByWeek  AnalyticsGroupBy = "week"
+	AnalyticsGroupByMonth AnalyticsGroupBy = "month"
+)
+
+// IsValid проверяет, что период группировки поддерживается
+func (g AnalyticsGroupBy) IsValid() bool {
+	switch g {
+	case AnalyticsGroupByDay, AnalyticsGroupByWeek, AnalyticsGroupByMonth:
+		return true
+	}
+	return false
+}
+
+// AnalyticsFilter представляет параметры построения отчёта: полуинтервал [From, To) и группировку
+type AnalyticsFilter struct {
+	From    time.Time        `json:"from"`
+	To      time.Time        `json:"to"`
+	GroupBy AnalyticsGroupBy `json:"group_by"`
+	Limit   int              `json:"limit,omitempty"`
+}
+
+// SalesMetrics представляет ключевые показатели заказов за период.
+// Выручка учитывает только доставленные заказы: товары + доставка - скидка
+type SalesMetrics struct {
+	Period                 *time.Time `json:"period,omitempty"`
+	OrdersCount            int        `json:"orders_count"`
+	DeliveredCount         int        `json:"delivered_count"`
+	CancelledCount         int        `json:"cancelled_count"`
+	Revenue                float64    `json:"revenue"`
+	DiscountAmount         float64    `json:"discount_amount"`
+	AverageOrderValue      float64    `json:"average_order_value"`
+	AvgDeliveryTimeMinutes float64    `json:"avg_delivery_time_minutes"`
+	CancellationRate       float64    `json:"cancellation_rate"`
+}
+
+// SummaryReport представляет сводный отчёт по заказам с итогами и разбивкой по периодам
+type SummaryReport struct {
+	Filter  AnalyticsFilter `json:"filter"`
+	Totals  SalesMetrics    `json:"totals"`
+	Periods []SalesMetrics  `json:"periods"`
+}
+
+// TopItem представляет позицию в рейтинге популярных товаров
+type TopItem struct {
+	Name        string  `json:"name"`
+	Quantity    int     `json:"quantity"`
+	Revenue     float64 `json:"revenue"`
+	OrdersCount int     `json:"orders_count"`
+}
+
+// TopItemsReport представляет отчёт по популярным товарам
+type TopItemsReport struct {
+	Filter AnalyticsFilter `json:"filter"`
+	Items  []TopItem       `json:"items"`
+}
+
+// CourierMetrics представляет показатели работы курьера за период.
+// Заработок курьера рассчитывается как сумма стоимости доставки доставленных им заказов
+type CourierMetrics struct {
+	CourierID              uuid.UUID `json:"courier_id"`
+	Name                   string    `json:"name"`
+	Deliveries             int       `json:"deliveries"`
+	Earnings               float64   `json:"earnings"`
+	AvgDeliveryTimeMinutes float64   `json:"avg_delivery_time_minutes"`
+	AvgRating              *float64  `json:"avg_rating,omitempty"`
+	ReviewsCount           int       `json:"reviews_count"`
+}
+
+// CourierReport представляет отчёт по курьерам
+type CourierReport struct {
+	Filter   AnalyticsFilter  `json:"filter"`
+	Couriers []CourierMetrics `json:"couriers"`
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Области действия API-ключа. Область задаётся как "<ресурс>:read" (GET-запросы к ресурсу) или
+// "<ресурс>:write" (остальные методы); "*" разрешает все запросы, доступные владельцу ключа
+const (
+	APIKeyScopeAll    = "*"
+	APIKeyAccessRead  = "read"
+	APIKeyAccessWrite = "write"
+)
+
+// APIKeyResources - ресурсы API, для которых выдаются области действия ключей
+var APIKeyResources = []string{
+	"orders", "customers", "merchants", "couriers", "shifts", "promo-codes",
+	"tariffs", "delivery", "zones", "analytics", "api-keys", "rate-limit",
+}
+
+// IsValidAPIKeyScope проверяет, что область действия ключа известна системе
+func IsValidAPIKeyScope(scope string) bool {
+	if scope == APIKeyScopeAll {
+		return true
+	}
+
+	resource, access, ok := strings.Cut(scope, ":")
+	if !ok || (access != APIKeyAccessRead && access != APIKeyAccessWrite) {
+		return false
+	}
+	for _, known := range APIKeyResources {
+		if resource == known {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKey представляет API-ключ машинного клиента. Запросы по ключу выполняются от имени владельца Owner
+// и ограничены как правами его роли, так и областями действия Scopes. Сам ключ не хранится, Prefix -
+// его начало для отображения в списках. RateLimitTier - тарифный план ограничения частоты запросов по ключу
+type APIKey struct {
+	ID            uuid.UUID     `json:"id" db:"id"`
+	Name          string        `json:"name" db:"name"`
+	Prefix        string        `json:"prefix" db:"key_prefix"`
+	Scopes        []string      `json:"scopes" db:"scopes"`
+	Owner         Actor         `json:"owner"`
+	RateLimitTier RateLimitTier `json:"rate_limit_tier" db:"rate_limit_tier"`
+	ExpiresAt     *time.Time    `json:"expires_at,omitempty" db:"expires_at"`
+	LastUsedAt    *time.Time    `json:"last_used_at,omitempty" db:"last_used_at"`
+	RotatedAt     *time.Time    `json:"rotated_at,omitempty" db:"rotated_at"`
+	RevokedAt     *time.Time    `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt     time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// HasScope проверяет, что ключ разрешает запросы с областью действия scope
+func (k *APIKey) HasScope(scope string) bool {
+	for _, granted := range k.Scopes {
+		if granted == APIKeyScopeAll || granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive проверяет, что ключ не отозван и не истёк к моменту at
+func (k *APIKey) IsActive(at time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || at.Before(*k.ExpiresAt))
+}
+
+// APIKeyRequest представляет запрос на выпуск API-ключа. OwnerID обязателен для ключей курьера,
+// клиента и продавца, без RateLimitTier ключ получает тарифный план default
+type APIKeyRequest struct {
+	Name          string        `json:"name"`
+	Scopes        []string      `json:"scopes"`
+	OwnerRole     Role          `json:"owner_role"`
+	OwnerID       string        `json:"owner_id,omitempty"`
+	RateLimitTier RateLimitTier `json:"rate_limit_tier,omitempty"`
+	ExpiresAt     *time.Time    `json:"expires_at,omitempty"`
+}
+
+// IssuedAPIKey представляет выпущенный или перевыпущенный ключ. Key возвращается только в этом ответе
+type IssuedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyDailyUsage представляет количество запросов по ключу за сутки (UTC)
+type APIKeyDailyUsage struct {
+	Date     string `json:"date"`
+	Requests int64  `json:"requests"`
+}
+
+// APIKeyUsage представляет статистику запросов по ключу за последние Days суток
+type APIKeyUsage struct {
+	KeyID      uuid.UUID          `json:"key_id"`
+	Days       int                `json:"days"`
+	Total      int64              `json:"total"`
+	LastUsedAt *time.Time         `json:"last_used_at,omitempty"`
+	Daily      []APIKeyDailyUsage `json:"daily"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "github.com/google/uuid"
+
+// CandidateScore представляет разбивку оценки курьера-кандидата при автоназначении
+type CandidateScore struct {
+	CourierID     uuid.UUID `json:"courier_id"`
+	DistanceKm    float64   `json:"distance_km"`
+	Rating        float64   `json:"rating"`
+	ActiveOrders  int       `json:"active_orders"`
+	DistanceScore float64   `json:"distance_score"`
+	RatingScore   float64   `json:"rating_score"`
+	LoadScore     float64   `json:"load_score"`
+	TotalScore    float64   `json:"total_score"`
+}
+
+// AssignmentResult представляет результат автоматического назначения курьера
+type AssignmentResult struct {
+	OrderID    uuid.UUID        `json:"order_id"`
+	CourierID  uuid.UUID        `json:"courier_id"`
+	Score      float64          `json:"score"`
+	Candidates []CandidateScore `json:"candidates"`
+}
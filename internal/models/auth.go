@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// TokenRequest представляет запрос на выпуск токена разработки. Subject - идентификатор курьера,
+// клиента или продавца, от имени которого выполняются запросы; TTLMinutes - срок действия токена
+type TokenRequest struct {
+	Role       Role   `json:"role"`
+	Subject    string `json:"subject,omitempty"`
+	TTLMinutes int    `json:"ttl_minutes,omitempty"`
+}
+
+// AuthToken представляет выпущенный токен доступа
+type AuthToken struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Actor       Actor     `json:"actor"`
+}
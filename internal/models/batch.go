@@ -0,0 +1,41 @@
+package models
+
+import "github.com/google/uuid"
+
+// RouteStopType представляет тип остановки маршрута курьера
+type RouteStopType string
+
+const (
+	RouteStopPickup   RouteStopType = "pickup"
+	RouteStopDelivery RouteStopType = "delivery"
+)
+
+// RouteStop представляет остановку маршрута курьера: получение или доставку заказа
+type RouteStop struct {
+	OrderID uuid.UUID     `json:"order_id"`
+	Type    RouteStopType `json:"type"`
+	Address string        `json:"address"`
+	Lat     float64       `json:"lat"`
+	Lon     float64       `json:"lon"`
+}
+
+// CourierRoute представляет последовательность остановок для доставки нескольких заказов.
+// Точка доставки заказа всегда следует за точкой его получения. DistanceMeters - длина маршрута
+// от текущего местоположения курьера, если оно известно, иначе от первой остановки
+type CourierRoute struct {
+	CourierID      *uuid.UUID  `json:"courier_id,omitempty"`
+	Stops          []RouteStop `json:"stops"`
+	DistanceMeters float64     `json:"distance_m"`
+}
+
+// OrderBatch представляет группу ожидающих курьера заказов с близкими точками получения и доставки,
+// которые выгодно отдать одному курьеру
+type OrderBatch struct {
+	OrderIDs []uuid.UUID   `json:"order_ids"`
+	Route    *CourierRoute `json:"route"`
+}
+
+// AssignBatchRequest представляет запрос на назначение курьеру нескольких заказов
+type AssignBatchRequest struct {
+	OrderIDs []uuid.UUID `json:"order_ids"`
+}
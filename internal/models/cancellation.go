@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CancellationReason представляет код причины отмены заказа
+type CancellationReason string
+
+const (
+	CancellationReasonCustomerRequest     CancellationReason = "customer_request"
+	CancellationReasonCustomerUnreachable CancellationReason = "customer_unreachable"
+	CancellationReasonDuplicateOrder      CancellationReason = "duplicate_order"
+	CancellationReasonMerchantClosed      CancellationReason = "merchant_closed"
+	CancellationReasonOutOfStock          CancellationReason = "out_of_stock"
+	CancellationReasonNoCourier           CancellationReason = "no_courier"
+	CancellationReasonAddressIssue        CancellationReason = "address_issue"
+	CancellationReasonPaymentFailed       CancellationReason = "payment_failed"
+	CancellationReasonFraudSuspected      CancellationReason = "fraud_suspected"
+	CancellationReasonOther               CancellationReason = "other"
+)
+
+// CancellationReasons описывает известные причины отмены заказа и роли, которым разрешено их указывать.
+// На каком этапе роль может отменить заказ, определяет OrderStatusTransitions
+var CancellationReasons = map[CancellationReason][]Role{
+	CancellationReasonCustomerRequest:     {RoleSystem, RoleAdmin, RoleDispatcher, RoleCustomer},
+	CancellationReasonCustomerUnreachable: {RoleSystem, RoleAdmin, RoleDispatcher},
+	CancellationReasonDuplicateOrder:      {RoleSystem, RoleAdmin, RoleDispatcher, RoleCustomer},
+	CancellationReasonMerchantClosed:      {RoleSystem, RoleAdmin, RoleDispatcher, RoleMerchant},
+	CancellationReasonOutOfStock:          {RoleSystem, RoleAdmin, RoleDispatcher, RoleMerchant},
+	CancellationReasonNoCourier:           {RoleSystem, RoleAdmin, RoleDispatcher},
+	CancellationReasonAddressIssue:        {RoleSystem, RoleAdmin, RoleDispatcher},
+	CancellationReasonPaymentFailed:       {RoleSystem, RoleAdmin},
+	CancellationReasonFraudSuspected:      {RoleSystem, RoleAdmin},
+	CancellationReasonOther:               {RoleSystem, RoleAdmin, RoleDispatcher},
+}
+
+// IsValid проверяет, что причина отмены известна системе
+func (r CancellationReason) IsValid() bool {
+	_, exists := CancellationReasons[r]
+	return exists
+}
+
+// AllowedFor проверяет, может ли указанная роль отменить заказ по этой причине
+func (r CancellationReason) AllowedFor(role Role) bool {
+	for _, allowed := range CancellationReasons[r] {
+		if allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ChargesCustomer сообщает, что заказ отменяется по инициативе или вине клиента
+// и с него удерживается плата за отмену
+func (r CancellationReason) ChargesCustomer() bool {
+	return r == CancellationReasonCustomerRequest || r == CancellationReasonCustomerUnreachable
+}
+
+// OrderCancellation представляет отмену заказа. Stage - статус заказа на момент отмены, PaidAmount - сумма,
+// оплаченная клиентом (товары и доставка за вычетом скидки), Fee - удержанная плата за отмену,
+// RefundAmount - сумма к возврату клиенту. CourierID - курьер, освобождённый отменой
+type OrderCancellation struct {
+	OrderID      uuid.UUID          `json:"order_id"`
+	Reason       CancellationReason `json:"reason"`
+	Comment      *string            `json:"comment,omitempty"`
+	Stage        OrderStatus        `json:"stage"`
+	CancelledBy  Actor              `json:"cancelled_by"`
+	PaidAmount   float64            `json:"paid_amount"`
+	Fee          float64            `json:"fee"`
+	RefundAmount float64            `json:"refund_amount"`
+	CourierID    *uuid.UUID         `json:"courier_id,omitempty"`
+	CancelledAt  time.Time          `json:"cancelled_at"`
+}
+
+// CancelOrderRequest представляет запрос на отмену заказа
+type CancelOrderRequest struct {
+	Reason  CancellationReason `json:"reason"`
+	Comment string             `json:"comment,omitempty"`
+}
+
+// AcceptOrderRequest представляет запрос продавца на принятие заказа с заявленным временем приготовления
+type AcceptOrderRequest struct {
+	PrepMinutes int `json:"prep_minutes"`
+}
@@ -23,6 +23,8 @@ type Courier struct {
 	Status       CourierStatus `json:"status" db:"status"`
 	Rating       *float64      `json:"rating,omitempty" db:"rating"`
 	TotalReviews int           `json:"total_reviews" db:"total_reviews"`
+	Zone         *string       `json:"zone,omitempty" db:"zone"`
+	Capacity     *int          `json:"capacity,omitempty" db:"capacity"`
 	CurrentLat   *float64      `json:"current_lat,omitempty" db:"current_lat"`
 	CurrentLon   *float64      `json:"current_lon,omitempty" db:"current_lon"`
 	CreatedAt    time.Time     `json:"created_at" db:"created_at"`
@@ -30,10 +32,31 @@ type Courier struct {
 	LastSeenAt   *time.Time    `json:"last_seen_at,omitempty" db:"last_seen_at"`
 }
 
-// CreateCourierRequest представляет запрос на создание курьера
+// NearbyCourier представляет доступного курьера с расстоянием до точки поиска
+type NearbyCourier struct {
+	*Courier
+	DistanceMeters float64 `json:"distance_m"`
+}
+
+// CreateCourierRequest представляет запрос на создание курьера. Курьер без зоны работает во всех зонах,
+// курьер без вместимости может везти число заказов по умолчанию
 type CreateCourierRequest struct {
-	Name  string `json:"name"`
-	Phone string `json:"phone"`
+	Name     string  `json:"name"`
+	Phone    string  `json:"phone"`
+	Zone     *string `json:"zone,omitempty"`
+	Capacity *int    `json:"capacity,omitempty"`
+}
+
+// UpdateCourierZoneRequest представляет запрос на привязку курьера к зоне доставки.
+// Пустое значение отвязывает курьера от зоны
+type UpdateCourierZoneRequest struct {
+	Zone *string `json:"zone"`
+}
+
+// UpdateCourierCapacityRequest представляет запрос на изменение вместимости курьера.
+// Пустое значение возвращает вместимость по умолчанию
+type UpdateCourierCapacityRequest struct {
+	Capacity *int `json:"capacity"`
 }
 
 // UpdateCourierStatusRequest представляет запрос на обновление статуса курьера
@@ -48,5 +71,20 @@ type CourierLocation struct {
 	CourierID uuid.UUID `json:"courier_id"`
 	Lat       float64   `json:"lat"`
 	Lon       float64   `json:"lon"`
+	Accuracy  *float64  `json:"accuracy,omitempty"`
+	Speed     *float64  `json:"speed,omitempty"`
+	Heading   *float64  `json:"heading,omitempty"`
 	Timestamp time.Time `json:"timestamp"`
 }
+
+// UpdateLocationRequest представляет запрос на обновление местоположения курьера.
+// Accuracy - точность в метрах, Speed - скорость в м/с, Heading - направление в градусах.
+// RecordedAt - время замера на устройстве курьера (по умолчанию - время получения запроса)
+type UpdateLocationRequest struct {
+	Lat        float64    `json:"lat"`
+	Lon        float64    `json:"lon"`
+	Accuracy   *float64   `json:"accuracy,omitempty"`
+	Speed      *float64   `json:"speed,omitempty"`
+	Heading    *float64   `json:"heading,omitempty"`
+	RecordedAt *time.Time `json:"recorded_at,omitempty"`
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Customer представляет клиента. Телефон хранится нормализованным (см. NormalizePhone)
+// и однозначно определяет клиента
+type Customer struct {
+	ID        uuid.UUID         `json:"id" db:"id"`
+	Name      string            `json:"name" db:"name"`
+	Phone     string            `json:"phone" db:"phone"`
+	Addresses []CustomerAddress `json:"addresses,omitempty"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// CustomerAddress представляет сохранённый адрес клиента. Координаты определяются один раз
+// при сохранении адреса и используются в заказах вместо повторного геокодирования
+type CustomerAddress struct {
+	ID         uuid.UUID `json:"id" db:"id"`
+	CustomerID uuid.UUID `json:"customer_id" db:"customer_id"`
+	Label      *string   `json:"label,omitempty" db:"label"`
+	Address    string    `json:"address" db:"address"`
+	Lat        float64   `json:"lat" db:"lat"`
+	Lon        float64   `json:"lon" db:"lon"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// CustomerRequest представляет запрос на создание или обновление клиента
+type CustomerRequest struct {
+	Name  string `json:"name"`
+	Phone string `json:"phone"`
+}
+
+// CustomerAddressRequest представляет запрос на сохранение адреса клиента
+type CustomerAddressRequest struct {
+	Label   string `json:"label,omitempty"`
+	Address string `json:"address"`
+}
+
+// NormalizePhone приводит телефон к виду, по которому определяется клиент: остаются только цифры,
+// российский номер приводится к 11 цифрам с ведущей 7. Должна совпадать с функцией normalize_phone в БД
+func NormalizePhone(phone string) string {
+	var b strings.Builder
+	for _, c := range phone {
+		if c >= '0' && c <= '9' {
+			b.WriteRune(c)
+		}
+	}
+	digits := b.String()
+
+	switch {
+	case len(digits) == 11 && digits[0] == '8':
+		return "7" + digits[1:]
+	case len(digits) == 10:
+		return "7" + digits
+	default:
+		return digits
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Провайдеры хранилища файлов
+const (
+	BlobStoreLocal string = "local"
+)
+
+// ProofFileKind представляет вид изображения, приложенного к подтверждению доставки
+type ProofFileKind string
+
+const (
+	ProofFilePhoto     ProofFileKind = "photo"
+	ProofFileSignature ProofFileKind = "signature"
+)
+
+// DeliveryProof представляет подтверждение доставки заказа курьером. Lat и Lon - местоположение курьера
+// в момент подтверждения, DistanceMeters - расстояние от него до точки доставки. PhotoKey и SignatureKey -
+// ключи приложенных изображений в хранилище файлов
+type DeliveryProof struct {
+	OrderID        uuid.UUID  `json:"order_id"`
+	CourierID      *uuid.UUID `json:"courier_id,omitempty"`
+	PinVerified    bool       `json:"pin_verified"`
+	Lat            float64    `json:"lat"`
+	Lon            float64    `json:"lon"`
+	DistanceMeters float64    `json:"distance_m"`
+	PhotoKey       *string    `json:"photo_key,omitempty"`
+	SignatureKey   *string    `json:"signature_key,omitempty"`
+	DeliveredAt    time.Time  `json:"delivered_at"`
+}
+
+// ProofFile представляет изображение, приложенное к подтверждению доставки
+type ProofFile struct {
+	ContentType string
+	Data        []byte
+}
+
+// DeliveryProofRequest представляет данные подтверждения доставки, переданные курьером
+type DeliveryProofRequest struct {
+	Pin       string
+	Lat       float64
+	Lon       float64
+	Photo     *ProofFile
+	Signature *ProofFile
+}
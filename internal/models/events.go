@@ -12,9 +12,18 @@ type EventType string
 const (
 	EventTypeOrderCreated         EventType = "order.created"
 	EventTypeOrderStatusChanged   EventType = "order.status_changed"
+	EventTypeOrderReviewAdded     EventType = "order.review_added"
+	EventTypeOrderCancelled       EventType = "order.cancelled"
+	EventTypeOrderSLABreached     EventType = "order.sla_breached"
+	EventTypeOrderAccepted        EventType = "order.accepted"
+	EventTypeOrderRejected        EventType = "order.rejected"
+	EventTypeOrderPreparing       EventType = "order.preparing"
+	EventTypeOrderReady           EventType = "order.ready"
 	EventTypeCourierAssigned      EventType = "courier.assigned"
 	EventTypeCourierStatusChanged EventType = "courier.status_changed"
 	EventTypeLocationUpdated      EventType = "location.updated"
+	EventTypeSurgeChanged         EventType = "pricing.surge_changed"
+	EventTypeDeliveryPinIssued    EventType = "notification.delivery_pin"
 )
 
 // Event представляет базовое событие
@@ -27,11 +36,17 @@ type Event struct {
 
 // OrderCreatedEvent представляет событие создания заказа
 type OrderCreatedEvent struct {
-	OrderID         uuid.UUID `json:"order_id"`
-	CustomerName    string    `json:"customer_name"`
-	CustomerPhone   string    `json:"customer_phone"`
-	DeliveryAddress string    `json:"delivery_address"`
-	TotalAmount     float64   `json:"total_amount"`
+	OrderID         uuid.UUID  `json:"order_id"`
+	CustomerID      *uuid.UUID `json:"customer_id,omitempty"`
+	MerchantID      *uuid.UUID `json:"merchant_id,omitempty"`
+	CustomerName    string     `json:"customer_name"`
+	CustomerPhone   string     `json:"customer_phone"`
+	DeliveryAddress string     `json:"delivery_address"`
+	TotalAmount     float64    `json:"total_amount"`
+	DeliveryCost    float64    `json:"delivery_cost"`
+	PromoCode       *string    `json:"promo_code,omitempty"`
+	DiscountAmount  float64    `json:"discount_amount"`
+	ScheduledFor    *time.Time `json:"scheduled_for,omitempty"`
 }
 
 // OrderStatusChangedEvent представляет событие изменения статуса заказа
@@ -43,6 +58,33 @@ type OrderStatusChangedEvent struct {
 	Timestamp time.Time   `json:"timestamp"`
 }
 
+// OrderCancelledEvent представляет событие отмены заказа с причиной, платой за отмену и суммой возврата.
+// CourierID - курьер, освобождённый отменой
+type OrderCancelledEvent struct {
+	OrderID      uuid.UUID          `json:"order_id"`
+	OldStatus    OrderStatus        `json:"old_status"`
+	Reason       CancellationReason `json:"reason"`
+	Comment      *string            `json:"comment,omitempty"`
+	CancelledBy  Actor              `json:"cancelled_by"`
+	Fee          float64            `json:"fee"`
+	RefundAmount float64            `json:"refund_amount"`
+	CourierID    *uuid.UUID         `json:"courier_id,omitempty"`
+	Timestamp    time.Time          `json:"timestamp"`
+}
+
+// OrderPreparationEvent представляет событие этапа приготовления заказа продавцом: принятия заказа,
+// начала приготовления и готовности. ReadyAt - ожидаемое время готовности, для готового заказа - фактическое
+type OrderPreparationEvent struct {
+	OrderID     uuid.UUID   `json:"order_id"`
+	MerchantID  uuid.UUID   `json:"merchant_id"`
+	OldStatus   OrderStatus `json:"old_status"`
+	NewStatus   OrderStatus `json:"new_status"`
+	PrepMinutes *int        `json:"prep_minutes,omitempty"`
+	ReadyAt     *time.Time  `json:"ready_at,omitempty"`
+	CourierID   *uuid.UUID  `json:"courier_id,omitempty"`
+	Timestamp   time.Time   `json:"timestamp"`
+}
+
 // CourierAssignedEvent представляет событие назначения курьера
 type CourierAssignedEvent struct {
 	OrderID   uuid.UUID `json:"order_id"`
@@ -65,3 +107,34 @@ type LocationUpdatedEvent struct {
 	Lon       float64   `json:"lon"`
 	Timestamp time.Time `json:"timestamp"`
 }
+
+// SurgeChangedEvent представляет событие изменения динамического коэффициента стоимости доставки зоны
+type SurgeChangedEvent struct {
+	Zone              string    `json:"zone"`
+	OldMultiplier     float64   `json:"old_multiplier"`
+	NewMultiplier     float64   `json:"new_multiplier"`
+	TargetMultiplier  float64   `json:"target_multiplier"`
+	OpenOrders        int       `json:"open_orders"`
+	AvailableCouriers int       `json:"available_couriers"`
+	Timestamp         time.Time `json:"timestamp"`
+}
+
+// DeliveryPinIssuedEvent представляет уведомление покупателя о PIN-коде для подтверждения доставки заказа
+type DeliveryPinIssuedEvent struct {
+	OrderID       uuid.UUID `json:"order_id"`
+	CustomerPhone string    `json:"customer_phone"`
+	Pin           string    `json:"pin"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// OrderSLABreachedEvent представляет событие нарушения срока доставки заказа. ETA - прогноз доставки
+// на момент обнаружения нарушения
+type OrderSLABreachedEvent struct {
+	OrderID     uuid.UUID   `json:"order_id"`
+	Status      OrderStatus `json:"status"`
+	CourierID   *uuid.UUID  `json:"courier_id,omitempty"`
+	Zone        *string     `json:"zone,omitempty"`
+	SLADeadline time.Time   `json:"sla_deadline"`
+	ETA         *time.Time  `json:"eta,omitempty"`
+	Timestamp   time.Time   `json:"timestamp"`
+}
@@ -0,0 +1,22 @@
+package models
+
+// Типы объектов GeoJSON (RFC 7946)
+const (
+	GeoJSONTypeFeature    = "Feature"
+	GeoJSONTypePoint      = "Point"
+	GeoJSONTypeLineString = "LineString"
+	GeoJSONTypePolygon    = "Polygon"
+)
+
+// GeoJSONGeometry представляет геометрию GeoJSON. Координаты задаются в порядке [долгота, широта]
+type GeoJSONGeometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// GeoJSONFeature представляет объект GeoJSON с геометрией и произвольными свойствами
+type GeoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Geometry   *GeoJSONGeometry       `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
@@ -1,8 +1,17 @@
 package models
 
+// Провайдеры геокодирования
 const (
-	YandexGeocoderURL      string = "https://geocode-maps.yandex.ru/v1/"
-	OpenrouteDirectionsURL string = "https://api.openrouteservice.org/v2/directions/foot-walking"
+	GeocoderYandex    string = "yandex"
+	GeocoderNominatim string = "nominatim"
+	GeocoderLocal     string = "local"
+)
+
+// Провайдеры построения маршрутов
+const (
+	RouterOpenroute string = "openroute"
+	RouterOSRM      string = "osrm"
+	RouterHaversine string = "haversine"
 )
 
 type YandexResponse struct {
@@ -33,6 +42,21 @@ type OpenrouteResponse struct {
 	} `json:"routes"`
 }
 
+// NominatimPlace представляет результат поиска Nominatim (format=jsonv2)
+type NominatimPlace struct {
+	Lat string `json:"lat"`
+	Lon string `json:"lon"`
+}
+
+// OSRMResponse представляет ответ OSRM Route API
+type OSRMResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Routes  []struct {
+		Distance float64 `json:"distance"`
+	} `json:"routes"`
+}
+
 type GeoCache struct {
 	PickupCoordinates   [2]float64 `json:"pickup_coordinates"`
 	DeliveryCoordinates [2]float64 `json:"delivery_coordinates"`
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// minutesPerDay - количество минут в сутках, "24:00" допускается как время закрытия
+const minutesPerDay = 24 * 60
+
+// OpeningHours представляет интервал работы продавца в день недели Weekday (0 - воскресенье).
+// Open и Close задаются в формате HH:MM; время закрытия не позже открытия означает работу после полуночи
+type OpeningHours struct {
+	Weekday time.Weekday `json:"weekday"`
+	Open    string       `json:"open"`
+	Close   string       `json:"close"`
+}
+
+// Merchant представляет продавца (ресторан, магазин). Координаты точки получения определяются
+// при сохранении адреса, заказы продавца не геокодируют адрес получения повторно.
+// Продавец без часов работы принимает заказы круглосуточно
+type Merchant struct {
+	ID            uuid.UUID      `json:"id" db:"id"`
+	Name          string         `json:"name" db:"name"`
+	Phone         *string        `json:"phone,omitempty" db:"phone"`
+	PickupAddress string         `json:"pickup_address" db:"pickup_address"`
+	Lat           float64        `json:"lat" db:"lat"`
+	Lon           float64        `json:"lon" db:"lon"`
+	OpeningHours  []OpeningHours `json:"opening_hours" db:"opening_hours"`
+	IsActive      bool           `json:"is_active" db:"is_active"`
+	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// IsOpen проверяет, работает ли продавец в момент at. Время должно быть приведено к часовому поясу бизнеса
+func (m *Merchant) IsOpen(at time.Time) bool {
+	if len(m.OpeningHours) == 0 {
+		return true
+	}
+
+	minute := at.Hour()*60 + at.Minute()
+	previousDay := (at.Weekday() + 6) % 7
+	for _, hours := range m.OpeningHours {
+		open, errOpen := ParseClock(hours.Open)
+		closing, errClose := ParseClock(hours.Close)
+		if errOpen != nil || errClose != nil {
+			continue
+		}
+
+		if open < closing {
+			if hours.Weekday == at.Weekday() && minute >= open && minute < closing {
+				return true
+			}
+			continue
+		}
+
+		// Интервал через полночь: вечер своего дня и утро следующего
+		if (hours.Weekday == at.Weekday() && minute >= open) || (hours.Weekday == previousDay && minute < closing) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseClock разбирает время суток в формате HH:MM и возвращает количество минут от полуночи
+func ParseClock(value string) (int, error) {
+	if len(value) != 5 || value[2] != ':' || strings.IndexFunc(value[:2]+value[3:], notDigit) >= 0 {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	hour, _ := strconv.Atoi(value[:2])
+	minute, _ := strconv.Atoi(value[3:])
+	if minute > 59 || hour*60+minute > minutesPerDay {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", value)
+	}
+	return hour*60 + minute, nil
+}
+
+func notDigit(c rune) bool {
+	return c < '0' || c > '9'
+}
+
+// MerchantRequest представляет запрос на создание или обновление продавца
+type MerchantRequest struct {
+	Name          string         `json:"name"`
+	Phone         string         `json:"phone,omitempty"`
+	PickupAddress string         `json:"pickup_address"`
+	OpeningHours  []OpeningHours `json:"opening_hours,omitempty"`
+	IsActive      *bool          `json:"is_active,omitempty"`
+}
+
+// Product представляет товар из каталога продавца. Цена и вес товара подставляются в заказ
+// на момент его создания
+type Product struct {
+	ID          uuid.UUID `json:"id" db:"id"`
+	MerchantID  uuid.UUID `json:"merchant_id" db:"merchant_id"`
+	Name        string    `json:"name" db:"name"`
+	Description *string   `json:"description,omitempty" db:"description"`
+	Price       float64   `json:"price" db:"price"`
+	WeightKg    *float64  `json:"weight_kg,omitempty" db:"weight_kg"`
+	IsAvailable bool      `json:"is_available" db:"is_available"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ProductRequest представляет запрос на создание или обновление товара каталога
+type ProductRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Price       float64  `json:"price"`
+	WeightKg    *float64 `json:"weight_kg,omitempty"`
+	IsAvailable *bool    `json:"is_available,omitempty"`
+}
@@ -10,6 +10,7 @@ import (
 type OrderStatus string
 
 const (
+	OrderStatusScheduled  OrderStatus = "scheduled"
 	OrderStatusCreated    OrderStatus = "created"
 	OrderStatusAccepted   OrderStatus = "accepted"
 	OrderStatusPreparing  OrderStatus = "preparing"
@@ -19,47 +20,144 @@ const (
 	OrderStatusCancelled  OrderStatus = "cancelled"
 )
 
-// Order представляет заказ в системе
+// OrderStatusTransitions описывает допустимые переходы между статусами заказа
+// и роли, которым разрешено их выполнять. Статусы без исходящих переходов - терминальные.
+// Отложенный заказ переводится в created планировщиком или вручную диспетчером
+var OrderStatusTransitions = map[OrderStatus]map[OrderStatus][]Role{
+	OrderStatusScheduled: {
+		OrderStatusCreated:   {RoleSystem, RoleAdmin, RoleDispatcher},
+		OrderStatusCancelled: {RoleSystem, RoleAdmin, RoleDispatcher, RoleMerchant, RoleCustomer},
+	},
+	OrderStatusCreated: {
+		OrderStatusAccepted:  {RoleSystem, RoleAdmin, RoleDispatcher, RoleMerchant},
+		OrderStatusCancelled: {RoleSystem, RoleAdmin, RoleDispatcher, RoleMerchant, RoleCustomer},
+	},
+	OrderStatusAccepted: {
+		OrderStatusPreparing: {RoleSystem, RoleAdmin, RoleDispatcher, RoleMerchant},
+		OrderStatusCancelled: {RoleSystem, RoleAdmin, RoleDispatcher, RoleMerchant, RoleCustomer},
+	},
+	OrderStatusPreparing: {
+		OrderStatusReady:     {RoleSystem, RoleAdmin, RoleDispatcher, RoleMerchant},
+		OrderStatusCancelled: {RoleSystem, RoleAdmin, RoleDispatcher, RoleMerchant},
+	},
+	OrderStatusReady: {
+		OrderStatusInDelivery: {RoleSystem, RoleAdmin, RoleDispatcher, RoleCourier},
+		OrderStatusCancelled:  {RoleSystem, RoleAdmin, RoleDispatcher},
+	},
+	OrderStatusInDelivery: {
+		OrderStatusDelivered: {RoleSystem, RoleAdmin, RoleDispatcher, RoleCourier},
+		OrderStatusCancelled: {RoleSystem, RoleAdmin, RoleDispatcher},
+	},
+	OrderStatusDelivered: {},
+	OrderStatusCancelled: {},
+}
+
+// IsValid проверяет, что статус заказа известен системе
+func (s OrderStatus) IsValid() bool {
+	_, exists := OrderStatusTransitions[s]
+	return exists
+}
+
+// IsTerminal сообщает, что заказ в этом статусе завершён и больше не меняется
+func (s OrderStatus) IsTerminal() bool {
+	transitions, exists := OrderStatusTransitions[s]
+	return exists && len(transitions) == 0
+}
+
+// CanTransitionTo проверяет, может ли указанная роль перевести заказ из текущего статуса в статус to
+func (s OrderStatus) CanTransitionTo(to OrderStatus, role Role) bool {
+	for _, allowed := range OrderStatusTransitions[s][to] {
+		if allowed == role {
+			return true
+		}
+	}
+	return false
+}
+
+// Order представляет заказ в системе. CustomerID - клиент, определённый по телефону заказа,
+// MerchantID - продавец, у которого забирается заказ. ETA - прогноз времени доставки, SLADeadline - срок доставки
+// по SLA зоны заказа. У отложенного заказа ScheduledFor - время, к которому его нужно доставить,
+// DispatchAt - время передачи заказа в работу. Заказ продавца готовится PrepMinutes минут к ReadyAt
+// (после готовности - фактическое время), курьер для него ищется в CourierDispatchAt
 type Order struct {
-	ID              uuid.UUID   `json:"id" db:"id"`
-	CustomerName    string      `json:"customer_name" db:"customer_name"`
-	CustomerPhone   string      `json:"customer_phone" db:"customer_phone"`
-	PickupAddress   string      `json:"pickup_address" db:"pickup_address"`
-	DeliveryAddress string      `json:"delivery_address" db:"delivery_address"`
-	Items           []OrderItem `json:"items"`
-	TotalAmount     float64     `json:"total_amount" db:"total_amount"`
-	DeliveryCost    float64     `json:"delivery_cost" db:"delivery_cost"`
-	Status          OrderStatus `json:"status" db:"status"`
-	CourierID       *uuid.UUID  `json:"courier_id,omitempty" db:"courier_id"`
-	CreatedAt       time.Time   `json:"created_at" db:"created_at"`
-	UpdatedAt       time.Time   `json:"updated_at" db:"updated_at"`
-	DeliveredAt     *time.Time  `json:"delivered_at,omitempty" db:"delivered_at"`
+	ID                uuid.UUID          `json:"id" db:"id"`
+	CustomerID        *uuid.UUID         `json:"customer_id,omitempty" db:"customer_id"`
+	MerchantID        *uuid.UUID         `json:"merchant_id,omitempty" db:"merchant_id"`
+	CustomerName      string             `json:"customer_name" db:"customer_name"`
+	CustomerPhone     string             `json:"customer_phone" db:"customer_phone"`
+	PickupAddress     string             `json:"pickup_address" db:"pickup_address"`
+	DeliveryAddress   string             `json:"delivery_address" db:"delivery_address"`
+	Items             []OrderItem        `json:"items"`
+	TotalAmount       float64            `json:"total_amount" db:"total_amount"`
+	DeliveryCost      float64            `json:"delivery_cost" db:"delivery_cost"`
+	DeliveryBreakdown *DeliveryBreakdown `json:"delivery_breakdown,omitempty" db:"delivery_breakdown"`
+	Zone              *string            `json:"zone,omitempty" db:"zone"`
+	PromoCode         *string            `json:"promo_code,omitempty" db:"promo_code"`
+	DiscountAmount    float64            `json:"discount_amount" db:"discount_amount"`
+	Status            OrderStatus        `json:"status" db:"status"`
+	CourierID         *uuid.UUID         `json:"courier_id,omitempty" db:"courier_id"`
+	CreatedAt         time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at" db:"updated_at"`
+	DeliveredAt       *time.Time         `json:"delivered_at,omitempty" db:"delivered_at"`
+	ETA               *time.Time         `json:"eta,omitempty" db:"eta"`
+	SLADeadline       *time.Time         `json:"sla_deadline,omitempty" db:"sla_deadline"`
+	ScheduledFor      *time.Time         `json:"scheduled_for,omitempty" db:"scheduled_for"`
+	DispatchAt        *time.Time         `json:"dispatch_at,omitempty" db:"dispatch_at"`
+	PrepMinutes       *int               `json:"prep_minutes,omitempty" db:"prep_minutes"`
+	ReadyAt           *time.Time         `json:"ready_at,omitempty" db:"ready_at"`
+	CourierDispatchAt *time.Time         `json:"courier_dispatch_at,omitempty" db:"courier_dispatch_at"`
+}
+
+// AwaitsCourier сообщает, что заказу можно назначить курьера. Заказ без продавца ждёт курьера сразу
+// после создания, заказ продавца - после того, как продавец принял его
+func (o *Order) AwaitsCourier() bool {
+	if o.CourierID != nil {
+		return false
+	}
+	if o.MerchantID == nil {
+		return o.Status == OrderStatusCreated
+	}
+	return o.Status == OrderStatusAccepted || o.Status == OrderStatusPreparing || o.Status == OrderStatusReady
 }
 
-// OrderItem представляет товар в заказе
+// OrderItem представляет товар в заказе. ProductID - товар из каталога продавца
 type OrderItem struct {
-	ID       uuid.UUID `json:"id" db:"id"`
-	OrderID  uuid.UUID `json:"order_id" db:"order_id"`
-	Name     string    `json:"name" db:"name"`
-	Quantity int       `json:"quantity" db:"quantity"`
-	Price    float64   `json:"price" db:"price"`
+	ID        uuid.UUID  `json:"id" db:"id"`
+	OrderID   uuid.UUID  `json:"order_id" db:"order_id"`
+	ProductID *uuid.UUID `json:"product_id,omitempty" db:"product_id"`
+	Name      string     `json:"name" db:"name"`
+	Quantity  int        `json:"quantity" db:"quantity"`
+	Price     float64    `json:"price" db:"price"`
 }
 
-// CreateOrderRequest представляет запрос на создание заказа
+// CreateOrderRequest представляет запрос на создание заказа. ScheduledFor - время, к которому нужно
+// доставить отложенный заказ; без него заказ передаётся в работу сразу. С CustomerID имя и телефон
+// можно не передавать - они берутся из клиента, а DeliveryAddressID заменяет адрес доставки
+// сохранённым адресом клиента. Заказ продавца MerchantID забирается с его точки получения, а товары
+// выбираются из каталога продавца по ProductID с ценами каталога
 type CreateOrderRequest struct {
-	CustomerName    string                   `json:"customer_name"`
-	CustomerPhone   string                   `json:"customer_phone"`
-	PickupAddress   string                   `json:"pickup_address"`
-	DeliveryAddress string                   `json:"delivery_address"`
-	DeliveryCost    *float64                 `json:"delivery_cost,omitempty"`
-	Items           []CreateOrderItemRequest `json:"items"`
+	CustomerID        *uuid.UUID               `json:"customer_id,omitempty"`
+	MerchantID        *uuid.UUID               `json:"merchant_id,omitempty"`
+	DeliveryAddressID *uuid.UUID               `json:"delivery_address_id,omitempty"`
+	CustomerName      string                   `json:"customer_name"`
+	CustomerPhone     string                   `json:"customer_phone"`
+	PickupAddress     string                   `json:"pickup_address"`
+	DeliveryAddress   string                   `json:"delivery_address"`
+	DeliveryCost      *float64                 `json:"delivery_cost,omitempty"`
+	Items             []CreateOrderItemRequest `json:"items"`
+	PromoCode         string                   `json:"promo_code,omitempty"`
+	AutoAssign        bool                     `json:"auto_assign,omitempty"`
+	ScheduledFor      *time.Time               `json:"scheduled_for,omitempty"`
 }
 
-// CreateOrderItemRequest представляет запрос на создание товара в заказе
+// CreateOrderItemRequest представляет запрос на создание товара в заказе. Для товара из каталога
+// название, цена и вес берутся из каталога
 type CreateOrderItemRequest struct {
-	Name     string  `json:"name"`
-	Quantity int     `json:"quantity"`
-	Price    float64 `json:"price"`
+	ProductID *uuid.UUID `json:"product_id,omitempty"`
+	Name      string     `json:"name"`
+	Quantity  int        `json:"quantity"`
+	Price     float64    `json:"price"`
+	WeightKg  *float64   `json:"weight_kg,omitempty"`
 }
 
 // UpdateOrderStatusRequest представляет запрос на обновление статуса заказа
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderEvent представляет неизменяемое событие из журнала заказа.
+// Payload зависит от типа события:
+//   - order.created - полное состояние заказа (Order)
+//   - courier.assigned, order.status_changed - изменение статуса (OrderStatusChangedEvent)
+//   - order.review_added - отзыв (Review)
+type OrderEvent struct {
+	ID        uuid.UUID       `json:"id"`
+	OrderID   uuid.UUID       `json:"order_id"`
+	Version   int             `json:"version"`
+	Type      EventType       `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	Actor     Actor           `json:"actor"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// OrderReplayResult представляет состояние заказа, восстановленное из журнала событий
+type OrderReplayResult struct {
+	Order           *Order `json:"order"`
+	Version         int    `json:"version"`
+	SnapshotVersion int    `json:"snapshot_version"`
+	EventsApplied   int    `json:"events_applied"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxMessage представляет событие, ожидающее публикации в Kafka.
+// Payload - сериализованное событие Event в том виде, в котором оно уйдёт в топик
+type OutboxMessage struct {
+	ID        uuid.UUID       `json:"id"`
+	Topic     string          `json:"topic"`
+	Key       string          `json:"key"`
+	EventType EventType       `json:"event_type"`
+	Payload   json.RawMessage `json:"payload"`
+	Attempts  int             `json:"attempts"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// OutboxMetrics представляет метрики outbox: глубину очереди и задержку публикации
+type OutboxMetrics struct {
+	Pending                 int     `json:"pending"`
+	Retrying                int     `json:"retrying"`
+	OldestPendingAgeSeconds float64 `json:"oldest_pending_age_seconds"`
+	Published               uint64  `json:"published"`
+	PublishErrors           uint64  `json:"publish_errors"`
+	LastPublishAgeSeconds   float64 `json:"last_publish_age_seconds"`
+	AvgPublishAgeSeconds    float64 `json:"avg_publish_age_seconds"`
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DiscountType представляет тип скидки промокода
+type DiscountType string
+
+const (
+	DiscountTypeFixed        DiscountType = "fixed"
+	DiscountTypePercent      DiscountType = "percent"
+	DiscountTypeFreeDelivery DiscountType = "free_delivery"
+)
+
+// IsValid проверяет, что тип скидки известен системе
+func (t DiscountType) IsValid() bool {
+	switch t {
+	case DiscountTypeFixed, DiscountTypePercent, DiscountTypeFreeDelivery:
+		return true
+	}
+	return false
+}
+
+// PromoCode представляет промокод
+type PromoCode struct {
+	ID                 uuid.UUID    `json:"id" db:"id"`
+	Code               string       `json:"code" db:"code"`
+	DiscountType       DiscountType `json:"discount_type" db:"discount_type"`
+	DiscountValue      float64      `json:"discount_value" db:"discount_value"`
+	MinOrderAmount     float64      `json:"min_order_amount" db:"min_order_amount"`
+	ValidFrom          *time.Time   `json:"valid_from,omitempty" db:"valid_from"`
+	ValidUntil         *time.Time   `json:"valid_until,omitempty" db:"valid_until"`
+	MaxUses            *int         `json:"max_uses,omitempty" db:"max_uses"`
+	MaxUsesPerCustomer *int         `json:"max_uses_per_customer,omitempty" db:"max_uses_per_customer"`
+	UsedCount          int          `json:"used_count" db:"used_count"`
+	IsActive           bool         `json:"is_active" db:"is_active"`
+	CreatedAt          time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// Discount рассчитывает размер скидки для заказа с указанной суммой товаров и стоимостью доставки.
+// Скидка не может превышать сумму, к которой она применяется
+func (p *PromoCode) Discount(totalAmount, deliveryCost float64) float64 {
+	switch p.DiscountType {
+	case DiscountTypeFixed:
+		if p.DiscountValue > totalAmount {
+			return totalAmount
+		}
+		return p.DiscountValue
+	case DiscountTypePercent:
+		return totalAmount * p.DiscountValue / 100
+	case DiscountTypeFreeDelivery:
+		return deliveryCost
+	}
+	return 0
+}
+
+// PromoCodeRequest представляет запрос на создание или обновление промокода
+type PromoCodeRequest struct {
+	Code               string       `json:"code"`
+	DiscountType       DiscountType `json:"discount_type"`
+	DiscountValue      float64      `json:"discount_value"`
+	MinOrderAmount     float64      `json:"min_order_amount"`
+	ValidFrom          *time.Time   `json:"valid_from,omitempty"`
+	ValidUntil         *time.Time   `json:"valid_until,omitempty"`
+	MaxUses            *int         `json:"max_uses,omitempty"`
+	MaxUsesPerCustomer *int         `json:"max_uses_per_customer,omitempty"`
+	IsActive           *bool        `json:"is_active,omitempty"`
+}
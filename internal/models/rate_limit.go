@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// RateLimitTier представляет тарифный план ограничения частоты запросов
+type RateLimitTier string
+
+const (
+	RateLimitTierDefault RateLimitTier = "default"
+	RateLimitTierVIP     RateLimitTier = "vip"
+)
+
+// IsValid проверяет, что тарифный план известен системе
+func (t RateLimitTier) IsValid() bool {
+	return t == RateLimitTierDefault || t == RateLimitTierVIP
+}
+
+// RateLimitClient представляет данные запроса, по которым определяется клиент:
+// аутентифицированный API-ключ с его тарифным планом (приоритетно) или IP-адрес
+type RateLimitClient struct {
+	APIKeyID     string
+	Tier         RateLimitTier
+	RemoteAddr   string
+	ForwardedFor string
+}
+
+// RateLimitStatus представляет результат проверки лимита запросов клиента.
+// ResetAt - момент, когда освободится место в скользящем окне или закончится блокировка
+type RateLimitStatus struct {
+	Client     string        `json:"client"`
+	Tier       RateLimitTier `json:"tier"`
+	Limit      int           `json:"limit"`
+	Remaining  int           `json:"remaining"`
+	Window     int           `json:"window_seconds"`
+	ResetAt    time.Time     `json:"reset_at"`
+	Allowed    bool          `json:"-"`
+	Banned     bool          `json:"banned"`
+	RetryAfter int           `json:"retry_after_seconds,omitempty"`
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShiftStatus представляет статус смены курьера
+type ShiftStatus string
+
+const (
+	ShiftStatusScheduled ShiftStatus = "scheduled"
+	ShiftStatusActive    ShiftStatus = "active"
+	ShiftStatusCompleted ShiftStatus = "completed"
+	ShiftStatusCancelled ShiftStatus = "cancelled"
+	ShiftStatusMissed    ShiftStatus = "missed"
+)
+
+// ShiftBreak представляет перерыв в смене. У незавершённого перерыва нет EndedAt
+type ShiftBreak struct {
+	ID        uuid.UUID  `json:"id" db:"id"`
+	StartedAt time.Time  `json:"started_at" db:"started_at"`
+	EndedAt   *time.Time `json:"ended_at,omitempty" db:"ended_at"`
+}
+
+// ShiftStats представляет итоги смены для расчёта оплаты и планирования.
+// OnlineSeconds - время смены без перерывов, DistanceMeters - путь по истории координат курьера
+type ShiftStats struct {
+	OrdersDelivered int     `json:"orders_delivered" db:"orders_delivered"`
+	DistanceMeters  float64 `json:"distance_m" db:"distance_m"`
+	OnlineSeconds   int64   `json:"online_seconds" db:"online_seconds"`
+	BreakSeconds    int64   `json:"break_seconds" db:"break_seconds"`
+}
+
+// CourierShift представляет смену курьера. Для активной смены статистика рассчитывается
+// на момент запроса, для завершённой - хранится зафиксированной
+type CourierShift struct {
+	ID             uuid.UUID    `json:"id" db:"id"`
+	CourierID      uuid.UUID    `json:"courier_id" db:"courier_id"`
+	Status         ShiftStatus  `json:"status" db:"status"`
+	ScheduledStart *time.Time   `json:"scheduled_start,omitempty" db:"scheduled_start"`
+	ScheduledEnd   *time.Time   `json:"scheduled_end,omitempty" db:"scheduled_end"`
+	StartedAt      *time.Time   `json:"started_at,omitempty" db:"started_at"`
+	EndedAt        *time.Time   `json:"ended_at,omitempty" db:"ended_at"`
+	Breaks         []ShiftBreak `json:"breaks"`
+	Stats          *ShiftStats  `json:"stats,omitempty"`
+	CreatedAt      time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// OnBreak проверяет, находится ли курьер на перерыве
+func (s *CourierShift) OnBreak() bool {
+	for _, b := range s.Breaks {
+		if b.EndedAt == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ScheduleShiftRequest представляет запрос на планирование смены курьера
+type ScheduleShiftRequest struct {
+	ScheduledStart time.Time `json:"scheduled_start"`
+	ScheduledEnd   time.Time `json:"scheduled_end"`
+}
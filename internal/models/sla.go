@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AtRiskOrder представляет активный заказ, срок доставки которого нарушен или под угрозой нарушения.
+// MinutesLeft - минут до срока (отрицательное значение - срок прошёл), DelayMinutes - на сколько минут
+// прогноз доставки превышает срок
+type AtRiskOrder struct {
+	OrderID      uuid.UUID   `json:"order_id"`
+	Status       OrderStatus `json:"status"`
+	CourierID    *uuid.UUID  `json:"courier_id,omitempty"`
+	Zone         *string     `json:"zone,omitempty"`
+	ETA          *time.Time  `json:"eta,omitempty"`
+	SLADeadline  time.Time   `json:"sla_deadline"`
+	MinutesLeft  float64     `json:"minutes_left"`
+	DelayMinutes float64     `json:"delay_minutes"`
+	Breached     bool        `json:"breached"`
+	BreachedAt   *time.Time  `json:"breached_at,omitempty"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// SurgeMultiplier представляет текущий динамический коэффициент стоимости доставки зоны.
+// Пустая зона соответствует заказам, созданным без зоны
+type SurgeMultiplier struct {
+	Zone              string    `json:"zone" db:"zone"`
+	Multiplier        float64   `json:"multiplier" db:"multiplier"`
+	TargetMultiplier  float64   `json:"target_multiplier" db:"target_multiplier"`
+	OpenOrders        int       `json:"open_orders" db:"open_orders"`
+	AvailableCouriers int       `json:"available_couriers" db:"available_couriers"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,216 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PriceLineType представляет тип строки детализации стоимости доставки
+type PriceLineType string
+
+const (
+	PriceLineBaseFee           PriceLineType = "base_fee"
+	PriceLineDistance          PriceLineType = "distance"
+	PriceLineWeightSurcharge   PriceLineType = "weight_surcharge"
+	PriceLineItemSurcharge     PriceLineType = "item_count_surcharge"
+	PriceLineNightMultiplier   PriceLineType = "night_multiplier"
+	PriceLineWeekendMultiplier PriceLineType = "weekend_multiplier"
+	PriceLineSurgeMultiplier   PriceLineType = "surge_multiplier"
+	PriceLineMinPrice          PriceLineType = "min_price"
+	PriceLineMaxPrice          PriceLineType = "max_price"
+)
+
+// DistanceBand представляет ставку за километр на участке маршрута до UpToKm.
+// Участок без UpToKm действует до конца маршрута и должен быть последним
+type DistanceBand struct {
+	UpToKm *float64 `json:"up_to_km,omitempty"`
+	PerKm  float64  `json:"per_km"`
+}
+
+// Surcharge представляет надбавку, применяемую, когда показатель заказа превышает порог Over.
+// Из нескольких подходящих надбавок применяется одна - с наибольшим порогом
+type Surcharge struct {
+	Over   float64 `json:"over"`
+	Amount float64 `json:"amount"`
+}
+
+// Tariff представляет версию тарифа доставки
+type Tariff struct {
+	ID                  uuid.UUID      `json:"id" db:"id"`
+	Code                string         `json:"code" db:"code"`
+	Version             int            `json:"version" db:"version"`
+	Zone                *string        `json:"zone,omitempty" db:"zone"`
+	BaseFee             float64        `json:"base_fee" db:"base_fee"`
+	DistanceBands       []DistanceBand `json:"distance_bands" db:"distance_bands"`
+	MinPrice            *float64       `json:"min_price,omitempty" db:"min_price"`
+	MaxPrice            *float64       `json:"max_price,omitempty" db:"max_price"`
+	WeightSurcharges    []Surcharge    `json:"weight_surcharges" db:"weight_surcharges"`
+	ItemCountSurcharges []Surcharge    `json:"item_count_surcharges" db:"item_count_surcharges"`
+	NightMultiplier     float64        `json:"night_multiplier" db:"night_multiplier"`
+	NightStartHour      int            `json:"night_start_hour" db:"night_start_hour"`
+	NightEndHour        int            `json:"night_end_hour" db:"night_end_hour"`
+	WeekendMultiplier   float64        `json:"weekend_multiplier" db:"weekend_multiplier"`
+	EffectiveFrom       time.Time      `json:"effective_from" db:"effective_from"`
+	EffectiveTo         *time.Time     `json:"effective_to,omitempty" db:"effective_to"`
+	CreatedAt           time.Time      `json:"created_at" db:"created_at"`
+}
+
+// IsNight проверяет, попадает ли время в ночной интервал тарифа.
+// Интервал может переходить через полночь, например с 23 до 6
+func (t *Tariff) IsNight(at time.Time) bool {
+	hour := at.Hour()
+	switch {
+	case t.NightStartHour == t.NightEndHour:
+		return false
+	case t.NightStartHour < t.NightEndHour:
+		return hour >= t.NightStartHour && hour < t.NightEndHour
+	default:
+		return hour >= t.NightStartHour || hour < t.NightEndHour
+	}
+}
+
+// Price рассчитывает стоимость доставки с детализацией по строкам. Время at должно быть приведено
+// к часовому поясу, в котором заданы ночные часы тарифа, surge - динамический коэффициент зоны
+func (t *Tariff) Price(distanceMeters float64, itemCount int, weightKg float64, at time.Time, surge float64) *DeliveryBreakdown {
+	breakdown := &DeliveryBreakdown{
+		TariffID:        t.ID,
+		TariffCode:      t.Code,
+		TariffVersion:   t.Version,
+		Zone:            t.Zone,
+		DistanceMeters:  distanceMeters,
+		ItemCount:       itemCount,
+		WeightKg:        weightKg,
+		SurgeMultiplier: surge,
+		CalculatedAt:    at,
+	}
+
+	if t.BaseFee > 0 {
+		breakdown.add(PriceLineBaseFee, "Base fee", t.BaseFee)
+	}
+
+	distanceKm := distanceMeters / 1000
+	prevKm := 0.0
+	for _, band := range t.DistanceBands {
+		upToKm := math.Inf(1)
+		if band.UpToKm != nil {
+			upToKm = *band.UpToKm
+		}
+		segment := math.Min(distanceKm, upToKm) - prevKm
+		if segment <= 0 {
+			break
+		}
+		breakdown.add(PriceLineDistance, fmt.Sprintf("%.2f km x %.2f", segment, band.PerKm), segment*band.PerKm)
+		prevKm = upToKm
+	}
+
+	if s := matchSurcharge(t.WeightSurcharges, weightKg); s != nil {
+		breakdown.add(PriceLineWeightSurcharge, fmt.Sprintf("Weight over %g kg", s.Over), s.Amount)
+	}
+	if s := matchSurcharge(t.ItemCountSurcharges, float64(itemCount)); s != nil {
+		breakdown.add(PriceLineItemSurcharge, fmt.Sprintf("More than %g items", s.Over), s.Amount)
+	}
+
+	// Множители применяются к сумме предыдущих строк и отражаются отдельной строкой надбавки
+	if t.NightMultiplier != 1 && t.IsNight(at) {
+		breakdown.add(PriceLineNightMultiplier, fmt.Sprintf("Night rate x%g", t.NightMultiplier),
+			breakdown.Total*(t.NightMultiplier-1))
+	}
+	if weekday := at.Weekday(); t.WeekendMultiplier != 1 && (weekday == time.Saturday || weekday == time.Sunday) {
+		breakdown.add(PriceLineWeekendMultiplier, fmt.Sprintf("Weekend rate x%g", t.WeekendMultiplier),
+			breakdown.Total*(t.WeekendMultiplier-1))
+	}
+	if surge > 1 {
+		breakdown.add(PriceLineSurgeMultiplier, fmt.Sprintf("High demand x%g", surge), breakdown.Total*(surge-1))
+	}
+
+	if t.MinPrice != nil && breakdown.Total < *t.MinPrice {
+		breakdown.add(PriceLineMinPrice, "Minimum price", *t.MinPrice-breakdown.Total)
+	}
+	if t.MaxPrice != nil && breakdown.Total > *t.MaxPrice {
+		breakdown.add(PriceLineMaxPrice, "Maximum price", *t.MaxPrice-breakdown.Total)
+	}
+
+	return breakdown
+}
+
+// matchSurcharge возвращает надбавку с наибольшим порогом, который превышает значение
+func matchSurcharge(surcharges []Surcharge, value float64) *Surcharge {
+	var matched *Surcharge
+	for i := range surcharges {
+		if value > surcharges[i].Over && (matched == nil || surcharges[i].Over > matched.Over) {
+			matched = &surcharges[i]
+		}
+	}
+	return matched
+}
+
+// TariffRequest представляет запрос на создание новой версии тарифа
+type TariffRequest struct {
+	Code                string         `json:"code"`
+	Zone                *string        `json:"zone,omitempty"`
+	BaseFee             float64        `json:"base_fee"`
+	DistanceBands       []DistanceBand `json:"distance_bands"`
+	MinPrice            *float64       `json:"min_price,omitempty"`
+	MaxPrice            *float64       `json:"max_price,omitempty"`
+	WeightSurcharges    []Surcharge    `json:"weight_surcharges,omitempty"`
+	ItemCountSurcharges []Surcharge    `json:"item_count_surcharges,omitempty"`
+	NightMultiplier     *float64       `json:"night_multiplier,omitempty"`
+	NightStartHour      *int           `json:"night_start_hour,omitempty"`
+	NightEndHour        *int           `json:"night_end_hour,omitempty"`
+	WeekendMultiplier   *float64       `json:"weekend_multiplier,omitempty"`
+	EffectiveFrom       *time.Time     `json:"effective_from,omitempty"`
+	EffectiveTo         *time.Time     `json:"effective_to,omitempty"`
+}
+
+// PriceLine представляет строку детализации стоимости доставки
+type PriceLine struct {
+	Type        PriceLineType `json:"type"`
+	Description string        `json:"description"`
+	Amount      float64       `json:"amount"`
+}
+
+// DeliveryBreakdown представляет детализированный расчёт стоимости доставки
+type DeliveryBreakdown struct {
+	TariffID        uuid.UUID   `json:"tariff_id"`
+	TariffCode      string      `json:"tariff_code"`
+	TariffVersion   int         `json:"tariff_version"`
+	Zone            *string     `json:"zone,omitempty"`
+	DistanceMeters  float64     `json:"distance_m"`
+	ItemCount       int         `json:"item_count"`
+	WeightKg        float64     `json:"weight_kg"`
+	SurgeMultiplier float64     `json:"surge_multiplier"`
+	Lines           []PriceLine `json:"lines"`
+	Total           float64     `json:"total"`
+	CalculatedAt    time.Time   `json:"calculated_at"`
+}
+
+// add добавляет строку детализации, округляя сумму до копеек
+func (b *DeliveryBreakdown) add(lineType PriceLineType, description string, amount float64) {
+	amount = math.Round(amount*100) / 100
+	b.Lines = append(b.Lines, PriceLine{Type: lineType, Description: description, Amount: amount})
+	b.Total = math.Round((b.Total+amount)*100) / 100
+}
+
+// DeliveryQuoteRequest представляет запрос на расчёт стоимости доставки без создания заказа
+type DeliveryQuoteRequest struct {
+	PickupAddress   string                   `json:"pickup_address"`
+	DeliveryAddress string                   `json:"delivery_address"`
+	Items           []CreateOrderItemRequest `json:"items,omitempty"`
+	At              *time.Time               `json:"at,omitempty"`
+}
+
+// ItemsSummary возвращает общее количество товаров и их суммарный вес
+func ItemsSummary(items []CreateOrderItemRequest) (int, float64) {
+	count := 0
+	weight := 0.0
+	for _, item := range items {
+		count += item.Quantity
+		if item.WeightKg != nil {
+			weight += *item.WeightKg * float64(item.Quantity)
+		}
+	}
+	return count, weight
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GeoJSONPolygon представляет геометрию GeoJSON Polygon. Первое кольцо - внешняя граница,
+// остальные - вырезы. Каждое кольцо замкнуто: последняя точка совпадает с первой
+type GeoJSONPolygon struct {
+	Type        string         `json:"type"`
+	Coordinates [][][2]float64 `json:"coordinates"`
+}
+
+// Contains проверяет, лежит ли точка внутри внешней границы и вне вырезов полигона
+func (p *GeoJSONPolygon) Contains(lng, lat float64) bool {
+	if len(p.Coordinates) == 0 || !ringContains(p.Coordinates[0], lng, lat) {
+		return false
+	}
+	for _, hole := range p.Coordinates[1:] {
+		if ringContains(hole, lng, lat) {
+			return false
+		}
+	}
+	return true
+}
+
+// Bounds возвращает ограничивающий прямоугольник внешней границы полигона
+func (p *GeoJSONPolygon) Bounds() (minLng, minLat, maxLng, maxLat float64) {
+	minLng, minLat = math.Inf(1), math.Inf(1)
+	maxLng, maxLat = math.Inf(-1), math.Inf(-1)
+	if len(p.Coordinates) == 0 {
+		return 0, 0, 0, 0
+	}
+	for _, point := range p.Coordinates[0] {
+		minLng = math.Min(minLng, point[0])
+		maxLng = math.Max(maxLng, point[0])
+		minLat = math.Min(minLat, point[1])
+		maxLat = math.Max(maxLat, point[1])
+	}
+	return minLng, minLat, maxLng, maxLat
+}
+
+// ringContains проверяет попадание точки в кольцо методом трассировки луча
+func ringContains(ring [][2]float64, lng, lat float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > lat) != (yj > lat) && lng < (xj-xi)*(lat-yi)/(yj-yi)+xi {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// DeliveryZone представляет зону доставки. MaxRouteDistance ограничивает длину маршрута
+// заказов из зоны и заменяет общее ограничение из настроек, SLAMinutes - срок доставки заказов зоны
+// в минутах от создания заказа
+type DeliveryZone struct {
+	ID               uuid.UUID       `json:"id" db:"id"`
+	Code             string          `json:"code" db:"code"`
+	Name             string          `json:"name" db:"name"`
+	Polygon          *GeoJSONPolygon `json:"polygon" db:"polygon"`
+	MaxRouteDistance *int            `json:"max_route_distance_m,omitempty" db:"max_route_distance_m"`
+	SLAMinutes       *int            `json:"sla_minutes,omitempty" db:"sla_minutes"`
+	IsActive         bool            `json:"is_active" db:"is_active"`
+	CreatedAt        time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// DeliveryZoneRequest представляет запрос на создание или обновление зоны доставки.
+// Код зоны задаётся только при создании
+type DeliveryZoneRequest struct {
+	Code             string          `json:"code"`
+	Name             string          `json:"name"`
+	Polygon          *GeoJSONPolygon `json:"polygon"`
+	MaxRouteDistance *int            `json:"max_route_distance_m,omitempty"`
+	SLAMinutes       *int            `json:"sla_minutes,omitempty"`
+	IsActive         *bool           `json:"is_active,omitempty"`
+}
@@ -0,0 +1,47 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// GeoMember - участник GEO-набора с расстоянием до точки поиска в метрах
+type GeoMember struct {
+	Name           string
+	DistanceMeters float64
+}
+
+// GeoAdd сохраняет координаты участника в GEO-наборе
+func (c *Client) GeoAdd(ctx context.Context, key, member string, lon, lat float64) error {
+	err := c.client.GeoAdd(ctx, key, &redis.GeoLocation{Name: member, Longitude: lon, Latitude: lat}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to add %s to geo set %s: %w", member, key, err)
+	}
+	return nil
+}
+
+// GeoSearch возвращает участников GEO-набора в радиусе radiusMeters от точки,
+// отсортированных по возрастанию расстояния
+func (c *Client) GeoSearch(ctx context.Context, key string, lon, lat, radiusMeters float64) ([]GeoMember, error) {
+	locations, err := c.client.GeoSearchLocation(ctx, key, &redis.GeoSearchLocationQuery{
+		GeoSearchQuery: redis.GeoSearchQuery{
+			Longitude:  lon,
+			Latitude:   lat,
+			Radius:     radiusMeters,
+			RadiusUnit: "m",
+			Sort:       "ASC",
+		},
+		WithDist: true,
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to search geo set %s: %w", key, err)
+	}
+
+	members := make([]GeoMember, 0, len(locations))
+	for _, location := range locations {
+		members = append(members, GeoMember{Name: location.Name, DistanceMeters: location.Dist})
+	}
+	return members, nil
+}
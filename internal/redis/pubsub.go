@@ -0,0 +1,50 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+)
+
+// Publish публикует сообщение в канал Redis Pub/Sub
+func (c *Client) Publish(ctx context.Context, channel string, message []byte) error {
+	if err := c.client.Publish(ctx, channel, message).Err(); err != nil {
+		return fmt.Errorf("failed to publish to channel %s: %w", channel, err)
+	}
+	return nil
+}
+
+// Subscribe подписывается на канал Redis Pub/Sub. Канал сообщений закрывается
+// после отмены контекста, подписка при этом снимается
+func (c *Client) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	pubsub := c.client.Subscribe(ctx, channel)
+	// Дожидаемся подтверждения подписки, чтобы не потерять сообщения, опубликованные сразу после неё
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to channel %s: %w", channel, err)
+	}
+
+	messages := make(chan []byte)
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+
+		source := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-source:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- []byte(msg.Payload):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return messages, nil
+}
@@ -0,0 +1,132 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+// slidingWindowScript атомарно проверяет блокировку клиента, удаляет устаревшие запросы из окна
+// и либо учитывает новый запрос, либо засчитывает нарушение. После banThreshold нарушений
+// в пределах окна клиент блокируется на banDuration. Время берётся из Redis, поэтому окно одинаково
+// для всех инстансов сервиса.
+//
+// KEYS: окно запросов (ZSET), счётчик нарушений, флаг блокировки
+// ARGV: размер окна (мс), лимит, идентификатор запроса, порог блокировки, длительность блокировки (мс)
+// Результат: {разрешён (0/1), осталось запросов, мс до сброса, заблокирован (0/1)}
+var slidingWindowScript = redis.NewScript(`
+redis.replicate_commands()
+local ban_ttl = redis.call('PTTL', KEYS[3])
+if ban_ttl > 0 then
+	return {0, 0, ban_ttl, 1}
+end
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+
+if count < limit then
+	redis.call('ZADD', KEYS[1], now, ARGV[3])
+	redis.call('PEXPIRE', KEYS[1], window)
+	local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+	return {1, limit - count - 1, tonumber(oldest[2]) + window - now, 0}
+end
+
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+local retry = window
+if oldest[2] then
+	retry = tonumber(oldest[2]) + window - now
+end
+
+local violations = redis.call('INCR', KEYS[2])
+redis.call('PEXPIRE', KEYS[2], window)
+if violations >= tonumber(ARGV[4]) then
+	redis.call('SET', KEYS[3], 1, 'PX', ARGV[5])
+	redis.call('DEL', KEYS[2])
+	return {0, 0, tonumber(ARGV[5]), 1}
+end
+
+return {0, 0, retry, 0}
+`)
+
+// slidingWindowStatusScript возвращает состояние окна без учёта нового запроса.
+// Результат: {осталось запросов, мс до сброса, заблокирован (0/1)}
+var slidingWindowStatusScript = redis.NewScript(`
+local ban_ttl = redis.call('PTTL', KEYS[3])
+if ban_ttl > 0 then
+	return {0, ban_ttl, 1}
+end
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+
+local count = redis.call('ZCOUNT', KEYS[1], '(' .. (now - window), '+inf')
+local reset = 0
+local oldest = redis.call('ZRANGEBYSCORE', KEYS[1], '(' .. (now - window), '+inf', 'WITHSCORES', 'LIMIT', 0, 1)
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+return {math.max(limit - count, 0), reset, 0}
+`)
+
+// SlidingWindowResult представляет результат проверки скользящего окна
+type SlidingWindowResult struct {
+	Allowed   bool
+	Remaining int
+	ResetIn   time.Duration
+	Banned    bool
+}
+
+// SlidingWindowAllow учитывает запрос клиента в скользящем окне, если лимит не исчерпан
+func (c *Client) SlidingWindowAllow(ctx context.Context, client string, limit int, window time.Duration,
+	banThreshold int, banDuration time.Duration) (*SlidingWindowResult, error) {
+	values, err := slidingWindowScript.Run(ctx, c.client, rateLimitKeys(client),
+		window.Milliseconds(), limit, uuid.New().String(), banThreshold, banDuration.Milliseconds()).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run rate limit script: %w", err)
+	}
+
+	return &SlidingWindowResult{
+		Allowed:   values[0] == 1,
+		Remaining: int(values[1]),
+		ResetIn:   time.Duration(values[2]) * time.Millisecond,
+		Banned:    values[3] == 1,
+	}, nil
+}
+
+// SlidingWindowStatus возвращает оставшийся лимит клиента, не учитывая запрос
+func (c *Client) SlidingWindowStatus(ctx context.Context, client string, limit int, window time.Duration) (*SlidingWindowResult, error) {
+	values, err := slidingWindowStatusScript.Run(ctx, c.client, rateLimitKeys(client),
+		window.Milliseconds(), limit).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run rate limit status script: %w", err)
+	}
+
+	return &SlidingWindowResult{
+		Allowed:   values[0] > 0 && values[2] == 0,
+		Remaining: int(values[0]),
+		ResetIn:   time.Duration(values[1]) * time.Millisecond,
+		Banned:    values[2] == 1,
+	}, nil
+}
+
+// rateLimitKeys возвращает ключи окна, счётчика нарушений и блокировки клиента.
+// Hash tag {client} размещает все ключи клиента в одном слоте Redis Cluster
+func rateLimitKeys(client string) []string {
+	tag := "{" + client + "}"
+	return []string{
+		GenerateKey(KeyPrefixRateLimit, tag+":window"),
+		GenerateKey(KeyPrefixRateLimit, tag+":violations"),
+		GenerateKey(KeyPrefixRateLimit, tag+":ban"),
+	}
+}
@@ -106,6 +106,19 @@ func (c *Client) Exists(ctx context.Context, key string) (bool, error) {
 	return exists > 0, nil
 }
 
+// Incr увеличивает счётчик на единицу и продлевает его TTL. Значение счётчика хранится числом,
+// а не в JSON, и читается через GetMultiple
+func (c *Client) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	pipe := c.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, ttl)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to increment key %s: %w", key, err)
+	}
+	return incr.Val(), nil
+}
+
 // SetMultiple устанавливает несколько значений за одну операцию
 func (c *Client) SetMultiple(ctx context.Context, values map[string]interface{}, ttl time.Duration) error {
 	pipe := c.client.Pipeline()
@@ -157,8 +170,12 @@ func (c *Client) Health(ctx context.Context) error {
 
 // Загрузка в кеш активных заказов
 func (c *Client) CacheWarmingOrders(db *database.DB) error {
-	query := `SELECT * FROM orders WHERE status IN ($1, $2, $3, $4)
-`
+	query := `
+		SELECT id, customer_name, customer_phone, pickup_address, delivery_address, total_amount,
+		       delivery_cost, promo_code, discount_amount, status, courier_id, created_at, updated_at, delivered_at
+		FROM orders
+		WHERE status IN ($1, $2, $3, $4)
+	`
 	rows, err := db.Query(query, models.OrderStatusAccepted, models.OrderStatusPreparing,
 		models.OrderStatusReady, models.OrderStatusInDelivery)
 	if err != nil {
@@ -173,7 +190,8 @@ func (c *Client) CacheWarmingOrders(db *database.DB) error {
 		order := &models.Order{}
 		if err = rows.Scan(&order.ID, &order.CustomerName, &order.CustomerPhone,
 			&order.PickupAddress, &order.DeliveryAddress, &order.TotalAmount, &order.DeliveryCost,
-			&order.Status, &order.CourierID, &order.CreatedAt, &order.UpdatedAt, &order.DeliveredAt); err != nil {
+			&order.PromoCode, &order.DiscountAmount, &order.Status, &order.CourierID, &order.CreatedAt,
+			&order.UpdatedAt, &order.DeliveredAt); err != nil {
 			return fmt.Errorf("failed to scan orders: %w", err)
 		}
 		cacheKey := GenerateKey(KeyPrefixOrder, order.ID.String())
@@ -255,6 +273,13 @@ const (
 	KeyPrefixStats            = "stats"
 	KeyPrefixOrderGeolocation = "order_geolocation"
 	KeyPrefixReview           = "review"
+	KeyPrefixAnalytics        = "analytics"
+	KeyPrefixRateLimit        = "rate_limit"
+	KeyPrefixCourierLocation  = "courier_location"
+	KeyPrefixGeocode          = "geocode"
+	KeyPrefixRoute            = "route"
+	// KeyCourierLocations - GEO-набор с последними координатами курьеров
+	KeyCourierLocations = "courier_locations"
 )
 
 // Константы, используемые при "прогреве" кеша
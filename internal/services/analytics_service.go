@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/redis"
+)
+
+// AnalyticsService - сервис построения бизнес-отчётов по заказам и курьерам.
+// Результаты тяжёлых запросов кешируются в Redis по ключу, включающему параметры отчёта
+type AnalyticsService struct {
+	db          *database.DB
+	redisClient *redis.Client
+	log         *logger.Logger
+	cacheTTL    time.Duration
+}
+
+// NewAnalyticsService создаёт новый экземпляр сервиса аналитики
+func NewAnalyticsService(db *database.DB, redisClient *redis.Client, log *logger.Logger, cfg *config.AnalyticsConfig) *AnalyticsService {
+	return &AnalyticsService{
+		db:          db,
+		redisClient: redisClient,
+		log:         log,
+		cacheTTL:    time.Duration(cfg.CacheTTL) * time.Second,
+	}
+}
+
+// GetSummary возвращает выручку, количество заказов, среднее время доставки и долю отмен
+// за период с разбивкой по дням, неделям или месяцам
+func (s *AnalyticsService) GetSummary(filter models.AnalyticsFilter) (*models.SummaryReport, error) {
+	report := &models.SummaryReport{}
+	cacheKey := analyticsCacheKey("summary", filter)
+	if s.getCached(cacheKey, report) {
+		return report, nil
+	}
+
+	// GROUPING SETS возвращает строки по периодам и итоговую строку (period = NULL) одним запросом
+	query := `
+		SELECT date_trunc($3, created_at) AS period,
+		       COUNT(*),
+		       COUNT(*) FILTER (WHERE status = 'delivered'),
+		       COUNT(*) FILTER (WHERE status = 'cancelled'),
+		       COALESCE(SUM(total_amount + delivery_cost - discount_amount) FILTER (WHERE status = 'delivered'), 0),
+		       COALESCE(SUM(discount_amount) FILTER (WHERE status = 'delivered'), 0),
+		       COALESCE(AVG(EXTRACT(EPOCH FROM (delivered_at - created_at)) / 60) FILTER (WHERE delivered_at IS NOT NULL), 0)
+		FROM orders
+		WHERE created_at >= $1 AND created_at < $2
+		GROUP BY GROUPING SETS ((period), ())
+		ORDER BY period NULLS FIRST
+	`
+	rows, err := s.db.Query(query, filter.From, filter.To, string(filter.GroupBy))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orders summary: %w", err)
+	}
+	defer rows.Close()
+
+	report.Filter = filter
+	report.Periods = []models.SalesMetrics{}
+	for rows.Next() {
+		var metrics models.SalesMetrics
+		if err := rows.Scan(&metrics.Period, &metrics.OrdersCount, &metrics.DeliveredCount, &metrics.CancelledCount,
+			&metrics.Revenue, &metrics.DiscountAmount, &metrics.AvgDeliveryTimeMinutes); err != nil {
+			return nil, fmt.Errorf("failed to scan orders summary: %w", err)
+		}
+		if metrics.DeliveredCount > 0 {
+			metrics.AverageOrderValue = metrics.Revenue / float64(metrics.DeliveredCount)
+		}
+		if metrics.OrdersCount > 0 {
+			metrics.CancellationRate = float64(metrics.CancelledCount) / float64(metrics.OrdersCount)
+		}
+
+		if metrics.Period == nil {
+			report.Totals = metrics
+		} else {
+			report.Periods = append(report.Periods, metrics)
+		}
+	}
+
+	s.setCached(cacheKey, report)
+	return report, nil
+}
+
+// GetTopItems возвращает самые популярные товары по количеству проданных единиц.
+// Отменённые заказы не учитываются
+func (s *AnalyticsService) GetTopItems(filter models.AnalyticsFilter) (*models.TopItemsReport, error) {
+	report := &models.TopItemsReport{}
+	cacheKey := analyticsCacheKey("top_items", filter)
+	if s.getCached(cacheKey, report) {
+		return report, nil
+	}
+
+	query := `
+		SELECT oi.name, SUM(oi.quantity), SUM(oi.quantity * oi.price), COUNT(DISTINCT oi.order_id)
+		FROM order_items oi
+		JOIN orders o ON o.id = oi.order_id
+		WHERE o.created_at >= $1 AND o.created_at < $2 AND o.status <> 'cancelled'
+		GROUP BY oi.name
+		ORDER BY SUM(oi.quantity) DESC, SUM(oi.quantity * oi.price) DESC
+		LIMIT $3
+	`
+	rows, err := s.db.Query(query, filter.From, filter.To, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top items: %w", err)
+	}
+	defer rows.Close()
+
+	report.Filter = filter
+	report.Items = []models.TopItem{}
+	for rows.Next() {
+		var item models.TopItem
+		if err := rows.Scan(&item.Name, &item.Quantity, &item.Revenue, &item.OrdersCount); err != nil {
+			return nil, fmt.Errorf("failed to scan top item: %w", err)
+		}
+		report.Items = append(report.Items, item)
+	}
+
+	s.setCached(cacheKey, report)
+	return report, nil
+}
+
+// GetCourierReport возвращает количество доставок, заработок, среднее время доставки
+// и средний рейтинг курьеров по заказам, доставленным в указанный период
+func (s *AnalyticsService) GetCourierReport(filter models.AnalyticsFilter) (*models.CourierReport, error) {
+	report := &models.CourierReport{}
+	cacheKey := analyticsCacheKey("couriers", filter)
+	if s.getCached(cacheKey, report) {
+		return report, nil
+	}
+
+	query := `
+		SELECT c.id, c.name,
+		       COUNT(o.id),
+		       COALESCE(SUM(o.delivery_cost), 0),
+		       COALESCE(AVG(EXTRACT(EPOCH FROM (o.delivered_at - o.created_at)) / 60), 0),
+		       AVG(r.rating),
+		       COUNT(r.id)
+		FROM couriers c
+		JOIN orders o ON o.courier_id = c.id
+		     AND o.status = 'delivered' AND o.delivered_at >= $1 AND o.delivered_at < $2
+		LEFT JOIN reviews r ON r.order_id = o.id AND r.courier_id = c.id
+		GROUP BY c.id, c.name
+		ORDER BY COUNT(o.id) DESC, c.name
+	`
+	rows, err := s.db.Query(query, filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get courier report: %w", err)
+	}
+	defer rows.Close()
+
+	report.Filter = filter
+	report.Couriers = []models.CourierMetrics{}
+	for rows.Next() {
+		var metrics models.CourierMetrics
+		if err := rows.Scan(&metrics.CourierID, &metrics.Name, &metrics.Deliveries, &metrics.Earnings,
+			&metrics.AvgDeliveryTimeMinutes, &metrics.AvgRating, &metrics.ReviewsCount); err != nil {
+			return nil, fmt.Errorf("failed to scan courier metrics: %w", err)
+		}
+		report.Couriers = append(report.Couriers, metrics)
+	}
+
+	s.setCached(cacheKey, report)
+	return report, nil
+}
+
+// getCached пытается получить отчёт из кеша. Ошибки Redis не прерывают построение отчёта
+func (s *AnalyticsService) getCached(key string, dest interface{}) bool {
+	if err := s.redisClient.Get(context.Background(), key, dest); err != nil {
+		s.redisClient.Miss()
+		return false
+	}
+	s.redisClient.Hit()
+	s.log.WithField("key", key).Debug("Analytics report retrieved from cache")
+	return true
+}
+
+func (s *AnalyticsService) setCached(key string, value interface{}) {
+	if err := s.redisClient.Set(context.Background(), key, value, s.cacheTTL); err != nil {
+		s.log.WithError(err).WithField("key", key).Warn("Failed to cache analytics report")
+	}
+}
+
+// analyticsCacheKey формирует ключ кеша отчёта, например analytics:summary:1704067200:1706745600:day:0
+func analyticsCacheKey(report string, filter models.AnalyticsFilter) string {
+	return redis.GenerateKey(redis.KeyPrefixAnalytics, fmt.Sprintf("%s:%d:%d:%s:%d",
+		report, filter.From.Unix(), filter.To.Unix(), filter.GroupBy, filter.Limit))
+}
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/redis"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// apiKeyColumns - список колонок API-ключа в порядке сканирования scanAPIKey
+const apiKeyColumns = `id, name, key_prefix, scopes, owner_role, owner_id, rate_limit_tier, expires_at, last_used_at,
+		       rotated_at, revoked_at, created_at, updated_at`
+
+const (
+	// apiKeyPrefix - начало всех выпускаемых ключей, по нему ключ легко найти в конфигурации и логах
+	apiKeyPrefix = "dsk_"
+	// apiKeyVisibleLength - длина начала ключа, сохраняемого для отображения
+	apiKeyVisibleLength = len(apiKeyPrefix) + 8
+	// apiKeyLastUsedInterval - как часто обновляется время последнего использования ключа в БД
+	apiKeyLastUsedInterval = time.Minute
+	// apiKeyUsageDateLayout - формат суток в ключах счётчиков запросов
+	apiKeyUsageDateLayout = "2006-01-02"
+)
+
+// APIKeyService - сервис API-ключей машинных клиентов. Ключи хранятся в БД в виде хешей,
+// счётчики запросов по ключам по суткам - в Redis
+type APIKeyService struct {
+	db          *database.DB
+	redisClient *redis.Client
+	log         *logger.Logger
+	cfg         *config.APIKeyConfig
+}
+
+// NewAPIKeyService создаёт новый экземпляр сервиса API-ключей
+func NewAPIKeyService(db *database.DB, redisClient *redis.Client, log *logger.Logger, cfg *config.APIKeyConfig) *APIKeyService {
+	return &APIKeyService{
+		db:          db,
+		redisClient: redisClient,
+		log:         log,
+		cfg:         cfg,
+	}
+}
+
+// IssueAPIKey выпускает новый ключ. Ключ возвращается только в ответе, в БД сохраняется его хеш
+func (s *APIKeyService) IssueAPIKey(req *models.APIKeyRequest) (*models.IssuedAPIKey, error) {
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	tier := req.RateLimitTier
+	if tier == "" {
+		tier = models.RateLimitTierDefault
+	}
+
+	query := `
+		INSERT INTO api_keys (id, name, key_prefix, key_hash, scopes, owner_role, owner_id, rate_limit_tier, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + apiKeyColumns
+
+	apiKey, err := scanAPIKey(s.db.QueryRow(query, uuid.New(), req.Name, key[:apiKeyVisibleLength], hashAPIKey(key),
+		pq.Array(req.Scopes), req.OwnerRole, optionalString(req.OwnerID), tier, req.ExpiresAt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"api_key_id": apiKey.ID,
+		"owner":      apiKey.Owner.String(),
+		"scopes":     apiKey.Scopes,
+		"tier":       apiKey.RateLimitTier,
+	}).Info("API key issued")
+
+	return &models.IssuedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+// GetAPIKey получает ключ по ID
+func (s *APIKeyService) GetAPIKey(keyID uuid.UUID) (*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1`
+
+	apiKey, err := scanAPIKey(s.db.QueryRow(query, keyID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("api key not found")
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+// GetAPIKeys получает список ключей. Если owner задан, возвращаются только ключи этого владельца
+func (s *APIKeyService) GetAPIKeys(owner *models.Actor) ([]*models.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys`
+	var args []interface{}
+	if owner != nil {
+		query += ` WHERE owner_role = $1 AND COALESCE(owner_id, '') = $2`
+		args = append(args, owner.Role, owner.ID)
+	}
+	query += ` ORDER BY created_at DESC`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var apiKeys []*models.APIKey
+	for rows.Next() {
+		apiKey, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+
+	return apiKeys, nil
+}
+
+// RotateAPIKey перевыпускает ключ с теми же владельцем и областями действия. Прежний ключ принимается
+// ещё RotationGrace минут, чтобы клиент успел перейти на новый. Отозванный или истёкший ключ
+// не перевыпускается, возвращается ErrAPIKeyInactive
+func (s *APIKeyService) RotateAPIKey(keyID uuid.UUID) (*models.IssuedAPIKey, error) {
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var previousExpiresAt *time.Time
+	if s.cfg.RotationGrace > 0 {
+		graceEnd := time.Now().Add(time.Duration(s.cfg.RotationGrace) * time.Minute)
+		previousExpiresAt = &graceEnd
+	}
+
+	query := `
+		UPDATE api_keys
+		SET previous_key_hash = CASE WHEN $2::timestamptz IS NULL THEN NULL ELSE key_hash END,
+		    previous_key_expires_at = $2, key_hash = $3, key_prefix = $4, rotated_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())
+		RETURNING ` + apiKeyColumns
+
+	apiKey, err := scanAPIKey(s.db.QueryRow(query, keyID, previousExpiresAt, hashAPIKey(key), key[:apiKeyVisibleLength]))
+	if err != nil {
+		if err != sql.ErrNoRows {
+			return nil, fmt.Errorf("failed to rotate api key: %w", err)
+		}
+		// Ключ не обновлён: он либо не существует, либо уже не действует
+		if _, err = s.GetAPIKey(keyID); err != nil {
+			return nil, err
+		}
+		return nil, ErrAPIKeyInactive
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"api_key_id":    apiKey.ID,
+		"owner":         apiKey.Owner.String(),
+		"grace_minutes": s.cfg.RotationGrace,
+	}).Info("API key rotated")
+
+	return &models.IssuedAPIKey{APIKey: apiKey, Key: key}, nil
+}
+
+// RevokeAPIKey отзывает ключ. Прежний ключ после ротации перестаёт приниматься вместе с текущим.
+// Повторный отзыв не меняет время отзыва
+func (s *APIKeyService) RevokeAPIKey(keyID uuid.UUID) error {
+	query := `
+		UPDATE api_keys
+		SET revoked_at = COALESCE(revoked_at, NOW()), previous_key_hash = NULL, previous_key_expires_at = NULL
+		WHERE id = $1`
+
+	result, err := s.db.Exec(query, keyID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("api key not found")
+	}
+
+	s.log.WithField("api_key_id", keyID).Info("API key revoked")
+	return nil
+}
+
+// Authenticate находит действующий ключ и учитывает запрос по нему. Неизвестный, отозванный
+// или истёкший ключ возвращает ErrInvalidAPIKey
+func (s *APIKeyService) Authenticate(key string) (*models.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + ` FROM api_keys
+		WHERE key_hash = $1 OR (previous_key_hash = $1 AND previous_key_expires_at > NOW())`
+
+	apiKey, err := scanAPIKey(s.db.QueryRow(query, hashAPIKey(key)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: unknown key", ErrInvalidAPIKey)
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	now := time.Now()
+	if !apiKey.IsActive(now) {
+		return nil, fmt.Errorf("%w: key is revoked or expired", ErrInvalidAPIKey)
+	}
+
+	s.recordUsage(apiKey, now)
+	return apiKey, nil
+}
+
+// GetUsage возвращает количество запросов по ключу за последние days суток, включая текущие
+func (s *APIKeyService) GetUsage(keyID uuid.UUID, days int) (*models.APIKeyUsage, error) {
+	apiKey, err := s.GetAPIKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := time.Now().UTC()
+	dates := make([]string, 0, days)
+	keys := make([]string, 0, days)
+	for i := days - 1; i >= 0; i-- {
+		date := today.AddDate(0, 0, -i).Format(apiKeyUsageDateLayout)
+		dates = append(dates, date)
+		keys = append(keys, apiKeyUsageKey(keyID, date))
+	}
+
+	counters, err := s.redisClient.GetMultiple(context.Background(), keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key usage: %w", err)
+	}
+
+	usage := &models.APIKeyUsage{
+		KeyID:      keyID,
+		Days:       days,
+		LastUsedAt: apiKey.LastUsedAt,
+		Daily:      make([]models.APIKeyDailyUsage, 0, days),
+	}
+	for i, date := range dates {
+		var requests int64
+		if value, ok := counters[keys[i]]; ok {
+			if requests, err = strconv.ParseInt(value, 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid api key usage counter %s: %w", keys[i], err)
+			}
+		}
+		usage.Total += requests
+		usage.Daily = append(usage.Daily, models.APIKeyDailyUsage{Date: date, Requests: requests})
+	}
+
+	return usage, nil
+}
+
+// recordUsage увеличивает суточный счётчик запросов ключа и не чаще apiKeyLastUsedInterval обновляет
+// время последнего использования. Ошибки учёта не мешают выполнению запроса
+func (s *APIKeyService) recordUsage(apiKey *models.APIKey, now time.Time) {
+	ttl := time.Duration(s.cfg.UsageRetentionDays) * 24 * time.Hour
+	key := apiKeyUsageKey(apiKey.ID, now.UTC().Format(apiKeyUsageDateLayout))
+	if _, err := s.redisClient.Incr(context.Background(), key, ttl); err != nil {
+		s.log.WithError(err).WithField("api_key_id", apiKey.ID).Warn("Failed to record api key usage")
+	}
+
+	if apiKey.LastUsedAt != nil && now.Sub(*apiKey.LastUsedAt) < apiKeyLastUsedInterval {
+		return
+	}
+	if _, err := s.db.Exec(`UPDATE api_keys SET last_used_at = $2 WHERE id = $1`, apiKey.ID, now); err != nil {
+		s.log.WithError(err).WithField("api_key_id", apiKey.ID).Warn("Failed to update api key last used time")
+		return
+	}
+	apiKey.LastUsedAt = &now
+}
+
+// generateAPIKey генерирует новый ключ: префикс и 32 случайных байта в base64url
+func generateAPIKey() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashAPIKey возвращает SHA-256 хеш ключа. Ключ содержит 256 бит случайных данных,
+// поэтому медленная функция хеширования, как для паролей, не требуется
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func apiKeyUsageKey(keyID uuid.UUID, date string) string {
+	return fmt.Sprintf("api_key_usage:%s:%s", keyID, date)
+}
+
+func scanAPIKey(row rowScanner) (*models.APIKey, error) {
+	apiKey := &models.APIKey{}
+	var ownerID sql.NullString
+	err := row.Scan(&apiKey.ID, &apiKey.Name, &apiKey.Prefix, pq.Array(&apiKey.Scopes), &apiKey.Owner.Role, &ownerID,
+		&apiKey.RateLimitTier, &apiKey.ExpiresAt, &apiKey.LastUsedAt, &apiKey.RotatedAt, &apiKey.RevokedAt, &apiKey.CreatedAt, &apiKey.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	apiKey.Owner.ID = ownerID.String
+	return apiKey, nil
+}
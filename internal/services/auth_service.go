@@ -0,0 +1,338 @@
+package services
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/models"
+)
+
+// Алгоритмы подписи JWT
+const (
+	jwtAlgorithmHS256 = "HS256"
+	jwtAlgorithmRS256 = "RS256"
+)
+
+// jwtClockSkew - допустимое расхождение часов при проверке срока действия токена
+const jwtClockSkew = 30 * time.Second
+
+// jwtHeader представляет заголовок JWT
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ,omitempty"`
+}
+
+// AuthService - сервис аутентификации по JWT. Проверяет подпись и срок действия токена и определяет
+// по его claims инициатора запроса: роль из claim RoleClaim и идентификатор из sub
+type AuthService struct {
+	cfg        *config.AuthConfig
+	secret     []byte
+	publicKey  *rsa.PublicKey
+	privateKey *rsa.PrivateKey
+}
+
+// NewAuthService создаёт новый экземпляр сервиса аутентификации и загружает ключи выбранного алгоритма
+func NewAuthService(cfg *config.AuthConfig) (*AuthService, error) {
+	s := &AuthService{cfg: cfg}
+
+	switch cfg.Algorithm {
+	case jwtAlgorithmHS256:
+		if cfg.Secret == "" {
+			return nil, fmt.Errorf("JWT secret is required for %s", jwtAlgorithmHS256)
+		}
+		s.secret = []byte(cfg.Secret)
+	case jwtAlgorithmRS256:
+		if cfg.PrivateKeyFile != "" {
+			key, err := loadRSAPrivateKey(cfg.PrivateKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			s.privateKey = key
+			s.publicKey = &key.PublicKey
+		}
+		if cfg.PublicKeyFile != "" {
+			key, err := loadRSAPublicKey(cfg.PublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			s.publicKey = key
+		}
+		if s.publicKey == nil {
+			return nil, fmt.Errorf("JWT public key is required for %s", jwtAlgorithmRS256)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported JWT algorithm: %s", cfg.Algorithm)
+	}
+
+	return s, nil
+}
+
+// Authenticate проверяет токен и возвращает инициатора запроса. Токен с другим алгоритмом подписи,
+// неверной подписью, истёкший, выпущенный для другого издателя или аудитории, а также без известной
+// роли возвращает ErrInvalidToken
+func (s *AuthService) Authenticate(token string) (models.Actor, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return models.Actor{}, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var header jwtHeader
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return models.Actor{}, err
+	}
+	// Алгоритм задаётся конфигурацией, а не токеном: иначе открытый ключ RS256 мог бы служить секретом HS256
+	if header.Algorithm != s.cfg.Algorithm {
+		return models.Actor{}, fmt.Errorf("%w: unexpected algorithm %s", ErrInvalidToken, header.Algorithm)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return models.Actor{}, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+	if err = s.verify(parts[0]+"."+parts[1], signature); err != nil {
+		return models.Actor{}, err
+	}
+
+	var claims map[string]interface{}
+	if err = decodeJWTSegment(parts[1], &claims); err != nil {
+		return models.Actor{}, err
+	}
+	if err = s.validateClaims(claims, time.Now()); err != nil {
+		return models.Actor{}, err
+	}
+
+	role := roleFromClaim(claims[s.cfg.RoleClaim])
+	if role == "" {
+		return models.Actor{}, fmt.Errorf("%w: no known role in claim %s", ErrInvalidToken, s.cfg.RoleClaim)
+	}
+	subject, _ := claims["sub"].(string)
+
+	return models.Actor{Role: role, ID: subject}, nil
+}
+
+// IssueToken выпускает токен для локальной разработки. Срок действия - TTLMinutes из запроса
+// или TokenTTL из конфигурации
+func (s *AuthService) IssueToken(req *models.TokenRequest) (*models.AuthToken, error) {
+	if s.cfg.Algorithm == jwtAlgorithmRS256 && s.privateKey == nil {
+		return nil, fmt.Errorf("JWT private key is not configured")
+	}
+
+	ttl := s.cfg.TokenTTL
+	if req.TTLMinutes > 0 {
+		ttl = req.TTLMinutes
+	}
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(ttl) * time.Minute)
+
+	claims := map[string]interface{}{
+		s.cfg.RoleClaim: req.Role,
+		"iat":           now.Unix(),
+		"exp":           expiresAt.Unix(),
+	}
+	if req.Subject != "" {
+		claims["sub"] = req.Subject
+	}
+	if s.cfg.Issuer != "" {
+		claims["iss"] = s.cfg.Issuer
+	}
+	if s.cfg.Audience != "" {
+		claims["aud"] = s.cfg.Audience
+	}
+
+	header, err := encodeJWTSegment(jwtHeader{Algorithm: s.cfg.Algorithm, Type: "JWT"})
+	if err != nil {
+		return nil, err
+	}
+	payload, err := encodeJWTSegment(claims)
+	if err != nil {
+		return nil, err
+	}
+	signingInput := header + "." + payload
+	signature, err := s.sign(signingInput)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AuthToken{
+		AccessToken: signingInput + "." + base64.RawURLEncoding.EncodeToString(signature),
+		TokenType:   "Bearer",
+		ExpiresAt:   time.Unix(expiresAt.Unix(), 0),
+		Actor:       models.Actor{Role: req.Role, ID: req.Subject},
+	}, nil
+}
+
+// sign подписывает заголовок и claims токена ключом настроенного алгоритма
+func (s *AuthService) sign(signingInput string) ([]byte, error) {
+	if s.cfg.Algorithm == jwtAlgorithmHS256 {
+		mac := hmac.New(sha256.New, s.secret)
+		mac.Write([]byte(signingInput))
+		return mac.Sum(nil), nil
+	}
+
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign token: %w", err)
+	}
+	return signature, nil
+}
+
+// verify проверяет подпись токена ключом настроенного алгоритма
+func (s *AuthService) verify(signingInput string, signature []byte) error {
+	if s.cfg.Algorithm == jwtAlgorithmHS256 {
+		mac := hmac.New(sha256.New, s.secret)
+		mac.Write([]byte(signingInput))
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return fmt.Errorf("%w: invalid signature", ErrInvalidToken)
+		}
+		return nil
+	}
+
+	digest := sha256.Sum256([]byte(signingInput))
+	if err := rsa.VerifyPKCS1v15(s.publicKey, crypto.SHA256, digest[:], signature); err != nil {
+		return fmt.Errorf("%w: invalid signature", ErrInvalidToken)
+	}
+	return nil
+}
+
+// validateClaims проверяет срок действия, издателя и аудиторию токена. Срок действия exp обязателен
+func (s *AuthService) validateClaims(claims map[string]interface{}, now time.Time) error {
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return fmt.Errorf("%w: exp claim is required", ErrInvalidToken)
+	}
+	if now.Add(-jwtClockSkew).After(time.Unix(int64(exp), 0)) {
+		return fmt.Errorf("%w: token is expired", ErrInvalidToken)
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(jwtClockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	}
+
+	if s.cfg.Issuer != "" {
+		if issuer, _ := claims["iss"].(string); issuer != s.cfg.Issuer {
+			return fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+		}
+	}
+	if s.cfg.Audience != "" && !claimContains(claims["aud"], s.cfg.Audience) {
+		return fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+	return nil
+}
+
+// roleFromClaim определяет роль по claim: строке или списку строк, в котором берётся первая известная роль.
+// Роль system токеном не выдаётся
+func roleFromClaim(value interface{}) models.Role {
+	switch claim := value.(type) {
+	case string:
+		if role := models.Role(claim); role.IsExternal() {
+			return role
+		}
+	case []interface{}:
+		for _, item := range claim {
+			if name, ok := item.(string); ok && models.Role(name).IsExternal() {
+				return models.Role(name)
+			}
+		}
+	}
+	return ""
+}
+
+// claimContains проверяет, что claim - строка value или список строк, содержащий value
+func claimContains(claim interface{}, value string) bool {
+	switch typed := claim.(type) {
+	case string:
+		return typed == value
+	case []interface{}:
+		for _, item := range typed {
+			if item == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func decodeJWTSegment(segment string, target interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: malformed segment", ErrInvalidToken)
+	}
+	if err = json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("%w: malformed segment", ErrInvalidToken)
+	}
+	return nil
+}
+
+func encodeJWTSegment(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal token segment: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// loadRSAPublicKey загружает открытый ключ RSA из PEM-файла в формате PKIX или PKCS#1
+func loadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWT public key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("JWT public key is not an RSA key")
+	}
+	return key, nil
+}
+
+// loadRSAPrivateKey загружает закрытый ключ RSA из PEM-файла в формате PKCS#8 или PKCS#1
+func loadRSAPrivateKey(path string) (*rsa.PrivateKey, error) {
+	block, err := readPEMBlock(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWT private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("JWT private key is not an RSA key")
+	}
+	return key, nil
+}
+
+func readPEMBlock(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM key %s", path)
+	}
+	return block, nil
+}
@@ -0,0 +1,322 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// maxBatchCandidates - максимальное количество ожидающих курьера заказов, рассматриваемых при группировке
+const maxBatchCandidates = 500
+
+// batchOrder - заказ с координатами точек получения и доставки в порядке [lng, lat]
+type batchOrder struct {
+	ID              uuid.UUID
+	PickupAddress   string
+	DeliveryAddress string
+	Status          models.OrderStatus
+	Pickup          [2]float64
+	Delivery        [2]float64
+}
+
+// BatchingService - сервис одновременной доставки нескольких заказов одним курьером.
+// Группирует ожидающие курьера заказы с близкими точками получения и доставки,
+// назначает группу одному курьеру и строит маршрут по всем его активным заказам
+type BatchingService struct {
+	db             *database.DB
+	log            *logger.Logger
+	geo            GeolocationServiceInterface
+	courierService CourierServiceInterface
+	cfg            *config.BatchingConfig
+}
+
+// NewBatchingService создаёт новый экземпляр сервиса пакетной доставки
+func NewBatchingService(
+	db *database.DB,
+	log *logger.Logger,
+	geo GeolocationServiceInterface,
+	courierService CourierServiceInterface,
+	cfg *config.BatchingConfig,
+) *BatchingService {
+	return &BatchingService{
+		db:             db,
+		log:            log,
+		geo:            geo,
+		courierService: courierService,
+		cfg:            cfg,
+	}
+}
+
+// GetBatches группирует ожидающие курьера заказы в пакеты. Заказы попадают в один пакет, если их точки
+// получения попарно не дальше PickupRadius, а точки доставки - не дальше DeliveryRadius. Размер пакета
+// ограничен вместимостью курьера по умолчанию, заказы без пары в пакеты не включаются. Курьер для заказов
+// продавцов назначается к их готовности, поэтому они в пакеты не группируются
+func (s *BatchingService) GetBatches() ([]*models.OrderBatch, error) {
+	query := `
+		SELECT id, pickup_address, delivery_address, status, pickup_lat, pickup_lon, delivery_lat, delivery_lon
+		FROM orders
+		WHERE status = $1 AND courier_id IS NULL AND merchant_id IS NULL
+		ORDER BY created_at
+		LIMIT $2`
+
+	orders, err := s.loadOrders(query, models.OrderStatusCreated, maxBatchCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	batches := []*models.OrderBatch{}
+	for _, group := range clusterOrders(orders, s.cfg.DefaultCapacity, float64(s.cfg.PickupRadius), float64(s.cfg.DeliveryRadius)) {
+		if len(group) < 2 {
+			continue
+		}
+
+		route, err := s.buildRoute(nil, group)
+		if err != nil {
+			return nil, err
+		}
+
+		batch := &models.OrderBatch{Route: route}
+		for _, order := range group {
+			batch.OrderIDs = append(batch.OrderIDs, order.ID)
+		}
+		batches = append(batches, batch)
+	}
+
+	return batches, nil
+}
+
+// AssignBatch назначает курьеру пакет заказов и возвращает его обновлённый маршрут
+func (s *BatchingService) AssignBatch(courierID uuid.UUID, orderIDs []uuid.UUID, actor models.Actor) (*models.CourierRoute, error) {
+	if err := s.courierService.AssignOrdersToCourier(orderIDs, courierID, actor); err != nil {
+		return nil, err
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"courier_id": courierID,
+		"orders":     len(orderIDs),
+	}).Info("Order batch assigned to courier")
+
+	return s.GetCourierRoute(courierID)
+}
+
+// GetCourierRoute строит маршрут курьера по всем его активным заказам от текущего местоположения.
+// Для заказов в доставке в маршрут входит только точка доставки
+func (s *BatchingService) GetCourierRoute(courierID uuid.UUID) (*models.CourierRoute, error) {
+	var lat, lon *float64
+	err := s.db.QueryRow("SELECT current_lat, current_lon FROM couriers WHERE id = $1", courierID).Scan(&lat, &lon)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("courier not found")
+		}
+		return nil, fmt.Errorf("failed to get courier location: %w", err)
+	}
+
+	query := `
+		SELECT id, pickup_address, delivery_address, status, pickup_lat, pickup_lon, delivery_lat, delivery_lon
+		FROM orders
+		WHERE courier_id = $1 AND status = ANY($2)
+		ORDER BY created_at`
+
+	orders, err := s.loadOrders(query, courierID, pq.Array(activeOrderStatuses))
+	if err != nil {
+		return nil, err
+	}
+
+	var start *[2]float64
+	if lat != nil && lon != nil {
+		start = &[2]float64{*lon, *lat}
+	}
+
+	route, err := s.buildRoute(start, orders)
+	if err != nil {
+		return nil, err
+	}
+	route.CourierID = &courierID
+
+	return route, nil
+}
+
+// loadOrders загружает заказы с координатами. Координаты заказов, созданных до их сохранения в БД,
+// определяются геокодером
+func (s *BatchingService) loadOrders(query string, args ...interface{}) ([]*batchOrder, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*batchOrder
+	var missing []*batchOrder
+	for rows.Next() {
+		order := &batchOrder{}
+		var pickupLat, pickupLon, deliveryLat, deliveryLon sql.NullFloat64
+		if err := rows.Scan(&order.ID, &order.PickupAddress, &order.DeliveryAddress, &order.Status,
+			&pickupLat, &pickupLon, &deliveryLat, &deliveryLon); err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		if pickupLat.Valid && pickupLon.Valid && deliveryLat.Valid && deliveryLon.Valid {
+			order.Pickup = [2]float64{pickupLon.Float64, pickupLat.Float64}
+			order.Delivery = [2]float64{deliveryLon.Float64, deliveryLat.Float64}
+		} else {
+			missing = append(missing, order)
+		}
+		orders = append(orders, order)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+
+	for _, order := range missing {
+		lng, lat, err := s.geo.GetCoordinates(order.PickupAddress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pickup coordinates of order %s: %w", order.ID, err)
+		}
+		order.Pickup = [2]float64{lng, lat}
+
+		if lng, lat, err = s.geo.GetCoordinates(order.DeliveryAddress); err != nil {
+			return nil, fmt.Errorf("failed to get delivery coordinates of order %s: %w", order.ID, err)
+		}
+		order.Delivery = [2]float64{lng, lat}
+	}
+
+	return orders, nil
+}
+
+// buildRoute упорядочивает остановки заказов и рассчитывает длину маршрута через геосервис
+func (s *BatchingService) buildRoute(start *[2]float64, orders []*batchOrder) (*models.CourierRoute, error) {
+	route := &models.CourierRoute{Stops: sequenceStops(start, orders)}
+
+	points := make([][2]float64, 0, len(route.Stops)+1)
+	if start != nil {
+		points = append(points, *start)
+	}
+	for _, stop := range route.Stops {
+		points = append(points, [2]float64{stop.Lon, stop.Lat})
+	}
+	if len(points) < 2 {
+		return route, nil
+	}
+
+	distance, err := s.geo.MakeRoute(points)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make route: %w", err)
+	}
+	route.DistanceMeters = distance
+
+	return route, nil
+}
+
+// clusterOrders жадно объединяет заказы в группы не больше capacity: заказ добавляется в группу,
+// если его точки получения и доставки находятся в пределах радиусов от точек каждого заказа группы.
+// Порядок заказов сохраняется, поэтому более старые заказы группируются первыми
+func clusterOrders(orders []*batchOrder, capacity int, pickupRadius, deliveryRadius float64) [][]*batchOrder {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	used := make([]bool, len(orders))
+	var groups [][]*batchOrder
+	for i, seed := range orders {
+		if used[i] {
+			continue
+		}
+		used[i] = true
+		group := []*batchOrder{seed}
+
+		for j := i + 1; j < len(orders) && len(group) < capacity; j++ {
+			if used[j] {
+				continue
+			}
+			fits := true
+			for _, member := range group {
+				if pointDistance(member.Pickup, orders[j].Pickup) > pickupRadius ||
+					pointDistance(member.Delivery, orders[j].Delivery) > deliveryRadius {
+					fits = false
+					break
+				}
+			}
+			if fits {
+				used[j] = true
+				group = append(group, orders[j])
+			}
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+// sequenceStops строит порядок остановок методом ближайшего соседа: на каждом шаге выбирается ближайшая
+// из доступных остановок, а точка доставки заказа становится доступной только после точки его получения.
+// Без начальной точки маршрут начинается с получения самого старого заказа
+func sequenceStops(start *[2]float64, orders []*batchOrder) []models.RouteStop {
+	type pendingStop struct {
+		stop    models.RouteStop
+		point   [2]float64
+		pickup  int // индекс остановки получения, которая должна предшествовать доставке, или -1
+		visited bool
+	}
+
+	var pending []*pendingStop
+	for _, order := range orders {
+		pickupIndex := -1
+		if order.Status != models.OrderStatusInDelivery {
+			pickupIndex = len(pending)
+			pending = append(pending, &pendingStop{
+				stop: models.RouteStop{
+					OrderID: order.ID, Type: models.RouteStopPickup, Address: order.PickupAddress,
+					Lat: order.Pickup[1], Lon: order.Pickup[0],
+				},
+				point:  order.Pickup,
+				pickup: -1,
+			})
+		}
+		pending = append(pending, &pendingStop{
+			stop: models.RouteStop{
+				OrderID: order.ID, Type: models.RouteStopDelivery, Address: order.DeliveryAddress,
+				Lat: order.Delivery[1], Lon: order.Delivery[0],
+			},
+			point:  order.Delivery,
+			pickup: pickupIndex,
+		})
+	}
+
+	stops := make([]models.RouteStop, 0, len(pending))
+	current := start
+	for len(stops) < len(pending) {
+		next := -1
+		best := math.Inf(1)
+		for i, candidate := range pending {
+			if candidate.visited || (candidate.pickup >= 0 && !pending[candidate.pickup].visited) {
+				continue
+			}
+			if current == nil {
+				next = i
+				break
+			}
+			if d := pointDistance(*current, candidate.point); d < best {
+				next, best = i, d
+			}
+		}
+
+		pending[next].visited = true
+		stops = append(stops, pending[next].stop)
+		current = &pending[next].point
+	}
+
+	return stops
+}
+
+// pointDistance возвращает расстояние в метрах между точками в порядке [lng, lat]
+func pointDistance(a, b [2]float64) float64 {
+	return haversineDistance(a[1], a[0], b[1], b[0])
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/models"
+)
+
+// NewBlobStore создаёт хранилище файлов, выбранное в конфигурации
+func NewBlobStore(cfg *config.BlobStoreConfig) (BlobStore, error) {
+	switch cfg.Provider {
+	case models.BlobStoreLocal:
+		return NewLocalBlobStore(cfg.LocalDir)
+	default:
+		return nil, fmt.Errorf("unknown blob store provider: %s", cfg.Provider)
+	}
+}
+
+// LocalBlobStore - хранилище файлов в каталоге локальной файловой системы.
+// Ключ файла - относительный путь внутри каталога
+type LocalBlobStore struct {
+	dir string
+}
+
+// NewLocalBlobStore создаёт хранилище файлов в указанном каталоге, создавая его при необходимости
+func NewLocalBlobStore(dir string) (*LocalBlobStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("directory is required for local blob store")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob store directory: %w", err)
+	}
+	return &LocalBlobStore{dir: dir}, nil
+}
+
+// Put сохраняет файл под указанным ключом. Файл записывается во временный файл и переименовывается,
+// поэтому читатели не увидят частично записанных данных. Тип содержимого локальным хранилищем не сохраняется
+func (s *LocalBlobStore) Put(key, contentType string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create blob file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to save blob: %w", err)
+	}
+	return nil
+}
+
+// Get возвращает содержимое файла по ключу
+func (s *LocalBlobStore) Get(key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrBlobNotFound, key)
+		}
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+	return data, nil
+}
+
+// path возвращает путь к файлу ключа, не допуская выхода за пределы каталога хранилища
+func (s *LocalBlobStore) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if key == "" || cleaned == "/" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+	return filepath.Join(s.dir, cleaned), nil
+}
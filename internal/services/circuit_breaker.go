@@ -0,0 +1,98 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"delivery-system/internal/logger"
+)
+
+// Состояния circuit breaker
+const (
+	circuitClosed   = "closed"
+	circuitOpen     = "open"
+	circuitHalfOpen = "half_open"
+)
+
+// CircuitBreaker отключает вызовы внешнего сервиса после threshold ошибок подряд.
+// Через openTimeout пропускается один пробный вызов: при успехе breaker закрывается,
+// при ошибке снова размыкается
+type CircuitBreaker struct {
+	name        string
+	threshold   int
+	openTimeout time.Duration
+	log         *logger.Logger
+	// now - источник текущего времени, подменяется в тестах
+	now func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+}
+
+// NewCircuitBreaker создаёт circuit breaker в замкнутом состоянии
+func NewCircuitBreaker(name string, threshold int, openTimeout time.Duration, log *logger.Logger) *CircuitBreaker {
+	return &CircuitBreaker{
+		name:        name,
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		log:         log,
+		now:         time.Now,
+		state:       circuitClosed,
+	}
+}
+
+// Allow сообщает, можно ли выполнить вызов
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.state = circuitHalfOpen
+		return true
+	case circuitHalfOpen:
+		// Пока идёт пробный вызов, остальные отклоняются
+		return false
+	default:
+		return true
+	}
+}
+
+// Success учитывает успешный вызов
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != circuitClosed {
+		b.log.WithField("provider", b.name).Info("Circuit breaker closed")
+	}
+	b.state = circuitClosed
+	b.failures = 0
+}
+
+// Failure учитывает ошибку вызова и размыкает breaker при достижении порога
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.threshold {
+		if b.state != circuitOpen {
+			b.log.WithField("provider", b.name).WithField("failures", b.failures).Warn("Circuit breaker opened")
+		}
+		b.state = circuitOpen
+		b.openedAt = b.now()
+	}
+}
+
+// State возвращает текущее состояние breaker
+func (b *CircuitBreaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
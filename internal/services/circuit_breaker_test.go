@@ -0,0 +1,81 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"delivery-system/internal/logger"
+)
+
+// fakeClock - управляемый источник времени для circuit breaker
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// newTestCircuitBreaker создаёт circuit breaker с управляемыми часами
+func newTestCircuitBreaker(name string, threshold int, openTimeout time.Duration) (*CircuitBreaker, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	breaker := NewCircuitBreaker(name, threshold, openTimeout, logger.NewTest())
+	breaker.now = clock.Now
+	return breaker, clock
+}
+
+// Действия над circuit breaker в шагах тестового сценария
+const (
+	breakerAllow   = "allow"
+	breakerSuccess = "success"
+	breakerFailure = "failure"
+)
+
+// Сценарий переходов breaker с порогом 3 ошибки и временем размыкания 30 секунд.
+// Каждый шаг сдвигает часы на advance, выполняет действие и проверяет состояние
+var circuitBreakerSteps = []struct {
+	name            string
+	advance         time.Duration
+	action          string
+	expectedAllowed bool
+	expectedState   string
+}{
+	{"closed_allows", 0, breakerAllow, true, circuitClosed},
+	{"first_failure", 0, breakerFailure, false, circuitClosed},
+	{"second_failure", 0, breakerFailure, false, circuitClosed},
+	{"closed_below_threshold_allows", 0, breakerAllow, true, circuitClosed},
+	{"success_resets_failures", 0, breakerSuccess, false, circuitClosed},
+	{"failure_after_reset", 0, breakerFailure, false, circuitClosed},
+	{"second_failure_after_reset", 0, breakerFailure, false, circuitClosed},
+	{"threshold_opens", 0, breakerFailure, false, circuitOpen},
+	{"open_rejects", 0, breakerAllow, false, circuitOpen},
+	{"open_rejects_before_timeout", 29 * time.Second, breakerAllow, false, circuitOpen},
+	{"timeout_lets_probe_through", time.Second, breakerAllow, true, circuitHalfOpen},
+	{"half_open_rejects_while_probing", 0, breakerAllow, false, circuitHalfOpen},
+	{"probe_failure_reopens", 0, breakerFailure, false, circuitOpen},
+	{"reopened_rejects", 29 * time.Second, breakerAllow, false, circuitOpen},
+	{"second_probe", time.Second, breakerAllow, true, circuitHalfOpen},
+	{"probe_success_closes", 0, breakerSuccess, false, circuitClosed},
+	{"closed_again_allows", 0, breakerAllow, true, circuitClosed},
+	{"failures_counted_from_zero", 0, breakerFailure, false, circuitClosed},
+}
+
+// TestCircuitBreakerTransitions выполняет тестирование переходов circuit breaker между состояниями
+func TestCircuitBreakerTransitions(t *testing.T) {
+	breaker, clock := newTestCircuitBreaker("test", 3, 30*time.Second)
+
+	for _, step := range circuitBreakerSteps {
+		clock.Advance(step.advance)
+		switch step.action {
+		case breakerAllow:
+			assert.Equal(t, step.expectedAllowed, breaker.Allow(), step.name)
+		case breakerSuccess:
+			breaker.Success()
+		case breakerFailure:
+			breaker.Failure()
+		}
+		assert.Equal(t, step.expectedState, breaker.State(), step.name)
+	}
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// activeOrderStatuses - статусы заказов, которые учитываются в текущей загруженности курьера
+var activeOrderStatuses = []string{
+	string(models.OrderStatusAccepted),
+	string(models.OrderStatusPreparing),
+	string(models.OrderStatusReady),
+	string(models.OrderStatusInDelivery),
+}
+
+// merchantAssignableStatuses - статусы заказов продавцов, в которых им назначается курьер: после принятия
+// заказа продавцом и до его передачи курьеру
+var merchantAssignableStatuses = []string{
+	string(models.OrderStatusAccepted),
+	string(models.OrderStatusPreparing),
+	string(models.OrderStatusReady),
+}
+
+// CourierAssignmentService - сервис автоматического назначения оптимального курьера на заказ
+type CourierAssignmentService struct {
+	db             *database.DB
+	log            *logger.Logger
+	geo            GeolocationServiceInterface
+	orderService   OrderServiceInterface
+	courierService CourierServiceInterface
+	cfg            *config.AssignmentConfig
+}
+
+// NewCourierAssignmentService создаёт новый экземпляр сервиса автоназначения
+func NewCourierAssignmentService(
+	db *database.DB,
+	log *logger.Logger,
+	geo GeolocationServiceInterface,
+	orderService OrderServiceInterface,
+	courierService CourierServiceInterface,
+	cfg *config.AssignmentConfig,
+) *CourierAssignmentService {
+	return &CourierAssignmentService{
+		db:             db,
+		log:            log,
+		geo:            geo,
+		orderService:   orderService,
+		courierService: courierService,
+		cfg:            cfg,
+	}
+}
+
+// AutoAssign выбирает лучшего доступного курьера для заказа и назначает его.
+// Кандидаты ранжируются по расстоянию до точки получения, рейтингу и текущей загруженности.
+// Назначение записывается в журнал заказа от имени инициатора actor
+func (s *CourierAssignmentService) AutoAssign(orderID uuid.UUID, actor models.Actor) (*models.AssignmentResult, error) {
+	order, err := s.orderService.GetOrder(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.MerchantID != nil && order.Status == models.OrderStatusCreated {
+		return nil, fmt.Errorf("order is awaiting merchant acceptance")
+	}
+	if !order.AwaitsCourier() {
+		return nil, ErrOrderAlreadyAssigned
+	}
+
+	pickupLat, pickupLon, err := s.pickupCoordinates(order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pickup coordinates: %w", err)
+	}
+
+	couriers, err := s.courierService.GetAvailableCouriers()
+	if err != nil {
+		return nil, err
+	}
+
+	loads, err := s.getCourierLoads(couriers)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := s.scoreCandidates(order, couriers, loads, pickupLat, pickupLon)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no available couriers for order")
+	}
+
+	// Пробуем назначить кандидатов по убыванию оценки: курьер мог стать недоступным после выборки
+	for _, candidate := range candidates {
+		if err := s.courierService.AssignOrderToCourier(orderID, candidate.CourierID, actor); err != nil {
+			if strings.Contains(err.Error(), "not available") {
+				s.log.WithField("courier_id", candidate.CourierID).Warn("Candidate courier became unavailable")
+				continue
+			}
+			return nil, err
+		}
+
+		s.log.WithFields(map[string]interface{}{
+			"order_id":    orderID,
+			"courier_id":  candidate.CourierID,
+			"total_score": candidate.TotalScore,
+		}).Info("Courier selected by auto-assignment")
+
+		return &models.AssignmentResult{
+			OrderID:    orderID,
+			CourierID:  candidate.CourierID,
+			Score:      candidate.TotalScore,
+			Candidates: candidates,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no available couriers for order")
+}
+
+// scoreCandidates рассчитывает оценки курьеров и возвращает их отсортированными по убыванию.
+// Курьеры, привязанные к другой зоне, в назначении заказа не участвуют
+func (s *CourierAssignmentService) scoreCandidates(
+	order *models.Order,
+	couriers []*models.Courier,
+	loads map[uuid.UUID]int,
+	pickupLat, pickupLon float64,
+) []models.CandidateScore {
+	candidates := make([]models.CandidateScore, 0, len(couriers))
+	for _, courier := range couriers {
+		if courier.Zone != nil && order.Zone != nil && *courier.Zone != *order.Zone {
+			s.log.WithFields(map[string]interface{}{
+				"courier_id": courier.ID,
+				"zone":       *courier.Zone,
+			}).Debug("Courier skipped: assigned to another zone")
+			continue
+		}
+		if courier.CurrentLat == nil || courier.CurrentLon == nil {
+			s.log.WithField("courier_id", courier.ID).Debug("Courier skipped: unknown location")
+			continue
+		}
+
+		distanceKm := haversineDistance(*courier.CurrentLat, *courier.CurrentLon, pickupLat, pickupLon) / 1000
+		if distanceKm > s.cfg.MaxDistanceKm {
+			s.log.WithFields(map[string]interface{}{
+				"courier_id":  courier.ID,
+				"distance_km": distanceKm,
+			}).Debug("Courier skipped: too far from pickup point")
+			continue
+		}
+
+		rating := s.cfg.DefaultRating
+		if courier.Rating != nil {
+			rating = *courier.Rating
+		}
+		load := loads[courier.ID]
+
+		candidate := models.CandidateScore{
+			CourierID:     courier.ID,
+			DistanceKm:    distanceKm,
+			Rating:        rating,
+			ActiveOrders:  load,
+			DistanceScore: headroomScore(distanceKm, s.cfg.MaxDistanceKm),
+			RatingScore:   (rating - 1) / 4,
+			LoadScore:     headroomScore(float64(load), float64(s.cfg.MaxLoad)),
+		}
+		candidate.TotalScore = s.cfg.DistanceWeight*candidate.DistanceScore +
+			s.cfg.RatingWeight*candidate.RatingScore +
+			s.cfg.LoadWeight*candidate.LoadScore
+
+		// Логируем разбивку оценки для аудита выбора курьера
+		s.log.WithFields(map[string]interface{}{
+			"order_id":       order.ID,
+			"courier_id":     candidate.CourierID,
+			"distance_km":    candidate.DistanceKm,
+			"rating":         candidate.Rating,
+			"active_orders":  candidate.ActiveOrders,
+			"distance_score": candidate.DistanceScore,
+			"rating_score":   candidate.RatingScore,
+			"load_score":     candidate.LoadScore,
+			"total_score":    candidate.TotalScore,
+		}).Info("Courier candidate scored")
+
+		candidates = append(candidates, candidate)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].TotalScore > candidates[j].TotalScore
+	})
+	return candidates
+}
+
+// pickupCoordinates возвращает координаты (lat, lon) точки получения заказа,
+// используя закешированные геоданные заказа либо геокодер
+func (s *CourierAssignmentService) pickupCoordinates(order *models.Order) (float64, float64, error) {
+	if cached, err := s.geo.GetOrderGeolocation(order.ID); err == nil {
+		return cached.PickupCoordinates[1], cached.PickupCoordinates[0], nil
+	}
+
+	lng, lat, err := s.geo.GetCoordinates(order.PickupAddress)
+	if err != nil {
+		return 0, 0, err
+	}
+	return lat, lng, nil
+}
+
+// getCourierLoads возвращает количество активных заказов у каждого из курьеров
+func (s *CourierAssignmentService) getCourierLoads(couriers []*models.Courier) (map[uuid.UUID]int, error) {
+	loads := make(map[uuid.UUID]int, len(couriers))
+	if len(couriers) == 0 {
+		return loads, nil
+	}
+
+	ids := make([]string, 0, len(couriers))
+	for _, courier := range couriers {
+		ids = append(ids, courier.ID.String())
+	}
+
+	query := `
+		SELECT courier_id, COUNT(*)
+		FROM orders
+		WHERE courier_id = ANY($1::uuid[]) AND status = ANY($2)
+		GROUP BY courier_id
+	`
+	rows, err := s.db.Query(query, pq.Array(ids), pq.Array(activeOrderStatuses))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get courier loads: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var courierID uuid.UUID
+		var count int
+		if err := rows.Scan(&courierID, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan courier load: %w", err)
+		}
+		loads[courierID] = count
+	}
+
+	return loads, nil
+}
+
+// headroomScore оценивает запас до предела от 0 до 1: чем дальше значение от предела, тем выше оценка.
+// При нулевом или отрицательном пределе запаса нет: оценка равна 0, деления на ноль не происходит
+func headroomScore(value, limit float64) float64 {
+	if limit <= 0 {
+		return 0
+	}
+	return math.Max(0, 1-value/limit)
+}
@@ -0,0 +1,367 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/redis"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// maxTrackPoints - максимальное количество точек в одном треке
+const maxTrackPoints = 10000
+
+// CourierLocationService - сервис отслеживания местоположения курьеров.
+// Полный трек хранится в таблице courier_locations, последняя позиция - в Redis GEO-наборе
+// и в карточке курьера. Новые координаты рассылаются через Redis Pub/Sub, поэтому
+// подписчик получает их независимо от того, какой инстанс принял обновление
+type CourierLocationService struct {
+	db          *database.DB
+	redisClient *redis.Client
+	log         *logger.Logger
+	cfg         *config.TrackingConfig
+}
+
+// NewCourierLocationService создаёт новый экземпляр сервиса местоположения курьеров
+func NewCourierLocationService(
+	db *database.DB,
+	redisClient *redis.Client,
+	log *logger.Logger,
+	cfg *config.TrackingConfig,
+) *CourierLocationService {
+	return &CourierLocationService{
+		db:          db,
+		redisClient: redisClient,
+		log:         log,
+		cfg:         cfg,
+	}
+}
+
+// UpdateLocation сохраняет новую точку трека курьера, обновляет его последнюю позицию
+// и рассылает её подписчикам
+func (s *CourierLocationService) UpdateLocation(courierID uuid.UUID, req *models.UpdateLocationRequest) (*models.CourierLocation, error) {
+	now := time.Now()
+	location := &models.CourierLocation{
+		CourierID: courierID,
+		Lat:       req.Lat,
+		Lon:       req.Lon,
+		Accuracy:  req.Accuracy,
+		Speed:     req.Speed,
+		Heading:   req.Heading,
+		Timestamp: now,
+	}
+	if req.RecordedAt != nil {
+		location.Timestamp = *req.RecordedAt
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Точки могут приходить не по порядку, поэтому текущая позиция обновляется только более свежей точкой
+	var latest bool
+	err = tx.QueryRow(`
+		UPDATE couriers
+		SET current_lat = CASE WHEN last_seen_at IS NULL OR last_seen_at <= $3 THEN $1 ELSE current_lat END,
+		    current_lon = CASE WHEN last_seen_at IS NULL OR last_seen_at <= $3 THEN $2 ELSE current_lon END,
+		    last_seen_at = GREATEST(COALESCE(last_seen_at, $3), $3),
+		    updated_at = $4
+		WHERE id = $5
+		RETURNING last_seen_at = $3
+	`, location.Lat, location.Lon, location.Timestamp, now, courierID).Scan(&latest)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("courier not found")
+		}
+		return nil, fmt.Errorf("failed to update courier location: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO courier_locations (courier_id, lat, lon, accuracy, speed, heading, recorded_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, courierID, location.Lat, location.Lon, location.Accuracy, location.Speed, location.Heading, location.Timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save courier location: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if !latest {
+		s.log.WithField("courier_id", courierID).Debug("Outdated courier location saved to track")
+		return location, nil
+	}
+
+	// Ошибки Redis не отменяют сохранение точки: трек уже записан в БД
+	ctx := context.Background()
+	if err := s.redisClient.GeoAdd(ctx, redis.KeyCourierLocations, courierID.String(), location.Lon, location.Lat); err != nil {
+		s.log.WithError(err).WithField("courier_id", courierID).Error("Failed to update courier position in Redis")
+	}
+	if data, err := json.Marshal(location); err == nil {
+		if err := s.redisClient.Publish(ctx, courierLocationChannel(courierID), data); err != nil {
+			s.log.WithError(err).WithField("courier_id", courierID).Error("Failed to broadcast courier location")
+		}
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"courier_id": courierID,
+		"lat":        location.Lat,
+		"lon":        location.Lon,
+	}).Debug("Courier location updated")
+
+	return location, nil
+}
+
+// GetLastLocation возвращает последнюю известную позицию курьера
+func (s *CourierLocationService) GetLastLocation(courierID uuid.UUID) (*models.CourierLocation, error) {
+	var lat, lon sql.NullFloat64
+	var lastSeenAt sql.NullTime
+	err := s.db.QueryRow("SELECT current_lat, current_lon, last_seen_at FROM couriers WHERE id = $1", courierID).
+		Scan(&lat, &lon, &lastSeenAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("courier not found")
+		}
+		return nil, fmt.Errorf("failed to get courier location: %w", err)
+	}
+	if !lat.Valid || !lon.Valid {
+		return nil, fmt.Errorf("courier location not found")
+	}
+
+	return &models.CourierLocation{
+		CourierID: courierID,
+		Lat:       lat.Float64,
+		Lon:       lon.Float64,
+		Timestamp: lastSeenAt.Time,
+	}, nil
+}
+
+// GetTrack возвращает трек курьера за период [from, to] в виде GeoJSON Feature.
+// Для трека из одной точки возвращается Point, для пустого - Feature без геометрии
+func (s *CourierLocationService) GetTrack(courierID uuid.UUID, from, to time.Time) (*models.GeoJSONFeature, error) {
+	var exists bool
+	if err := s.db.QueryRow("SELECT EXISTS(SELECT 1 FROM couriers WHERE id = $1)", courierID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check courier: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("courier not found")
+	}
+
+	rows, err := s.db.Query(`
+		SELECT lat, lon, recorded_at
+		FROM courier_locations
+		WHERE courier_id = $1 AND recorded_at >= $2 AND recorded_at <= $3
+		ORDER BY recorded_at
+		LIMIT $4
+	`, courierID, from, to, maxTrackPoints)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get courier track: %w", err)
+	}
+	defer rows.Close()
+
+	coordinates := [][2]float64{}
+	timestamps := []time.Time{}
+	for rows.Next() {
+		var lat, lon float64
+		var recordedAt time.Time
+		if err := rows.Scan(&lat, &lon, &recordedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan courier location: %w", err)
+		}
+		coordinates = append(coordinates, [2]float64{lon, lat})
+		timestamps = append(timestamps, recordedAt)
+	}
+
+	feature := &models.GeoJSONFeature{
+		Type: models.GeoJSONTypeFeature,
+		Properties: map[string]interface{}{
+			"courier_id": courierID,
+			"from":       from,
+			"to":         to,
+			"points":     len(coordinates),
+			"timestamps": timestamps,
+		},
+	}
+	switch len(coordinates) {
+	case 0:
+	case 1:
+		feature.Geometry = &models.GeoJSONGeometry{Type: models.GeoJSONTypePoint, Coordinates: coordinates[0]}
+	default:
+		feature.Geometry = &models.GeoJSONGeometry{Type: models.GeoJSONTypeLineString, Coordinates: coordinates}
+	}
+
+	return feature, nil
+}
+
+// GetNearbyCouriers возвращает доступных курьеров в радиусе radiusMeters от точки, отсортированных по расстоянию.
+// Кандидаты ищутся в Redis GEO-наборе командой GEOSEARCH, статус и свежесть позиции проверяются по БД.
+// Курьеры, не присылавшие координаты дольше StaleAfter секунд, не учитываются.
+// При нулевом радиусе используется радиус по умолчанию, радиус больше максимального ограничивается
+func (s *CourierLocationService) GetNearbyCouriers(lat, lon, radiusMeters float64, limit int) ([]*models.NearbyCourier, error) {
+	if radiusMeters <= 0 {
+		radiusMeters = float64(s.cfg.NearbyDefaultRadius)
+	}
+	radiusMeters = math.Min(radiusMeters, float64(s.cfg.NearbyMaxRadius))
+
+	members, err := s.redisClient.GeoSearch(context.Background(), redis.KeyCourierLocations, lon, lat, radiusMeters)
+	if err != nil {
+		s.log.WithError(err).Warn("Failed to search couriers in Redis, falling back to database")
+		return s.getNearbyCouriersFromDB(lat, lon, radiusMeters, limit)
+	}
+	if len(members) == 0 {
+		return []*models.NearbyCourier{}, nil
+	}
+
+	// В GEO-наборе остаются и занятые, и давно не выходившие на связь курьеры,
+	// поэтому ограничение по количеству применяется после проверки по БД
+	distances := make(map[uuid.UUID]float64, len(members))
+	ids := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		id, err := uuid.Parse(member.Name)
+		if err != nil {
+			continue
+		}
+		distances[id] = member.DistanceMeters
+		ids = append(ids, id)
+	}
+
+	rows, err := s.db.Query(`
+		SELECT id, name, phone, status, rating, total_reviews, zone, capacity,
+		       current_lat, current_lon, created_at, updated_at, last_seen_at
+		FROM couriers
+		WHERE id = ANY($1)
+		  AND status = $2
+		  AND last_seen_at >= NOW() - $3 * INTERVAL '1 second'
+	`, pq.Array(ids), models.CourierStatusAvailable, s.cfg.StaleAfter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nearby couriers: %w", err)
+	}
+	defer rows.Close()
+
+	available := make(map[uuid.UUID]*models.NearbyCourier, len(ids))
+	for rows.Next() {
+		courier := &models.NearbyCourier{Courier: &models.Courier{}}
+		if err := rows.Scan(&courier.ID, &courier.Name, &courier.Phone, &courier.Status,
+			&courier.Rating, &courier.TotalReviews, &courier.Zone, &courier.Capacity, &courier.CurrentLat, &courier.CurrentLon,
+			&courier.CreatedAt, &courier.UpdatedAt, &courier.LastSeenAt); err != nil {
+			return nil, fmt.Errorf("failed to scan nearby courier: %w", err)
+		}
+		courier.DistanceMeters = distances[courier.ID]
+		available[courier.ID] = courier
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get nearby couriers: %w", err)
+	}
+
+	// Порядок по расстоянию задаёт выдача GEOSEARCH
+	couriers := []*models.NearbyCourier{}
+	for _, id := range ids {
+		if courier, ok := available[id]; ok {
+			couriers = append(couriers, courier)
+			if len(couriers) == limit {
+				break
+			}
+		}
+	}
+
+	return couriers, nil
+}
+
+// getNearbyCouriersFromDB ищет ближайших доступных курьеров по их карточкам в БД.
+// Используется, когда Redis недоступен
+func (s *CourierLocationService) getNearbyCouriersFromDB(lat, lon, radiusMeters float64, limit int) ([]*models.NearbyCourier, error) {
+	// Ограничивающий прямоугольник отсекает дальних курьеров по индексу до расчёта точного расстояния.
+	// Вблизи полюсов и антимеридиана ограничение по долготе не применяется
+	deltaLat := radiusMeters / earthRadiusMeters * 180 / math.Pi
+	minLon, maxLon := -180.0, 180.0
+	if cosLat := math.Cos(lat * math.Pi / 180); math.Abs(lat)+deltaLat < 90 && cosLat > 0 {
+		deltaLon := deltaLat / cosLat
+		if lon-deltaLon >= -180 && lon+deltaLon <= 180 {
+			minLon, maxLon = lon-deltaLon, lon+deltaLon
+		}
+	}
+
+	query := `
+		SELECT id, name, phone, status, rating, total_reviews, zone, capacity,
+		       current_lat, current_lon, created_at, updated_at, last_seen_at, distance
+		FROM (
+			SELECT *, 2 * $1::float8 * ASIN(SQRT(
+				POWER(SIN(RADIANS(current_lat - $2) / 2), 2) +
+				COS(RADIANS($2)) * COS(RADIANS(current_lat)) * POWER(SIN(RADIANS(current_lon - $3) / 2), 2)
+			)) AS distance
+			FROM couriers
+			WHERE status = $4
+			  AND current_lat IS NOT NULL AND current_lon IS NOT NULL
+			  AND current_lat BETWEEN $5 AND $6
+			  AND current_lon BETWEEN $7 AND $8
+			  AND last_seen_at >= NOW() - $9 * INTERVAL '1 second'
+		) c
+		WHERE distance <= $10
+		ORDER BY distance
+		LIMIT $11
+	`
+	rows, err := s.db.Query(query, earthRadiusMeters, lat, lon, models.CourierStatusAvailable,
+		lat-deltaLat, lat+deltaLat, minLon, maxLon, s.cfg.StaleAfter, radiusMeters, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nearby couriers: %w", err)
+	}
+	defer rows.Close()
+
+	couriers := []*models.NearbyCourier{}
+	for rows.Next() {
+		courier := &models.NearbyCourier{Courier: &models.Courier{}}
+		if err := rows.Scan(&courier.ID, &courier.Name, &courier.Phone, &courier.Status,
+			&courier.Rating, &courier.TotalReviews, &courier.Zone, &courier.Capacity, &courier.CurrentLat, &courier.CurrentLon,
+			&courier.CreatedAt, &courier.UpdatedAt, &courier.LastSeenAt, &courier.DistanceMeters); err != nil {
+			return nil, fmt.Errorf("failed to scan nearby courier: %w", err)
+		}
+		couriers = append(couriers, courier)
+	}
+
+	return couriers, nil
+}
+
+// Subscribe подписывается на обновления местоположения курьера.
+// Канал закрывается после отмены контекста
+func (s *CourierLocationService) Subscribe(ctx context.Context, courierID uuid.UUID) (<-chan *models.CourierLocation, error) {
+	messages, err := s.redisClient.Subscribe(ctx, courierLocationChannel(courierID))
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make(chan *models.CourierLocation)
+	go func() {
+		defer close(locations)
+		for data := range messages {
+			location := &models.CourierLocation{}
+			if err := json.Unmarshal(data, location); err != nil {
+				s.log.WithError(err).WithField("courier_id", courierID).Warn("Invalid courier location message")
+				continue
+			}
+			select {
+			case locations <- location:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return locations, nil
+}
+
+// courierLocationChannel возвращает канал Pub/Sub с обновлениями местоположения курьера
+func courierLocationChannel(courierID uuid.UUID) string {
+	return redis.GenerateKey(redis.KeyPrefixCourierLocation, courierID.String())
+}
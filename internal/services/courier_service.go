@@ -5,24 +5,41 @@ import (
 	"fmt"
 	"time"
 
+	"delivery-system/internal/config"
 	"delivery-system/internal/database"
 	"delivery-system/internal/logger"
 	"delivery-system/internal/models"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 // CourierService представляет сервис для работы с курьерами
 type CourierService struct {
-	db  *database.DB
-	log *logger.Logger
+	db     *database.DB
+	log    *logger.Logger
+	events *OrderEventService
+	outbox *OutboxService
+	shifts *ShiftService
+	cfg    *config.BatchingConfig
 }
 
 // NewCourierService создает новый экземпляр сервиса курьеров
-func NewCourierService(db *database.DB, log *logger.Logger) *CourierService {
+func NewCourierService(
+	db *database.DB,
+	log *logger.Logger,
+	events *OrderEventService,
+	outbox *OutboxService,
+	shifts *ShiftService,
+	cfg *config.BatchingConfig,
+) *CourierService {
 	return &CourierService{
-		db:  db,
-		log: log,
+		db:     db,
+		log:    log,
+		events: events,
+		outbox: outbox,
+		shifts: shifts,
+		cfg:    cfg,
 	}
 }
 
@@ -32,19 +49,24 @@ func (s *CourierService) CreateCourier(req *models.CreateCourierRequest) (*model
 		ID:        uuid.New(),
 		Name:      req.Name,
 		Phone:     req.Phone,
+		Zone:      req.Zone,
+		Capacity:  req.Capacity,
 		Status:    models.CourierStatusOffline,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
 	query := `
-		INSERT INTO couriers (id, name, phone, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO couriers (id, name, phone, zone, capacity, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
-	_, err := s.db.Exec(query, courier.ID, courier.Name, courier.Phone,
+	_, err := s.db.Exec(query, courier.ID, courier.Name, courier.Phone, courier.Zone, courier.Capacity,
 		courier.Status, courier.CreatedAt, courier.UpdatedAt)
 	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, fmt.Errorf("delivery zone not found")
+		}
 		return nil, fmt.Errorf("failed to create courier: %w", err)
 	}
 
@@ -62,7 +84,7 @@ func (s *CourierService) GetCourier(courierID uuid.UUID) (*models.Courier, error
 	courier := &models.Courier{}
 
 	query := `
-		SELECT id, name, phone, status, rating, total_reviews, 
+		SELECT id, name, phone, status, rating, total_reviews, zone, capacity,
 		       current_lat, current_lon, created_at, updated_at, last_seen_at
 		FROM couriers 
 		WHERE id = $1
@@ -70,7 +92,7 @@ func (s *CourierService) GetCourier(courierID uuid.UUID) (*models.Courier, error
 
 	err := s.db.QueryRow(query, courierID).Scan(
 		&courier.ID, &courier.Name, &courier.Phone, &courier.Status,
-		&courier.Rating, &courier.TotalReviews, &courier.CurrentLat,
+		&courier.Rating, &courier.TotalReviews, &courier.Zone, &courier.Capacity, &courier.CurrentLat,
 		&courier.CurrentLon, &courier.CreatedAt, &courier.UpdatedAt,
 		&courier.LastSeenAt,
 	)
@@ -85,8 +107,16 @@ func (s *CourierService) GetCourier(courierID uuid.UUID) (*models.Courier, error
 	return courier, nil
 }
 
-// UpdateCourierStatus обновляет статус курьера
+// UpdateCourierStatus обновляет статус курьера. Выйти на линию курьер может только во время активной смены.
+// Статус курьера на линии определяется его загрузкой: available, пока число активных заказов меньше вместимости,
+// и busy, когда вместимость исчерпана
 func (s *CourierService) UpdateCourierStatus(courierID uuid.UUID, req *models.UpdateCourierStatusRequest) error {
+	if req.Status != models.CourierStatusOffline {
+		if err := s.shifts.CheckOnDuty(courierID); err != nil {
+			return err
+		}
+	}
+
 	query := `
 		UPDATE couriers 
 		SET status = $1, current_lat = $2, current_lon = $3, updated_at = $4, last_seen_at = $5
@@ -108,6 +138,13 @@ func (s *CourierService) UpdateCourierStatus(courierID uuid.UUID, req *models.Up
 		return fmt.Errorf("courier not found")
 	}
 
+	// Курьер, вышедший на линию с полной загрузкой, сразу становится занятым
+	if req.Status != models.CourierStatusOffline {
+		if err = syncCourierLoadStatus(s.db, courierID, s.cfg.DefaultCapacity, now); err != nil {
+			return err
+		}
+	}
+
 	s.log.WithFields(map[string]interface{}{
 		"courier_id": courierID,
 		"new_status": req.Status,
@@ -118,10 +155,76 @@ func (s *CourierService) UpdateCourierStatus(courierID uuid.UUID, req *models.Up
 	return nil
 }
 
+// UpdateCourierZone привязывает курьера к зоне доставки или отвязывает его при zone = nil
+func (s *CourierService) UpdateCourierZone(courierID uuid.UUID, zone *string) error {
+	result, err := s.db.Exec("UPDATE couriers SET zone = $1 WHERE id = $2", zone, courierID)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return fmt.Errorf("delivery zone not found")
+		}
+		return fmt.Errorf("failed to update courier zone: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("courier not found")
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"courier_id": courierID,
+		"zone":       zone,
+	}).Info("Courier zone updated")
+
+	return nil
+}
+
+// UpdateCourierCapacity изменяет вместимость курьера или возвращает вместимость по умолчанию при capacity = nil
+// и пересчитывает статус курьера по его текущей загрузке
+func (s *CourierService) UpdateCourierCapacity(courierID uuid.UUID, capacity *int) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec("UPDATE couriers SET capacity = $1 WHERE id = $2", capacity, courierID)
+	if err != nil {
+		return fmt.Errorf("failed to update courier capacity: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("courier not found")
+	}
+
+	if err = syncCourierLoadStatus(tx, courierID, s.cfg.DefaultCapacity, time.Now()); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"courier_id": courierID,
+		"capacity":   capacity,
+	}).Info("Courier capacity updated")
+
+	return nil
+}
+
 // GetCouriers получает список курьеров с фильтрацией
 func (s *CourierService) GetCouriers(status *models.CourierStatus, limit, offset int, ratingSort bool) ([]*models.Courier, error) {
 	query := `
-		SELECT id, name, phone, status, rating, total_reviews,
+		SELECT id, name, phone, status, rating, total_reviews, zone, capacity,
 		       current_lat, current_lon, created_at, updated_at, last_seen_at
 		FROM couriers 
 		WHERE 1=1
@@ -162,7 +265,7 @@ func (s *CourierService) GetCouriers(status *models.CourierStatus, limit, offset
 	for rows.Next() {
 		courier := &models.Courier{}
 		if err := rows.Scan(&courier.ID, &courier.Name, &courier.Phone, &courier.Status,
-			&courier.Rating, &courier.TotalReviews, &courier.CurrentLat, &courier.CurrentLon,
+			&courier.Rating, &courier.TotalReviews, &courier.Zone, &courier.Capacity, &courier.CurrentLat, &courier.CurrentLon,
 			&courier.CreatedAt, &courier.UpdatedAt, &courier.LastSeenAt); err != nil {
 			return nil, fmt.Errorf("failed to scan courier: %w", err)
 		}
@@ -178,18 +281,38 @@ func (s *CourierService) GetAvailableCouriers() ([]*models.Courier, error) {
 	return s.GetCouriers(&status, 0, 0, true)
 }
 
-// AssignOrderToCourier назначает заказ курьеру
-func (s *CourierService) AssignOrderToCourier(orderID, courierID uuid.UUID) error {
+// orderNotAssignableError определяет, почему заказ не удалось назначить: его нет или он уже назначен
+func orderNotAssignableError(tx *sql.Tx, orderID uuid.UUID) error {
+	var exists bool
+	if err := tx.QueryRow("SELECT EXISTS(SELECT 1 FROM orders WHERE id = $1)", orderID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check order: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("order %s not found", orderID)
+	}
+	return fmt.Errorf("%w: %s", ErrOrderAlreadyAssigned, orderID)
+}
+
+// AssignOrderToCourier назначает заказ курьеру и в той же транзакции записывает событие назначения в журнал заказа и outbox
+func (s *CourierService) AssignOrderToCourier(orderID, courierID uuid.UUID, actor models.Actor) error {
+	return s.AssignOrdersToCourier([]uuid.UUID{orderID}, courierID, actor)
+}
+
+// AssignOrdersToCourier назначает курьеру пакет заказов: либо все заказы, либо ни одного.
+// Курьер должен быть на линии, а число его активных заказов вместе с новыми не должно превышать вместимость.
+// Для каждого заказа в той же транзакции записывается событие назначения в журнал заказа и outbox
+func (s *CourierService) AssignOrdersToCourier(orderIDs []uuid.UUID, courierID uuid.UUID, actor models.Actor) error {
 	tx, err := s.db.Begin()
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	// Проверяем, что курьер доступен
-	var courierStatus string
-	courierQuery := "SELECT status FROM couriers WHERE id = $1"
-	err = tx.QueryRow(courierQuery, courierID).Scan(&courierStatus)
+	// Блокируем курьера, чтобы параллельные назначения не превысили его вместимость
+	var courierStatus models.CourierStatus
+	var capacity *int
+	courierQuery := "SELECT status, capacity FROM couriers WHERE id = $1 FOR UPDATE"
+	err = tx.QueryRow(courierQuery, courierID).Scan(&courierStatus, &capacity)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return fmt.Errorf("courier not found")
@@ -197,39 +320,72 @@ func (s *CourierService) AssignOrderToCourier(orderID, courierID uuid.UUID) erro
 		return fmt.Errorf("failed to check courier status: %w", err)
 	}
 
-	if courierStatus != string(models.CourierStatusAvailable) {
+	if courierStatus == models.CourierStatusOffline {
 		return fmt.Errorf("courier is not available")
 	}
 
-	// Назначаем заказ курьеру и меняем статус заказа
-	orderQuery := `
-		UPDATE orders 
-		SET courier_id = $1, status = $2, updated_at = $3
-		WHERE id = $4 AND status = $5
-	`
-	result, err := tx.Exec(orderQuery, courierID, models.OrderStatusAccepted, time.Now(), orderID, models.OrderStatusCreated)
-	if err != nil {
-		return fmt.Errorf("failed to assign order to courier: %w", err)
+	limit := s.cfg.DefaultCapacity
+	if capacity != nil {
+		limit = *capacity
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+	var load int
+	loadQuery := "SELECT COUNT(*) FROM orders WHERE courier_id = $1 AND status = ANY($2)"
+	if err = tx.QueryRow(loadQuery, courierID, pq.Array(activeOrderStatuses)).Scan(&load); err != nil {
+		return fmt.Errorf("failed to get courier load: %w", err)
+	}
+	if load+len(orderIDs) > limit {
+		return fmt.Errorf("courier is not available: %d active orders, capacity %d", load, limit)
 	}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("order not found or already assigned")
+	if err = setCurrentActor(tx, actor); err != nil {
+		return err
 	}
 
-	// Меняем статус курьера на "занят"
-	courierUpdateQuery := `
-		UPDATE couriers 
-		SET status = $1, updated_at = $2
-		WHERE id = $3
+	// Назначаем заказы курьеру и меняем их статус. Статус заказа продавца ведёт продавец,
+	// поэтому такому заказу назначается только курьер
+	now := time.Now()
+	orderQuery := `
+		UPDATE orders 
+		SET courier_id = $1, status = CASE WHEN merchant_id IS NULL THEN $2 ELSE status END, updated_at = $3
+		WHERE id = $4 AND courier_id IS NULL
+		  AND ((merchant_id IS NULL AND status = $5) OR (merchant_id IS NOT NULL AND status = ANY($6)))
+		RETURNING status, merchant_id IS NOT NULL
 	`
-	_, err = tx.Exec(courierUpdateQuery, models.CourierStatusBusy, time.Now(), courierID)
-	if err != nil {
-		return fmt.Errorf("failed to update courier status: %w", err)
+	for _, orderID := range orderIDs {
+		var newStatus models.OrderStatus
+		var merchantOrder bool
+		err := tx.QueryRow(orderQuery, courierID, models.OrderStatusAccepted, now, orderID, models.OrderStatusCreated,
+			pq.Array(merchantAssignableStatuses)).Scan(&newStatus, &merchantOrder)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return orderNotAssignableError(tx, orderID)
+			}
+			return fmt.Errorf("failed to assign order to courier: %w", err)
+		}
+
+		oldStatus := models.OrderStatusCreated
+		if merchantOrder {
+			oldStatus = newStatus
+		}
+		change := &models.OrderStatusChangedEvent{
+			OrderID:   orderID,
+			OldStatus: oldStatus,
+			NewStatus: newStatus,
+			CourierID: &courierID,
+			Timestamp: now,
+		}
+		if _, err = s.events.Append(tx, orderID, models.EventTypeCourierAssigned, change, actor); err != nil {
+			return err
+		}
+		if err = s.outbox.EnqueueCourierAssigned(tx, orderID, courierID, now); err != nil {
+			return err
+		}
+	}
+
+	// Курьер становится занятым, только когда его вместимость исчерпана
+	if err = syncCourierLoadStatus(tx, courierID, s.cfg.DefaultCapacity, now); err != nil {
+		return err
 	}
 
 	if err = tx.Commit(); err != nil {
@@ -237,9 +393,37 @@ func (s *CourierService) AssignOrderToCourier(orderID, courierID uuid.UUID) erro
 	}
 
 	s.log.WithFields(map[string]interface{}{
-		"order_id":   orderID,
+		"order_ids":  orderIDs,
 		"courier_id": courierID,
-	}).Info("Order assigned to courier successfully")
+		"load":       load + len(orderIDs),
+		"capacity":   limit,
+	}).Info("Orders assigned to courier successfully")
+
+	return nil
+}
+
+// execer - общий интерфейс *sql.DB и *sql.Tx для выполнения запросов без результата
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
 
+// syncCourierLoadStatus выставляет курьеру на линии статус по загрузке: busy, если число активных заказов
+// достигло вместимости, иначе available. Статус курьера не на линии не меняется
+func syncCourierLoadStatus(db execer, courierID uuid.UUID, defaultCapacity int, now time.Time) error {
+	query := `
+		UPDATE couriers c
+		SET status = CASE
+		        WHEN (SELECT COUNT(*) FROM orders o WHERE o.courier_id = c.id AND o.status = ANY($2))
+		             >= COALESCE(c.capacity, $3) THEN $4
+		        ELSE $5
+		    END,
+		    updated_at = $6
+		WHERE c.id = $1 AND c.status IN ($4, $5)
+	`
+	_, err := db.Exec(query, courierID, pq.Array(activeOrderStatuses), defaultCapacity,
+		models.CourierStatusBusy, models.CourierStatusAvailable, now)
+	if err != nil {
+		return fmt.Errorf("failed to update courier status: %w", err)
+	}
 	return nil
 }
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// customerColumns - список колонок клиента в порядке сканирования scanCustomer
+const customerColumns = `id, name, phone, created_at, updated_at`
+
+// CustomerService - сервис клиентов и их сохранённых адресов
+type CustomerService struct {
+	db  *database.DB
+	log *logger.Logger
+	geo GeolocationServiceInterface
+}
+
+// NewCustomerService создаёт новый экземпляр сервиса клиентов
+func NewCustomerService(db *database.DB, log *logger.Logger, geo GeolocationServiceInterface) *CustomerService {
+	return &CustomerService{
+		db:  db,
+		log: log,
+		geo: geo,
+	}
+}
+
+// CreateCustomer создаёт нового клиента
+func (s *CustomerService) CreateCustomer(req *models.CustomerRequest) (*models.Customer, error) {
+	query := `
+		INSERT INTO customers (id, name, phone)
+		VALUES ($1, $2, $3)
+		RETURNING ` + customerColumns
+
+	customer, err := scanCustomer(s.db.QueryRow(query, uuid.New(), req.Name, models.NormalizePhone(req.Phone)))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("customer already exists")
+		}
+		return nil, fmt.Errorf("failed to create customer: %w", err)
+	}
+
+	s.log.WithField("customer_id", customer.ID).Info("Customer created successfully")
+
+	return customer, nil
+}
+
+// GetCustomer получает клиента по ID вместе с сохранёнными адресами
+func (s *CustomerService) GetCustomer(customerID uuid.UUID) (*models.Customer, error) {
+	query := `SELECT ` + customerColumns + ` FROM customers WHERE id = $1`
+
+	customer, err := scanCustomer(s.db.QueryRow(query, customerID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("customer not found")
+		}
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+
+	if customer.Addresses, err = s.getAddresses(customerID); err != nil {
+		return nil, err
+	}
+
+	return customer, nil
+}
+
+// GetCustomers получает список клиентов. Телефон, если указан, сравнивается в нормализованном виде
+func (s *CustomerService) GetCustomers(phone string, limit, offset int) ([]*models.Customer, error) {
+	query := `SELECT ` + customerColumns + ` FROM customers WHERE 1=1`
+	args := []interface{}{}
+	argIndex := 1
+
+	if phone != "" {
+		query += fmt.Sprintf(" AND phone = $%d", argIndex)
+		args = append(args, models.NormalizePhone(phone))
+		argIndex++
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, limit)
+		argIndex++
+	}
+
+	if offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, offset)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customers: %w", err)
+	}
+	defer rows.Close()
+
+	var customers []*models.Customer
+	for rows.Next() {
+		customer, err := scanCustomer(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan customer: %w", err)
+		}
+		customers = append(customers, customer)
+	}
+
+	return customers, nil
+}
+
+// UpdateCustomer обновляет имя и телефон клиента. Заказы, созданные ранее, сохраняют имя и телефон,
+// указанные при их создании
+func (s *CustomerService) UpdateCustomer(customerID uuid.UUID, req *models.CustomerRequest) (*models.Customer, error) {
+	query := `
+		UPDATE customers SET name = $1, phone = $2
+		WHERE id = $3
+		RETURNING ` + customerColumns
+
+	customer, err := scanCustomer(s.db.QueryRow(query, req.Name, models.NormalizePhone(req.Phone), customerID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("customer not found")
+		}
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("customer already exists")
+		}
+		return nil, fmt.Errorf("failed to update customer: %w", err)
+	}
+
+	if customer.Addresses, err = s.getAddresses(customerID); err != nil {
+		return nil, err
+	}
+
+	s.log.WithField("customer_id", customer.ID).Info("Customer updated")
+
+	return customer, nil
+}
+
+// AddAddress сохраняет адрес клиента. Координаты адреса определяются один раз при сохранении
+func (s *CustomerService) AddAddress(customerID uuid.UUID, req *models.CustomerAddressRequest) (*models.CustomerAddress, error) {
+	var exists bool
+	if err := s.db.QueryRow("SELECT EXISTS(SELECT 1 FROM customers WHERE id = $1)", customerID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("customer not found")
+	}
+
+	lng, lat, err := s.geo.GetCoordinates(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	address := &models.CustomerAddress{
+		ID:         uuid.New(),
+		CustomerID: customerID,
+		Label:      optionalString(req.Label),
+		Address:    req.Address,
+		Lat:        lat,
+		Lon:        lng,
+		CreatedAt:  time.Now(),
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO customer_addresses (id, customer_id, label, address, lat, lon, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, address.ID, address.CustomerID, address.Label, address.Address, address.Lat, address.Lon, address.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save customer address: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"customer_id": customerID,
+		"address_id":  address.ID,
+	}).Info("Customer address saved")
+
+	return address, nil
+}
+
+// DeleteAddress удаляет сохранённый адрес клиента. Заказы, созданные с этим адресом, не изменяются
+func (s *CustomerService) DeleteAddress(customerID, addressID uuid.UUID) error {
+	result, err := s.db.Exec("DELETE FROM customer_addresses WHERE id = $1 AND customer_id = $2", addressID, customerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete customer address: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("customer address not found")
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"customer_id": customerID,
+		"address_id":  addressID,
+	}).Info("Customer address deleted")
+
+	return nil
+}
+
+// GetCustomerOrders получает историю заказов клиента, начиная с последних
+func (s *CustomerService) GetCustomerOrders(customerID uuid.UUID, limit, offset int) ([]*models.Order, error) {
+	var exists bool
+	if err := s.db.QueryRow("SELECT EXISTS(SELECT 1 FROM customers WHERE id = $1)", customerID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("customer not found")
+	}
+
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE customer_id = $1 ORDER BY created_at DESC`
+	args := []interface{}{customerID}
+	if limit > 0 {
+		query += " LIMIT $2"
+		args = append(args, limit)
+	}
+	if offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", len(args)+1)
+		args = append(args, offset)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := []*models.Order{}
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	return orders, nil
+}
+
+// getAddresses получает сохранённые адреса клиента
+func (s *CustomerService) getAddresses(customerID uuid.UUID) ([]models.CustomerAddress, error) {
+	rows, err := s.db.Query(`
+		SELECT id, customer_id, label, address, lat, lon, created_at
+		FROM customer_addresses
+		WHERE customer_id = $1
+		ORDER BY created_at
+	`, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get customer addresses: %w", err)
+	}
+	defer rows.Close()
+
+	addresses := []models.CustomerAddress{}
+	for rows.Next() {
+		var address models.CustomerAddress
+		if err := rows.Scan(&address.ID, &address.CustomerID, &address.Label, &address.Address,
+			&address.Lat, &address.Lon, &address.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan customer address: %w", err)
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
+
+// linkCustomer возвращает ID клиента с телефоном заказа, создавая клиента при первом заказе.
+// Для телефона без цифр клиент не определяется
+func linkCustomer(tx *sql.Tx, name, phone string, now time.Time) (*uuid.UUID, error) {
+	phone = models.NormalizePhone(phone)
+	if phone == "" {
+		return nil, nil
+	}
+
+	// Обновление при конфликте нужно, чтобы RETURNING вернул ID уже существующего клиента
+	var customerID uuid.UUID
+	err := tx.QueryRow(`
+		INSERT INTO customers (id, name, phone, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $4)
+		ON CONFLICT (phone) DO UPDATE SET name = customers.name
+		RETURNING id
+	`, uuid.New(), name, phone, now).Scan(&customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link customer: %w", err)
+	}
+	return &customerID, nil
+}
+
+func scanCustomer(row rowScanner) (*models.Customer, error) {
+	customer := &models.Customer{}
+	err := row.Scan(&customer.ID, &customer.Name, &customer.Phone, &customer.CreatedAt, &customer.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return customer, nil
+}
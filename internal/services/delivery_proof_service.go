@@ -0,0 +1,280 @@
+package services
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// proofFileExtensions - расширения файлов изображений по типу содержимого
+var proofFileExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// DeliveryProofService - сервис подтверждения доставки заказа. Курьер подтверждает доставку PIN-кодом,
+// выданным покупателю при создании заказа, и своим местоположением рядом с точкой доставки.
+// Фото и подпись получателя сохраняются в хранилище файлов
+type DeliveryProofService struct {
+	db       *database.DB
+	log      *logger.Logger
+	geo      GeolocationServiceInterface
+	blobs    BlobStore
+	events   *OrderEventService
+	outbox   *OutboxService
+	batching *config.BatchingConfig
+	cfg      *config.DeliveryProofConfig
+}
+
+// NewDeliveryProofService создаёт новый экземпляр сервиса подтверждения доставки
+func NewDeliveryProofService(
+	db *database.DB,
+	log *logger.Logger,
+	geo GeolocationServiceInterface,
+	blobs BlobStore,
+	events *OrderEventService,
+	outbox *OutboxService,
+	batching *config.BatchingConfig,
+	cfg *config.DeliveryProofConfig,
+) *DeliveryProofService {
+	return &DeliveryProofService{
+		db:       db,
+		log:      log,
+		geo:      geo,
+		blobs:    blobs,
+		events:   events,
+		outbox:   outbox,
+		batching: batching,
+		cfg:      cfg,
+	}
+}
+
+// ConfirmDelivery подтверждает доставку заказа и переводит его в статус delivered. PIN-код проверяется
+// для заказов, созданных с ним; после MaxPinAttempts неверных вводов подтверждение блокируется.
+// Местоположение курьера должно быть не дальше LocationTolerance от точки доставки
+func (s *DeliveryProofService) ConfirmDelivery(orderID uuid.UUID, req *models.DeliveryProofRequest, actor models.Actor) (*models.DeliveryProof, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Блокируем строку заказа, чтобы попытки ввода PIN-кода учитывались последовательно
+	var status models.OrderStatus
+	var courierID *uuid.UUID
+	var deliveryAddress string
+	var pin sql.NullString
+	var pinAttempts int
+	var deliveryLat, deliveryLon sql.NullFloat64
+	err = tx.QueryRow(`
+		SELECT status, courier_id, delivery_address, delivery_pin, delivery_pin_attempts, delivery_lat, delivery_lon
+		FROM orders WHERE id = $1 FOR UPDATE
+	`, orderID).Scan(&status, &courierID, &deliveryAddress, &pin, &pinAttempts, &deliveryLat, &deliveryLon)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("order not found")
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if !status.CanTransitionTo(models.OrderStatusDelivered, actor.Role) {
+		return nil, &InvalidTransitionError{From: status, To: models.OrderStatusDelivered, Role: actor.Role}
+	}
+	if courierID == nil {
+		return nil, &DeliveryProofError{OrderID: orderID, Reason: "order has no courier"}
+	}
+	if actor.Role == models.RoleCourier && courierID.String() != actor.ID {
+		return nil, &InvalidTransitionError{
+			From: status, To: models.OrderStatusDelivered, Role: actor.Role,
+			Reason: "order is not assigned to this courier",
+		}
+	}
+
+	if pin.Valid {
+		if pinAttempts >= s.cfg.MaxPinAttempts {
+			return nil, &DeliveryProofError{OrderID: orderID, Reason: "too many invalid PIN attempts"}
+		}
+		if subtle.ConstantTimeCompare([]byte(pin.String), []byte(req.Pin)) != 1 {
+			// Неверная попытка сохраняется, даже если подтверждение отклонено
+			if _, err = tx.Exec("UPDATE orders SET delivery_pin_attempts = delivery_pin_attempts + 1 WHERE id = $1", orderID); err != nil {
+				return nil, fmt.Errorf("failed to record pin attempt: %w", err)
+			}
+			if err = tx.Commit(); err != nil {
+				return nil, fmt.Errorf("failed to commit transaction: %w", err)
+			}
+			return nil, &DeliveryProofError{
+				OrderID: orderID,
+				Reason:  fmt.Sprintf("invalid PIN, %d attempts left", s.cfg.MaxPinAttempts-pinAttempts-1),
+			}
+		}
+	}
+
+	lat, lon, err := s.deliveryCoordinates(orderID, deliveryAddress, deliveryLat, deliveryLon)
+	if err != nil {
+		return nil, err
+	}
+	distance := haversineDistance(req.Lat, req.Lon, lat, lon)
+	if distance > float64(s.cfg.LocationTolerance) {
+		return nil, &DeliveryProofError{
+			OrderID: orderID,
+			Reason: fmt.Sprintf("courier is %.0f m away from the delivery address, at most %d m allowed",
+				distance, s.cfg.LocationTolerance),
+		}
+	}
+
+	now := time.Now()
+	proof := &models.DeliveryProof{
+		OrderID:        orderID,
+		CourierID:      courierID,
+		PinVerified:    pin.Valid,
+		Lat:            req.Lat,
+		Lon:            req.Lon,
+		DistanceMeters: roundAmount(distance),
+		DeliveredAt:    now,
+	}
+
+	// Файлы сохраняются до фиксации транзакции: при её откате в хранилище останутся только лишние файлы
+	if proof.PhotoKey, err = s.saveFile(orderID, models.ProofFilePhoto, req.Photo); err != nil {
+		return nil, err
+	}
+	if proof.SignatureKey, err = s.saveFile(orderID, models.ProofFileSignature, req.Signature); err != nil {
+		return nil, err
+	}
+
+	if err = setCurrentActor(tx, actor); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec("UPDATE orders SET status = $1, updated_at = $2, delivered_at = $2 WHERE id = $3",
+		models.OrderStatusDelivered, now, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO delivery_proofs (order_id, courier_id, pin_verified, lat, lon, distance_m, photo_key, signature_key, delivered_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, orderID, proof.CourierID, proof.PinVerified, proof.Lat, proof.Lon, proof.DistanceMeters,
+		proof.PhotoKey, proof.SignatureKey, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save delivery proof: %w", err)
+	}
+
+	if err = syncCourierLoadStatus(tx, *courierID, s.batching.DefaultCapacity, now); err != nil {
+		return nil, err
+	}
+
+	change := &models.OrderStatusChangedEvent{
+		OrderID:   orderID,
+		OldStatus: status,
+		NewStatus: models.OrderStatusDelivered,
+		CourierID: courierID,
+		Timestamp: now,
+	}
+	if _, err = s.events.Append(tx, orderID, models.EventTypeOrderStatusChanged, change, actor); err != nil {
+		return nil, err
+	}
+	if err = s.outbox.EnqueueOrderStatusChanged(tx, change); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"order_id":     orderID,
+		"courier_id":   courierID,
+		"pin_verified": proof.PinVerified,
+		"distance_m":   proof.DistanceMeters,
+		"photo":        proof.PhotoKey != nil,
+		"signature":    proof.SignatureKey != nil,
+	}).Info("Order delivery confirmed")
+
+	return proof, nil
+}
+
+// GetDeliveryProof получает подтверждение доставки заказа
+func (s *DeliveryProofService) GetDeliveryProof(orderID uuid.UUID) (*models.DeliveryProof, error) {
+	proof := &models.DeliveryProof{}
+	err := s.db.QueryRow(`
+		SELECT order_id, courier_id, pin_verified, lat, lon, distance_m, photo_key, signature_key, delivered_at
+		FROM delivery_proofs
+		WHERE order_id = $1
+	`, orderID).Scan(&proof.OrderID, &proof.CourierID, &proof.PinVerified, &proof.Lat, &proof.Lon,
+		&proof.DistanceMeters, &proof.PhotoKey, &proof.SignatureKey, &proof.DeliveredAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("delivery proof not found")
+		}
+		return nil, fmt.Errorf("failed to get delivery proof: %w", err)
+	}
+	return proof, nil
+}
+
+// GetDeliveryProofFile возвращает фото или подпись из подтверждения доставки заказа
+func (s *DeliveryProofService) GetDeliveryProofFile(orderID uuid.UUID, kind models.ProofFileKind) ([]byte, error) {
+	proof, err := s.GetDeliveryProof(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	key := proof.PhotoKey
+	if kind == models.ProofFileSignature {
+		key = proof.SignatureKey
+	}
+	if key == nil {
+		return nil, fmt.Errorf("delivery proof %s not found", kind)
+	}
+
+	data, err := s.blobs.Get(*key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delivery proof %s: %w", kind, err)
+	}
+	return data, nil
+}
+
+// deliveryCoordinates возвращает координаты (lat, lon) точки доставки заказа из закешированных геоданных
+// заказа, из сохранённых в заказе координат либо через геокодер
+func (s *DeliveryProofService) deliveryCoordinates(orderID uuid.UUID, address string, lat, lon sql.NullFloat64) (float64, float64, error) {
+	if cached, err := s.geo.GetOrderGeolocation(orderID); err == nil {
+		return cached.DeliveryCoordinates[1], cached.DeliveryCoordinates[0], nil
+	}
+	if lat.Valid && lon.Valid {
+		return lat.Float64, lon.Float64, nil
+	}
+
+	lng, latitude, err := s.geo.GetCoordinates(address)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get delivery coordinates: %w", err)
+	}
+	return latitude, lng, nil
+}
+
+// saveFile сохраняет изображение подтверждения доставки и возвращает его ключ в хранилище
+func (s *DeliveryProofService) saveFile(orderID uuid.UUID, kind models.ProofFileKind, file *models.ProofFile) (*string, error) {
+	if file == nil {
+		return nil, nil
+	}
+
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = http.DetectContentType(file.Data)
+	}
+	key := fmt.Sprintf("delivery-proofs/%s/%s%s", orderID, kind, proofFileExtensions[contentType])
+
+	if err := s.blobs.Put(key, contentType, file.Data); err != nil {
+		return nil, fmt.Errorf("failed to save delivery proof %s: %w", kind, err)
+	}
+	return &key, nil
+}
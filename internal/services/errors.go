@@ -0,0 +1,110 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"delivery-system/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ErrAddressNotFound возвращается, если ни один геокодер не нашёл адрес
+var ErrAddressNotFound = errors.New("address not found")
+
+// ErrRouteNotFound возвращается, если между точками не удалось построить маршрут
+var ErrRouteNotFound = errors.New("route not found")
+
+// ErrGeoUnavailable возвращается, если все провайдеры геосервиса недоступны
+var ErrGeoUnavailable = errors.New("geo providers unavailable")
+
+// ErrOrderAlreadyAssigned возвращается, если заказ уже назначен курьеру или больше не ожидает назначения
+var ErrOrderAlreadyAssigned = errors.New("order already assigned")
+
+// ErrNoActiveTariff возвращается, если на момент расчёта не действует ни один подходящий тариф
+var ErrNoActiveTariff = errors.New("no active tariff")
+
+// ErrOutsideDeliveryArea возвращается, если точка маршрута не попадает ни в одну активную зону доставки
+var ErrOutsideDeliveryArea = errors.New("address is outside the delivery area")
+
+// ErrRouteTooLong возвращается, если длина маршрута превышает допустимую для зоны
+var ErrRouteTooLong = errors.New("route is too long")
+
+// ErrScheduleTooFar возвращается, если время отложенного заказа дальше допустимого горизонта планирования
+var ErrScheduleTooFar = errors.New("scheduled time is too far in the future")
+
+// ErrCustomerNotFound возвращается, если клиент, указанный в заказе, не найден
+var ErrCustomerNotFound = errors.New("customer not found")
+
+// ErrCustomerAddressNotFound возвращается, если у клиента нет сохранённого адреса, указанного в заказе
+var ErrCustomerAddressNotFound = errors.New("customer address not found")
+
+// ErrMerchantNotFound возвращается, если продавец, указанный в заказе, не найден
+var ErrMerchantNotFound = errors.New("merchant not found")
+
+// ErrMerchantUnavailable возвращается, если продавец не принимает заказы: отключён или не работает в это время
+var ErrMerchantUnavailable = errors.New("merchant is not accepting orders")
+
+// ErrProductUnavailable возвращается, если товара нет в каталоге продавца или он недоступен для заказа
+var ErrProductUnavailable = errors.New("product is unavailable")
+
+// ErrPrepTimeTooLong возвращается, если продавец заявил время приготовления больше допустимого
+var ErrPrepTimeTooLong = errors.New("preparation time is too long")
+
+// ErrInvalidToken возвращается, если токен доступа не прошёл проверку
+var ErrInvalidToken = errors.New("invalid token")
+
+// ErrInvalidAPIKey возвращается, если API-ключ неизвестен, отозван или истёк
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// ErrAPIKeyInactive возвращается при попытке перевыпустить отозванный или истёкший API-ключ
+var ErrAPIKeyInactive = errors.New("api key is revoked or expired")
+
+// ErrBlobNotFound возвращается, если файла с указанным ключом нет в хранилище
+var ErrBlobNotFound = errors.New("blob not found")
+
+// InvalidTransitionError возвращается при попытке недопустимого перехода статуса заказа
+type InvalidTransitionError struct {
+	From   models.OrderStatus
+	To     models.OrderStatus
+	Role   models.Role
+	Reason string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	msg := fmt.Sprintf("invalid order status transition from %s to %s for role %s", e.From, e.To, e.Role)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+// PromoCodeError возвращается, если промокод не может быть применён к заказу
+type PromoCodeError struct {
+	Code   string
+	Reason string
+}
+
+func (e *PromoCodeError) Error() string {
+	return fmt.Sprintf("promo code %s cannot be applied: %s", e.Code, e.Reason)
+}
+
+// ShiftError возвращается, если действие со сменой курьера недопустимо в её текущем состоянии
+type ShiftError struct {
+	CourierID uuid.UUID
+	Reason    string
+}
+
+func (e *ShiftError) Error() string {
+	return fmt.Sprintf("shift action rejected for courier %s: %s", e.CourierID, e.Reason)
+}
+
+// DeliveryProofError возвращается, если подтверждение доставки заказа отклонено
+type DeliveryProofError struct {
+	OrderID uuid.UUID
+	Reason  string
+}
+
+func (e *DeliveryProofError) Error() string {
+	return fmt.Sprintf("delivery proof rejected for order %s: %s", e.OrderID, e.Reason)
+}
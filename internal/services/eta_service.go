@@ -0,0 +1,512 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/redis"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// etaStatsTTL - время, в течение которого используется рассчитанная средняя длительность этапов заказа
+const etaStatsTTL = 10 * time.Minute
+
+// maxAtRiskOrders - максимальное количество заказов под угрозой нарушения SLA в одном ответе
+const maxAtRiskOrders = 500
+
+// etaStages - этапы заказа до передачи курьеру в порядке прохождения. Время в доставке
+// прогнозируется по длине маршрута
+var etaStages = []models.OrderStatus{
+	models.OrderStatusCreated,
+	models.OrderStatusAccepted,
+	models.OrderStatusPreparing,
+	models.OrderStatusReady,
+}
+
+// etaOrder - состояние заказа, по которому прогнозируется время доставки. Координаты в порядке [lng, lat]
+type etaOrder struct {
+	ID          uuid.UUID
+	Status      models.OrderStatus
+	StatusSince time.Time
+	Pickup      [2]float64
+	Delivery    [2]float64
+	Courier     *[2]float64
+}
+
+// ETAService - сервис прогноза времени доставки и контроля SLA. Прогноз складывается из средней
+// длительности оставшихся этапов по истории статусов и времени в пути по маршруту с учётом текущего
+// местоположения курьера. Фоновый процесс отмечает заказы с нарушенным сроком доставки
+// и публикует событие нарушения через outbox
+type ETAService struct {
+	db          *database.DB
+	log         *logger.Logger
+	geo         GeolocationServiceInterface
+	redisClient redis.RedisClientInterface
+	outbox      *OutboxService
+	cfg         *config.ETAConfig
+	sla         *config.SLAConfig
+
+	statsMu sync.Mutex
+	stats   map[models.OrderStatus]time.Duration
+	statsAt time.Time
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewETAService создаёт новый экземпляр сервиса прогноза времени доставки
+func NewETAService(
+	db *database.DB,
+	log *logger.Logger,
+	geo GeolocationServiceInterface,
+	redisClient redis.RedisClientInterface,
+	outbox *OutboxService,
+	cfg *config.ETAConfig,
+	sla *config.SLAConfig,
+) *ETAService {
+	return &ETAService{
+		db:          db,
+		log:         log,
+		geo:         geo,
+		redisClient: redisClient,
+		outbox:      outbox,
+		cfg:         cfg,
+		sla:         sla,
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+}
+
+// Start запускает периодическую проверку нарушений SLA
+func (s *ETAService) Start() {
+	if !s.sla.Enabled {
+		close(s.done)
+		s.log.Info("SLA monitoring is disabled")
+		return
+	}
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(time.Duration(s.sla.Interval) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				if _, err := s.CheckBreaches(); err != nil {
+					s.log.WithError(err).Error("Failed to check SLA breaches")
+				}
+			}
+		}
+	}()
+
+	s.log.Info("SLA monitoring started")
+}
+
+// Stop останавливает проверку нарушений SLA и дожидается завершения текущего прохода
+func (s *ETAService) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+		s.log.Info("SLA monitoring stopped")
+	})
+}
+
+// SLADeadline возвращает срок доставки заказа, созданного в createdAt, по SLA зоны
+// или по значению по умолчанию из настроек
+func (s *ETAService) SLADeadline(zone *models.DeliveryZone, createdAt time.Time) time.Time {
+	minutes := s.sla.DefaultMinutes
+	if zone != nil && zone.SLAMinutes != nil {
+		minutes = *zone.SLAMinutes
+	}
+	return createdAt.Add(time.Duration(minutes) * time.Minute)
+}
+
+// EstimateNew прогнозирует время доставки нового заказа с маршрутом длиной distance метров:
+// все этапы до передачи курьеру и путь от точки получения до точки доставки
+func (s *ETAService) EstimateNew(distance float64, now time.Time) time.Time {
+	stats := s.stageDurations()
+
+	var wait time.Duration
+	for _, stage := range etaStages {
+		wait += stats[stage]
+	}
+	return now.Add(wait + s.travelTime(distance))
+}
+
+// RefreshOrder пересчитывает прогноз времени доставки активного заказа и сохраняет его в заказе.
+// Для доставленных и отменённых заказов прогноз не пересчитывается, для отложенных он остаётся рассчитанным
+// при создании до передачи заказа в работу
+func (s *ETAService) RefreshOrder(orderID uuid.UUID) error {
+	var order etaOrder
+	var pickupAddress, deliveryAddress string
+	var pickupLat, pickupLon, deliveryLat, deliveryLon, courierLat, courierLon sql.NullFloat64
+	query := `
+		SELECT o.id, o.status, o.pickup_address, o.delivery_address,
+		       o.pickup_lat, o.pickup_lon, o.delivery_lat, o.delivery_lon, c.current_lat, c.current_lon,
+		       COALESCE((SELECT MAX(h.changed_at) FROM order_status_history h WHERE h.order_id = o.id), o.created_at)
+		FROM orders o
+		LEFT JOIN couriers c ON c.id = o.courier_id
+		WHERE o.id = $1`
+	err := s.db.QueryRow(query, orderID).Scan(&order.ID, &order.Status, &pickupAddress, &deliveryAddress,
+		&pickupLat, &pickupLon, &deliveryLat, &deliveryLon, &courierLat, &courierLon, &order.StatusSince)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("order not found")
+		}
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if order.Status == models.OrderStatusDelivered || order.Status == models.OrderStatusCancelled ||
+		order.Status == models.OrderStatusScheduled {
+		return nil
+	}
+
+	if order.Pickup, err = s.coordinates(pickupLat, pickupLon, pickupAddress); err != nil {
+		return err
+	}
+	if order.Delivery, err = s.coordinates(deliveryLat, deliveryLon, deliveryAddress); err != nil {
+		return err
+	}
+	if courierLat.Valid && courierLon.Valid {
+		order.Courier = &[2]float64{courierLon.Float64, courierLat.Float64}
+	}
+
+	now := time.Now()
+	eta, err := s.estimate(&order, now)
+	if err != nil {
+		return err
+	}
+
+	// Статус проверяется повторно: заказ мог быть закрыт, пока строился маршрут
+	_, err = s.db.Exec(`
+		UPDATE orders SET eta = $1, eta_updated_at = $2
+		WHERE id = $3 AND status NOT IN ($4, $5)
+	`, eta, now, orderID, models.OrderStatusDelivered, models.OrderStatusCancelled)
+	if err != nil {
+		return fmt.Errorf("failed to update order eta: %w", err)
+	}
+
+	if err = s.redisClient.Delete(context.Background(), redis.GenerateKey(redis.KeyPrefixOrder, orderID.String())); err != nil {
+		s.log.WithError(err).Error("Failed to invalidate cache")
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"order_id": orderID,
+		"status":   order.Status,
+		"eta":      eta,
+	}).Debug("Order ETA updated")
+
+	return nil
+}
+
+// RefreshCourierOrders пересчитывает прогноз для активных заказов курьера после обновления его
+// местоположения. Прогноз заказа пересчитывается не чаще, чем раз в LocationRefresh секунд
+func (s *ETAService) RefreshCourierOrders(courierID uuid.UUID) error {
+	query := `
+		SELECT id FROM orders
+		WHERE courier_id = $1 AND status = ANY($2) AND (eta_updated_at IS NULL OR eta_updated_at < $3)`
+
+	since := time.Now().Add(-time.Duration(s.cfg.LocationRefresh) * time.Second)
+	rows, err := s.db.Query(query, courierID, pq.Array(activeOrderStatuses), since)
+	if err != nil {
+		return fmt.Errorf("failed to get courier orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orderIDs []uuid.UUID
+	for rows.Next() {
+		var orderID uuid.UUID
+		if err := rows.Scan(&orderID); err != nil {
+			return fmt.Errorf("failed to scan order id: %w", err)
+		}
+		orderIDs = append(orderIDs, orderID)
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to get courier orders: %w", err)
+	}
+
+	for _, orderID := range orderIDs {
+		if err = s.RefreshOrder(orderID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetAtRiskOrders возвращает активные заказы, срок доставки которых нарушен, истекает в ближайшие
+// AtRiskMinutes минут или будет нарушен по прогнозу. Заказы упорядочены по сроку доставки
+func (s *ETAService) GetAtRiskOrders(limit int) ([]*models.AtRiskOrder, error) {
+	if limit <= 0 || limit > maxAtRiskOrders {
+		limit = maxAtRiskOrders
+	}
+
+	now := time.Now()
+	query := `
+		SELECT id, status, courier_id, zone, eta, sla_deadline, sla_breached_at
+		FROM orders
+		WHERE sla_deadline IS NOT NULL AND status NOT IN ($1, $2)
+		  AND (sla_deadline <= $3 OR eta > sla_deadline)
+		ORDER BY sla_deadline
+		LIMIT $4`
+
+	threshold := now.Add(time.Duration(s.sla.AtRiskMinutes) * time.Minute)
+	rows, err := s.db.Query(query, models.OrderStatusDelivered, models.OrderStatusCancelled, threshold, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get at-risk orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := []*models.AtRiskOrder{}
+	for rows.Next() {
+		order := &models.AtRiskOrder{}
+		if err := rows.Scan(&order.OrderID, &order.Status, &order.CourierID, &order.Zone, &order.ETA,
+			&order.SLADeadline, &order.BreachedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan at-risk order: %w", err)
+		}
+
+		order.MinutesLeft = roundAmount(order.SLADeadline.Sub(now).Minutes())
+		order.Breached = order.BreachedAt != nil || !order.SLADeadline.After(now)
+		if order.ETA != nil && order.ETA.After(order.SLADeadline) {
+			order.DelayMinutes = roundAmount(order.ETA.Sub(order.SLADeadline).Minutes())
+		}
+		orders = append(orders, order)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get at-risk orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// CheckBreaches отмечает активные заказы с истёкшим сроком доставки и в той же транзакции записывает
+// событие нарушения SLA в outbox. Каждое нарушение публикуется один раз. Возвращает количество нарушений
+func (s *ETAService) CheckBreaches() (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	query := `
+		UPDATE orders SET sla_breached_at = $1
+		WHERE id IN (
+			SELECT id FROM orders
+			WHERE sla_deadline < $1 AND sla_breached_at IS NULL AND status NOT IN ($2, $3)
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, status, courier_id, zone, sla_deadline, eta`
+
+	rows, err := tx.Query(query, now, models.OrderStatusDelivered, models.OrderStatusCancelled)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark SLA breaches: %w", err)
+	}
+
+	var breaches []*models.OrderSLABreachedEvent
+	for rows.Next() {
+		breach := &models.OrderSLABreachedEvent{Timestamp: now}
+		if err := rows.Scan(&breach.OrderID, &breach.Status, &breach.CourierID, &breach.Zone,
+			&breach.SLADeadline, &breach.ETA); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan SLA breach: %w", err)
+		}
+		breaches = append(breaches, breach)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to mark SLA breaches: %w", err)
+	}
+
+	for _, breach := range breaches {
+		if err = s.outbox.EnqueueOrderSLABreached(tx, breach); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	for _, breach := range breaches {
+		s.log.WithFields(map[string]interface{}{
+			"order_id":     breach.OrderID,
+			"status":       breach.Status,
+			"courier_id":   breach.CourierID,
+			"sla_deadline": breach.SLADeadline,
+		}).Warn("Order SLA breached")
+	}
+
+	return len(breaches), nil
+}
+
+// estimate прогнозирует время доставки заказа. До передачи курьеру заказ ждёт окончания оставшихся этапов
+// (время в текущем этапе вычитается из его средней длительности) или прибытия назначенного курьера,
+// если тот дальше, затем едет от точки получения до точки доставки. Заказ в доставке едет
+// от текущего местоположения курьера
+func (s *ETAService) estimate(order *etaOrder, now time.Time) (time.Time, error) {
+	if order.Status == models.OrderStatusInDelivery {
+		from := order.Pickup
+		if order.Courier != nil {
+			from = *order.Courier
+		}
+		travel, err := s.routeTime(from, order.Delivery)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(travel), nil
+	}
+
+	stats := s.stageDurations()
+
+	var wait time.Duration
+	started := false
+	for _, stage := range etaStages {
+		if stage == order.Status {
+			started = true
+			if remaining := stats[stage] - now.Sub(order.StatusSince); remaining > 0 {
+				wait += remaining
+			}
+			continue
+		}
+		if started {
+			wait += stats[stage]
+		}
+	}
+
+	if order.Courier != nil {
+		arrival, err := s.routeTime(*order.Courier, order.Pickup)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if arrival > wait {
+			wait = arrival
+		}
+	}
+
+	travel, err := s.routeTime(order.Pickup, order.Delivery)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return now.Add(wait + travel), nil
+}
+
+// stageDurations возвращает среднюю длительность этапов заказа за последние HistoryDays дней.
+// Этапы без истории принимаются равными DefaultStageMinutes. Результат кешируется на etaStatsTTL
+func (s *ETAService) stageDurations() map[models.OrderStatus]time.Duration {
+	s.statsMu.Lock()
+	defer s.statsMu.Unlock()
+
+	if s.stats != nil && time.Since(s.statsAt) < etaStatsTTL {
+		return s.stats
+	}
+
+	stats := make(map[models.OrderStatus]time.Duration, len(etaStages))
+	for _, stage := range etaStages {
+		stats[stage] = time.Duration(s.cfg.DefaultStageMinutes) * time.Minute
+	}
+
+	loaded, err := s.loadStageDurations()
+	if err != nil {
+		// Прогноз строится по значениям по умолчанию до следующей успешной загрузки
+		s.log.WithError(err).Warn("Failed to load order stage durations")
+	}
+	for stage, duration := range loaded {
+		stats[stage] = duration
+	}
+
+	s.stats, s.statsAt = stats, time.Now()
+	return stats
+}
+
+// loadStageDurations рассчитывает среднюю длительность этапов по истории статусов. Длительность этапа -
+// время от перехода в статус (для created - от создания заказа) до перехода из него; переходы в отмену
+// не учитываются
+func (s *ETAService) loadStageDurations() (map[models.OrderStatus]time.Duration, error) {
+	query := `
+		WITH transitions AS (
+			SELECT h.old_status, h.new_status,
+			       h.changed_at - LAG(h.changed_at, 1, o.created_at) OVER (PARTITION BY h.order_id ORDER BY h.changed_at) AS duration
+			FROM order_status_history h
+			JOIN orders o ON o.id = h.order_id
+			WHERE o.created_at >= $1
+		)
+		SELECT old_status, EXTRACT(EPOCH FROM AVG(duration))
+		FROM transitions
+		WHERE old_status = ANY($2) AND new_status <> $3
+		GROUP BY old_status`
+
+	stages := make([]string, len(etaStages))
+	for i, stage := range etaStages {
+		stages[i] = string(stage)
+	}
+
+	since := time.Now().AddDate(0, 0, -s.cfg.HistoryDays)
+	rows, err := s.db.Query(query, since, pq.Array(stages), models.OrderStatusCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order stage durations: %w", err)
+	}
+	defer rows.Close()
+
+	durations := make(map[models.OrderStatus]time.Duration, len(etaStages))
+	for rows.Next() {
+		var stage models.OrderStatus
+		var seconds float64
+		if err := rows.Scan(&stage, &seconds); err != nil {
+			return nil, fmt.Errorf("failed to scan order stage duration: %w", err)
+		}
+		durations[stage] = time.Duration(seconds * float64(time.Second))
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get order stage durations: %w", err)
+	}
+
+	return durations, nil
+}
+
+// routeTime возвращает время в пути между точками по маршруту геосервиса
+func (s *ETAService) routeTime(from, to [2]float64) (time.Duration, error) {
+	distance, err := s.geo.MakeRoute([][2]float64{from, to})
+	if err != nil {
+		return 0, fmt.Errorf("failed to make route: %w", err)
+	}
+	return s.travelTime(distance), nil
+}
+
+// travelTime возвращает время в пути на distance метров со средней скоростью курьера
+func (s *ETAService) travelTime(distance float64) time.Duration {
+	if s.cfg.CourierSpeed <= 0 {
+		return 0
+	}
+	hours := distance / 1000 / s.cfg.CourierSpeed
+	return time.Duration(hours * float64(time.Hour))
+}
+
+// coordinates возвращает сохранённые в заказе координаты точки в порядке [lng, lat]. Для заказов,
+// созданных до сохранения координат, точка определяется геокодером
+func (s *ETAService) coordinates(lat, lon sql.NullFloat64, address string) ([2]float64, error) {
+	if lat.Valid && lon.Valid {
+		return [2]float64{lon.Float64, lat.Float64}, nil
+	}
+
+	lng, latitude, err := s.geo.GetCoordinates(address)
+	if err != nil {
+		return [2]float64{}, fmt.Errorf("failed to get coordinates: %w", err)
+	}
+	return [2]float64{lng, latitude}, nil
+}
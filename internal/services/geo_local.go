@@ -0,0 +1,72 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// LocalGeocoder - детерминированный офлайн-геокодер на основе статической адресной книги.
+// Адресная книга - JSON-объект вида {"адрес": [lng, lat]}. Адреса сравниваются
+// без учёта регистра и лишних пробелов
+type LocalGeocoder struct {
+	addresses map[string][2]float64
+}
+
+// NewLocalGeocoder загружает адресную книгу из файла
+func NewLocalGeocoder(addressBookFile string) (*LocalGeocoder, error) {
+	if addressBookFile == "" {
+		return nil, fmt.Errorf("address book file is required for local geocoder")
+	}
+
+	data, err := os.ReadFile(addressBookFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read address book: %w", err)
+	}
+
+	var book map[string][2]float64
+	if err := json.Unmarshal(data, &book); err != nil {
+		return nil, fmt.Errorf("failed to parse address book: %w", err)
+	}
+
+	return NewLocalGeocoderFromMap(book), nil
+}
+
+// NewLocalGeocoderFromMap создаёт офлайн-геокодер из адресов с координатами (lng, lat)
+func NewLocalGeocoderFromMap(book map[string][2]float64) *LocalGeocoder {
+	addresses := make(map[string][2]float64, len(book))
+	for address, point := range book {
+		addresses[normalizeAddress(address)] = point
+	}
+	return &LocalGeocoder{addresses: addresses}
+}
+
+// GetCoordinates возвращает координаты (lng, lat) адреса из адресной книги
+func (g *LocalGeocoder) GetCoordinates(address string) (float64, float64, error) {
+	point, ok := g.addresses[normalizeAddress(address)]
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: %s", ErrAddressNotFound, address)
+	}
+	return point[0], point[1], nil
+}
+
+// HaversineRouter - офлайн-расчёт длины маршрута как суммы расстояний по прямой между точками
+type HaversineRouter struct{}
+
+// NewHaversineRouter создаёт офлайн-сервис маршрутов
+func NewHaversineRouter() *HaversineRouter {
+	return &HaversineRouter{}
+}
+
+// MakeRoute возвращает длину ломаной через переданные координаты (lng, lat) в метрах
+func (r *HaversineRouter) MakeRoute(coordinates [][2]float64) (float64, error) {
+	if len(coordinates) < 2 {
+		return 0, fmt.Errorf("%w: at least two points are required", ErrRouteNotFound)
+	}
+
+	var distance float64
+	for i := 1; i < len(coordinates); i++ {
+		distance += haversineDistance(coordinates[i-1][1], coordinates[i-1][0], coordinates[i][1], coordinates[i][0])
+	}
+	return distance, nil
+}
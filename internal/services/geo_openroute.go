@@ -0,0 +1,87 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+)
+
+// OpenrouteRouter - построение маршрутов через OpenRouteService Directions API
+type OpenrouteRouter struct {
+	directionsURL string
+	apiKey        string
+	httpClient    *http.Client
+	log           *logger.Logger
+}
+
+// NewOpenrouteRouter создаёт сервис маршрутов OpenRouteService.
+// directionsURL включает профиль передвижения, например .../v2/directions/foot-walking
+func NewOpenrouteRouter(directionsURL, apiKey string, httpClient *http.Client, log *logger.Logger) *OpenrouteRouter {
+	return &OpenrouteRouter{
+		directionsURL: directionsURL,
+		apiKey:        apiKey,
+		httpClient:    httpClient,
+		log:           log,
+	}
+}
+
+// MakeRoute возвращает длину маршрута, построенного по переданным координатам
+func (r *OpenrouteRouter) MakeRoute(coordinates [][2]float64) (float64, error) {
+	requestBody := models.OpenrouteRequest{
+		Coordinates:  coordinates,
+		Instructions: "false",
+		Maneuvers:    "false",
+	}
+
+	jsonBody, err := json.Marshal(requestBody)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to marshal request body")
+		return 0, err
+	}
+
+	req, err := http.NewRequest("POST", r.directionsURL, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		r.log.WithError(err).Error("Failed to create request")
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", r.apiKey)
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to send request")
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		r.log.WithFields(map[string]interface{}{
+			"status_code": resp.StatusCode,
+			"respBody":    string(body),
+		}).Error("Bad response from Openroute API")
+		return 0, fmt.Errorf("bad response with status code %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		r.log.WithError(err).Error("Failed to read response body")
+		return 0, err
+	}
+
+	var apiResponse models.OpenrouteResponse
+	if err = json.Unmarshal(data, &apiResponse); err != nil {
+		r.log.WithError(err).Error("Failed to unmarshal Openroute API response")
+		return 0, err
+	}
+
+	if len(apiResponse.Routes) == 0 {
+		return 0, ErrRouteNotFound
+	}
+	return apiResponse.Routes[0].Summary.Distance, nil
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+)
+
+// NominatimGeocoder - геокодер, совместимый с Nominatim Search API (OpenStreetMap)
+type NominatimGeocoder struct {
+	baseURL    string
+	userAgent  string
+	httpClient *http.Client
+	log        *logger.Logger
+}
+
+// NewNominatimGeocoder создаёт геокодер Nominatim. Публичный Nominatim требует осмысленный User-Agent
+func NewNominatimGeocoder(baseURL, userAgent string, httpClient *http.Client, log *logger.Logger) *NominatimGeocoder {
+	return &NominatimGeocoder{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		userAgent:  userAgent,
+		httpClient: httpClient,
+		log:        log,
+	}
+}
+
+// GetCoordinates возвращает координаты (lng, lat) указанного адреса
+func (g *NominatimGeocoder) GetCoordinates(address string) (float64, float64, error) {
+	queryParams := url.Values{}
+	queryParams.Add("q", address)
+	queryParams.Add("format", "jsonv2")
+	queryParams.Add("limit", "1")
+
+	var places []models.NominatimPlace
+	if err := getGeoJSON(g.httpClient, g.baseURL+"/search?"+queryParams.Encode(), g.userAgent, &places); err != nil {
+		g.log.WithError(err).Error("Failed to get response from Nominatim API")
+		return 0, 0, err
+	}
+
+	if len(places) == 0 {
+		g.log.Warn("No objects in response body")
+		return 0, 0, fmt.Errorf("%w: %s", ErrAddressNotFound, address)
+	}
+
+	lng, err := strconv.ParseFloat(places[0].Lon, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse coordinates: %w", err)
+	}
+	lat, err := strconv.ParseFloat(places[0].Lat, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse coordinates: %w", err)
+	}
+
+	return lng, lat, nil
+}
+
+// OSRMRouter - построение маршрутов через OSRM Route API
+type OSRMRouter struct {
+	baseURL    string
+	profile    string
+	httpClient *http.Client
+	log        *logger.Logger
+}
+
+// NewOSRMRouter создаёт сервис маршрутов OSRM с указанным профилем передвижения (foot, bike, car)
+func NewOSRMRouter(baseURL, profile string, httpClient *http.Client, log *logger.Logger) *OSRMRouter {
+	return &OSRMRouter{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		profile:    profile,
+		httpClient: httpClient,
+		log:        log,
+	}
+}
+
+// MakeRoute возвращает длину маршрута, построенного по переданным координатам
+func (r *OSRMRouter) MakeRoute(coordinates [][2]float64) (float64, error) {
+	points := make([]string, 0, len(coordinates))
+	for _, point := range coordinates {
+		points = append(points, strconv.FormatFloat(point[0], 'f', -1, 64)+","+strconv.FormatFloat(point[1], 'f', -1, 64))
+	}
+	requestURL := fmt.Sprintf("%s/route/v1/%s/%s?overview=false", r.baseURL, url.PathEscape(r.profile), strings.Join(points, ";"))
+
+	var apiResponse models.OSRMResponse
+	if err := getGeoJSON(r.httpClient, requestURL, "", &apiResponse); err != nil {
+		r.log.WithError(err).Error("Failed to get response from OSRM API")
+		return 0, err
+	}
+
+	if apiResponse.Code != "Ok" || len(apiResponse.Routes) == 0 {
+		return 0, fmt.Errorf("%w: %s %s", ErrRouteNotFound, apiResponse.Code, apiResponse.Message)
+	}
+	return apiResponse.Routes[0].Distance, nil
+}
+
+// getGeoJSON выполняет GET-запрос к геосервису и декодирует JSON-ответ
+func getGeoJSON(httpClient *http.Client, requestURL, userAgent string, result interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("bad response with status code %d: %s", resp.StatusCode, string(data))
+	}
+
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
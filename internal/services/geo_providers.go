@@ -0,0 +1,185 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+)
+
+// NewGeocoder создаёт цепочку геокодеров в порядке, заданном в конфигурации
+func NewGeocoder(cfg *config.GeolocationConfig, log *logger.Logger) (Geocoder, error) {
+	if len(cfg.Geocoders) == 0 {
+		return nil, fmt.Errorf("no geocoder providers configured")
+	}
+
+	chain := &GeocoderChain{log: log}
+	for _, name := range cfg.Geocoders {
+		geocoder, err := newGeocoderProvider(name, cfg, log)
+		if err != nil {
+			return nil, err
+		}
+		chain.providers = append(chain.providers, chainedGeocoder{
+			name:     name,
+			geocoder: geocoder,
+			breaker:  newGeoCircuitBreaker("geocoder:"+name, cfg, log),
+		})
+	}
+	return chain, nil
+}
+
+// NewRouter создаёт цепочку сервисов построения маршрутов в порядке, заданном в конфигурации
+func NewRouter(cfg *config.GeolocationConfig, log *logger.Logger) (Router, error) {
+	if len(cfg.Routers) == 0 {
+		return nil, fmt.Errorf("no router providers configured")
+	}
+
+	chain := &RouterChain{log: log}
+	for _, name := range cfg.Routers {
+		router, err := newRouterProvider(name, cfg, log)
+		if err != nil {
+			return nil, err
+		}
+		chain.providers = append(chain.providers, chainedRouter{
+			name:    name,
+			router:  router,
+			breaker: newGeoCircuitBreaker("router:"+name, cfg, log),
+		})
+	}
+	return chain, nil
+}
+
+func newGeocoderProvider(name string, cfg *config.GeolocationConfig, log *logger.Logger) (Geocoder, error) {
+	switch name {
+	case models.GeocoderYandex:
+		return NewYandexGeocoder(cfg.YandexURL, cfg.YandexAPIKey, newGeoHTTPClient(cfg), log), nil
+	case models.GeocoderNominatim:
+		return NewNominatimGeocoder(cfg.NominatimURL, cfg.UserAgent, newGeoHTTPClient(cfg), log), nil
+	case models.GeocoderLocal:
+		return NewLocalGeocoder(cfg.AddressBookFile)
+	default:
+		return nil, fmt.Errorf("unknown geocoder provider: %s", name)
+	}
+}
+
+func newRouterProvider(name string, cfg *config.GeolocationConfig, log *logger.Logger) (Router, error) {
+	switch name {
+	case models.RouterOpenroute:
+		return NewOpenrouteRouter(cfg.OpenrouteURL, cfg.OperouteAPIKey, newGeoHTTPClient(cfg), log), nil
+	case models.RouterOSRM:
+		return NewOSRMRouter(cfg.OSRMURL, cfg.OSRMProfile, newGeoHTTPClient(cfg), log), nil
+	case models.RouterHaversine:
+		return NewHaversineRouter(), nil
+	default:
+		return nil, fmt.Errorf("unknown router provider: %s", name)
+	}
+}
+
+func newGeoHTTPClient(cfg *config.GeolocationConfig) *http.Client {
+	return &http.Client{Timeout: time.Duration(cfg.Timeout) * time.Second}
+}
+
+func newGeoCircuitBreaker(name string, cfg *config.GeolocationConfig, log *logger.Logger) *CircuitBreaker {
+	return NewCircuitBreaker(name, cfg.BreakerThreshold, time.Duration(cfg.BreakerOpenTimeout)*time.Second, log)
+}
+
+type chainedGeocoder struct {
+	name     string
+	geocoder Geocoder
+	breaker  *CircuitBreaker
+}
+
+// GeocoderChain опрашивает геокодеры по порядку до первого успешного ответа.
+// Провайдеры с разомкнутым circuit breaker пропускаются. Ответ «адрес не найден»
+// не считается сбоем провайдера, но адрес всё равно ищется у следующих
+type GeocoderChain struct {
+	providers []chainedGeocoder
+	log       *logger.Logger
+}
+
+// GetCoordinates возвращает координаты (lng, lat) адреса от первого ответившего геокодера
+func (c *GeocoderChain) GetCoordinates(address string) (float64, float64, error) {
+	var lastErr error
+	notFound := false
+	for _, provider := range c.providers {
+		if !provider.breaker.Allow() {
+			c.log.WithField("provider", provider.name).Debug("Geocoder skipped, circuit breaker is open")
+			continue
+		}
+
+		lng, lat, err := provider.geocoder.GetCoordinates(address)
+		if err == nil {
+			provider.breaker.Success()
+			return lng, lat, nil
+		}
+
+		if errors.Is(err, ErrAddressNotFound) {
+			provider.breaker.Success()
+			notFound = true
+		} else {
+			provider.breaker.Failure()
+			c.log.WithError(err).WithField("provider", provider.name).Warn("Geocoder failed, trying next provider")
+		}
+		lastErr = err
+	}
+
+	if notFound {
+		return 0, 0, fmt.Errorf("%w: %s", ErrAddressNotFound, address)
+	}
+	if lastErr == nil {
+		return 0, 0, fmt.Errorf("%w: all geocoders are disabled by circuit breaker", ErrGeoUnavailable)
+	}
+	return 0, 0, fmt.Errorf("%w: %v", ErrGeoUnavailable, lastErr)
+}
+
+type chainedRouter struct {
+	name    string
+	router  Router
+	breaker *CircuitBreaker
+}
+
+// RouterChain строит маршрут у первого доступного провайдера, пропуская провайдеров
+// с разомкнутым circuit breaker
+type RouterChain struct {
+	providers []chainedRouter
+	log       *logger.Logger
+}
+
+// MakeRoute возвращает длину маршрута от первого ответившего провайдера
+func (c *RouterChain) MakeRoute(coordinates [][2]float64) (float64, error) {
+	var lastErr error
+	notFound := false
+	for _, provider := range c.providers {
+		if !provider.breaker.Allow() {
+			c.log.WithField("provider", provider.name).Debug("Router skipped, circuit breaker is open")
+			continue
+		}
+
+		distance, err := provider.router.MakeRoute(coordinates)
+		if err == nil {
+			provider.breaker.Success()
+			return distance, nil
+		}
+
+		if errors.Is(err, ErrRouteNotFound) {
+			provider.breaker.Success()
+			notFound = true
+		} else {
+			provider.breaker.Failure()
+			c.log.WithError(err).WithField("provider", provider.name).Warn("Router failed, trying next provider")
+		}
+		lastErr = err
+	}
+
+	if notFound {
+		return 0, ErrRouteNotFound
+	}
+	if lastErr == nil {
+		return 0, fmt.Errorf("%w: all routers are disabled by circuit breaker", ErrGeoUnavailable)
+	}
+	return 0, fmt.Errorf("%w: %v", ErrGeoUnavailable, lastErr)
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"delivery-system/internal/logger"
+)
+
+// Параметры circuit breaker провайдеров в тестах цепочек
+const (
+	testBreakerThreshold   = 2
+	testBreakerOpenTimeout = 30 * time.Second
+)
+
+var errProviderDown = errors.New("provider is down")
+
+// fakeGeocoder - геокодер с заранее заданным ответом, считающий обращения к нему
+type fakeGeocoder struct {
+	lng, lat float64
+	err      error
+	calls    int
+}
+
+func (g *fakeGeocoder) GetCoordinates(address string) (float64, float64, error) {
+	g.calls++
+	if g.err != nil {
+		return 0, 0, g.err
+	}
+	return g.lng, g.lat, nil
+}
+
+// fakeRouter - сервис маршрутов с заранее заданным ответом, считающий обращения к нему
+type fakeRouter struct {
+	distance float64
+	err      error
+	calls    int
+}
+
+func (r *fakeRouter) MakeRoute(coordinates [][2]float64) (float64, error) {
+	r.calls++
+	if r.err != nil {
+		return 0, r.err
+	}
+	return r.distance, nil
+}
+
+// newTestGeocoderChain собирает цепочку геокодеров с общими управляемыми часами для всех breaker
+func newTestGeocoderChain(geocoders ...*fakeGeocoder) (*GeocoderChain, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	chain := &GeocoderChain{log: logger.NewTest()}
+	for i, geocoder := range geocoders {
+		name := fmt.Sprintf("geocoder_%d", i)
+		breaker := NewCircuitBreaker(name, testBreakerThreshold, testBreakerOpenTimeout, logger.NewTest())
+		breaker.now = clock.Now
+		chain.providers = append(chain.providers, chainedGeocoder{name: name, geocoder: geocoder, breaker: breaker})
+	}
+	return chain, clock
+}
+
+// newTestRouterChain собирает цепочку сервисов маршрутов с общими управляемыми часами для всех breaker
+func newTestRouterChain(routers ...*fakeRouter) (*RouterChain, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	chain := &RouterChain{log: logger.NewTest()}
+	for i, router := range routers {
+		name := fmt.Sprintf("router_%d", i)
+		breaker := NewCircuitBreaker(name, testBreakerThreshold, testBreakerOpenTimeout, logger.NewTest())
+		breaker.now = clock.Now
+		chain.providers = append(chain.providers, chainedRouter{name: name, router: router, breaker: breaker})
+	}
+	return chain, clock
+}
+
+var geocoderChainTestCases = []struct {
+	name          string
+	errors        []error
+	expectedCalls []int
+	expectedLng   float64
+	expectedError error
+}{
+	{"test_first_answers", []error{nil, nil}, []int{1, 0}, 0, nil},
+	{"test_fallback_on_failure", []error{errProviderDown, nil}, []int{1, 1}, 1, nil},
+	{"test_fallback_on_not_found", []error{ErrAddressNotFound, nil}, []int{1, 1}, 1, nil},
+	{"test_all_not_found", []error{ErrAddressNotFound, ErrAddressNotFound}, []int{1, 1}, 0, ErrAddressNotFound},
+	{"test_not_found_wins_over_failure", []error{errProviderDown, ErrAddressNotFound}, []int{1, 1}, 0, ErrAddressNotFound},
+	{"test_all_failed", []error{errProviderDown, errProviderDown}, []int{1, 1}, 0, ErrGeoUnavailable},
+}
+
+// TestGeocoderChain выполняет тестирование порядка опроса геокодеров в цепочке
+func TestGeocoderChain(t *testing.T) {
+	for _, tc := range geocoderChainTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var geocoders []*fakeGeocoder
+			for i, err := range tc.errors {
+				geocoders = append(geocoders, &fakeGeocoder{lng: float64(i), lat: float64(i), err: err})
+			}
+			chain, _ := newTestGeocoderChain(geocoders...)
+
+			lng, _, err := chain.GetCoordinates("Москва, Тверская улица, 1")
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedLng, lng)
+			}
+			for i, geocoder := range geocoders {
+				assert.Equal(t, tc.expectedCalls[i], geocoder.calls, "calls of geocoder %d", i)
+			}
+		})
+	}
+}
+
+// TestGeocoderChainCircuitBreaker выполняет тестирование отключения сбоящего геокодера и его возврата в цепочку
+func TestGeocoderChainCircuitBreaker(t *testing.T) {
+	failing := &fakeGeocoder{err: errProviderDown}
+	fallback := &fakeGeocoder{lng: 37.6117, lat: 55.7574}
+	chain, clock := newTestGeocoderChain(failing, fallback)
+
+	// Сбои до порога размыкают breaker первого геокодера, запросы уходят следующему
+	for i := 0; i < testBreakerThreshold; i++ {
+		_, _, err := chain.GetCoordinates("Москва, Тверская улица, 1")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, circuitOpen, chain.providers[0].breaker.State())
+
+	_, _, err := chain.GetCoordinates("Москва, Тверская улица, 1")
+	assert.NoError(t, err)
+	assert.Equal(t, testBreakerThreshold, failing.calls, "open breaker must skip the provider")
+	assert.Equal(t, testBreakerThreshold+1, fallback.calls)
+
+	// По истечении времени размыкания пробный запрос снова идёт первому геокодеру
+	clock.Advance(testBreakerOpenTimeout)
+	failing.err = nil
+	lng, _, err := chain.GetCoordinates("Москва, Тверская улица, 1")
+	assert.NoError(t, err)
+	assert.Equal(t, failing.lng, lng)
+	assert.Equal(t, testBreakerThreshold+1, failing.calls)
+	assert.Equal(t, circuitClosed, chain.providers[0].breaker.State())
+}
+
+// TestGeocoderChainNotFoundIsSuccess выполняет тестирование того, что «адрес не найден» не отключает геокодер
+func TestGeocoderChainNotFoundIsSuccess(t *testing.T) {
+	geocoder := &fakeGeocoder{err: ErrAddressNotFound}
+	chain, _ := newTestGeocoderChain(geocoder)
+
+	for i := 0; i < testBreakerThreshold*3; i++ {
+		_, _, err := chain.GetCoordinates("Москва, Ленинский проспект, 1")
+		assert.ErrorIs(t, err, ErrAddressNotFound)
+	}
+	assert.Equal(t, testBreakerThreshold*3, geocoder.calls)
+	assert.Equal(t, circuitClosed, chain.providers[0].breaker.State())
+}
+
+// TestGeocoderChainAllDisabled выполняет тестирование цепочки, в которой все геокодеры отключены
+func TestGeocoderChainAllDisabled(t *testing.T) {
+	geocoder := &fakeGeocoder{err: errProviderDown}
+	chain, _ := newTestGeocoderChain(geocoder)
+
+	for i := 0; i < testBreakerThreshold; i++ {
+		_, _, _ = chain.GetCoordinates("Москва, Тверская улица, 1")
+	}
+
+	_, _, err := chain.GetCoordinates("Москва, Тверская улица, 1")
+	assert.ErrorIs(t, err, ErrGeoUnavailable)
+	assert.Contains(t, err.Error(), "circuit breaker")
+	assert.Equal(t, testBreakerThreshold, geocoder.calls)
+}
+
+var routerChainTestCases = []struct {
+	name             string
+	errors           []error
+	expectedCalls    []int
+	expectedDistance float64
+	expectedError    error
+}{
+	{"test_first_answers", []error{nil, nil}, []int{1, 0}, 1000, nil},
+	{"test_fallback_on_failure", []error{errProviderDown, nil}, []int{1, 1}, 2000, nil},
+	{"test_fallback_on_not_found", []error{ErrRouteNotFound, nil}, []int{1, 1}, 2000, nil},
+	{"test_all_not_found", []error{ErrRouteNotFound, ErrRouteNotFound}, []int{1, 1}, 0, ErrRouteNotFound},
+	{"test_all_failed", []error{errProviderDown, errProviderDown}, []int{1, 1}, 0, ErrGeoUnavailable},
+}
+
+// TestRouterChain выполняет тестирование порядка опроса сервисов маршрутов в цепочке
+func TestRouterChain(t *testing.T) {
+	for _, tc := range routerChainTestCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			var routers []*fakeRouter
+			for i, err := range tc.errors {
+				routers = append(routers, &fakeRouter{distance: float64(i+1) * 1000, err: err})
+			}
+			chain, _ := newTestRouterChain(routers...)
+
+			distance, err := chain.MakeRoute([][2]float64{{37.6117, 55.7574}, {37.5963, 55.7510}})
+			if tc.expectedError != nil {
+				assert.ErrorIs(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedDistance, distance)
+			}
+			for i, router := range routers {
+				assert.Equal(t, tc.expectedCalls[i], router.calls, "calls of router %d", i)
+			}
+		})
+	}
+}
+
+// TestRouterChainCircuitBreaker выполняет тестирование отключения сбоящего сервиса маршрутов,
+// повторного размыкания после неудачной пробы и того, что «маршрут не найден» не считается сбоем
+func TestRouterChainCircuitBreaker(t *testing.T) {
+	failing := &fakeRouter{err: errProviderDown}
+	fallback := &fakeRouter{distance: 2000}
+	chain, clock := newTestRouterChain(failing, fallback)
+	route := [][2]float64{{37.6117, 55.7574}, {37.5963, 55.7510}}
+
+	for i := 0; i < testBreakerThreshold; i++ {
+		_, err := chain.MakeRoute(route)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, circuitOpen, chain.providers[0].breaker.State())
+
+	// Неудачная проба снова размыкает breaker на полное время
+	clock.Advance(testBreakerOpenTimeout)
+	_, err := chain.MakeRoute(route)
+	assert.NoError(t, err)
+	assert.Equal(t, testBreakerThreshold+1, failing.calls)
+	assert.Equal(t, circuitOpen, chain.providers[0].breaker.State())
+
+	clock.Advance(testBreakerOpenTimeout - time.Second)
+	_, err = chain.MakeRoute(route)
+	assert.NoError(t, err)
+	assert.Equal(t, testBreakerThreshold+1, failing.calls, "reopened breaker must skip the provider")
+
+	// Проба с ответом «маршрут не найден» закрывает breaker
+	clock.Advance(time.Second)
+	failing.err = ErrRouteNotFound
+	distance, err := chain.MakeRoute(route)
+	assert.NoError(t, err)
+	assert.Equal(t, fallback.distance, distance)
+	assert.Equal(t, testBreakerThreshold+2, failing.calls)
+	assert.Equal(t, circuitClosed, chain.providers[0].breaker.State())
+}
@@ -0,0 +1,27 @@
+package services
+
+import (
+	"math"
+	"strings"
+)
+
+// earthRadiusMeters - средний радиус Земли в метрах
+const earthRadiusMeters = 6371000.0
+
+// haversineDistance возвращает расстояние в метрах между двумя точками по формуле гаверсинусов
+func haversineDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLon := toRad(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadiusMeters * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// normalizeAddress приводит адрес к нижнему регистру и схлопывает пробелы,
+// чтобы одинаковые адреса в разном написании совпадали в адресной книге и кеше
+func normalizeAddress(address string) string {
+	return strings.ToLower(strings.Join(strings.Fields(address), " "))
+}
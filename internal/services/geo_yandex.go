@@ -0,0 +1,98 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+)
+
+// YandexGeocoder - геокодер на основе Яндекс Геокодера
+type YandexGeocoder struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	log        *logger.Logger
+}
+
+// NewYandexGeocoder создаёт геокодер Яндекс Геокодера
+func NewYandexGeocoder(baseURL, apiKey string, httpClient *http.Client, log *logger.Logger) *YandexGeocoder {
+	return &YandexGeocoder{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		httpClient: httpClient,
+		log:        log,
+	}
+}
+
+// GetCoordinates возвращает координаты (lng, lat) указанного адреса
+func (g *YandexGeocoder) GetCoordinates(address string) (float64, float64, error) {
+	requestURL, err := g.buildURL(address)
+	if err != nil {
+		g.log.WithError(err).Error("Failed to parse Yandex API URL")
+		return 0, 0, err
+	}
+
+	resp, err := g.httpClient.Get(requestURL)
+	if err != nil {
+		g.log.WithError(err).Error("Failed to get response from Yandex API")
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		g.log.WithFields(map[string]interface{}{
+			"status_code": resp.StatusCode,
+			"respBody":    string(body),
+		}).Error("Bad response from Yandex API")
+		return 0, 0, fmt.Errorf("bad response with status code %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		g.log.Error("Failed to read response body")
+		return 0, 0, err
+	}
+
+	var apiResponse models.YandexResponse
+	if err := json.Unmarshal(data, &apiResponse); err != nil {
+		g.log.WithError(err).Error("Failed to unmarshal Yandex API response")
+		return 0, 0, err
+	}
+
+	featureMember := apiResponse.Response.GeoObjectCollection.FeatureMember
+	if len(featureMember) == 0 {
+		g.log.Warn("No objects in response body")
+		return 0, 0, fmt.Errorf("%w: %s", ErrAddressNotFound, address)
+	}
+
+	pos := featureMember[0].GeoObject.Point.Pos
+	var lng, lat float64
+	_, err = fmt.Sscanf(pos, "%f %f", &lng, &lat)
+	if err != nil {
+		g.log.WithError(err).Error("Failed to parse coordinates")
+		return 0, 0, fmt.Errorf("failed to parse coordinates: %w", err)
+	}
+
+	return lng, lat, nil
+}
+
+func (g *YandexGeocoder) buildURL(address string) (string, error) {
+	u, err := url.Parse(g.baseURL)
+	if err != nil {
+		return "", err
+	}
+	queryParams := url.Values{}
+	queryParams.Add("apikey", g.apiKey)
+	queryParams.Add("geocode", address)
+	queryParams.Add("results", "1")
+	queryParams.Add("format", "json")
+
+	u.RawQuery = queryParams.Encode()
+	return u.String(), nil
+}
@@ -1,147 +1,99 @@
 package services
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
+	"strings"
 	"time"
 
 	"delivery-system/internal/config"
 	"delivery-system/internal/logger"
 	"delivery-system/internal/models"
 	"delivery-system/internal/redis"
+
+	"github.com/google/uuid"
 )
 
 const defaultCacheTTL = 15 * time.Minute
 
-/*
-GeolocationService - сервис для работы с геосервисами.
-GeolocationService.yandexKey - API-ключ для работы с Яндекс-Геокодер для получения координат адресов
-GeolocationService.openrouteKey - API-ключ для работы с OpenrouteService для расчёта маршрута между точками
-*/
+// GeolocationService - сервис для работы с геосервисами. Геокодирование адресов и построение маршрутов
+// делегируются провайдерам, выбранным в GeolocationConfig. Координаты адресов и длины маршрутов
+// кешируются в Redis на длительный срок, геоданные заказов - по ID заказа
 type GeolocationService struct {
-	openrouteKey string
-	yandexKey    string
-	httpClient   *http.Client
-	redisClient  *redis.Client
-	log          *logger.Logger
+	geocoder    Geocoder
+	router      Router
+	redisClient *redis.Client
+	log         *logger.Logger
+	geocodeTTL  time.Duration
+	routeTTL    time.Duration
 }
 
 // NewGeolocationService создаёт новый экземпляр геосервиса
-func NewGeolocationService(cfg *config.GeolocationConfig, redisClient *redis.Client, log *logger.Logger) *GeolocationService {
+func NewGeolocationService(
+	geocoder Geocoder,
+	router Router,
+	redisClient *redis.Client,
+	log *logger.Logger,
+	cfg *config.GeolocationConfig,
+) *GeolocationService {
 	return &GeolocationService{
-		openrouteKey: cfg.OperouteAPIKey,
-		yandexKey:    cfg.YandexAPIKey,
-		httpClient:   &http.Client{},
-		redisClient:  redisClient,
-		log:          log,
+		geocoder:    geocoder,
+		router:      router,
+		redisClient: redisClient,
+		log:         log,
+		geocodeTTL:  time.Duration(cfg.GeocodeCacheTTL) * time.Hour,
+		routeTTL:    time.Duration(cfg.RouteCacheTTL) * time.Hour,
 	}
 }
 
-// GetCoordinates возвращает координаты (lng, lat) указанного адреса
+// GetCoordinates возвращает координаты (lng, lat) указанного адреса.
+// Адрес ищется в кеше по нормализованному написанию
 func (g *GeolocationService) GetCoordinates(address string) (float64, float64, error) {
-	requestURL := g.buildYandexApiURL(address)
-	resp, err := g.httpClient.Get(requestURL)
-	if err != nil {
-		g.log.WithError(err).Error("Failed to get response from Yandex API")
-		return 0, 0, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		g.log.WithFields(map[string]interface{}{
-			"status_code": resp.StatusCode,
-			"respBody":    string(body),
-		}).Error("Bad response from Yandex API")
-		return 0, 0, fmt.Errorf("bad response with status code %d", resp.StatusCode)
-	}
+	ctx := context.Background()
+	cacheKey := redis.GenerateKey(redis.KeyPrefixGeocode, hashGeoKey(normalizeAddress(address)))
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		g.log.Error("Failed to read response body")
-		return 0, 0, err
+	var point [2]float64
+	if err := g.redisClient.Get(ctx, cacheKey, &point); err == nil {
+		return point[0], point[1], nil
 	}
 
-	var apiResponse models.YandexResponse
-	if err := json.Unmarshal(data, &apiResponse); err != nil {
-		g.log.WithError(err).Error("Failed to unmarshal Yandex API response")
+	lng, lat, err := g.geocoder.GetCoordinates(address)
+	if err != nil {
 		return 0, 0, err
 	}
 
-	featureMember := apiResponse.Response.GeoObjectCollection.FeatureMember
-	if len(featureMember) == 0 {
-		g.log.Warn("No objects in response body")
-		return 0, 0, fmt.Errorf("couldn't get coordinates from address: %s", address)
-	}
-
-	pos := featureMember[0].GeoObject.Point.Pos
-	var lng, lat float64
-	_, err = fmt.Sscanf(pos, "%f %f", &lng, &lat)
-	if err != nil {
-		g.log.WithError(err).Error("Failed to parse coordinates")
-		return 0, 0, fmt.Errorf("failed to parse coordinates: %w", err)
+	if err := g.redisClient.Set(ctx, cacheKey, [2]float64{lng, lat}, g.geocodeTTL); err != nil {
+		g.log.WithError(err).Error("Failed to cache geocoding result")
 	}
-
 	return lng, lat, nil
 }
 
-// MakeRoute возвращает длину маршрута, построенного по переданным координатам
+// MakeRoute возвращает длину маршрута, построенного по переданным координатам.
+// Для ключа кеша координаты округляются до 5 знаков (около метра)
 func (g *GeolocationService) MakeRoute(coordinates [][2]float64) (float64, error) {
-	requestBody := models.OpenrouteRequest{
-		Coordinates:  coordinates,
-		Instructions: "false",
-		Maneuvers:    "false",
-	}
-
-	jsonBody, err := json.Marshal(requestBody)
-	if err != nil {
-		g.log.WithError(err).Error("Failed to marshal request body")
-		return 0, err
+	ctx := context.Background()
+	points := make([]string, 0, len(coordinates))
+	for _, point := range coordinates {
+		points = append(points, fmt.Sprintf("%.5f,%.5f", point[0], point[1]))
 	}
+	cacheKey := redis.GenerateKey(redis.KeyPrefixRoute, hashGeoKey(strings.Join(points, ";")))
 
-	req, err := http.NewRequest("POST", models.OpenrouteDirectionsURL, bytes.NewBuffer(jsonBody))
-	if err != nil {
-		g.log.WithError(err).Error("Failed to create request")
-		return 0, err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", g.openrouteKey)
-
-	resp, err := g.httpClient.Do(req)
-	if err != nil {
-		g.log.WithError(err).Error("Failed to send request")
-		return 0, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		g.log.WithFields(map[string]interface{}{
-			"status_code": resp.StatusCode,
-			"respBody":    string(body),
-		}).Error("Bad response from Openroute API")
-		return 0, fmt.Errorf("bad response with status code %d", resp.StatusCode)
+	var distance float64
+	if err := g.redisClient.Get(ctx, cacheKey, &distance); err == nil {
+		return distance, nil
 	}
 
-	data, err := io.ReadAll(resp.Body)
+	distance, err := g.router.MakeRoute(coordinates)
 	if err != nil {
-		g.log.WithError(err).Error("Failed to read response body")
 		return 0, err
 	}
 
-	var apiResponse models.OpenrouteResponse
-	if err = json.Unmarshal(data, &apiResponse); err != nil {
-		g.log.WithError(err).Error("Failed to unmarshal Openroute API response")
-		return 0, err
+	if err := g.redisClient.Set(ctx, cacheKey, distance, g.routeTTL); err != nil {
+		g.log.WithError(err).Error("Failed to cache route")
 	}
-
-	dist := apiResponse.Routes[0].Summary.Distance
-	return dist, nil
+	return distance, nil
 }
 
 func (g *GeolocationService) CacheResults(coordinates [][2]float64, distance float64, order *models.Order) {
@@ -157,17 +109,18 @@ func (g *GeolocationService) CacheResults(coordinates [][2]float64, distance flo
 	}
 }
 
-func (g *GeolocationService) buildYandexApiURL(address string) string {
-	u, err := url.Parse(models.YandexGeocoderURL)
-	if err != nil {
-		g.log.WithError(err).Error("Failed to parse Yandex API URL")
+// GetOrderGeolocation возвращает закешированные геоданные заказа
+func (g *GeolocationService) GetOrderGeolocation(orderID uuid.UUID) (*models.GeoCache, error) {
+	var orderGeolocation models.GeoCache
+	cacheKey := redis.GenerateKey(redis.KeyPrefixOrderGeolocation, orderID.String())
+	if err := g.redisClient.Get(context.Background(), cacheKey, &orderGeolocation); err != nil {
+		return nil, err
 	}
-	queryParams := url.Values{}
-	queryParams.Add("apikey", g.yandexKey)
-	queryParams.Add("geocode", address)
-	queryParams.Add("results", "1")
-	queryParams.Add("format", "json")
-
-	u.RawQuery = queryParams.Encode()
-	return u.String()
+	return &orderGeolocation, nil
+}
+
+// hashGeoKey возвращает компактный идентификатор для ключа кеша геоданных
+func hashGeoKey(value string) string {
+	sum := sha1.Sum([]byte(value))
+	return hex.EncodeToString(sum[:])
 }
@@ -3,25 +3,71 @@ package services
 import (
 	"context"
 	"delivery-system/internal/models"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+type Geocoder interface {
+	GetCoordinates(address string) (float64, float64, error)
+}
+
+type Router interface {
+	MakeRoute(coordinates [][2]float64) (float64, error)
+}
+
+type BlobStore interface {
+	Put(key, contentType string, data []byte) error
+	Get(key string) ([]byte, error)
+}
+
 type GeolocationServiceInterface interface {
 	GetCoordinates(address string) (float64, float64, error)
 	MakeRoute(coordinates [][2]float64) (float64, error)
 	CacheResults(coordinates [][2]float64, distance float64, order *models.Order)
+	GetOrderGeolocation(orderID uuid.UUID) (*models.GeoCache, error)
 }
 
 type OrderServiceInterface interface {
-	CreateOrder(req *models.CreateOrderRequest) (*models.Order, error)
+	CreateOrder(req *models.CreateOrderRequest, actor models.Actor) (*models.Order, error)
 	GetOrder(orderID uuid.UUID) (*models.Order, error)
-	UpdateOrderStatus(orderID uuid.UUID, req *models.UpdateOrderStatusRequest) error
+	UpdateOrderStatus(orderID uuid.UUID, req *models.UpdateOrderStatusRequest, actor models.Actor) (*models.OrderStatusChangedEvent, error)
 	GetOrders(status *models.OrderStatus, courierID *uuid.UUID, limit, offset int) ([]*models.Order, error)
+	CancelOrder(orderID uuid.UUID, req *models.CancelOrderRequest, actor models.Actor) (*models.OrderCancellation, error)
+	GetOrderCancellation(orderID uuid.UUID) (*models.OrderCancellation, error)
+}
+
+type CustomerServiceInterface interface {
+	CreateCustomer(req *models.CustomerRequest) (*models.Customer, error)
+	GetCustomer(customerID uuid.UUID) (*models.Customer, error)
+	GetCustomers(phone string, limit, offset int) ([]*models.Customer, error)
+	UpdateCustomer(customerID uuid.UUID, req *models.CustomerRequest) (*models.Customer, error)
+	AddAddress(customerID uuid.UUID, req *models.CustomerAddressRequest) (*models.CustomerAddress, error)
+	DeleteAddress(customerID, addressID uuid.UUID) error
+	GetCustomerOrders(customerID uuid.UUID, limit, offset int) ([]*models.Order, error)
+}
+
+type MerchantServiceInterface interface {
+	CreateMerchant(req *models.MerchantRequest) (*models.Merchant, error)
+	GetMerchant(merchantID uuid.UUID) (*models.Merchant, error)
+	GetMerchants(activeOnly bool) ([]*models.Merchant, error)
+	UpdateMerchant(merchantID uuid.UUID, req *models.MerchantRequest) (*models.Merchant, error)
+	DeleteMerchant(merchantID uuid.UUID) error
+	CreateProduct(merchantID uuid.UUID, req *models.ProductRequest) (*models.Product, error)
+	GetProducts(merchantID uuid.UUID, availableOnly bool) ([]*models.Product, error)
+	UpdateProduct(merchantID, productID uuid.UUID, req *models.ProductRequest) (*models.Product, error)
+	DeleteProduct(merchantID, productID uuid.UUID) error
+}
+
+type OrderPreparationServiceInterface interface {
+	AcceptOrder(orderID uuid.UUID, prepMinutes int, actor models.Actor) (*models.OrderPreparationEvent, error)
+	RejectOrder(orderID uuid.UUID, req *models.CancelOrderRequest, actor models.Actor) (*models.OrderCancellation, error)
+	StartPreparing(orderID uuid.UUID, actor models.Actor) (*models.OrderPreparationEvent, error)
+	MarkReady(orderID uuid.UUID, actor models.Actor) (*models.OrderPreparationEvent, error)
 }
 
 type ReviewServiceInterface interface {
-	CreateReview(req *models.CreateReviewRequest, order *models.Order) (*models.Review, error)
+	CreateReview(req *models.CreateReviewRequest, order *models.Order, actor models.Actor) (*models.Review, error)
 	GetReviews(courierID uuid.UUID) ([]*models.Review, error)
 	RecalculateRating(courierID uuid.UUID) error
 }
@@ -30,9 +76,114 @@ type CourierServiceInterface interface {
 	CreateCourier(req *models.CreateCourierRequest) (*models.Courier, error)
 	GetCourier(courierID uuid.UUID) (*models.Courier, error)
 	UpdateCourierStatus(courierID uuid.UUID, req *models.UpdateCourierStatusRequest) error
+	UpdateCourierZone(courierID uuid.UUID, zone *string) error
+	UpdateCourierCapacity(courierID uuid.UUID, capacity *int) error
 	GetCouriers(status *models.CourierStatus, limit, offset int, ratingSort bool) ([]*models.Courier, error)
 	GetAvailableCouriers() ([]*models.Courier, error)
-	AssignOrderToCourier(orderID, courierID uuid.UUID) error
+	AssignOrderToCourier(orderID, courierID uuid.UUID, actor models.Actor) error
+	AssignOrdersToCourier(orderIDs []uuid.UUID, courierID uuid.UUID, actor models.Actor) error
+}
+
+type BatchingServiceInterface interface {
+	GetBatches() ([]*models.OrderBatch, error)
+	AssignBatch(courierID uuid.UUID, orderIDs []uuid.UUID, actor models.Actor) (*models.CourierRoute, error)
+	GetCourierRoute(courierID uuid.UUID) (*models.CourierRoute, error)
+}
+
+type DeliveryProofServiceInterface interface {
+	ConfirmDelivery(orderID uuid.UUID, req *models.DeliveryProofRequest, actor models.Actor) (*models.DeliveryProof, error)
+	GetDeliveryProof(orderID uuid.UUID) (*models.DeliveryProof, error)
+	GetDeliveryProofFile(orderID uuid.UUID, kind models.ProofFileKind) ([]byte, error)
+}
+
+type CourierLocationServiceInterface interface {
+	UpdateLocation(courierID uuid.UUID, req *models.UpdateLocationRequest) (*models.CourierLocation, error)
+	GetLastLocation(courierID uuid.UUID) (*models.CourierLocation, error)
+	GetTrack(courierID uuid.UUID, from, to time.Time) (*models.GeoJSONFeature, error)
+	GetNearbyCouriers(lat, lon, radiusMeters float64, limit int) ([]*models.NearbyCourier, error)
+	Subscribe(ctx context.Context, courierID uuid.UUID) (<-chan *models.CourierLocation, error)
+}
+
+type CourierAssignmentServiceInterface interface {
+	AutoAssign(orderID uuid.UUID, actor models.Actor) (*models.AssignmentResult, error)
+}
+
+type OrderEventServiceInterface interface {
+	GetEvents(orderID uuid.UUID) ([]*models.OrderEvent, error)
+	Replay(orderID uuid.UUID, version int) (*models.OrderReplayResult, error)
+}
+
+type PromoCodeServiceInterface interface {
+	CreatePromoCode(req *models.PromoCodeRequest) (*models.PromoCode, error)
+	GetPromoCode(promoCodeID uuid.UUID) (*models.PromoCode, error)
+	GetPromoCodes(activeOnly bool, limit, offset int) ([]*models.PromoCode, error)
+	UpdatePromoCode(promoCodeID uuid.UUID, req *models.PromoCodeRequest) (*models.PromoCode, error)
+	DeletePromoCode(promoCodeID uuid.UUID) error
+}
+
+type TariffServiceInterface interface {
+	CreateTariff(req *models.TariffRequest) (*models.Tariff, error)
+	GetTariff(tariffID uuid.UUID) (*models.Tariff, error)
+	GetTariffs(code string, activeOnly bool, limit, offset int) ([]*models.Tariff, error)
+	RetireTariff(tariffID uuid.UUID) (*models.Tariff, error)
+	QuoteDelivery(req *models.DeliveryQuoteRequest) (*models.DeliveryBreakdown, error)
+}
+
+type SurgeServiceInterface interface {
+	GetSurgeMultipliers() ([]*models.SurgeMultiplier, error)
+}
+
+type ZoneServiceInterface interface {
+	CreateZone(req *models.DeliveryZoneRequest) (*models.DeliveryZone, error)
+	GetZone(zoneID uuid.UUID) (*models.DeliveryZone, error)
+	GetZones(activeOnly bool) ([]*models.DeliveryZone, error)
+	UpdateZone(zoneID uuid.UUID, req *models.DeliveryZoneRequest) (*models.DeliveryZone, error)
+	DeleteZone(zoneID uuid.UUID) error
+}
+
+type ShiftServiceInterface interface {
+	ScheduleShift(courierID uuid.UUID, req *models.ScheduleShiftRequest) (*models.CourierShift, error)
+	StartShift(courierID uuid.UUID) (*models.CourierShift, error)
+	EndShift(courierID uuid.UUID) (*models.CourierShift, error)
+	StartBreak(courierID uuid.UUID) (*models.CourierShift, error)
+	EndBreak(courierID uuid.UUID) (*models.CourierShift, error)
+	CancelShift(shiftID uuid.UUID) error
+	GetShift(shiftID uuid.UUID) (*models.CourierShift, error)
+	GetCourierShifts(courierID uuid.UUID, from, to *time.Time, limit, offset int) ([]*models.CourierShift, error)
+}
+
+type ETAServiceInterface interface {
+	GetAtRiskOrders(limit int) ([]*models.AtRiskOrder, error)
+}
+
+type OutboxServiceInterface interface {
+	GetMetrics() (*models.OutboxMetrics, error)
+}
+
+type AuthServiceInterface interface {
+	Authenticate(token string) (models.Actor, error)
+	IssueToken(req *models.TokenRequest) (*models.AuthToken, error)
+}
+
+type APIKeyServiceInterface interface {
+	IssueAPIKey(req *models.APIKeyRequest) (*models.IssuedAPIKey, error)
+	GetAPIKey(keyID uuid.UUID) (*models.APIKey, error)
+	GetAPIKeys(owner *models.Actor) ([]*models.APIKey, error)
+	RotateAPIKey(keyID uuid.UUID) (*models.IssuedAPIKey, error)
+	RevokeAPIKey(keyID uuid.UUID) error
+	Authenticate(key string) (*models.APIKey, error)
+	GetUsage(keyID uuid.UUID, days int) (*models.APIKeyUsage, error)
+}
+
+type RateLimitServiceInterface interface {
+	Allow(client models.RateLimitClient) *models.RateLimitStatus
+	GetStatus(client models.RateLimitClient) (*models.RateLimitStatus, error)
+}
+
+type AnalyticsServiceInterface interface {
+	GetSummary(filter models.AnalyticsFilter) (*models.SummaryReport, error)
+	GetTopItems(filter models.AnalyticsFilter) (*models.TopItemsReport, error)
+	GetCourierReport(filter models.AnalyticsFilter) (*models.CourierReport, error)
 }
 
 type KafkaMetricsServiceInterface interface {
@@ -0,0 +1,387 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// merchantColumns - список колонок продавца в порядке сканирования scanMerchant
+const merchantColumns = `id, name, phone, pickup_address, lat, lon, opening_hours, is_active, created_at, updated_at`
+
+// productColumns - список колонок товара в порядке сканирования scanProduct
+const productColumns = `id, merchant_id, name, description, price, weight_kg, is_available, created_at, updated_at`
+
+// MerchantService - сервис продавцов и их каталогов товаров
+type MerchantService struct {
+	db       *database.DB
+	log      *logger.Logger
+	geo      GeolocationServiceInterface
+	location *time.Location
+}
+
+// NewMerchantService создаёт новый экземпляр сервиса продавцов. Часы работы продавцов
+// сравниваются со временем в часовом поясе бизнеса
+func NewMerchantService(db *database.DB, log *logger.Logger, geo GeolocationServiceInterface, cfg *config.BusinessConfig) *MerchantService {
+	location, err := time.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		log.WithError(err).WithField("time_zone", cfg.TimeZone).Warn("Unknown business time zone, falling back to UTC")
+		location = time.UTC
+	}
+
+	return &MerchantService{
+		db:       db,
+		log:      log,
+		geo:      geo,
+		location: location,
+	}
+}
+
+// CreateMerchant создаёт нового продавца. Координаты точки получения определяются при создании
+func (s *MerchantService) CreateMerchant(req *models.MerchantRequest) (*models.Merchant, error) {
+	lng, lat, err := s.geo.GetCoordinates(req.PickupAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	hours, err := marshalOpeningHours(req.OpeningHours)
+	if err != nil {
+		return nil, err
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	query := `
+		INSERT INTO merchants (id, name, phone, pickup_address, lat, lon, opening_hours, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING ` + merchantColumns
+
+	merchant, err := scanMerchant(s.db.QueryRow(query, uuid.New(), req.Name, optionalString(req.Phone),
+		req.PickupAddress, lat, lng, hours, isActive))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create merchant: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"merchant_id": merchant.ID,
+		"name":        merchant.Name,
+	}).Info("Merchant created successfully")
+
+	return merchant, nil
+}
+
+// GetMerchant получает продавца по ID
+func (s *MerchantService) GetMerchant(merchantID uuid.UUID) (*models.Merchant, error) {
+	query := `SELECT ` + merchantColumns + ` FROM merchants WHERE id = $1`
+
+	merchant, err := scanMerchant(s.db.QueryRow(query, merchantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("merchant not found")
+		}
+		return nil, fmt.Errorf("failed to get merchant: %w", err)
+	}
+
+	return merchant, nil
+}
+
+// GetMerchants получает список продавцов
+func (s *MerchantService) GetMerchants(activeOnly bool) ([]*models.Merchant, error) {
+	query := `SELECT ` + merchantColumns + ` FROM merchants`
+	if activeOnly {
+		query += " WHERE is_active = TRUE"
+	}
+	query += " ORDER BY name"
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merchants: %w", err)
+	}
+	defer rows.Close()
+
+	var merchants []*models.Merchant
+	for rows.Next() {
+		merchant, err := scanMerchant(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan merchant: %w", err)
+		}
+		merchants = append(merchants, merchant)
+	}
+
+	return merchants, nil
+}
+
+// UpdateMerchant обновляет продавца. Точка получения геокодируется повторно, только если изменился её адрес
+func (s *MerchantService) UpdateMerchant(merchantID uuid.UUID, req *models.MerchantRequest) (*models.Merchant, error) {
+	current, err := s.GetMerchant(merchantID)
+	if err != nil {
+		return nil, err
+	}
+
+	lat, lng := current.Lat, current.Lon
+	if req.PickupAddress != current.PickupAddress {
+		if lng, lat, err = s.geo.GetCoordinates(req.PickupAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	hours, err := marshalOpeningHours(req.OpeningHours)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		UPDATE merchants
+		SET name = $1, phone = $2, pickup_address = $3, lat = $4, lon = $5, opening_hours = $6,
+		    is_active = COALESCE($7, is_active)
+		WHERE id = $8
+		RETURNING ` + merchantColumns
+
+	merchant, err := scanMerchant(s.db.QueryRow(query, req.Name, optionalString(req.Phone), req.PickupAddress,
+		lat, lng, hours, req.IsActive, merchantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("merchant not found")
+		}
+		return nil, fmt.Errorf("failed to update merchant: %w", err)
+	}
+
+	s.log.WithField("merchant_id", merchant.ID).Info("Merchant updated")
+
+	return merchant, nil
+}
+
+// DeleteMerchant деактивирует продавца. Продавец остаётся в БД, так как на него ссылаются заказы
+func (s *MerchantService) DeleteMerchant(merchantID uuid.UUID) error {
+	result, err := s.db.Exec("UPDATE merchants SET is_active = FALSE WHERE id = $1", merchantID)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate merchant: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("merchant not found")
+	}
+
+	s.log.WithField("merchant_id", merchantID).Info("Merchant deactivated")
+
+	return nil
+}
+
+// CreateProduct добавляет товар в каталог продавца
+func (s *MerchantService) CreateProduct(merchantID uuid.UUID, req *models.ProductRequest) (*models.Product, error) {
+	isAvailable := true
+	if req.IsAvailable != nil {
+		isAvailable = *req.IsAvailable
+	}
+
+	query := `
+		INSERT INTO products (id, merchant_id, name, description, price, weight_kg, is_available)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + productColumns
+
+	product, err := scanProduct(s.db.QueryRow(query, uuid.New(), merchantID, req.Name, optionalString(req.Description),
+		req.Price, req.WeightKg, isAvailable))
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return nil, fmt.Errorf("merchant not found")
+		}
+		return nil, fmt.Errorf("failed to create product: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"merchant_id": merchantID,
+		"product_id":  product.ID,
+	}).Info("Product created successfully")
+
+	return product, nil
+}
+
+// GetProducts получает каталог товаров продавца
+func (s *MerchantService) GetProducts(merchantID uuid.UUID, availableOnly bool) ([]*models.Product, error) {
+	if _, err := s.GetMerchant(merchantID); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT ` + productColumns + ` FROM products WHERE merchant_id = $1`
+	if availableOnly {
+		query += " AND is_available = TRUE"
+	}
+	query += " ORDER BY name"
+
+	rows, err := s.db.Query(query, merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+	defer rows.Close()
+
+	products := []*models.Product{}
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product: %w", err)
+		}
+		products = append(products, product)
+	}
+
+	return products, nil
+}
+
+// UpdateProduct обновляет товар каталога. Созданные ранее заказы сохраняют прежние название и цену
+func (s *MerchantService) UpdateProduct(merchantID, productID uuid.UUID, req *models.ProductRequest) (*models.Product, error) {
+	query := `
+		UPDATE products
+		SET name = $1, description = $2, price = $3, weight_kg = $4, is_available = COALESCE($5, is_available)
+		WHERE id = $6 AND merchant_id = $7
+		RETURNING ` + productColumns
+
+	product, err := scanProduct(s.db.QueryRow(query, req.Name, optionalString(req.Description), req.Price,
+		req.WeightKg, req.IsAvailable, productID, merchantID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("product not found")
+		}
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"merchant_id": merchantID,
+		"product_id":  productID,
+	}).Info("Product updated")
+
+	return product, nil
+}
+
+// DeleteProduct удаляет товар из каталога. Товары созданных ранее заказов сохраняются без ссылки на каталог
+func (s *MerchantService) DeleteProduct(merchantID, productID uuid.UUID) error {
+	result, err := s.db.Exec("DELETE FROM products WHERE id = $1 AND merchant_id = $2", productID, merchantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("product not found")
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"merchant_id": merchantID,
+		"product_id":  productID,
+	}).Info("Product deleted")
+
+	return nil
+}
+
+// ApplyCatalog проверяет, что продавец заказа принимает заказы в момент at, и заменяет в запросе адрес
+// получения адресом продавца, а название, цену и вес товаров - данными каталога. Цены из запроса игнорируются
+func (s *MerchantService) ApplyCatalog(req *models.CreateOrderRequest, at time.Time) (*models.Merchant, error) {
+	merchant, err := s.GetMerchant(*req.MerchantID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrMerchantNotFound
+		}
+		return nil, err
+	}
+	if !merchant.IsActive {
+		return nil, fmt.Errorf("%w: merchant is inactive", ErrMerchantUnavailable)
+	}
+	if !merchant.IsOpen(at.In(s.location)) {
+		return nil, fmt.Errorf("%w: merchant is closed at %s", ErrMerchantUnavailable, at.In(s.location).Format("Mon 15:04"))
+	}
+
+	productIDs := make([]uuid.UUID, 0, len(req.Items))
+	for _, item := range req.Items {
+		productIDs = append(productIDs, *item.ProductID)
+	}
+
+	query := `SELECT ` + productColumns + ` FROM products WHERE merchant_id = $1 AND id = ANY($2)`
+	rows, err := s.db.Query(query, merchant.ID, pq.Array(productIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+	defer rows.Close()
+
+	products := make(map[uuid.UUID]*models.Product, len(productIDs))
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan product: %w", err)
+		}
+		products[product.ID] = product
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get products: %w", err)
+	}
+
+	for i := range req.Items {
+		item := &req.Items[i]
+		product, ok := products[*item.ProductID]
+		if !ok {
+			return nil, fmt.Errorf("%w: product %s is not in the merchant catalog", ErrProductUnavailable, item.ProductID)
+		}
+		if !product.IsAvailable {
+			return nil, fmt.Errorf("%w: %s is out of stock", ErrProductUnavailable, product.Name)
+		}
+		item.Name = product.Name
+		item.Price = product.Price
+		item.WeightKg = product.WeightKg
+	}
+	req.PickupAddress = merchant.PickupAddress
+
+	return merchant, nil
+}
+
+// marshalOpeningHours сериализует часы работы продавца. Отсутствие часов сохраняется как пустой массив
+func marshalOpeningHours(hours []models.OpeningHours) ([]byte, error) {
+	if hours == nil {
+		hours = []models.OpeningHours{}
+	}
+	data, err := json.Marshal(hours)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal opening hours: %w", err)
+	}
+	return data, nil
+}
+
+func scanMerchant(row rowScanner) (*models.Merchant, error) {
+	merchant := &models.Merchant{}
+	var hours []byte
+	err := row.Scan(&merchant.ID, &merchant.Name, &merchant.Phone, &merchant.PickupAddress, &merchant.Lat,
+		&merchant.Lon, &hours, &merchant.IsActive, &merchant.CreatedAt, &merchant.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(hours, &merchant.OpeningHours); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal opening hours: %w", err)
+	}
+	return merchant, nil
+}
+
+func scanProduct(row rowScanner) (*models.Product, error) {
+	product := &models.Product{}
+	err := row.Scan(&product.ID, &product.MerchantID, &product.Name, &product.Description, &product.Price,
+		&product.WeightKg, &product.IsAvailable, &product.CreatedAt, &product.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return product, nil
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// queryer - общий интерфейс *sql.DB и *sql.Tx для чтения данных
+type queryer interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// OrderEventService - сервис журнала событий заказа. События записываются в той же транзакции,
+// что и изменение заказа, а состояние заказа может быть восстановлено из журнала со снимками
+type OrderEventService struct {
+	db               *database.DB
+	log              *logger.Logger
+	snapshotInterval int
+}
+
+// NewOrderEventService создаёт новый экземпляр сервиса журнала событий заказа
+func NewOrderEventService(db *database.DB, log *logger.Logger, cfg *config.EventStoreConfig) *OrderEventService {
+	return &OrderEventService{
+		db:               db,
+		log:              log,
+		snapshotInterval: cfg.SnapshotInterval,
+	}
+}
+
+// Append добавляет событие в журнал заказа в рамках переданной транзакции.
+// Строка заказа блокируется, поэтому версии событий одного заказа выдаются последовательно.
+// Каждое snapshotInterval-е событие сопровождается снимком состояния заказа
+func (s *OrderEventService) Append(tx *sql.Tx, orderID uuid.UUID, eventType models.EventType, payload interface{}, actor models.Actor) (*models.OrderEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal order event payload: %w", err)
+	}
+
+	var lockedID uuid.UUID
+	if err = tx.QueryRow("SELECT id FROM orders WHERE id = $1 FOR UPDATE", orderID).Scan(&lockedID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("order not found")
+		}
+		return nil, fmt.Errorf("failed to lock order: %w", err)
+	}
+
+	event := &models.OrderEvent{
+		ID:        uuid.New(),
+		OrderID:   orderID,
+		Type:      eventType,
+		Payload:   data,
+		Actor:     actor,
+		CreatedAt: time.Now(),
+	}
+	err = tx.QueryRow("SELECT COALESCE(MAX(version), 0) + 1 FROM order_events WHERE order_id = $1", orderID).
+		Scan(&event.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order event version: %w", err)
+	}
+
+	query := `
+		INSERT INTO order_events (id, order_id, version, event_type, payload, actor_role, actor_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err = tx.Exec(query, event.ID, event.OrderID, event.Version, event.Type, []byte(event.Payload),
+		actor.Role, sql.NullString{String: actor.ID, Valid: actor.ID != ""}, event.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to append order event: %w", err)
+	}
+
+	if s.snapshotInterval > 0 && event.Version%s.snapshotInterval == 0 {
+		if err = s.saveSnapshot(tx, orderID, event.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"order_id":   orderID,
+		"event_type": eventType,
+		"version":    event.Version,
+		"actor":      actor.String(),
+	}).Debug("Order event appended")
+
+	return event, nil
+}
+
+// GetEvents возвращает журнал событий заказа в порядке версий
+func (s *OrderEventService) GetEvents(orderID uuid.UUID) ([]*models.OrderEvent, error) {
+	events, err := s.loadEvents(s.db, orderID, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Пустой журнал допустим для заказов, созданных до появления журнала событий
+	if len(events) == 0 {
+		var exists bool
+		if err := s.db.QueryRow("SELECT EXISTS(SELECT 1 FROM orders WHERE id = $1)", orderID).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("failed to check order: %w", err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("order not found")
+		}
+	}
+
+	return events, nil
+}
+
+// Replay восстанавливает состояние заказа из журнала событий на указанную версию (0 - последняя),
+// начиная с ближайшего предшествующего снимка
+func (s *OrderEventService) Replay(orderID uuid.UUID, version int) (*models.OrderReplayResult, error) {
+	return s.replay(s.db, orderID, version)
+}
+
+func (s *OrderEventService) replay(q queryer, orderID uuid.UUID, toVersion int) (*models.OrderReplayResult, error) {
+	result := &models.OrderReplayResult{}
+
+	var state []byte
+	err := q.QueryRow(`
+		SELECT version, state FROM order_snapshots
+		WHERE order_id = $1 AND ($2 = 0 OR version <= $2)
+		ORDER BY version DESC
+		LIMIT 1
+	`, orderID, toVersion).Scan(&result.SnapshotVersion, &state)
+	switch {
+	case err == nil:
+		result.Order = &models.Order{}
+		if err := json.Unmarshal(state, result.Order); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal order snapshot: %w", err)
+		}
+		result.Version = result.SnapshotVersion
+	case err != sql.ErrNoRows:
+		return nil, fmt.Errorf("failed to get order snapshot: %w", err)
+	}
+
+	events, err := s.loadEvents(q, orderID, result.SnapshotVersion, toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		if err := applyOrderEvent(&result.Order, event); err != nil {
+			return nil, err
+		}
+		result.Version = event.Version
+		result.EventsApplied++
+	}
+
+	if result.Order == nil {
+		return nil, fmt.Errorf("order events not found")
+	}
+
+	return result, nil
+}
+
+// saveSnapshot сохраняет снимок состояния заказа, восстановленного из журнала на указанную версию
+func (s *OrderEventService) saveSnapshot(tx *sql.Tx, orderID uuid.UUID, version int) error {
+	result, err := s.replay(tx, orderID, version)
+	if err != nil {
+		return fmt.Errorf("failed to replay order for snapshot: %w", err)
+	}
+
+	state, err := json.Marshal(result.Order)
+	if err != nil {
+		return fmt.Errorf("failed to marshal order snapshot: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO order_snapshots (order_id, version, state)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (order_id, version) DO NOTHING
+	`, orderID, version, state)
+	if err != nil {
+		return fmt.Errorf("failed to save order snapshot: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"order_id": orderID,
+		"version":  version,
+	}).Info("Order snapshot saved")
+	return nil
+}
+
+// loadEvents загружает события заказа с версиями в диапазоне (afterVersion, toVersion]; toVersion = 0 - без ограничения
+func (s *OrderEventService) loadEvents(q queryer, orderID uuid.UUID, afterVersion, toVersion int) ([]*models.OrderEvent, error) {
+	rows, err := q.Query(`
+		SELECT id, order_id, version, event_type, payload, actor_role, actor_id, created_at
+		FROM order_events
+		WHERE order_id = $1 AND version > $2 AND ($3 = 0 OR version <= $3)
+		ORDER BY version
+	`, orderID, afterVersion, toVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*models.OrderEvent{}
+	for rows.Next() {
+		event := &models.OrderEvent{}
+		var payload []byte
+		var actorID sql.NullString
+		if err := rows.Scan(&event.ID, &event.OrderID, &event.Version, &event.Type, &payload,
+			&event.Actor.Role, &actorID, &event.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan order event: %w", err)
+		}
+		event.Payload = payload
+		event.Actor.ID = actorID.String
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// applyOrderEvent применяет событие к состоянию заказа
+func applyOrderEvent(order **models.Order, event *models.OrderEvent) error {
+	switch event.Type {
+	case models.EventTypeOrderCreated:
+		created := &models.Order{}
+		if err := json.Unmarshal(event.Payload, created); err != nil {
+			return fmt.Errorf("failed to unmarshal order.created event %d: %w", event.Version, err)
+		}
+		*order = created
+
+	case models.EventTypeCourierAssigned, models.EventTypeOrderStatusChanged:
+		if *order == nil {
+			return fmt.Errorf("event %d applied before order creation", event.Version)
+		}
+		var change models.OrderStatusChangedEvent
+		if err := json.Unmarshal(event.Payload, &change); err != nil {
+			return fmt.Errorf("failed to unmarshal %s event %d: %w", event.Type, event.Version, err)
+		}
+		(*order).Status = change.NewStatus
+		(*order).CourierID = change.CourierID
+		(*order).UpdatedAt = change.Timestamp
+		if change.NewStatus == models.OrderStatusDelivered {
+			deliveredAt := change.Timestamp
+			(*order).DeliveredAt = &deliveredAt
+		}
+
+	case models.EventTypeOrderAccepted, models.EventTypeOrderPreparing, models.EventTypeOrderReady:
+		if *order == nil {
+			return fmt.Errorf("event %d applied before order creation", event.Version)
+		}
+		var preparation models.OrderPreparationEvent
+		if err := json.Unmarshal(event.Payload, &preparation); err != nil {
+			return fmt.Errorf("failed to unmarshal %s event %d: %w", event.Type, event.Version, err)
+		}
+		(*order).Status = preparation.NewStatus
+		(*order).CourierID = preparation.CourierID
+		(*order).ReadyAt = preparation.ReadyAt
+		(*order).UpdatedAt = preparation.Timestamp
+		if preparation.PrepMinutes != nil {
+			(*order).PrepMinutes = preparation.PrepMinutes
+		}
+
+	case models.EventTypeOrderCancelled, models.EventTypeOrderRejected:
+		if *order == nil {
+			return fmt.Errorf("event %d applied before order creation", event.Version)
+		}
+		var cancelled models.OrderCancelledEvent
+		if err := json.Unmarshal(event.Payload, &cancelled); err != nil {
+			return fmt.Errorf("failed to unmarshal %s event %d: %w", event.Type, event.Version, err)
+		}
+		(*order).Status = models.OrderStatusCancelled
+		(*order).UpdatedAt = cancelled.Timestamp
+
+	case models.EventTypeOrderReviewAdded:
+		// Отзыв не меняет состояние заказа, но сохраняется в журнале
+	}
+
+	return nil
+}
+
+// setCurrentActor передаёт инициатора изменения триггеру истории статусов в рамках транзакции
+func setCurrentActor(tx *sql.Tx, actor models.Actor) error {
+	if _, err := tx.Exec("SELECT set_config('app.current_actor', $1, true)", actor.String()); err != nil {
+		return fmt.Errorf("failed to set current actor: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// dispatchBatchSize - максимальное количество заказов продавцов, для которых ищется курьер за один проход
+const dispatchBatchSize = 50
+
+// preparationEventTypes - события журнала заказа и outbox для этапов приготовления заказа продавцом
+var preparationEventTypes = map[models.OrderStatus]models.EventType{
+	models.OrderStatusAccepted:  models.EventTypeOrderAccepted,
+	models.OrderStatusPreparing: models.EventTypeOrderPreparing,
+	models.OrderStatusReady:     models.EventTypeOrderReady,
+}
+
+// OrderPreparationService - сервис приготовления заказов продавцами. Продавец принимает заказ с заявленным
+// временем приготовления или отказывается от него, затем отмечает начало приготовления и готовность.
+// Курьер для заказа продавца ищется в courier_dispatch_at - за CourierLead минут до ожидаемой готовности,
+// чтобы он прибыл к точке получения, когда заказ готов. Время пересчитывается на каждом этапе,
+// а блокировка строк с SKIP LOCKED не даёт нескольким инстансам искать курьера для заказа одновременно
+type OrderPreparationService struct {
+	db         *database.DB
+	log        *logger.Logger
+	orders     *OrderService
+	assignment *CourierAssignmentService
+	events     *OrderEventService
+	outbox     *OutboxService
+	cfg        *config.PreparationConfig
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewOrderPreparationService создаёт новый экземпляр сервиса приготовления заказов
+func NewOrderPreparationService(
+	db *database.DB,
+	log *logger.Logger,
+	orders *OrderService,
+	assignment *CourierAssignmentService,
+	events *OrderEventService,
+	outbox *OutboxService,
+	cfg *config.PreparationConfig,
+) *OrderPreparationService {
+	return &OrderPreparationService{
+		db:         db,
+		log:        log,
+		orders:     orders,
+		assignment: assignment,
+		events:     events,
+		outbox:     outbox,
+		cfg:        cfg,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Start запускает периодический поиск курьеров для заказов продавцов. Заказы, время поиска курьера
+// для которых наступило, пока сервис был остановлен, обрабатываются при первом проходе
+func (s *OrderPreparationService) Start() {
+	if !s.cfg.Enabled {
+		close(s.done)
+		s.log.Info("Merchant order dispatch is disabled")
+		return
+	}
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(time.Duration(s.cfg.Interval) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				if _, err := s.DispatchDue(); err != nil {
+					s.log.WithError(err).Error("Failed to dispatch couriers for merchant orders")
+				}
+			}
+		}
+	}()
+
+	s.log.Info("Merchant order dispatch started")
+}
+
+// Stop останавливает поиск курьеров и дожидается завершения текущего прохода
+func (s *OrderPreparationService) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+		s.log.Info("Merchant order dispatch stopped")
+	})
+}
+
+// AcceptOrder принимает заказ продавцом. Ожидаемое время готовности - через prepMinutes минут,
+// время больше MaxPrepMinutes возвращает ErrPrepTimeTooLong
+func (s *OrderPreparationService) AcceptOrder(orderID uuid.UUID, prepMinutes int, actor models.Actor) (*models.OrderPreparationEvent, error) {
+	if prepMinutes > s.cfg.MaxPrepMinutes {
+		return nil, fmt.Errorf("%w: at most %d minutes", ErrPrepTimeTooLong, s.cfg.MaxPrepMinutes)
+	}
+	return s.transition(orderID, models.OrderStatusAccepted, &prepMinutes, actor)
+}
+
+// StartPreparing отмечает начало приготовления заказа. Ожидаемое время готовности не меняется
+func (s *OrderPreparationService) StartPreparing(orderID uuid.UUID, actor models.Actor) (*models.OrderPreparationEvent, error) {
+	return s.transition(orderID, models.OrderStatusPreparing, nil, actor)
+}
+
+// MarkReady отмечает готовность заказа. Если курьер ещё не назначен, он ищется при ближайшем проходе
+func (s *OrderPreparationService) MarkReady(orderID uuid.UUID, actor models.Actor) (*models.OrderPreparationEvent, error) {
+	return s.transition(orderID, models.OrderStatusReady, nil, actor)
+}
+
+// RejectOrder отказывается от заказа, который продавец ещё не принял. Заказ отменяется с указанной
+// причиной, в журнал заказа и outbox записывается событие order.rejected
+func (s *OrderPreparationService) RejectOrder(orderID uuid.UUID, req *models.CancelOrderRequest, actor models.Actor) (*models.OrderCancellation, error) {
+	check := func(stage models.OrderStatus, merchantID *uuid.UUID) error {
+		if err := checkOrderMerchant(stage, models.OrderStatusCancelled, merchantID, actor); err != nil {
+			return err
+		}
+		if stage != models.OrderStatusScheduled && stage != models.OrderStatusCreated {
+			return &InvalidTransitionError{
+				From: stage, To: models.OrderStatusCancelled, Role: actor.Role,
+				Reason: "order has already been accepted",
+			}
+		}
+		return nil
+	}
+	return s.orders.cancelOrder(orderID, req, actor, models.EventTypeOrderRejected, check)
+}
+
+// DispatchDue назначает курьеров заказам продавцов, время поиска курьера для которых наступило.
+// Заказ, для которого курьер не найден, остаётся в очереди до следующего прохода.
+// Возвращает количество заказов, которым назначен курьер
+func (s *OrderPreparationService) DispatchDue() (int, error) {
+	query := `
+		SELECT id FROM orders
+		WHERE merchant_id IS NOT NULL AND courier_id IS NULL AND status = ANY($1) AND courier_dispatch_at <= $2
+		ORDER BY courier_dispatch_at
+		LIMIT $3`
+
+	rows, err := s.db.Query(query, pq.Array(merchantAssignableStatuses), time.Now(), dispatchBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get merchant orders awaiting courier: %w", err)
+	}
+	var orderIDs []uuid.UUID
+	for rows.Next() {
+		var orderID uuid.UUID
+		if err := rows.Scan(&orderID); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan order id: %w", err)
+		}
+		orderIDs = append(orderIDs, orderID)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to get merchant orders awaiting courier: %w", err)
+	}
+
+	// Назначение блокирует курьера и заказ в собственной транзакции: занятый другим инстансом заказ
+	// вернёт ошибку и будет пропущен
+	assigned := 0
+	for _, orderID := range orderIDs {
+		if _, err := s.assignment.AutoAssign(orderID, models.SystemActor); err != nil {
+			s.log.WithError(err).WithField("order_id", orderID).Warn("Failed to assign courier to merchant order")
+			continue
+		}
+		assigned++
+	}
+
+	if assigned > 0 {
+		s.log.WithField("count", assigned).Info("Couriers dispatched for merchant orders")
+	}
+	return assigned, nil
+}
+
+// transition переводит заказ продавца в статус этапа приготовления to, пересчитывает время готовности
+// и поиска курьера и в той же транзакции записывает событие этапа в журнал заказа и outbox
+func (s *OrderPreparationService) transition(orderID uuid.UUID, to models.OrderStatus, prepMinutes *int, actor models.Actor) (*models.OrderPreparationEvent, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Блокируем строку заказа и получаем текущий этап приготовления
+	var oldStatus models.OrderStatus
+	var merchantID, courierID *uuid.UUID
+	var currentPrep *int
+	var readyAt, dispatchAt *time.Time
+	err = tx.QueryRow(`
+		SELECT status, merchant_id, courier_id, prep_minutes, ready_at, courier_dispatch_at
+		FROM orders WHERE id = $1 FOR UPDATE
+	`, orderID).Scan(&oldStatus, &merchantID, &courierID, &currentPrep, &readyAt, &dispatchAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("order not found")
+		}
+		return nil, fmt.Errorf("failed to get order status: %w", err)
+	}
+
+	if err = checkOrderMerchant(oldStatus, to, merchantID, actor); err != nil {
+		return nil, err
+	}
+	if !oldStatus.CanTransitionTo(to, actor.Role) {
+		return nil, &InvalidTransitionError{From: oldStatus, To: to, Role: actor.Role}
+	}
+
+	now := time.Now()
+	switch to {
+	case models.OrderStatusAccepted:
+		currentPrep = prepMinutes
+		ready := now.Add(time.Duration(*prepMinutes) * time.Minute)
+		readyAt = &ready
+	case models.OrderStatusReady:
+		readyAt = &now
+	}
+
+	// Курьер должен прибыть к готовности заказа; если курьер уже назначен, время поиска не нужно
+	if courierID == nil && readyAt != nil {
+		dispatch := readyAt.Add(-time.Duration(s.cfg.CourierLead) * time.Minute)
+		if dispatch.Before(now) {
+			dispatch = now
+		}
+		dispatchAt = &dispatch
+	}
+
+	if err = setCurrentActor(tx, actor); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE orders
+		SET status = $1, prep_minutes = $2, ready_at = $3, courier_dispatch_at = $4, updated_at = $5
+		WHERE id = $6
+	`, to, currentPrep, readyAt, dispatchAt, now, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update order preparation: %w", err)
+	}
+
+	eventType := preparationEventTypes[to]
+	event := &models.OrderPreparationEvent{
+		OrderID:     orderID,
+		MerchantID:  *merchantID,
+		OldStatus:   oldStatus,
+		NewStatus:   to,
+		PrepMinutes: currentPrep,
+		ReadyAt:     readyAt,
+		CourierID:   courierID,
+		Timestamp:   now,
+	}
+	if _, err = s.events.Append(tx, orderID, eventType, event, actor); err != nil {
+		return nil, err
+	}
+	if err = s.outbox.EnqueueOrderPreparation(tx, eventType, event); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"order_id":            orderID,
+		"merchant_id":         *merchantID,
+		"old_status":          oldStatus,
+		"new_status":          to,
+		"ready_at":            readyAt,
+		"courier_dispatch_at": dispatchAt,
+		"actor_role":          actor.Role,
+	}).Info("Order preparation updated")
+
+	return event, nil
+}
+
+// checkOrderMerchant проверяет, что заказ принадлежит продавцу и продавец-инициатор работает со своим заказом
+func checkOrderMerchant(from, to models.OrderStatus, merchantID *uuid.UUID, actor models.Actor) error {
+	if merchantID == nil {
+		return &InvalidTransitionError{From: from, To: to, Role: actor.Role, Reason: "order has no merchant"}
+	}
+	if actor.Role == models.RoleMerchant && actor.ID != merchantID.String() {
+		return &InvalidTransitionError{From: from, To: to, Role: actor.Role, Reason: "order belongs to another merchant"}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// releaseBatchSize - максимальное количество отложенных заказов, передаваемых в работу за одну транзакцию
+const releaseBatchSize = 100
+
+// OrderSchedulerService - планировщик отложенных заказов. Отложенный заказ хранится в статусе scheduled
+// и передаётся в работу (статус created) в момент dispatch_at - за прогнозируемую длительность выполнения
+// заказа с запасом до времени доставки. Состояние хранится в БД, поэтому переживает перезапуск сервиса,
+// а блокировка строк с SKIP LOCKED не даёт нескольким инстансам передать один заказ дважды
+type OrderSchedulerService struct {
+	db     *database.DB
+	log    *logger.Logger
+	eta    *ETAService
+	events *OrderEventService
+	outbox *OutboxService
+	cfg    *config.SchedulingConfig
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewOrderSchedulerService создаёт новый экземпляр планировщика отложенных заказов
+func NewOrderSchedulerService(
+	db *database.DB,
+	log *logger.Logger,
+	eta *ETAService,
+	events *OrderEventService,
+	outbox *OutboxService,
+	cfg *config.SchedulingConfig,
+) *OrderSchedulerService {
+	return &OrderSchedulerService{
+		db:     db,
+		log:    log,
+		eta:    eta,
+		events: events,
+		outbox: outbox,
+		cfg:    cfg,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start запускает периодическую передачу отложенных заказов в работу. Заказы, время которых наступило,
+// пока сервис был остановлен, передаются при первом проходе
+func (s *OrderSchedulerService) Start() {
+	if !s.cfg.Enabled {
+		close(s.done)
+		s.log.Info("Order scheduler is disabled")
+		return
+	}
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(time.Duration(s.cfg.Interval) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				if _, err := s.ReleaseDue(); err != nil {
+					s.log.WithError(err).Error("Failed to release scheduled orders")
+				}
+			}
+		}
+	}()
+
+	s.log.Info("Order scheduler started")
+}
+
+// Stop останавливает планировщик и дожидается завершения текущего прохода
+func (s *OrderSchedulerService) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+		s.log.Info("Order scheduler stopped")
+	})
+}
+
+// DispatchAt возвращает время передачи в работу заказа с маршрутом длиной distance метров, который нужно
+// доставить к scheduledFor: прогнозируемая длительность выполнения заказа плюс LeadBuffer до слота,
+// но не раньше now. Время дальше MaxDaysAhead дней возвращает ErrScheduleTooFar
+func (s *OrderSchedulerService) DispatchAt(distance float64, scheduledFor, now time.Time) (time.Time, error) {
+	if scheduledFor.After(now.AddDate(0, 0, s.cfg.MaxDaysAhead)) {
+		return time.Time{}, fmt.Errorf("%w: at most %d days ahead", ErrScheduleTooFar, s.cfg.MaxDaysAhead)
+	}
+
+	lead := s.eta.EstimateNew(distance, now).Sub(now) + time.Duration(s.cfg.LeadBuffer)*time.Minute
+	dispatchAt := scheduledFor.Add(-lead)
+	if dispatchAt.Before(now) {
+		dispatchAt = now
+	}
+	return dispatchAt, nil
+}
+
+// ReleaseDue передаёт в работу отложенные заказы, время передачи которых наступило, и в той же транзакции
+// записывает смену статуса в журнал заказа и outbox. Возвращает количество переданных заказов
+func (s *OrderSchedulerService) ReleaseDue() (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	query := `
+		SELECT id FROM orders
+		WHERE status = $1 AND dispatch_at <= $2
+		ORDER BY dispatch_at
+		LIMIT $3
+		FOR UPDATE SKIP LOCKED`
+
+	rows, err := tx.Query(query, models.OrderStatusScheduled, now, releaseBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get scheduled orders: %w", err)
+	}
+	var orderIDs []uuid.UUID
+	for rows.Next() {
+		var orderID uuid.UUID
+		if err := rows.Scan(&orderID); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan order id: %w", err)
+		}
+		orderIDs = append(orderIDs, orderID)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to get scheduled orders: %w", err)
+	}
+	if len(orderIDs) == 0 {
+		return 0, nil
+	}
+
+	if err = setCurrentActor(tx, models.SystemActor); err != nil {
+		return 0, err
+	}
+
+	for _, orderID := range orderIDs {
+		if _, err = tx.Exec("UPDATE orders SET status = $1, updated_at = $2 WHERE id = $3",
+			models.OrderStatusCreated, now, orderID); err != nil {
+			return 0, fmt.Errorf("failed to release scheduled order: %w", err)
+		}
+
+		change := &models.OrderStatusChangedEvent{
+			OrderID:   orderID,
+			OldStatus: models.OrderStatusScheduled,
+			NewStatus: models.OrderStatusCreated,
+			Timestamp: now,
+		}
+		if _, err = s.events.Append(tx, orderID, models.EventTypeOrderStatusChanged, change, models.SystemActor); err != nil {
+			return 0, err
+		}
+		if err = s.outbox.EnqueueOrderStatusChanged(tx, change); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.log.WithField("count", len(orderIDs)).Info("Scheduled orders released for dispatch")
+	return len(orderIDs), nil
+}
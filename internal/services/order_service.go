@@ -1,8 +1,13 @@
 package services
 
 import (
+	"crypto/rand"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
+	"strings"
 	"time"
 
 	"delivery-system/internal/config"
@@ -13,36 +18,101 @@ import (
 	"github.com/google/uuid"
 )
 
+// orderColumns - список колонок заказа в порядке сканирования scanOrder
+const orderColumns = `id, customer_id, merchant_id, customer_name, customer_phone, pickup_address, delivery_address, total_amount,
+		       delivery_cost, delivery_breakdown, zone, promo_code, discount_amount, status, courier_id, created_at, updated_at, delivered_at,
+		       eta, sla_deadline, scheduled_for, dispatch_at, prep_minutes, ready_at, courier_dispatch_at`
+
+// rowScanner - общий интерфейс *sql.Row и *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 // OrderService представляет сервис для работы с заказами
 type OrderService struct {
-	db       *database.DB
-	log      *logger.Logger
-	geo      GeolocationServiceInterface
-	business *config.BusinessConfig
+	db        *database.DB
+	log       *logger.Logger
+	geo       GeolocationServiceInterface
+	tariffs   *TariffService
+	zones     *ZoneService
+	eta       *ETAService
+	scheduler *OrderSchedulerService
+	customers *CustomerService
+	merchants *MerchantService
+	events    *OrderEventService
+	outbox    *OutboxService
+	batching  *config.BatchingConfig
+	cancel    *config.CancellationConfig
 }
 
 // NewOrderService создает новый экземпляр сервиса заказов
-func NewOrderService(db *database.DB, log *logger.Logger, geo GeolocationServiceInterface, cfg *config.BusinessConfig) *OrderService {
+func NewOrderService(
+	db *database.DB,
+	log *logger.Logger,
+	geo GeolocationServiceInterface,
+	tariffs *TariffService,
+	zones *ZoneService,
+	eta *ETAService,
+	scheduler *OrderSchedulerService,
+	customers *CustomerService,
+	merchants *MerchantService,
+	events *OrderEventService,
+	outbox *OutboxService,
+	batching *config.BatchingConfig,
+	cancel *config.CancellationConfig,
+) *OrderService {
 	return &OrderService{
-		db:       db,
-		log:      log,
-		geo:      geo,
-		business: cfg,
+		db:        db,
+		log:       log,
+		geo:       geo,
+		tariffs:   tariffs,
+		zones:     zones,
+		eta:       eta,
+		scheduler: scheduler,
+		customers: customers,
+		merchants: merchants,
+		events:    events,
+		outbox:    outbox,
+		batching:  batching,
+		cancel:    cancel,
 	}
 }
 
-// CreateOrder создает новый заказ
-func (s *OrderService) CreateOrder(req *models.CreateOrderRequest) (*models.Order, error) {
+// CreateOrder создает новый заказ и в той же транзакции записывает событие его создания в журнал заказа и outbox
+func (s *OrderService) CreateOrder(req *models.CreateOrderRequest, actor models.Actor) (*models.Order, error) {
 	var coordinates [][2]float64
 
+	// Для известного клиента недостающие имя и телефон берутся из профиля, а сохранённый адрес доставки
+	// используется вместе с его координатами
+	savedAddress, err := s.applyCustomer(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Заказ продавца забирается из его точки получения по ценам каталога
+	var merchant *models.Merchant
+	if req.MerchantID != nil {
+		at := time.Now()
+		if req.ScheduledFor != nil {
+			at = *req.ScheduledFor
+		}
+		if merchant, err = s.merchants.ApplyCatalog(req, at); err != nil {
+			return nil, err
+		}
+	}
+
 	// Определяем коодинаты адреса получения
-	if err := s.getCoordinates(&coordinates, req.PickupAddress); err != nil {
+	if merchant != nil {
+		coordinates = append(coordinates, [2]float64{merchant.Lon, merchant.Lat})
+	} else if err := s.getCoordinates(&coordinates, req.PickupAddress); err != nil {
 		s.log.WithError(err).Error("Failed to get pickup coordinates")
 		return nil, err
 	}
 
 	// Определяем коодинаты адреса доставки
-	if err := s.getCoordinates(&coordinates, req.DeliveryAddress); err != nil {
+	if savedAddress != nil {
+		coordinates = append(coordinates, [2]float64{savedAddress.Lon, savedAddress.Lat})
+	} else if err := s.getCoordinates(&coordinates, req.DeliveryAddress); err != nil {
 		s.log.WithError(err).Error("Failed to get delivery coordinates")
 		return nil, err
 	}
@@ -54,9 +124,32 @@ func (s *OrderService) CreateOrder(req *models.CreateOrderRequest) (*models.Orde
 		return nil, fmt.Errorf("failed to calculate delivery cost. Error: %w", err)
 	}
 
-	// Если в запросе отсутствовал delivery_cost, то рассчитываем стоимость доставки
+	// Проверяем, что адреса обслуживаются, и определяем зону заказа по адресу получения
+	zone, err := s.zones.CheckRoute(coordinates[0], coordinates[1], distance)
+	if err != nil {
+		return nil, err
+	}
+	var zoneCode string
+	if zone != nil {
+		zoneCode = zone.Code
+	}
+
+	now := time.Now()
+
+	// Если в запросе отсутствовал delivery_cost, то рассчитываем стоимость доставки по тарифу,
+	// действующему на момент доставки: для отложенного заказа - на время выбранного слота
+	var breakdown *models.DeliveryBreakdown
 	if req.DeliveryCost == nil {
-		req.DeliveryCost = s.calculateDeliveryCost(distance)
+		pricedAt := now
+		if req.ScheduledFor != nil {
+			pricedAt = *req.ScheduledFor
+		}
+		breakdown, err = s.tariffs.CalculateDeliveryCost(distance, req.Items, zoneCode, pricedAt)
+		if err != nil {
+			s.log.WithError(err).Error("Failed to calculate delivery cost")
+			return nil, fmt.Errorf("failed to calculate delivery cost: %w", err)
+		}
+		req.DeliveryCost = &breakdown.Total
 	}
 
 	tx, err := s.db.Begin()
@@ -71,53 +164,140 @@ func (s *OrderService) CreateOrder(req *models.CreateOrderRequest) (*models.Orde
 		totalAmount += item.Price * float64(item.Quantity)
 	}
 
+	// Проверяем промокод и рассчитываем скидку в той же транзакции, что и создание заказа
+	var promoCode *models.PromoCode
+	var discountAmount float64
+	if req.PromoCode != "" {
+		promoCode, discountAmount, err = applyPromoCode(tx, req.PromoCode, req.CustomerPhone, totalAmount, *req.DeliveryCost, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Заказ без указанного клиента привязывается к клиенту по телефону
+	customerID := req.CustomerID
+	if customerID == nil {
+		if customerID, err = linkCustomer(tx, req.CustomerName, req.CustomerPhone, now); err != nil {
+			return nil, err
+		}
+	}
+
+	// PIN-код покупатель назовёт курьеру при получении заказа
+	pin, err := generateDeliveryPin()
+	if err != nil {
+		return nil, err
+	}
+
+	// Срок доставки определяется SLA зоны, начальный прогноз - средней длительностью этапов и длиной маршрута.
+	// Отложенный заказ ждёт передачи в работу, и его срок доставки - время, к которому его заказали
+	status := models.OrderStatusCreated
+	slaDeadline := s.eta.SLADeadline(zone, now)
+	eta := s.eta.EstimateNew(distance, now)
+	var dispatchAt *time.Time
+	if req.ScheduledFor != nil {
+		at, err := s.scheduler.DispatchAt(distance, *req.ScheduledFor, now)
+		if err != nil {
+			return nil, err
+		}
+		status = models.OrderStatusScheduled
+		slaDeadline = *req.ScheduledFor
+		eta = s.eta.EstimateNew(distance, at)
+		dispatchAt = &at
+	}
+
 	// Создание заказа
 	orderID := uuid.New()
 	order := &models.Order{
-		ID:              orderID,
-		CustomerName:    req.CustomerName,
-		CustomerPhone:   req.CustomerPhone,
-		PickupAddress:   req.PickupAddress,
-		DeliveryAddress: req.DeliveryAddress,
-		TotalAmount:     totalAmount,
-		DeliveryCost:    *req.DeliveryCost,
-		Status:          models.OrderStatusCreated,
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+		ID:                orderID,
+		CustomerID:        customerID,
+		MerchantID:        req.MerchantID,
+		CustomerName:      req.CustomerName,
+		CustomerPhone:     req.CustomerPhone,
+		PickupAddress:     req.PickupAddress,
+		DeliveryAddress:   req.DeliveryAddress,
+		TotalAmount:       totalAmount,
+		DeliveryCost:      *req.DeliveryCost,
+		DeliveryBreakdown: breakdown,
+		Zone:              optionalString(zoneCode),
+		DiscountAmount:    discountAmount,
+		Status:            status,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+		ETA:               &eta,
+		SLADeadline:       &slaDeadline,
+		ScheduledFor:      req.ScheduledFor,
+		DispatchAt:        dispatchAt,
+	}
+	if promoCode != nil {
+		order.PromoCode = &promoCode.Code
+	}
+
+	// Детализация сохраняется только для стоимости, рассчитанной по тарифу, иначе в БД записывается NULL
+	var breakdownJSON interface{}
+	if breakdown != nil {
+		data, err := json.Marshal(breakdown)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal delivery breakdown: %w", err)
+		}
+		breakdownJSON = data
 	}
 
 	query := `
-		INSERT INTO orders (id, customer_name, customer_phone, pickup_address,
-		            delivery_address, total_amount, delivery_cost, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO orders (id, customer_name, customer_phone, pickup_address, delivery_address, total_amount,
+		            delivery_cost, delivery_breakdown, zone, promo_code, discount_amount, status, created_at, updated_at,
+		            pickup_lat, pickup_lon, delivery_lat, delivery_lon, delivery_pin, eta, eta_updated_at, sla_deadline,
+		            scheduled_for, dispatch_at, customer_id, merchant_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22,
+		        $23, $24, $25, $26)
 	`
 	_, err = tx.Exec(query, order.ID, order.CustomerName, order.CustomerPhone, order.PickupAddress,
-		order.DeliveryAddress, order.TotalAmount, order.DeliveryCost, order.Status, order.CreatedAt, order.UpdatedAt)
+		order.DeliveryAddress, order.TotalAmount, order.DeliveryCost, breakdownJSON, order.Zone, order.PromoCode,
+		order.DiscountAmount, order.Status, order.CreatedAt, order.UpdatedAt,
+		coordinates[0][1], coordinates[0][0], coordinates[1][1], coordinates[1][0], pin,
+		order.ETA, now, order.SLADeadline, order.ScheduledFor, order.DispatchAt, order.CustomerID,
+		order.MerchantID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 
+	if promoCode != nil {
+		if err = recordPromoCodeRedemption(tx, promoCode.ID, orderID, order.CustomerPhone, discountAmount); err != nil {
+			return nil, err
+		}
+	}
+
 	// Добавление товаров в заказ
 	for _, item := range req.Items {
 		itemID := uuid.New()
 		itemQuery := `
-			INSERT INTO order_items (id, order_id, name, quantity, price)
-			VALUES ($1, $2, $3, $4, $5)
+			INSERT INTO order_items (id, order_id, product_id, name, quantity, price)
+			VALUES ($1, $2, $3, $4, $5, $6)
 		`
-		_, err = tx.Exec(itemQuery, itemID, orderID, item.Name, item.Quantity, item.Price)
+		_, err = tx.Exec(itemQuery, itemID, orderID, item.ProductID, item.Name, item.Quantity, item.Price)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create order item: %w", err)
 		}
 
 		order.Items = append(order.Items, models.OrderItem{
-			ID:       itemID,
-			OrderID:  orderID,
-			Name:     item.Name,
-			Quantity: item.Quantity,
-			Price:    item.Price,
+			ID:        itemID,
+			OrderID:   orderID,
+			ProductID: item.ProductID,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			Price:     item.Price,
 		})
 	}
 
+	if _, err = s.events.Append(tx, orderID, models.EventTypeOrderCreated, order, actor); err != nil {
+		return nil, err
+	}
+	if err = s.outbox.EnqueueOrderCreated(tx, order); err != nil {
+		return nil, err
+	}
+	if err = s.outbox.EnqueueDeliveryPinIssued(tx, order, pin); err != nil {
+		return nil, err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -129,6 +309,9 @@ func (s *OrderService) CreateOrder(req *models.CreateOrderRequest) (*models.Orde
 		"order_id":      order.ID,
 		"customer_name": order.CustomerName,
 		"total_amount":  order.TotalAmount,
+		"promo_code":    req.PromoCode,
+		"discount":      order.DiscountAmount,
+		"status":        order.Status,
 	}).Info("Order created successfully")
 
 	return order, nil
@@ -136,20 +319,9 @@ func (s *OrderService) CreateOrder(req *models.CreateOrderRequest) (*models.Orde
 
 // GetOrder получает заказ по ID
 func (s *OrderService) GetOrder(orderID uuid.UUID) (*models.Order, error) {
-	order := &models.Order{}
-
-	query := `
-		SELECT id, customer_name, customer_phone, pickup_address,delivery_address, total_amount, 
-		       delivery_cost, status, courier_id, created_at, updated_at, delivered_at
-		FROM orders 
-		WHERE id = $1
-	`
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE id = $1`
 
-	err := s.db.QueryRow(query, orderID).Scan(
-		&order.ID, &order.CustomerName, &order.CustomerPhone, &order.PickupAddress, &order.DeliveryAddress,
-		&order.TotalAmount, &order.DeliveryCost, &order.Status, &order.CourierID, &order.CreatedAt,
-		&order.UpdatedAt, &order.DeliveredAt,
-	)
+	order, err := scanOrder(s.db.QueryRow(query, orderID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("order not found")
@@ -159,7 +331,7 @@ func (s *OrderService) GetOrder(orderID uuid.UUID) (*models.Order, error) {
 
 	// Получение товаров заказа
 	itemsQuery := `
-		SELECT id, order_id, name, quantity, price
+		SELECT id, order_id, product_id, name, quantity, price
 		FROM order_items
 		WHERE order_id = $1
 	`
@@ -172,7 +344,7 @@ func (s *OrderService) GetOrder(orderID uuid.UUID) (*models.Order, error) {
 
 	for rows.Next() {
 		var item models.OrderItem
-		if err := rows.Scan(&item.ID, &item.OrderID, &item.Name, &item.Quantity, &item.Price); err != nil {
+		if err := rows.Scan(&item.ID, &item.OrderID, &item.ProductID, &item.Name, &item.Quantity, &item.Price); err != nil {
 			return nil, fmt.Errorf("failed to scan order item: %w", err)
 		}
 		order.Items = append(order.Items, item)
@@ -181,18 +353,69 @@ func (s *OrderService) GetOrder(orderID uuid.UUID) (*models.Order, error) {
 	return order, nil
 }
 
-// UpdateOrderStatus обновляет статус заказа
-func (s *OrderService) UpdateOrderStatus(orderID uuid.UUID, req *models.UpdateOrderStatusRequest) error {
+// UpdateOrderStatus обновляет статус заказа с проверкой допустимости перехода.
+// Текущий статус читается в той же транзакции, что и обновление, поэтому возвращаемое
+// изменение статуса соответствует фактически выполненному переходу
+func (s *OrderService) UpdateOrderStatus(orderID uuid.UUID, req *models.UpdateOrderStatusRequest, actor models.Actor) (*models.OrderStatusChangedEvent, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Блокируем строку заказа и получаем текущий статус
+	var oldStatus models.OrderStatus
+	var currentCourierID *uuid.UUID
+	err = tx.QueryRow("SELECT status, courier_id FROM orders WHERE id = $1 FOR UPDATE", orderID).
+		Scan(&oldStatus, &currentCourierID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("order not found")
+		}
+		return nil, fmt.Errorf("failed to get order status: %w", err)
+	}
+
+	if !oldStatus.CanTransitionTo(req.Status, actor.Role) {
+		return nil, &InvalidTransitionError{From: oldStatus, To: req.Status, Role: actor.Role}
+	}
+
+	// Курьер подтверждает доставку только PIN-кодом и местоположением через подтверждение доставки
+	if actor.Role == models.RoleCourier && req.Status == models.OrderStatusDelivered {
+		return nil, &InvalidTransitionError{
+			From: oldStatus, To: req.Status, Role: actor.Role,
+			Reason: "delivery must be confirmed with proof of delivery",
+		}
+	}
+
+	// Курьер может менять статус только назначенных ему заказов
+	if actor.Role == models.RoleCourier &&
+		(currentCourierID == nil || currentCourierID.String() != actor.ID) {
+		return nil, &InvalidTransitionError{
+			From: oldStatus, To: req.Status, Role: actor.Role,
+			Reason: "order is not assigned to this courier",
+		}
+	}
+
+	courierID := currentCourierID
+	if req.CourierID != nil {
+		courierID = req.CourierID
+	}
+
+	if err = setCurrentActor(tx, actor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
 	query := `
 		UPDATE orders 
 		SET status = $1, courier_id = $2, updated_at = $3
 	`
-	args := []interface{}{req.Status, req.CourierID, time.Now()}
+	args := []interface{}{req.Status, courierID, now}
 
 	// Если статус "доставлен", устанавливаем время доставки
 	if req.Status == models.OrderStatusDelivered {
 		query += ", delivered_at = $4"
-		args = append(args, time.Now())
+		args = append(args, now)
 		query += " WHERE id = $5"
 		args = append(args, orderID)
 	} else {
@@ -200,37 +423,229 @@ func (s *OrderService) UpdateOrderStatus(orderID uuid.UUID, req *models.UpdateOr
 		args = append(args, orderID)
 	}
 
-	result, err := s.db.Exec(query, args...)
-	if err != nil {
-		return fmt.Errorf("failed to update order status: %w", err)
+	if _, err = tx.Exec(query, args...); err != nil {
+		return nil, fmt.Errorf("failed to update order status: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+	// Загрузка курьеров заказа изменилась: пересчитываем, может ли курьер принять ещё заказ
+	if currentCourierID != nil {
+		if err = syncCourierLoadStatus(tx, *currentCourierID, s.batching.DefaultCapacity, now); err != nil {
+			return nil, err
+		}
+	}
+	if courierID != nil && (currentCourierID == nil || *courierID != *currentCourierID) {
+		if err = syncCourierLoadStatus(tx, *courierID, s.batching.DefaultCapacity, now); err != nil {
+			return nil, err
+		}
 	}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("order not found")
+	change := &models.OrderStatusChangedEvent{
+		OrderID:   orderID,
+		OldStatus: oldStatus,
+		NewStatus: req.Status,
+		CourierID: courierID,
+		Timestamp: now,
+	}
+	if _, err = s.events.Append(tx, orderID, models.EventTypeOrderStatusChanged, change, actor); err != nil {
+		return nil, err
+	}
+	if err = s.outbox.EnqueueOrderStatusChanged(tx, change); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
 	s.log.WithFields(map[string]interface{}{
 		"order_id":   orderID,
+		"old_status": oldStatus,
 		"new_status": req.Status,
-		"courier_id": req.CourierID,
+		"courier_id": courierID,
+		"actor_role": actor.Role,
 	}).Info("Order status updated")
 
-	return nil
+	return change, nil
+}
+
+// CancelOrder отменяет заказ. Роль инициатора должна иметь право отменить заказ на текущем этапе
+// и указать причину отмены. Плата за отмену удерживается только при отмене по инициативе клиента
+// и зависит от этапа заказа, остаток оплаченной суммы подлежит возврату. Назначенный курьер
+// освобождается в той же транзакции
+func (s *OrderService) CancelOrder(orderID uuid.UUID, req *models.CancelOrderRequest, actor models.Actor) (*models.OrderCancellation, error) {
+	return s.cancelOrder(orderID, req, actor, models.EventTypeOrderCancelled, nil)
+}
+
+// cancelOrder отменяет заказ и записывает в журнал заказа и outbox событие eventType: отмену заказа
+// или отказ продавца от заказа. Дополнительная проверка check, если задана, выполняется под блокировкой
+// строки заказа до проверки перехода статуса
+func (s *OrderService) cancelOrder(
+	orderID uuid.UUID,
+	req *models.CancelOrderRequest,
+	actor models.Actor,
+	eventType models.EventType,
+	check func(stage models.OrderStatus, merchantID *uuid.UUID) error,
+) (*models.OrderCancellation, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Блокируем строку заказа и получаем его этап и суммы
+	var stage models.OrderStatus
+	var courierID, merchantID *uuid.UUID
+	var totalAmount, deliveryCost, discountAmount float64
+	err = tx.QueryRow(`
+		SELECT status, courier_id, merchant_id, total_amount, delivery_cost, discount_amount
+		FROM orders WHERE id = $1 FOR UPDATE
+	`, orderID).Scan(&stage, &courierID, &merchantID, &totalAmount, &deliveryCost, &discountAmount)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("order not found")
+		}
+		return nil, fmt.Errorf("failed to get order status: %w", err)
+	}
+
+	if check != nil {
+		if err = check(stage, merchantID); err != nil {
+			return nil, err
+		}
+	}
+
+	if !stage.CanTransitionTo(models.OrderStatusCancelled, actor.Role) {
+		return nil, &InvalidTransitionError{From: stage, To: models.OrderStatusCancelled, Role: actor.Role}
+	}
+	if !req.Reason.AllowedFor(actor.Role) {
+		return nil, &InvalidTransitionError{
+			From: stage, To: models.OrderStatusCancelled, Role: actor.Role,
+			Reason: fmt.Sprintf("cancellation reason %s is not allowed for this role", req.Reason),
+		}
+	}
+
+	if err = setCurrentActor(tx, actor); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	cancellation := &models.OrderCancellation{
+		OrderID:     orderID,
+		Reason:      req.Reason,
+		Comment:     optionalString(req.Comment),
+		Stage:       stage,
+		CancelledBy: actor,
+		PaidAmount:  roundAmount(math.Max(totalAmount+deliveryCost-discountAmount, 0)),
+		CourierID:   courierID,
+		CancelledAt: now,
+	}
+	if req.Reason.ChargesCustomer() {
+		fee := cancellation.PaidAmount * s.cancellationFeePercent(stage) / 100
+		cancellation.Fee = roundAmount(math.Min(fee, cancellation.PaidAmount))
+	}
+	cancellation.RefundAmount = roundAmount(cancellation.PaidAmount - cancellation.Fee)
+
+	if _, err = tx.Exec("UPDATE orders SET status = $1, updated_at = $2 WHERE id = $3",
+		models.OrderStatusCancelled, now, orderID); err != nil {
+		return nil, fmt.Errorf("failed to cancel order: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO order_cancellations (order_id, reason, comment, stage, actor_role, actor_id,
+		            paid_amount, fee, refund_amount, courier_id, cancelled_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`, orderID, cancellation.Reason, cancellation.Comment, cancellation.Stage, actor.Role,
+		sql.NullString{String: actor.ID, Valid: actor.ID != ""}, cancellation.PaidAmount, cancellation.Fee,
+		cancellation.RefundAmount, cancellation.CourierID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record order cancellation: %w", err)
+	}
+
+	// Заказ больше не занимает курьера: возвращаем его в available, если вместимость освободилась
+	if courierID != nil {
+		if err = syncCourierLoadStatus(tx, *courierID, s.batching.DefaultCapacity, now); err != nil {
+			return nil, err
+		}
+	}
+
+	event := &models.OrderCancelledEvent{
+		OrderID:      orderID,
+		OldStatus:    stage,
+		Reason:       cancellation.Reason,
+		Comment:      cancellation.Comment,
+		CancelledBy:  actor,
+		Fee:          cancellation.Fee,
+		RefundAmount: cancellation.RefundAmount,
+		CourierID:    courierID,
+		Timestamp:    now,
+	}
+	if _, err = s.events.Append(tx, orderID, eventType, event, actor); err != nil {
+		return nil, err
+	}
+	enqueue := s.outbox.EnqueueOrderCancelled
+	if eventType == models.EventTypeOrderRejected {
+		enqueue = s.outbox.EnqueueOrderRejected
+	}
+	if err = enqueue(tx, event); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"order_id":      orderID,
+		"stage":         stage,
+		"reason":        req.Reason,
+		"fee":           cancellation.Fee,
+		"refund_amount": cancellation.RefundAmount,
+		"courier_id":    courierID,
+		"actor_role":    actor.Role,
+	}).Info("Order cancelled")
+
+	return cancellation, nil
+}
+
+// GetOrderCancellation получает сведения об отмене заказа
+func (s *OrderService) GetOrderCancellation(orderID uuid.UUID) (*models.OrderCancellation, error) {
+	cancellation := &models.OrderCancellation{}
+	var actorID sql.NullString
+	err := s.db.QueryRow(`
+		SELECT order_id, reason, comment, stage, actor_role, actor_id, paid_amount, fee, refund_amount,
+		       courier_id, cancelled_at
+		FROM order_cancellations
+		WHERE order_id = $1
+	`, orderID).Scan(&cancellation.OrderID, &cancellation.Reason, &cancellation.Comment, &cancellation.Stage,
+		&cancellation.CancelledBy.Role, &actorID, &cancellation.PaidAmount, &cancellation.Fee,
+		&cancellation.RefundAmount, &cancellation.CourierID, &cancellation.CancelledAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("order cancellation not found")
+		}
+		return nil, fmt.Errorf("failed to get order cancellation: %w", err)
+	}
+	cancellation.CancelledBy.ID = actorID.String
+
+	return cancellation, nil
+}
+
+// cancellationFeePercent возвращает плату за отмену на этапе заказа в процентах от оплаченной суммы
+func (s *OrderService) cancellationFeePercent(stage models.OrderStatus) float64 {
+	switch stage {
+	case models.OrderStatusPreparing:
+		return s.cancel.PreparingFeePercent
+	case models.OrderStatusReady:
+		return s.cancel.ReadyFeePercent
+	case models.OrderStatusInDelivery:
+		return s.cancel.InDeliveryFeePercent
+	default:
+		return 0
+	}
 }
 
 // GetOrders получает список заказов с фильтрацией
 func (s *OrderService) GetOrders(status *models.OrderStatus, courierID *uuid.UUID, limit, offset int) ([]*models.Order, error) {
-	query := `
-		SELECT id, customer_name, customer_phone, pickup_address, delivery_address, total_amount, 
-		       delivery_cost, status, courier_id, created_at, updated_at, delivered_at
-		FROM orders 
-		WHERE 1=1
-	`
+	query := `SELECT ` + orderColumns + ` FROM orders WHERE 1=1`
 	args := []interface{}{}
 	argIndex := 1
 
@@ -267,10 +682,8 @@ func (s *OrderService) GetOrders(status *models.OrderStatus, courierID *uuid.UUI
 
 	var orders []*models.Order
 	for rows.Next() {
-		order := &models.Order{}
-		if err := rows.Scan(&order.ID, &order.CustomerName, &order.CustomerPhone,
-			&order.PickupAddress, &order.DeliveryAddress, &order.TotalAmount, &order.DeliveryCost,
-			&order.Status, &order.CourierID, &order.CreatedAt, &order.UpdatedAt, &order.DeliveredAt); err != nil {
+		order, err := scanOrder(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan order: %w", err)
 		}
 		orders = append(orders, order)
@@ -279,6 +692,80 @@ func (s *OrderService) GetOrders(status *models.OrderStatus, courierID *uuid.UUI
 	return orders, nil
 }
 
+func scanOrder(row rowScanner) (*models.Order, error) {
+	order := &models.Order{}
+	var breakdown []byte
+	err := row.Scan(&order.ID, &order.CustomerID, &order.MerchantID, &order.CustomerName, &order.CustomerPhone, &order.PickupAddress,
+		&order.DeliveryAddress, &order.TotalAmount, &order.DeliveryCost, &breakdown, &order.Zone,
+		&order.PromoCode, &order.DiscountAmount, &order.Status, &order.CourierID, &order.CreatedAt,
+		&order.UpdatedAt, &order.DeliveredAt, &order.ETA, &order.SLADeadline,
+		&order.ScheduledFor, &order.DispatchAt, &order.PrepMinutes, &order.ReadyAt, &order.CourierDispatchAt)
+	if err != nil {
+		return nil, err
+	}
+	if breakdown != nil {
+		if err = json.Unmarshal(breakdown, &order.DeliveryBreakdown); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal delivery breakdown: %w", err)
+		}
+	}
+	return order, nil
+}
+
+// optionalString возвращает nil для пустой строки, чтобы в БД сохранялся NULL
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// generateDeliveryPin генерирует случайный четырёхзначный PIN-код подтверждения доставки
+func generateDeliveryPin() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(10000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate delivery pin: %w", err)
+	}
+	return fmt.Sprintf("%04d", n.Int64()), nil
+}
+
+// roundAmount округляет денежную сумму до копеек
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// applyCustomer дополняет запрос данными клиента, указанного в заказе, и возвращает выбранный
+// сохранённый адрес доставки
+func (s *OrderService) applyCustomer(req *models.CreateOrderRequest) (*models.CustomerAddress, error) {
+	if req.CustomerID == nil {
+		return nil, nil
+	}
+
+	customer, err := s.customers.GetCustomer(*req.CustomerID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrCustomerNotFound
+		}
+		return nil, err
+	}
+	if req.CustomerName == "" {
+		req.CustomerName = customer.Name
+	}
+	if req.CustomerPhone == "" {
+		req.CustomerPhone = customer.Phone
+	}
+
+	if req.DeliveryAddressID == nil {
+		return nil, nil
+	}
+	for i := range customer.Addresses {
+		if customer.Addresses[i].ID == *req.DeliveryAddressID {
+			req.DeliveryAddress = customer.Addresses[i].Address
+			return &customer.Addresses[i], nil
+		}
+	}
+	return nil, ErrCustomerAddressNotFound
+}
+
 func (s *OrderService) getCoordinates(coordinates *[][2]float64, address string) error {
 	lng, lat, err := s.geo.GetCoordinates(address)
 	if err != nil {
@@ -297,8 +784,3 @@ func (s *OrderService) makeRoute(coordinates *[][2]float64) (float64, error) {
 	}
 	return dist, nil
 }
-
-func (s *OrderService) calculateDeliveryCost(dist float64) *float64 {
-	deliveryCost := dist / float64(1000) * float64(s.business.DeliveryRate)
-	return &deliveryCost
-}
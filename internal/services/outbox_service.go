@@ -0,0 +1,394 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/kafka"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// outboxCleanupInterval - период удаления опубликованных сообщений старше срока хранения
+const outboxCleanupInterval = time.Hour
+
+// surgeEventKeyPrefix отделяет ключи событий коэффициентов от идентификаторов заказов
+const surgeEventKeyPrefix = "surge:"
+
+// OutboxService - сервис transactional outbox. События Kafka записываются в таблицу outbox
+// в транзакции изменения данных, а фоновый релей публикует их через Kafka producer с повторами.
+// Публикация выполняется не менее одного раза: при сбое после отправки событие может уйти повторно
+type OutboxService struct {
+	db       *database.DB
+	producer kafka.ProducerInterface
+	log      *logger.Logger
+	topics   *config.Topics
+	cfg      *config.OutboxConfig
+
+	published      atomic.Uint64
+	publishErrors  atomic.Uint64
+	publishAgeSum  atomic.Int64
+	lastPublishAge atomic.Int64
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewOutboxService создаёт новый экземпляр сервиса outbox
+func NewOutboxService(
+	db *database.DB,
+	producer kafka.ProducerInterface,
+	log *logger.Logger,
+	topics *config.Topics,
+	cfg *config.OutboxConfig,
+) *OutboxService {
+	return &OutboxService{
+		db:       db,
+		producer: producer,
+		log:      log,
+		topics:   topics,
+		cfg:      cfg,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// EnqueueOrderCreated записывает событие создания заказа в outbox в рамках транзакции
+func (s *OutboxService) EnqueueOrderCreated(tx *sql.Tx, order *models.Order) error {
+	return s.enqueue(tx, s.topics.Orders, order.ID.String(), models.EventTypeOrderCreated, models.OrderCreatedEvent{
+		OrderID:         order.ID,
+		CustomerID:      order.CustomerID,
+		MerchantID:      order.MerchantID,
+		CustomerName:    order.CustomerName,
+		CustomerPhone:   order.CustomerPhone,
+		DeliveryAddress: order.DeliveryAddress,
+		TotalAmount:     order.TotalAmount,
+		DeliveryCost:    order.DeliveryCost,
+		PromoCode:       order.PromoCode,
+		DiscountAmount:  order.DiscountAmount,
+		ScheduledFor:    order.ScheduledFor,
+	})
+}
+
+// EnqueueOrderStatusChanged записывает событие изменения статуса заказа в outbox в рамках транзакции
+func (s *OutboxService) EnqueueOrderStatusChanged(tx *sql.Tx, change *models.OrderStatusChangedEvent) error {
+	return s.enqueue(tx, s.topics.Orders, change.OrderID.String(), models.EventTypeOrderStatusChanged, change)
+}
+
+// EnqueueDeliveryPinIssued записывает уведомление покупателя о PIN-коде доставки в outbox в рамках транзакции
+func (s *OutboxService) EnqueueDeliveryPinIssued(tx *sql.Tx, order *models.Order, pin string) error {
+	return s.enqueue(tx, s.topics.Notifications, order.ID.String(), models.EventTypeDeliveryPinIssued, models.DeliveryPinIssuedEvent{
+		OrderID:       order.ID,
+		CustomerPhone: order.CustomerPhone,
+		Pin:           pin,
+		Timestamp:     order.CreatedAt,
+	})
+}
+
+// EnqueueOrderCancelled записывает событие отмены заказа в outbox в рамках транзакции
+func (s *OutboxService) EnqueueOrderCancelled(tx *sql.Tx, cancelled *models.OrderCancelledEvent) error {
+	return s.enqueue(tx, s.topics.Orders, cancelled.OrderID.String(), models.EventTypeOrderCancelled, cancelled)
+}
+
+// EnqueueOrderRejected записывает событие отказа продавца от заказа в outbox в рамках транзакции
+func (s *OutboxService) EnqueueOrderRejected(tx *sql.Tx, rejected *models.OrderCancelledEvent) error {
+	return s.enqueue(tx, s.topics.Orders, rejected.OrderID.String(), models.EventTypeOrderRejected, rejected)
+}
+
+// EnqueueOrderPreparation записывает событие этапа приготовления заказа продавцом в outbox в рамках транзакции
+func (s *OutboxService) EnqueueOrderPreparation(tx *sql.Tx, eventType models.EventType, event *models.OrderPreparationEvent) error {
+	return s.enqueue(tx, s.topics.Orders, event.OrderID.String(), eventType, event)
+}
+
+// EnqueueOrderSLABreached записывает событие нарушения срока доставки заказа в outbox в рамках транзакции
+func (s *OutboxService) EnqueueOrderSLABreached(tx *sql.Tx, breach *models.OrderSLABreachedEvent) error {
+	return s.enqueue(tx, s.topics.Orders, breach.OrderID.String(), models.EventTypeOrderSLABreached, breach)
+}
+
+// EnqueueCourierAssigned записывает событие назначения курьера в outbox в рамках транзакции
+func (s *OutboxService) EnqueueCourierAssigned(tx *sql.Tx, orderID, courierID uuid.UUID, assignedAt time.Time) error {
+	return s.enqueue(tx, s.topics.Couriers, orderID.String(), models.EventTypeCourierAssigned, models.CourierAssignedEvent{
+		OrderID:   orderID,
+		CourierID: courierID,
+		Timestamp: assignedAt,
+	})
+}
+
+// EnqueueSurgeChanged записывает событие изменения динамического коэффициента зоны в outbox в рамках транзакции
+func (s *OutboxService) EnqueueSurgeChanged(tx *sql.Tx, change *models.SurgeChangedEvent) error {
+	return s.enqueue(tx, s.topics.Pricing, surgeEventKeyPrefix+change.Zone, models.EventTypeSurgeChanged, change)
+}
+
+// enqueue сериализует событие и сохраняет его в outbox. Ключом сообщения служит идентификатор заказа
+// (для коэффициентов - зона), поэтому события одного заказа попадают в одну партицию и публикуются по порядку
+func (s *OutboxService) enqueue(tx *sql.Tx, topic string, key string, eventType models.EventType, data interface{}) error {
+	event := models.Event{
+		ID:        uuid.New(),
+		Type:      eventType,
+		Timestamp: time.Now(),
+		Data:      data,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox event: %w", err)
+	}
+
+	query := `
+		INSERT INTO outbox (id, topic, event_key, event_type, payload, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	if _, err = tx.Exec(query, event.ID, topic, key, eventType, payload, event.Timestamp); err != nil {
+		return fmt.Errorf("failed to enqueue outbox event: %w", err)
+	}
+
+	return nil
+}
+
+// Start запускает фоновый релей, публикующий события из outbox
+func (s *OutboxService) Start() {
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(time.Duration(s.cfg.PollInterval) * time.Millisecond)
+		defer ticker.Stop()
+		lastCleanup := time.Now()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.relay()
+				if time.Since(lastCleanup) >= outboxCleanupInterval {
+					s.cleanup()
+					lastCleanup = time.Now()
+				}
+			}
+		}
+	}()
+
+	s.log.Info("Outbox relay started")
+}
+
+// Stop останавливает релей и дожидается завершения текущей пачки
+func (s *OutboxService) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+		s.log.Info("Outbox relay stopped")
+	})
+}
+
+// relay публикует накопившиеся события пачками, пока outbox не опустеет или публикация не завершится ошибкой.
+// При ошибке публикации пачка оказывается неполной, и следующий проход начнётся по таймеру
+func (s *OutboxService) relay() {
+	for {
+		published, err := s.relayBatch()
+		if err != nil {
+			s.log.WithError(err).Error("Failed to relay outbox events")
+			return
+		}
+		if published < s.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+// relayBatch блокирует пачку готовых к отправке сообщений и публикует их по порядку.
+// Несколько инстансов сервиса разбирают outbox параллельно, но каждый ключ в один момент времени
+// обрабатывает только один инстанс: ключи захватываются транзакционными advisory-блокировками,
+// поэтому события одного заказа не публикуются вперемешку. Сообщения ключа, у которого есть более
+// раннее неотправленное сообщение в ожидании повтора, пропускаются, чтобы не нарушать порядок событий заказа
+func (s *OutboxService) relayBatch() (int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	keys, err := lockOutboxKeys(tx, s.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	// Сообщения выбираются отдельным запросом уже после захвата ключей, чтобы видеть
+	// изменения, зафиксированные инстансом, который держал ключ до нас
+	rows, err := tx.Query(`
+		SELECT o.id, o.topic, o.event_key, o.event_type, o.payload, o.attempts, o.created_at
+		FROM outbox o
+		WHERE o.sent_at IS NULL AND o.next_attempt_at <= NOW() AND o.event_key = ANY($2)
+		  AND NOT EXISTS (
+		      SELECT 1 FROM outbox p
+		      WHERE p.event_key = o.event_key AND p.sent_at IS NULL AND p.seq < o.seq AND p.next_attempt_at > NOW()
+		  )
+		ORDER BY o.seq
+		LIMIT $1
+		FOR UPDATE OF o
+	`, s.cfg.BatchSize, pq.Array(keys))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get outbox messages: %w", err)
+	}
+
+	var messages []*models.OutboxMessage
+	for rows.Next() {
+		message := &models.OutboxMessage{}
+		var payload []byte
+		if err := rows.Scan(&message.ID, &message.Topic, &message.Key, &message.EventType, &payload,
+			&message.Attempts, &message.CreatedAt); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		message.Payload = payload
+		messages = append(messages, message)
+	}
+	rows.Close()
+
+	published := 0
+	for _, message := range messages {
+		if publishErr := s.producer.PublishMessage(message.Topic, message.Key, message.EventType,
+			message.CreatedAt, message.Payload); publishErr != nil {
+			s.publishErrors.Add(1)
+			if err := s.scheduleRetry(tx, message, publishErr); err != nil {
+				return published, err
+			}
+			// Остальные сообщения пачки остаются неотправленными и будут взяты следующим проходом
+			break
+		}
+
+		if _, err := tx.Exec("UPDATE outbox SET sent_at = NOW(), attempts = attempts + 1, last_error = NULL WHERE id = $1",
+			message.ID); err != nil {
+			return published, fmt.Errorf("failed to mark outbox message as sent: %w", err)
+		}
+
+		age := time.Since(message.CreatedAt)
+		s.published.Add(1)
+		s.publishAgeSum.Add(age.Milliseconds())
+		s.lastPublishAge.Store(age.Milliseconds())
+		published++
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	if published > 0 {
+		s.log.WithField("count", published).Debug("Outbox events published")
+	}
+	return published, nil
+}
+
+// lockOutboxKeys захватывает до limit ключей с неотправленными сообщениями, начиная с самых давних.
+// Ключи, которые уже обрабатывает другой инстанс, пропускаются. Блокировки снимаются при завершении транзакции
+func lockOutboxKeys(tx *sql.Tx, limit int) ([]string, error) {
+	rows, err := tx.Query(`
+		WITH candidates AS MATERIALIZED (
+		    SELECT event_key, MIN(seq) AS first_seq
+		    FROM outbox
+		    WHERE sent_at IS NULL AND next_attempt_at <= NOW()
+		    GROUP BY event_key
+		    ORDER BY first_seq
+		    LIMIT $1
+		)
+		SELECT event_key FROM candidates
+		WHERE pg_try_advisory_xact_lock(hashtext(event_key))
+		ORDER BY first_seq
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock outbox keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// scheduleRetry откладывает повторную публикацию сообщения с экспоненциальной задержкой
+func (s *OutboxService) scheduleRetry(tx *sql.Tx, message *models.OutboxMessage, publishErr error) error {
+	backoff := s.backoff(message.Attempts + 1)
+	_, err := tx.Exec(`
+		UPDATE outbox
+		SET attempts = attempts + 1, last_error = $2, next_attempt_at = NOW() + $3 * INTERVAL '1 millisecond'
+		WHERE id = $1
+	`, message.ID, publishErr.Error(), backoff.Milliseconds())
+	if err != nil {
+		return fmt.Errorf("failed to schedule outbox retry: %w", err)
+	}
+
+	s.log.WithError(publishErr).WithFields(map[string]interface{}{
+		"outbox_id":  message.ID,
+		"event_type": message.EventType,
+		"attempts":   message.Attempts + 1,
+		"retry_in":   backoff.String(),
+	}).Warn("Failed to publish outbox event, retry scheduled")
+	return nil
+}
+
+// backoff возвращает задержку перед повтором: интервал опроса, удваиваемый с каждой попыткой
+func (s *OutboxService) backoff(attempts int) time.Duration {
+	maxBackoff := time.Duration(s.cfg.MaxBackoff) * time.Second
+	backoff := time.Duration(s.cfg.PollInterval) * time.Millisecond
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+// cleanup удаляет опубликованные сообщения старше срока хранения
+func (s *OutboxService) cleanup() {
+	result, err := s.db.Exec("DELETE FROM outbox WHERE sent_at < NOW() - $1 * INTERVAL '1 hour'", s.cfg.RetentionHours)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to clean up outbox")
+		return
+	}
+	if deleted, _ := result.RowsAffected(); deleted > 0 {
+		s.log.WithField("count", deleted).Info("Published outbox events cleaned up")
+	}
+}
+
+// GetMetrics возвращает глубину outbox, возраст самого старого неотправленного события
+// и задержку публикации событий с момента записи
+func (s *OutboxService) GetMetrics() (*models.OutboxMetrics, error) {
+	metrics := &models.OutboxMetrics{}
+	err := s.db.QueryRow(`
+		SELECT COUNT(*),
+		       COUNT(*) FILTER (WHERE attempts > 0),
+		       COALESCE(EXTRACT(EPOCH FROM (NOW() - MIN(created_at))), 0)
+		FROM outbox
+		WHERE sent_at IS NULL
+	`).Scan(&metrics.Pending, &metrics.Retrying, &metrics.OldestPendingAgeSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbox metrics: %w", err)
+	}
+
+	metrics.Published = s.published.Load()
+	metrics.PublishErrors = s.publishErrors.Load()
+	metrics.LastPublishAgeSeconds = float64(s.lastPublishAge.Load()) / 1000
+	if metrics.Published > 0 {
+		metrics.AvgPublishAgeSeconds = float64(s.publishAgeSum.Load()) / float64(metrics.Published) / 1000
+	}
+
+	return metrics, nil
+}
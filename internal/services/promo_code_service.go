@@ -0,0 +1,275 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	// pqUniqueViolation - код ошибки PostgreSQL при нарушении ограничения уникальности
+	pqUniqueViolation = "23505"
+	// pqForeignKeyViolation - код ошибки PostgreSQL при ссылке на несуществующую запись
+	pqForeignKeyViolation = "23503"
+)
+
+// promoCodeColumns - список колонок промокода в порядке сканирования scanPromoCode
+const promoCodeColumns = `id, code, discount_type, discount_value, min_order_amount, valid_from, valid_until,
+		       max_uses, max_uses_per_customer, used_count, is_active, created_at, updated_at`
+
+// PromoCodeService - сервис для работы с промокодами
+type PromoCodeService struct {
+	db  *database.DB
+	log *logger.Logger
+}
+
+// NewPromoCodeService создаёт новый экземпляр сервиса промокодов
+func NewPromoCodeService(db *database.DB, log *logger.Logger) *PromoCodeService {
+	return &PromoCodeService{
+		db:  db,
+		log: log,
+	}
+}
+
+// NormalizePromoCode приводит промокод к каноническому виду: без пробелов по краям и в верхнем регистре
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// CreatePromoCode создаёт новый промокод
+func (s *PromoCodeService) CreatePromoCode(req *models.PromoCodeRequest) (*models.PromoCode, error) {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	query := `
+		INSERT INTO promo_codes (id, code, discount_type, discount_value, min_order_amount, valid_from,
+		            valid_until, max_uses, max_uses_per_customer, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING ` + promoCodeColumns
+
+	promoCode, err := scanPromoCode(s.db.QueryRow(query, uuid.New(), NormalizePromoCode(req.Code), req.DiscountType,
+		req.DiscountValue, req.MinOrderAmount, req.ValidFrom, req.ValidUntil, req.MaxUses, req.MaxUsesPerCustomer, isActive))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("promo code already exists")
+		}
+		return nil, fmt.Errorf("failed to create promo code: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"promo_code_id":  promoCode.ID,
+		"code":           promoCode.Code,
+		"discount_type":  promoCode.DiscountType,
+		"discount_value": promoCode.DiscountValue,
+	}).Info("Promo code created successfully")
+
+	return promoCode, nil
+}
+
+// GetPromoCode получает промокод по ID
+func (s *PromoCodeService) GetPromoCode(promoCodeID uuid.UUID) (*models.PromoCode, error) {
+	query := `SELECT ` + promoCodeColumns + ` FROM promo_codes WHERE id = $1`
+
+	promoCode, err := scanPromoCode(s.db.QueryRow(query, promoCodeID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("promo code not found")
+		}
+		return nil, fmt.Errorf("failed to get promo code: %w", err)
+	}
+
+	return promoCode, nil
+}
+
+// GetPromoCodes получает список промокодов
+func (s *PromoCodeService) GetPromoCodes(activeOnly bool, limit, offset int) ([]*models.PromoCode, error) {
+	query := `SELECT ` + promoCodeColumns + ` FROM promo_codes WHERE 1=1`
+	args := []interface{}{}
+	argIndex := 1
+
+	if activeOnly {
+		query += " AND is_active = TRUE"
+	}
+
+	query += " ORDER BY created_at DESC"
+
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, limit)
+		argIndex++
+	}
+
+	if offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, offset)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promo codes: %w", err)
+	}
+	defer rows.Close()
+
+	var promoCodes []*models.PromoCode
+	for rows.Next() {
+		promoCode, err := scanPromoCode(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan promo code: %w", err)
+		}
+		promoCodes = append(promoCodes, promoCode)
+	}
+
+	return promoCodes, nil
+}
+
+// UpdatePromoCode полностью обновляет параметры промокода. Счётчик использований не изменяется
+func (s *PromoCodeService) UpdatePromoCode(promoCodeID uuid.UUID, req *models.PromoCodeRequest) (*models.PromoCode, error) {
+	query := `
+		UPDATE promo_codes
+		SET code = $1, discount_type = $2, discount_value = $3, min_order_amount = $4, valid_from = $5,
+		    valid_until = $6, max_uses = $7, max_uses_per_customer = $8, is_active = COALESCE($9, is_active)
+		WHERE id = $10
+		RETURNING ` + promoCodeColumns
+
+	promoCode, err := scanPromoCode(s.db.QueryRow(query, NormalizePromoCode(req.Code), req.DiscountType,
+		req.DiscountValue, req.MinOrderAmount, req.ValidFrom, req.ValidUntil, req.MaxUses, req.MaxUsesPerCustomer,
+		req.IsActive, promoCodeID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("promo code not found")
+		}
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("promo code already exists")
+		}
+		return nil, fmt.Errorf("failed to update promo code: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"promo_code_id": promoCode.ID,
+		"code":          promoCode.Code,
+	}).Info("Promo code updated")
+
+	return promoCode, nil
+}
+
+// DeletePromoCode деактивирует промокод. Запись сохраняется, чтобы не терять историю использований
+func (s *PromoCodeService) DeletePromoCode(promoCodeID uuid.UUID) error {
+	result, err := s.db.Exec("UPDATE promo_codes SET is_active = FALSE WHERE id = $1", promoCodeID)
+	if err != nil {
+		return fmt.Errorf("failed to delete promo code: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("promo code not found")
+	}
+
+	s.log.WithField("promo_code_id", promoCodeID).Info("Promo code deactivated")
+	return nil
+}
+
+// applyPromoCode блокирует строку промокода в транзакции создания заказа, проверяет срок действия
+// и лимиты использований и рассчитывает размер скидки. Блокировка не даёт параллельным заказам
+// превысить лимиты до фиксации использования в recordPromoCodeRedemption
+func applyPromoCode(tx *sql.Tx, code, customerPhone string, totalAmount, deliveryCost float64, now time.Time) (*models.PromoCode, float64, error) {
+	code = NormalizePromoCode(code)
+
+	query := `SELECT ` + promoCodeColumns + ` FROM promo_codes WHERE code = $1 FOR UPDATE`
+	promoCode, err := scanPromoCode(tx.QueryRow(query, code))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, 0, &PromoCodeError{Code: code, Reason: "not found"}
+		}
+		return nil, 0, fmt.Errorf("failed to get promo code: %w", err)
+	}
+
+	if !promoCode.IsActive {
+		return nil, 0, &PromoCodeError{Code: code, Reason: "inactive"}
+	}
+	if promoCode.ValidFrom != nil && now.Before(*promoCode.ValidFrom) {
+		return nil, 0, &PromoCodeError{Code: code, Reason: "not yet valid"}
+	}
+	if promoCode.ValidUntil != nil && now.After(*promoCode.ValidUntil) {
+		return nil, 0, &PromoCodeError{Code: code, Reason: "expired"}
+	}
+	if promoCode.MaxUses != nil && promoCode.UsedCount >= *promoCode.MaxUses {
+		return nil, 0, &PromoCodeError{Code: code, Reason: "usage limit reached"}
+	}
+	if totalAmount < promoCode.MinOrderAmount {
+		return nil, 0, &PromoCodeError{
+			Code:   code,
+			Reason: fmt.Sprintf("minimum order amount is %.2f", promoCode.MinOrderAmount),
+		}
+	}
+
+	// Телефоны сравниваются в нормализованном виде, чтобы разные записи одного номера
+	// не обходили лимит использований на клиента
+	if promoCode.MaxUsesPerCustomer != nil {
+		var customerUses int
+		err = tx.QueryRow(
+			"SELECT COUNT(*) FROM promo_code_redemptions WHERE promo_code_id = $1 AND normalize_phone(customer_phone) = $2",
+			promoCode.ID, models.NormalizePhone(customerPhone),
+		).Scan(&customerUses)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to count promo code redemptions: %w", err)
+		}
+		if customerUses >= *promoCode.MaxUsesPerCustomer {
+			return nil, 0, &PromoCodeError{Code: code, Reason: "customer usage limit reached"}
+		}
+	}
+
+	return promoCode, promoCode.Discount(totalAmount, deliveryCost), nil
+}
+
+// recordPromoCodeRedemption фиксирует использование промокода заказом и увеличивает счётчик использований
+func recordPromoCodeRedemption(tx *sql.Tx, promoCodeID, orderID uuid.UUID, customerPhone string, discount float64) error {
+	_, err := tx.Exec(`
+		INSERT INTO promo_code_redemptions (id, promo_code_id, order_id, customer_phone, discount_amount)
+		VALUES ($1, $2, $3, $4, $5)
+	`, uuid.New(), promoCodeID, orderID, customerPhone, discount)
+	if err != nil {
+		return fmt.Errorf("failed to record promo code redemption: %w", err)
+	}
+
+	if _, err = tx.Exec("UPDATE promo_codes SET used_count = used_count + 1 WHERE id = $1", promoCodeID); err != nil {
+		return fmt.Errorf("failed to update promo code usage: %w", err)
+	}
+
+	return nil
+}
+
+func scanPromoCode(row rowScanner) (*models.PromoCode, error) {
+	promoCode := &models.PromoCode{}
+	err := row.Scan(&promoCode.ID, &promoCode.Code, &promoCode.DiscountType, &promoCode.DiscountValue,
+		&promoCode.MinOrderAmount, &promoCode.ValidFrom, &promoCode.ValidUntil, &promoCode.MaxUses,
+		&promoCode.MaxUsesPerCustomer, &promoCode.UsedCount, &promoCode.IsActive, &promoCode.CreatedAt,
+		&promoCode.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return promoCode, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == pqForeignKeyViolation
+}
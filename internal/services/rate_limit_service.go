@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+	"delivery-system/internal/redis"
+)
+
+// RateLimitService - сервис ограничения частоты запросов по алгоритму скользящего окна.
+// Состояние окна хранится в Redis, поэтому лимит общий для всех инстансов сервиса
+type RateLimitService struct {
+	redisClient *redis.Client
+	log         *logger.Logger
+	cfg         *config.RateLimitConfig
+}
+
+// NewRateLimitService создаёт новый экземпляр сервиса ограничения частоты запросов
+func NewRateLimitService(redisClient *redis.Client, log *logger.Logger, cfg *config.RateLimitConfig) *RateLimitService {
+	return &RateLimitService{
+		redisClient: redisClient,
+		log:         log,
+		cfg:         cfg,
+	}
+}
+
+// Allow учитывает запрос клиента и возвращает результат проверки лимита.
+// При недоступности Redis запрос пропускается, чтобы сбой лимитера не останавливал API
+func (s *RateLimitService) Allow(client models.RateLimitClient) *models.RateLimitStatus {
+	status := s.newStatus(client)
+	if !s.cfg.Enabled {
+		status.Allowed = true
+		status.Remaining = status.Limit
+		return status
+	}
+
+	result, err := s.redisClient.SlidingWindowAllow(context.Background(), status.Client, status.Limit, s.window(),
+		s.cfg.BanThreshold, time.Duration(s.cfg.BanDuration)*time.Second)
+	if err != nil {
+		s.log.WithError(err).WithField("client", status.Client).Warn("Rate limit check failed, request allowed")
+		status.Allowed = true
+		status.Remaining = status.Limit
+		return status
+	}
+
+	s.fillStatus(status, result)
+	if !status.Allowed {
+		s.log.WithFields(map[string]interface{}{
+			"client": status.Client,
+			"tier":   status.Tier,
+			"banned": status.Banned,
+		}).Warn("Rate limit exceeded")
+	}
+	return status
+}
+
+// GetStatus возвращает оставшийся лимит клиента, не учитывая текущий запрос
+func (s *RateLimitService) GetStatus(client models.RateLimitClient) (*models.RateLimitStatus, error) {
+	status := s.newStatus(client)
+	if !s.cfg.Enabled {
+		status.Allowed = true
+		status.Remaining = status.Limit
+		return status, nil
+	}
+
+	result, err := s.redisClient.SlidingWindowStatus(context.Background(), status.Client, status.Limit, s.window())
+	if err != nil {
+		return nil, err
+	}
+
+	s.fillStatus(status, result)
+	return status, nil
+}
+
+// newStatus определяет клиента и его тарифный план
+func (s *RateLimitService) newStatus(client models.RateLimitClient) *models.RateLimitStatus {
+	status := &models.RateLimitStatus{
+		Tier:   models.RateLimitTierDefault,
+		Limit:  s.cfg.DefaultLimit,
+		Window: s.cfg.Window,
+	}
+
+	// Запросы по аутентифицированному API-ключу учитываются в лимите ключа по его тарифному плану
+	if client.APIKeyID != "" {
+		status.Client = "key:" + client.APIKeyID
+		if client.Tier == models.RateLimitTierVIP {
+			status.Tier = models.RateLimitTierVIP
+			status.Limit = s.cfg.VIPLimit
+		}
+		return status
+	}
+
+	status.Client = "ip:" + s.clientIP(client)
+	return status
+}
+
+// clientIP возвращает IP-адрес клиента. X-Forwarded-For учитывается только за доверенным прокси,
+// иначе клиент мог бы обойти лимит, подставляя произвольный адрес
+func (s *RateLimitService) clientIP(client models.RateLimitClient) string {
+	if s.cfg.TrustProxy && client.ForwardedFor != "" {
+		if ip := strings.TrimSpace(strings.Split(client.ForwardedFor, ",")[0]); ip != "" {
+			return ip
+		}
+	}
+	if host, _, err := net.SplitHostPort(client.RemoteAddr); err == nil {
+		return host
+	}
+	return client.RemoteAddr
+}
+
+func (s *RateLimitService) fillStatus(status *models.RateLimitStatus, result *redis.SlidingWindowResult) {
+	status.Allowed = result.Allowed
+	status.Remaining = result.Remaining
+	status.Banned = result.Banned
+	status.ResetAt = time.Now().Add(result.ResetIn).UTC()
+	if !result.Allowed {
+		// Округление вверх, чтобы повтор через Retry-After гарантированно попал в новое окно
+		status.RetryAfter = int((result.ResetIn + time.Second - 1) / time.Second)
+	}
+}
+
+func (s *RateLimitService) window() time.Duration {
+	return time.Duration(s.cfg.Window) * time.Second
+}
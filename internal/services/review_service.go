@@ -12,20 +12,22 @@ import (
 
 // ReviewService - сервис для работы с отзывами
 type ReviewService struct {
-	db  *database.DB
-	log *logger.Logger
+	db     *database.DB
+	log    *logger.Logger
+	events *OrderEventService
 }
 
 // NewReviewService создаёт экземпляр объекта ReviewService
-func NewReviewService(db *database.DB, log *logger.Logger) *ReviewService {
+func NewReviewService(db *database.DB, log *logger.Logger, events *OrderEventService) *ReviewService {
 	return &ReviewService{
-		db:  db,
-		log: log,
+		db:     db,
+		log:    log,
+		events: events,
 	}
 }
 
-// CreateReview создаёт новый отзыв на курьера
-func (s *ReviewService) CreateReview(req *models.CreateReviewRequest, order *models.Order) (*models.Review, error) {
+// CreateReview создаёт новый отзыв на курьера и записывает событие в журнал заказа
+func (s *ReviewService) CreateReview(req *models.CreateReviewRequest, order *models.Order, actor models.Actor) (*models.Review, error) {
 	review := &models.Review{
 		ID:        uuid.New(),
 		OrderID:   order.ID,
@@ -39,12 +41,26 @@ func (s *ReviewService) CreateReview(req *models.CreateReviewRequest, order *mod
 		VALUES ($1, $2, $3, $4, $5)
 	`
 
-	_, err := s.db.Exec(query, review.ID, order.ID, *order.CourierID, req.Rating, req.Text)
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(query, review.ID, order.ID, *order.CourierID, req.Rating, req.Text)
 	if err != nil {
 		s.log.WithError(err).Error("Failed to create review")
 		return nil, fmt.Errorf("failed to create review: %w", err)
 	}
 
+	if _, err = s.events.Append(tx, order.ID, models.EventTypeOrderReviewAdded, review, actor); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	s.log.WithFields(map[string]interface{}{
 		"id":         review.ID,
 		"order_id":   order.ID,
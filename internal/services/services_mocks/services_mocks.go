@@ -6,19 +6,21 @@ package services_mocks
 
 import (
 	"context"
+	"database/sql"
 	"delivery-system/internal/models"
+	"time"
 
 	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
-// NewMockGeolocationServiceInterface creates a new instance of MockGeolocationServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// newMockexecer creates a new instance of mockexecer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockGeolocationServiceInterface(t interface {
+func newMockexecer(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockGeolocationServiceInterface {
-	mock := &MockGeolocationServiceInterface{}
+}) *mockexecer {
+	mock := &mockexecer{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -26,73 +28,125 @@ func NewMockGeolocationServiceInterface(t interface {
 	return mock
 }
 
-// MockGeolocationServiceInterface is an autogenerated mock type for the GeolocationServiceInterface type
-type MockGeolocationServiceInterface struct {
+// mockexecer is an autogenerated mock type for the execer type
+type mockexecer struct {
 	mock.Mock
 }
 
-type MockGeolocationServiceInterface_Expecter struct {
+type mockexecer_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockGeolocationServiceInterface) EXPECT() *MockGeolocationServiceInterface_Expecter {
-	return &MockGeolocationServiceInterface_Expecter{mock: &_m.Mock}
+func (_m *mockexecer) EXPECT() *mockexecer_Expecter {
+	return &mockexecer_Expecter{mock: &_m.Mock}
 }
 
-// CacheResults provides a mock function for the type MockGeolocationServiceInterface
-func (_mock *MockGeolocationServiceInterface) CacheResults(coordinates [][2]float64, distance float64, order *models.Order) {
-	_mock.Called(coordinates, distance, order)
-	return
+// Exec provides a mock function for the type mockexecer
+func (_mock *mockexecer) Exec(query string, args ...interface{}) (sql.Result, error) {
+	var tmpRet mock.Arguments
+	if len(args) > 0 {
+		tmpRet = _mock.Called(query, args)
+	} else {
+		tmpRet = _mock.Called(query)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 sql.Result
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, ...interface{}) (sql.Result, error)); ok {
+		return returnFunc(query, args...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, ...interface{}) sql.Result); ok {
+		r0 = returnFunc(query, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(sql.Result)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, ...interface{}) error); ok {
+		r1 = returnFunc(query, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockGeolocationServiceInterface_CacheResults_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CacheResults'
-type MockGeolocationServiceInterface_CacheResults_Call struct {
+// mockexecer_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type mockexecer_Exec_Call struct {
 	*mock.Call
 }
 
-// CacheResults is a helper method to define mock.On call
-//   - coordinates [][2]float64
-//   - distance float64
-//   - order *models.Order
-func (_e *MockGeolocationServiceInterface_Expecter) CacheResults(coordinates interface{}, distance interface{}, order interface{}) *MockGeolocationServiceInterface_CacheResults_Call {
-	return &MockGeolocationServiceInterface_CacheResults_Call{Call: _e.mock.On("CacheResults", coordinates, distance, order)}
+// Exec is a helper method to define mock.On call
+//   - query string
+//   - args ...interface{}
+func (_e *mockexecer_Expecter) Exec(query interface{}, args ...interface{}) *mockexecer_Exec_Call {
+	return &mockexecer_Exec_Call{Call: _e.mock.On("Exec",
+		append([]interface{}{query}, args...)...)}
 }
 
-func (_c *MockGeolocationServiceInterface_CacheResults_Call) Run(run func(coordinates [][2]float64, distance float64, order *models.Order)) *MockGeolocationServiceInterface_CacheResults_Call {
+func (_c *mockexecer_Exec_Call) Run(run func(query string, args ...interface{})) *mockexecer_Exec_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 [][2]float64
+		var arg0 string
 		if args[0] != nil {
-			arg0 = args[0].([][2]float64)
-		}
-		var arg1 float64
-		if args[1] != nil {
-			arg1 = args[1].(float64)
+			arg0 = args[0].(string)
 		}
-		var arg2 *models.Order
-		if args[2] != nil {
-			arg2 = args[2].(*models.Order)
+		var arg1 []interface{}
+		var variadicArgs []interface{}
+		if len(args) > 1 {
+			variadicArgs = args[1].([]interface{})
 		}
+		arg1 = variadicArgs
 		run(
 			arg0,
-			arg1,
-			arg2,
+			arg1...,
 		)
 	})
 	return _c
 }
 
-func (_c *MockGeolocationServiceInterface_CacheResults_Call) Return() *MockGeolocationServiceInterface_CacheResults_Call {
-	_c.Call.Return()
+func (_c *mockexecer_Exec_Call) Return(result sql.Result, err error) *mockexecer_Exec_Call {
+	_c.Call.Return(result, err)
 	return _c
 }
 
-func (_c *MockGeolocationServiceInterface_CacheResults_Call) RunAndReturn(run func(coordinates [][2]float64, distance float64, order *models.Order)) *MockGeolocationServiceInterface_CacheResults_Call {
-	_c.Run(run)
+func (_c *mockexecer_Exec_Call) RunAndReturn(run func(query string, args ...interface{}) (sql.Result, error)) *mockexecer_Exec_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// GetCoordinates provides a mock function for the type MockGeolocationServiceInterface
-func (_mock *MockGeolocationServiceInterface) GetCoordinates(address string) (float64, float64, error) {
+// NewMockGeocoder creates a new instance of MockGeocoder. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGeocoder(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGeocoder {
+	mock := &MockGeocoder{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockGeocoder is an autogenerated mock type for the Geocoder type
+type MockGeocoder struct {
+	mock.Mock
+}
+
+type MockGeocoder_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGeocoder) EXPECT() *MockGeocoder_Expecter {
+	return &MockGeocoder_Expecter{mock: &_m.Mock}
+}
+
+// GetCoordinates provides a mock function for the type MockGeocoder
+func (_mock *MockGeocoder) GetCoordinates(address string) (float64, float64, error) {
 	ret := _mock.Called(address)
 
 	if len(ret) == 0 {
@@ -123,18 +177,18 @@ func (_mock *MockGeolocationServiceInterface) GetCoordinates(address string) (fl
 	return r0, r1, r2
 }
 
-// MockGeolocationServiceInterface_GetCoordinates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoordinates'
-type MockGeolocationServiceInterface_GetCoordinates_Call struct {
+// MockGeocoder_GetCoordinates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoordinates'
+type MockGeocoder_GetCoordinates_Call struct {
 	*mock.Call
 }
 
 // GetCoordinates is a helper method to define mock.On call
 //   - address string
-func (_e *MockGeolocationServiceInterface_Expecter) GetCoordinates(address interface{}) *MockGeolocationServiceInterface_GetCoordinates_Call {
-	return &MockGeolocationServiceInterface_GetCoordinates_Call{Call: _e.mock.On("GetCoordinates", address)}
+func (_e *MockGeocoder_Expecter) GetCoordinates(address interface{}) *MockGeocoder_GetCoordinates_Call {
+	return &MockGeocoder_GetCoordinates_Call{Call: _e.mock.On("GetCoordinates", address)}
 }
 
-func (_c *MockGeolocationServiceInterface_GetCoordinates_Call) Run(run func(address string)) *MockGeolocationServiceInterface_GetCoordinates_Call {
+func (_c *MockGeocoder_GetCoordinates_Call) Run(run func(address string)) *MockGeocoder_GetCoordinates_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
@@ -147,18 +201,45 @@ func (_c *MockGeolocationServiceInterface_GetCoordinates_Call) Run(run func(addr
 	return _c
 }
 
-func (_c *MockGeolocationServiceInterface_GetCoordinates_Call) Return(f float64, f1 float64, err error) *MockGeolocationServiceInterface_GetCoordinates_Call {
+func (_c *MockGeocoder_GetCoordinates_Call) Return(f float64, f1 float64, err error) *MockGeocoder_GetCoordinates_Call {
 	_c.Call.Return(f, f1, err)
 	return _c
 }
 
-func (_c *MockGeolocationServiceInterface_GetCoordinates_Call) RunAndReturn(run func(address string) (float64, float64, error)) *MockGeolocationServiceInterface_GetCoordinates_Call {
+func (_c *MockGeocoder_GetCoordinates_Call) RunAndReturn(run func(address string) (float64, float64, error)) *MockGeocoder_GetCoordinates_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MakeRoute provides a mock function for the type MockGeolocationServiceInterface
-func (_mock *MockGeolocationServiceInterface) MakeRoute(coordinates [][2]float64) (float64, error) {
+// NewMockRouter creates a new instance of MockRouter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRouter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRouter {
+	mock := &MockRouter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRouter is an autogenerated mock type for the Router type
+type MockRouter struct {
+	mock.Mock
+}
+
+type MockRouter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRouter) EXPECT() *MockRouter_Expecter {
+	return &MockRouter_Expecter{mock: &_m.Mock}
+}
+
+// MakeRoute provides a mock function for the type MockRouter
+func (_mock *MockRouter) MakeRoute(coordinates [][2]float64) (float64, error) {
 	ret := _mock.Called(coordinates)
 
 	if len(ret) == 0 {
@@ -183,18 +264,18 @@ func (_mock *MockGeolocationServiceInterface) MakeRoute(coordinates [][2]float64
 	return r0, r1
 }
 
-// MockGeolocationServiceInterface_MakeRoute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MakeRoute'
-type MockGeolocationServiceInterface_MakeRoute_Call struct {
+// MockRouter_MakeRoute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MakeRoute'
+type MockRouter_MakeRoute_Call struct {
 	*mock.Call
 }
 
 // MakeRoute is a helper method to define mock.On call
 //   - coordinates [][2]float64
-func (_e *MockGeolocationServiceInterface_Expecter) MakeRoute(coordinates interface{}) *MockGeolocationServiceInterface_MakeRoute_Call {
-	return &MockGeolocationServiceInterface_MakeRoute_Call{Call: _e.mock.On("MakeRoute", coordinates)}
+func (_e *MockRouter_Expecter) MakeRoute(coordinates interface{}) *MockRouter_MakeRoute_Call {
+	return &MockRouter_MakeRoute_Call{Call: _e.mock.On("MakeRoute", coordinates)}
 }
 
-func (_c *MockGeolocationServiceInterface_MakeRoute_Call) Run(run func(coordinates [][2]float64)) *MockGeolocationServiceInterface_MakeRoute_Call {
+func (_c *MockRouter_MakeRoute_Call) Run(run func(coordinates [][2]float64)) *MockRouter_MakeRoute_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 [][2]float64
 		if args[0] != nil {
@@ -207,23 +288,23 @@ func (_c *MockGeolocationServiceInterface_MakeRoute_Call) Run(run func(coordinat
 	return _c
 }
 
-func (_c *MockGeolocationServiceInterface_MakeRoute_Call) Return(f float64, err error) *MockGeolocationServiceInterface_MakeRoute_Call {
+func (_c *MockRouter_MakeRoute_Call) Return(f float64, err error) *MockRouter_MakeRoute_Call {
 	_c.Call.Return(f, err)
 	return _c
 }
 
-func (_c *MockGeolocationServiceInterface_MakeRoute_Call) RunAndReturn(run func(coordinates [][2]float64) (float64, error)) *MockGeolocationServiceInterface_MakeRoute_Call {
+func (_c *MockRouter_MakeRoute_Call) RunAndReturn(run func(coordinates [][2]float64) (float64, error)) *MockRouter_MakeRoute_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockOrderServiceInterface creates a new instance of MockOrderServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockBlobStore creates a new instance of MockBlobStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockOrderServiceInterface(t interface {
+func NewMockBlobStore(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockOrderServiceInterface {
-	mock := &MockOrderServiceInterface{}
+}) *MockBlobStore {
+	mock := &MockBlobStore{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -231,63 +312,63 @@ func NewMockOrderServiceInterface(t interface {
 	return mock
 }
 
-// MockOrderServiceInterface is an autogenerated mock type for the OrderServiceInterface type
-type MockOrderServiceInterface struct {
+// MockBlobStore is an autogenerated mock type for the BlobStore type
+type MockBlobStore struct {
 	mock.Mock
 }
 
-type MockOrderServiceInterface_Expecter struct {
+type MockBlobStore_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockOrderServiceInterface) EXPECT() *MockOrderServiceInterface_Expecter {
-	return &MockOrderServiceInterface_Expecter{mock: &_m.Mock}
+func (_m *MockBlobStore) EXPECT() *MockBlobStore_Expecter {
+	return &MockBlobStore_Expecter{mock: &_m.Mock}
 }
 
-// CreateOrder provides a mock function for the type MockOrderServiceInterface
-func (_mock *MockOrderServiceInterface) CreateOrder(req *models.CreateOrderRequest) (*models.Order, error) {
-	ret := _mock.Called(req)
+// Get provides a mock function for the type MockBlobStore
+func (_mock *MockBlobStore) Get(key string) ([]byte, error) {
+	ret := _mock.Called(key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOrder")
+		panic("no return value specified for Get")
 	}
 
-	var r0 *models.Order
+	var r0 []byte
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*models.CreateOrderRequest) (*models.Order, error)); ok {
-		return returnFunc(req)
+	if returnFunc, ok := ret.Get(0).(func(string) ([]byte, error)); ok {
+		return returnFunc(key)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*models.CreateOrderRequest) *models.Order); ok {
-		r0 = returnFunc(req)
+	if returnFunc, ok := ret.Get(0).(func(string) []byte); ok {
+		r0 = returnFunc(key)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Order)
+			r0 = ret.Get(0).([]byte)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*models.CreateOrderRequest) error); ok {
-		r1 = returnFunc(req)
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(key)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockOrderServiceInterface_CreateOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrder'
-type MockOrderServiceInterface_CreateOrder_Call struct {
+// MockBlobStore_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockBlobStore_Get_Call struct {
 	*mock.Call
 }
 
-// CreateOrder is a helper method to define mock.On call
-//   - req *models.CreateOrderRequest
-func (_e *MockOrderServiceInterface_Expecter) CreateOrder(req interface{}) *MockOrderServiceInterface_CreateOrder_Call {
-	return &MockOrderServiceInterface_CreateOrder_Call{Call: _e.mock.On("CreateOrder", req)}
+// Get is a helper method to define mock.On call
+//   - key string
+func (_e *MockBlobStore_Expecter) Get(key interface{}) *MockBlobStore_Get_Call {
+	return &MockBlobStore_Get_Call{Call: _e.mock.On("Get", key)}
 }
 
-func (_c *MockOrderServiceInterface_CreateOrder_Call) Run(run func(req *models.CreateOrderRequest)) *MockOrderServiceInterface_CreateOrder_Call {
+func (_c *MockBlobStore_Get_Call) Run(run func(key string)) *MockBlobStore_Get_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *models.CreateOrderRequest
+		var arg0 string
 		if args[0] != nil {
-			arg0 = args[0].(*models.CreateOrderRequest)
+			arg0 = args[0].(string)
 		}
 		run(
 			arg0,
@@ -296,196 +377,6309 @@ func (_c *MockOrderServiceInterface_CreateOrder_Call) Run(run func(req *models.C
 	return _c
 }
 
-func (_c *MockOrderServiceInterface_CreateOrder_Call) Return(order *models.Order, err error) *MockOrderServiceInterface_CreateOrder_Call {
-	_c.Call.Return(order, err)
+func (_c *MockBlobStore_Get_Call) Return(bytes []byte, err error) *MockBlobStore_Get_Call {
+	_c.Call.Return(bytes, err)
 	return _c
 }
 
-func (_c *MockOrderServiceInterface_CreateOrder_Call) RunAndReturn(run func(req *models.CreateOrderRequest) (*models.Order, error)) *MockOrderServiceInterface_CreateOrder_Call {
+func (_c *MockBlobStore_Get_Call) RunAndReturn(run func(key string) ([]byte, error)) *MockBlobStore_Get_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetOrder provides a mock function for the type MockOrderServiceInterface
-func (_mock *MockOrderServiceInterface) GetOrder(orderID uuid.UUID) (*models.Order, error) {
-	ret := _mock.Called(orderID)
+// Put provides a mock function for the type MockBlobStore
+func (_mock *MockBlobStore) Put(key string, contentType string, data []byte) error {
+	ret := _mock.Called(key, contentType, data)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOrder")
+		panic("no return value specified for Put")
 	}
 
-	var r0 *models.Order
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.Order, error)); ok {
-		return returnFunc(orderID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.Order); ok {
-		r0 = returnFunc(orderID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Order)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = returnFunc(orderID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, string, []byte) error); ok {
+		r0 = returnFunc(key, contentType, data)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockOrderServiceInterface_GetOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrder'
-type MockOrderServiceInterface_GetOrder_Call struct {
+// MockBlobStore_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type MockBlobStore_Put_Call struct {
 	*mock.Call
 }
 
-// GetOrder is a helper method to define mock.On call
-//   - orderID uuid.UUID
-func (_e *MockOrderServiceInterface_Expecter) GetOrder(orderID interface{}) *MockOrderServiceInterface_GetOrder_Call {
-	return &MockOrderServiceInterface_GetOrder_Call{Call: _e.mock.On("GetOrder", orderID)}
+// Put is a helper method to define mock.On call
+//   - key string
+//   - contentType string
+//   - data []byte
+func (_e *MockBlobStore_Expecter) Put(key interface{}, contentType interface{}, data interface{}) *MockBlobStore_Put_Call {
+	return &MockBlobStore_Put_Call{Call: _e.mock.On("Put", key, contentType, data)}
 }
 
-func (_c *MockOrderServiceInterface_GetOrder_Call) Run(run func(orderID uuid.UUID)) *MockOrderServiceInterface_GetOrder_Call {
+func (_c *MockBlobStore_Put_Call) Run(run func(key string, contentType string, data []byte)) *MockBlobStore_Put_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 uuid.UUID
+		var arg0 string
 		if args[0] != nil {
-			arg0 = args[0].(uuid.UUID)
+			arg0 = args[0].(string)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []byte
+		if args[2] != nil {
+			arg2 = args[2].([]byte)
 		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockOrderServiceInterface_GetOrder_Call) Return(order *models.Order, err error) *MockOrderServiceInterface_GetOrder_Call {
-	_c.Call.Return(order, err)
+func (_c *MockBlobStore_Put_Call) Return(err error) *MockBlobStore_Put_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockOrderServiceInterface_GetOrder_Call) RunAndReturn(run func(orderID uuid.UUID) (*models.Order, error)) *MockOrderServiceInterface_GetOrder_Call {
+func (_c *MockBlobStore_Put_Call) RunAndReturn(run func(key string, contentType string, data []byte) error) *MockBlobStore_Put_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetOrders provides a mock function for the type MockOrderServiceInterface
-func (_mock *MockOrderServiceInterface) GetOrders(status *models.OrderStatus, courierID *uuid.UUID, limit int, offset int) ([]*models.Order, error) {
-	ret := _mock.Called(status, courierID, limit, offset)
+// NewMockGeolocationServiceInterface creates a new instance of MockGeolocationServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGeolocationServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGeolocationServiceInterface {
+	mock := &MockGeolocationServiceInterface{}
+	mock.Mock.Test(t)
 
-	if len(ret) == 0 {
-		panic("no return value specified for GetOrders")
-	}
+	t.Cleanup(func() { mock.AssertExpectations(t) })
 
-	var r0 []*models.Order
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*models.OrderStatus, *uuid.UUID, int, int) ([]*models.Order, error)); ok {
-		return returnFunc(status, courierID, limit, offset)
-	}
-	if returnFunc, ok := ret.Get(0).(func(*models.OrderStatus, *uuid.UUID, int, int) []*models.Order); ok {
-		r0 = returnFunc(status, courierID, limit, offset)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Order)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(*models.OrderStatus, *uuid.UUID, int, int) error); ok {
-		r1 = returnFunc(status, courierID, limit, offset)
-	} else {
-		r1 = ret.Error(1)
-	}
-	return r0, r1
+	return mock
 }
 
-// MockOrderServiceInterface_GetOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrders'
-type MockOrderServiceInterface_GetOrders_Call struct {
+// MockGeolocationServiceInterface is an autogenerated mock type for the GeolocationServiceInterface type
+type MockGeolocationServiceInterface struct {
+	mock.Mock
+}
+
+type MockGeolocationServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockGeolocationServiceInterface) EXPECT() *MockGeolocationServiceInterface_Expecter {
+	return &MockGeolocationServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// CacheResults provides a mock function for the type MockGeolocationServiceInterface
+func (_mock *MockGeolocationServiceInterface) CacheResults(coordinates [][2]float64, distance float64, order *models.Order) {
+	_mock.Called(coordinates, distance, order)
+	return
+}
+
+// MockGeolocationServiceInterface_CacheResults_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CacheResults'
+type MockGeolocationServiceInterface_CacheResults_Call struct {
 	*mock.Call
 }
 
-// GetOrders is a helper method to define mock.On call
-//   - status *models.OrderStatus
-//   - courierID *uuid.UUID
-//   - limit int
-//   - offset int
-func (_e *MockOrderServiceInterface_Expecter) GetOrders(status interface{}, courierID interface{}, limit interface{}, offset interface{}) *MockOrderServiceInterface_GetOrders_Call {
-	return &MockOrderServiceInterface_GetOrders_Call{Call: _e.mock.On("GetOrders", status, courierID, limit, offset)}
+// CacheResults is a helper method to define mock.On call
+//   - coordinates [][2]float64
+//   - distance float64
+//   - order *models.Order
+func (_e *MockGeolocationServiceInterface_Expecter) CacheResults(coordinates interface{}, distance interface{}, order interface{}) *MockGeolocationServiceInterface_CacheResults_Call {
+	return &MockGeolocationServiceInterface_CacheResults_Call{Call: _e.mock.On("CacheResults", coordinates, distance, order)}
 }
 
-func (_c *MockOrderServiceInterface_GetOrders_Call) Run(run func(status *models.OrderStatus, courierID *uuid.UUID, limit int, offset int)) *MockOrderServiceInterface_GetOrders_Call {
+func (_c *MockGeolocationServiceInterface_CacheResults_Call) Run(run func(coordinates [][2]float64, distance float64, order *models.Order)) *MockGeolocationServiceInterface_CacheResults_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *models.OrderStatus
+		var arg0 [][2]float64
 		if args[0] != nil {
-			arg0 = args[0].(*models.OrderStatus)
+			arg0 = args[0].([][2]float64)
 		}
-		var arg1 *uuid.UUID
+		var arg1 float64
 		if args[1] != nil {
-			arg1 = args[1].(*uuid.UUID)
+			arg1 = args[1].(float64)
 		}
-		var arg2 int
+		var arg2 *models.Order
 		if args[2] != nil {
-			arg2 = args[2].(int)
+			arg2 = args[2].(*models.Order)
 		}
-		var arg3 int
-		if args[3] != nil {
-			arg3 = args[3].(int)
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGeolocationServiceInterface_CacheResults_Call) Return() *MockGeolocationServiceInterface_CacheResults_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockGeolocationServiceInterface_CacheResults_Call) RunAndReturn(run func(coordinates [][2]float64, distance float64, order *models.Order)) *MockGeolocationServiceInterface_CacheResults_Call {
+	_c.Run(run)
+	return _c
+}
+
+// GetCoordinates provides a mock function for the type MockGeolocationServiceInterface
+func (_mock *MockGeolocationServiceInterface) GetCoordinates(address string) (float64, float64, error) {
+	ret := _mock.Called(address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCoordinates")
+	}
+
+	var r0 float64
+	var r1 float64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(string) (float64, float64, error)); ok {
+		return returnFunc(address)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) float64); ok {
+		r0 = returnFunc(address)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) float64); ok {
+		r1 = returnFunc(address)
+	} else {
+		r1 = ret.Get(1).(float64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(string) error); ok {
+		r2 = returnFunc(address)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockGeolocationServiceInterface_GetCoordinates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCoordinates'
+type MockGeolocationServiceInterface_GetCoordinates_Call struct {
+	*mock.Call
+}
+
+// GetCoordinates is a helper method to define mock.On call
+//   - address string
+func (_e *MockGeolocationServiceInterface_Expecter) GetCoordinates(address interface{}) *MockGeolocationServiceInterface_GetCoordinates_Call {
+	return &MockGeolocationServiceInterface_GetCoordinates_Call{Call: _e.mock.On("GetCoordinates", address)}
+}
+
+func (_c *MockGeolocationServiceInterface_GetCoordinates_Call) Run(run func(address string)) *MockGeolocationServiceInterface_GetCoordinates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGeolocationServiceInterface_GetCoordinates_Call) Return(f float64, f1 float64, err error) *MockGeolocationServiceInterface_GetCoordinates_Call {
+	_c.Call.Return(f, f1, err)
+	return _c
+}
+
+func (_c *MockGeolocationServiceInterface_GetCoordinates_Call) RunAndReturn(run func(address string) (float64, float64, error)) *MockGeolocationServiceInterface_GetCoordinates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrderGeolocation provides a mock function for the type MockGeolocationServiceInterface
+func (_mock *MockGeolocationServiceInterface) GetOrderGeolocation(orderID uuid.UUID) (*models.GeoCache, error) {
+	ret := _mock.Called(orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrderGeolocation")
+	}
+
+	var r0 *models.GeoCache
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.GeoCache, error)); ok {
+		return returnFunc(orderID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.GeoCache); ok {
+		r0 = returnFunc(orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GeoCache)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGeolocationServiceInterface_GetOrderGeolocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrderGeolocation'
+type MockGeolocationServiceInterface_GetOrderGeolocation_Call struct {
+	*mock.Call
+}
+
+// GetOrderGeolocation is a helper method to define mock.On call
+//   - orderID uuid.UUID
+func (_e *MockGeolocationServiceInterface_Expecter) GetOrderGeolocation(orderID interface{}) *MockGeolocationServiceInterface_GetOrderGeolocation_Call {
+	return &MockGeolocationServiceInterface_GetOrderGeolocation_Call{Call: _e.mock.On("GetOrderGeolocation", orderID)}
+}
+
+func (_c *MockGeolocationServiceInterface_GetOrderGeolocation_Call) Run(run func(orderID uuid.UUID)) *MockGeolocationServiceInterface_GetOrderGeolocation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGeolocationServiceInterface_GetOrderGeolocation_Call) Return(geoCache *models.GeoCache, err error) *MockGeolocationServiceInterface_GetOrderGeolocation_Call {
+	_c.Call.Return(geoCache, err)
+	return _c
+}
+
+func (_c *MockGeolocationServiceInterface_GetOrderGeolocation_Call) RunAndReturn(run func(orderID uuid.UUID) (*models.GeoCache, error)) *MockGeolocationServiceInterface_GetOrderGeolocation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MakeRoute provides a mock function for the type MockGeolocationServiceInterface
+func (_mock *MockGeolocationServiceInterface) MakeRoute(coordinates [][2]float64) (float64, error) {
+	ret := _mock.Called(coordinates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MakeRoute")
+	}
+
+	var r0 float64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([][2]float64) (float64, error)); ok {
+		return returnFunc(coordinates)
+	}
+	if returnFunc, ok := ret.Get(0).(func([][2]float64) float64); ok {
+		r0 = returnFunc(coordinates)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+	if returnFunc, ok := ret.Get(1).(func([][2]float64) error); ok {
+		r1 = returnFunc(coordinates)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGeolocationServiceInterface_MakeRoute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MakeRoute'
+type MockGeolocationServiceInterface_MakeRoute_Call struct {
+	*mock.Call
+}
+
+// MakeRoute is a helper method to define mock.On call
+//   - coordinates [][2]float64
+func (_e *MockGeolocationServiceInterface_Expecter) MakeRoute(coordinates interface{}) *MockGeolocationServiceInterface_MakeRoute_Call {
+	return &MockGeolocationServiceInterface_MakeRoute_Call{Call: _e.mock.On("MakeRoute", coordinates)}
+}
+
+func (_c *MockGeolocationServiceInterface_MakeRoute_Call) Run(run func(coordinates [][2]float64)) *MockGeolocationServiceInterface_MakeRoute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 [][2]float64
+		if args[0] != nil {
+			arg0 = args[0].([][2]float64)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockGeolocationServiceInterface_MakeRoute_Call) Return(f float64, err error) *MockGeolocationServiceInterface_MakeRoute_Call {
+	_c.Call.Return(f, err)
+	return _c
+}
+
+func (_c *MockGeolocationServiceInterface_MakeRoute_Call) RunAndReturn(run func(coordinates [][2]float64) (float64, error)) *MockGeolocationServiceInterface_MakeRoute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOrderServiceInterface creates a new instance of MockOrderServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOrderServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOrderServiceInterface {
+	mock := &MockOrderServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOrderServiceInterface is an autogenerated mock type for the OrderServiceInterface type
+type MockOrderServiceInterface struct {
+	mock.Mock
+}
+
+type MockOrderServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOrderServiceInterface) EXPECT() *MockOrderServiceInterface_Expecter {
+	return &MockOrderServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// CancelOrder provides a mock function for the type MockOrderServiceInterface
+func (_mock *MockOrderServiceInterface) CancelOrder(orderID uuid.UUID, req *models.CancelOrderRequest, actor models.Actor) (*models.OrderCancellation, error) {
+	ret := _mock.Called(orderID, req, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelOrder")
+	}
+
+	var r0 *models.OrderCancellation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.CancelOrderRequest, models.Actor) (*models.OrderCancellation, error)); ok {
+		return returnFunc(orderID, req, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.CancelOrderRequest, models.Actor) *models.OrderCancellation); ok {
+		r0 = returnFunc(orderID, req, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderCancellation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, *models.CancelOrderRequest, models.Actor) error); ok {
+		r1 = returnFunc(orderID, req, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderServiceInterface_CancelOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelOrder'
+type MockOrderServiceInterface_CancelOrder_Call struct {
+	*mock.Call
+}
+
+// CancelOrder is a helper method to define mock.On call
+//   - orderID uuid.UUID
+//   - req *models.CancelOrderRequest
+//   - actor models.Actor
+func (_e *MockOrderServiceInterface_Expecter) CancelOrder(orderID interface{}, req interface{}, actor interface{}) *MockOrderServiceInterface_CancelOrder_Call {
+	return &MockOrderServiceInterface_CancelOrder_Call{Call: _e.mock.On("CancelOrder", orderID, req, actor)}
+}
+
+func (_c *MockOrderServiceInterface_CancelOrder_Call) Run(run func(orderID uuid.UUID, req *models.CancelOrderRequest, actor models.Actor)) *MockOrderServiceInterface_CancelOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *models.CancelOrderRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.CancelOrderRequest)
+		}
+		var arg2 models.Actor
+		if args[2] != nil {
+			arg2 = args[2].(models.Actor)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderServiceInterface_CancelOrder_Call) Return(orderCancellation *models.OrderCancellation, err error) *MockOrderServiceInterface_CancelOrder_Call {
+	_c.Call.Return(orderCancellation, err)
+	return _c
+}
+
+func (_c *MockOrderServiceInterface_CancelOrder_Call) RunAndReturn(run func(orderID uuid.UUID, req *models.CancelOrderRequest, actor models.Actor) (*models.OrderCancellation, error)) *MockOrderServiceInterface_CancelOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrder provides a mock function for the type MockOrderServiceInterface
+func (_mock *MockOrderServiceInterface) CreateOrder(req *models.CreateOrderRequest, actor models.Actor) (*models.Order, error) {
+	ret := _mock.Called(req, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrder")
+	}
+
+	var r0 *models.Order
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*models.CreateOrderRequest, models.Actor) (*models.Order, error)); ok {
+		return returnFunc(req, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.CreateOrderRequest, models.Actor) *models.Order); ok {
+		r0 = returnFunc(req, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Order)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.CreateOrderRequest, models.Actor) error); ok {
+		r1 = returnFunc(req, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderServiceInterface_CreateOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrder'
+type MockOrderServiceInterface_CreateOrder_Call struct {
+	*mock.Call
+}
+
+// CreateOrder is a helper method to define mock.On call
+//   - req *models.CreateOrderRequest
+//   - actor models.Actor
+func (_e *MockOrderServiceInterface_Expecter) CreateOrder(req interface{}, actor interface{}) *MockOrderServiceInterface_CreateOrder_Call {
+	return &MockOrderServiceInterface_CreateOrder_Call{Call: _e.mock.On("CreateOrder", req, actor)}
+}
+
+func (_c *MockOrderServiceInterface_CreateOrder_Call) Run(run func(req *models.CreateOrderRequest, actor models.Actor)) *MockOrderServiceInterface_CreateOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.CreateOrderRequest
+		if args[0] != nil {
+			arg0 = args[0].(*models.CreateOrderRequest)
+		}
+		var arg1 models.Actor
+		if args[1] != nil {
+			arg1 = args[1].(models.Actor)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderServiceInterface_CreateOrder_Call) Return(order *models.Order, err error) *MockOrderServiceInterface_CreateOrder_Call {
+	_c.Call.Return(order, err)
+	return _c
+}
+
+func (_c *MockOrderServiceInterface_CreateOrder_Call) RunAndReturn(run func(req *models.CreateOrderRequest, actor models.Actor) (*models.Order, error)) *MockOrderServiceInterface_CreateOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrder provides a mock function for the type MockOrderServiceInterface
+func (_mock *MockOrderServiceInterface) GetOrder(orderID uuid.UUID) (*models.Order, error) {
+	ret := _mock.Called(orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrder")
+	}
+
+	var r0 *models.Order
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.Order, error)); ok {
+		return returnFunc(orderID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.Order); ok {
+		r0 = returnFunc(orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Order)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderServiceInterface_GetOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrder'
+type MockOrderServiceInterface_GetOrder_Call struct {
+	*mock.Call
+}
+
+// GetOrder is a helper method to define mock.On call
+//   - orderID uuid.UUID
+func (_e *MockOrderServiceInterface_Expecter) GetOrder(orderID interface{}) *MockOrderServiceInterface_GetOrder_Call {
+	return &MockOrderServiceInterface_GetOrder_Call{Call: _e.mock.On("GetOrder", orderID)}
+}
+
+func (_c *MockOrderServiceInterface_GetOrder_Call) Run(run func(orderID uuid.UUID)) *MockOrderServiceInterface_GetOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderServiceInterface_GetOrder_Call) Return(order *models.Order, err error) *MockOrderServiceInterface_GetOrder_Call {
+	_c.Call.Return(order, err)
+	return _c
+}
+
+func (_c *MockOrderServiceInterface_GetOrder_Call) RunAndReturn(run func(orderID uuid.UUID) (*models.Order, error)) *MockOrderServiceInterface_GetOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrderCancellation provides a mock function for the type MockOrderServiceInterface
+func (_mock *MockOrderServiceInterface) GetOrderCancellation(orderID uuid.UUID) (*models.OrderCancellation, error) {
+	ret := _mock.Called(orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrderCancellation")
+	}
+
+	var r0 *models.OrderCancellation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.OrderCancellation, error)); ok {
+		return returnFunc(orderID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.OrderCancellation); ok {
+		r0 = returnFunc(orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderCancellation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderServiceInterface_GetOrderCancellation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrderCancellation'
+type MockOrderServiceInterface_GetOrderCancellation_Call struct {
+	*mock.Call
+}
+
+// GetOrderCancellation is a helper method to define mock.On call
+//   - orderID uuid.UUID
+func (_e *MockOrderServiceInterface_Expecter) GetOrderCancellation(orderID interface{}) *MockOrderServiceInterface_GetOrderCancellation_Call {
+	return &MockOrderServiceInterface_GetOrderCancellation_Call{Call: _e.mock.On("GetOrderCancellation", orderID)}
+}
+
+func (_c *MockOrderServiceInterface_GetOrderCancellation_Call) Run(run func(orderID uuid.UUID)) *MockOrderServiceInterface_GetOrderCancellation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderServiceInterface_GetOrderCancellation_Call) Return(orderCancellation *models.OrderCancellation, err error) *MockOrderServiceInterface_GetOrderCancellation_Call {
+	_c.Call.Return(orderCancellation, err)
+	return _c
+}
+
+func (_c *MockOrderServiceInterface_GetOrderCancellation_Call) RunAndReturn(run func(orderID uuid.UUID) (*models.OrderCancellation, error)) *MockOrderServiceInterface_GetOrderCancellation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOrders provides a mock function for the type MockOrderServiceInterface
+func (_mock *MockOrderServiceInterface) GetOrders(status *models.OrderStatus, courierID *uuid.UUID, limit int, offset int) ([]*models.Order, error) {
+	ret := _mock.Called(status, courierID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrders")
+	}
+
+	var r0 []*models.Order
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*models.OrderStatus, *uuid.UUID, int, int) ([]*models.Order, error)); ok {
+		return returnFunc(status, courierID, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.OrderStatus, *uuid.UUID, int, int) []*models.Order); ok {
+		r0 = returnFunc(status, courierID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Order)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.OrderStatus, *uuid.UUID, int, int) error); ok {
+		r1 = returnFunc(status, courierID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderServiceInterface_GetOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOrders'
+type MockOrderServiceInterface_GetOrders_Call struct {
+	*mock.Call
+}
+
+// GetOrders is a helper method to define mock.On call
+//   - status *models.OrderStatus
+//   - courierID *uuid.UUID
+//   - limit int
+//   - offset int
+func (_e *MockOrderServiceInterface_Expecter) GetOrders(status interface{}, courierID interface{}, limit interface{}, offset interface{}) *MockOrderServiceInterface_GetOrders_Call {
+	return &MockOrderServiceInterface_GetOrders_Call{Call: _e.mock.On("GetOrders", status, courierID, limit, offset)}
+}
+
+func (_c *MockOrderServiceInterface_GetOrders_Call) Run(run func(status *models.OrderStatus, courierID *uuid.UUID, limit int, offset int)) *MockOrderServiceInterface_GetOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.OrderStatus
+		if args[0] != nil {
+			arg0 = args[0].(*models.OrderStatus)
+		}
+		var arg1 *uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(*uuid.UUID)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderServiceInterface_GetOrders_Call) Return(orders []*models.Order, err error) *MockOrderServiceInterface_GetOrders_Call {
+	_c.Call.Return(orders, err)
+	return _c
+}
+
+func (_c *MockOrderServiceInterface_GetOrders_Call) RunAndReturn(run func(status *models.OrderStatus, courierID *uuid.UUID, limit int, offset int) ([]*models.Order, error)) *MockOrderServiceInterface_GetOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateOrderStatus provides a mock function for the type MockOrderServiceInterface
+func (_mock *MockOrderServiceInterface) UpdateOrderStatus(orderID uuid.UUID, req *models.UpdateOrderStatusRequest, actor models.Actor) (*models.OrderStatusChangedEvent, error) {
+	ret := _mock.Called(orderID, req, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrderStatus")
+	}
+
+	var r0 *models.OrderStatusChangedEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.UpdateOrderStatusRequest, models.Actor) (*models.OrderStatusChangedEvent, error)); ok {
+		return returnFunc(orderID, req, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.UpdateOrderStatusRequest, models.Actor) *models.OrderStatusChangedEvent); ok {
+		r0 = returnFunc(orderID, req, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderStatusChangedEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, *models.UpdateOrderStatusRequest, models.Actor) error); ok {
+		r1 = returnFunc(orderID, req, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderServiceInterface_UpdateOrderStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOrderStatus'
+type MockOrderServiceInterface_UpdateOrderStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateOrderStatus is a helper method to define mock.On call
+//   - orderID uuid.UUID
+//   - req *models.UpdateOrderStatusRequest
+//   - actor models.Actor
+func (_e *MockOrderServiceInterface_Expecter) UpdateOrderStatus(orderID interface{}, req interface{}, actor interface{}) *MockOrderServiceInterface_UpdateOrderStatus_Call {
+	return &MockOrderServiceInterface_UpdateOrderStatus_Call{Call: _e.mock.On("UpdateOrderStatus", orderID, req, actor)}
+}
+
+func (_c *MockOrderServiceInterface_UpdateOrderStatus_Call) Run(run func(orderID uuid.UUID, req *models.UpdateOrderStatusRequest, actor models.Actor)) *MockOrderServiceInterface_UpdateOrderStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *models.UpdateOrderStatusRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.UpdateOrderStatusRequest)
+		}
+		var arg2 models.Actor
+		if args[2] != nil {
+			arg2 = args[2].(models.Actor)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderServiceInterface_UpdateOrderStatus_Call) Return(orderStatusChangedEvent *models.OrderStatusChangedEvent, err error) *MockOrderServiceInterface_UpdateOrderStatus_Call {
+	_c.Call.Return(orderStatusChangedEvent, err)
+	return _c
+}
+
+func (_c *MockOrderServiceInterface_UpdateOrderStatus_Call) RunAndReturn(run func(orderID uuid.UUID, req *models.UpdateOrderStatusRequest, actor models.Actor) (*models.OrderStatusChangedEvent, error)) *MockOrderServiceInterface_UpdateOrderStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCustomerServiceInterface creates a new instance of MockCustomerServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCustomerServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCustomerServiceInterface {
+	mock := &MockCustomerServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCustomerServiceInterface is an autogenerated mock type for the CustomerServiceInterface type
+type MockCustomerServiceInterface struct {
+	mock.Mock
+}
+
+type MockCustomerServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCustomerServiceInterface) EXPECT() *MockCustomerServiceInterface_Expecter {
+	return &MockCustomerServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// AddAddress provides a mock function for the type MockCustomerServiceInterface
+func (_mock *MockCustomerServiceInterface) AddAddress(customerID uuid.UUID, req *models.CustomerAddressRequest) (*models.CustomerAddress, error) {
+	ret := _mock.Called(customerID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAddress")
+	}
+
+	var r0 *models.CustomerAddress
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.CustomerAddressRequest) (*models.CustomerAddress, error)); ok {
+		return returnFunc(customerID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.CustomerAddressRequest) *models.CustomerAddress); ok {
+		r0 = returnFunc(customerID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CustomerAddress)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, *models.CustomerAddressRequest) error); ok {
+		r1 = returnFunc(customerID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCustomerServiceInterface_AddAddress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAddress'
+type MockCustomerServiceInterface_AddAddress_Call struct {
+	*mock.Call
+}
+
+// AddAddress is a helper method to define mock.On call
+//   - customerID uuid.UUID
+//   - req *models.CustomerAddressRequest
+func (_e *MockCustomerServiceInterface_Expecter) AddAddress(customerID interface{}, req interface{}) *MockCustomerServiceInterface_AddAddress_Call {
+	return &MockCustomerServiceInterface_AddAddress_Call{Call: _e.mock.On("AddAddress", customerID, req)}
+}
+
+func (_c *MockCustomerServiceInterface_AddAddress_Call) Run(run func(customerID uuid.UUID, req *models.CustomerAddressRequest)) *MockCustomerServiceInterface_AddAddress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *models.CustomerAddressRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.CustomerAddressRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCustomerServiceInterface_AddAddress_Call) Return(customerAddress *models.CustomerAddress, err error) *MockCustomerServiceInterface_AddAddress_Call {
+	_c.Call.Return(customerAddress, err)
+	return _c
+}
+
+func (_c *MockCustomerServiceInterface_AddAddress_Call) RunAndReturn(run func(customerID uuid.UUID, req *models.CustomerAddressRequest) (*models.CustomerAddress, error)) *MockCustomerServiceInterface_AddAddress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCustomer provides a mock function for the type MockCustomerServiceInterface
+func (_mock *MockCustomerServiceInterface) CreateCustomer(req *models.CustomerRequest) (*models.Customer, error) {
+	ret := _mock.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCustomer")
+	}
+
+	var r0 *models.Customer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*models.CustomerRequest) (*models.Customer, error)); ok {
+		return returnFunc(req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.CustomerRequest) *models.Customer); ok {
+		r0 = returnFunc(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Customer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.CustomerRequest) error); ok {
+		r1 = returnFunc(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCustomerServiceInterface_CreateCustomer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCustomer'
+type MockCustomerServiceInterface_CreateCustomer_Call struct {
+	*mock.Call
+}
+
+// CreateCustomer is a helper method to define mock.On call
+//   - req *models.CustomerRequest
+func (_e *MockCustomerServiceInterface_Expecter) CreateCustomer(req interface{}) *MockCustomerServiceInterface_CreateCustomer_Call {
+	return &MockCustomerServiceInterface_CreateCustomer_Call{Call: _e.mock.On("CreateCustomer", req)}
+}
+
+func (_c *MockCustomerServiceInterface_CreateCustomer_Call) Run(run func(req *models.CustomerRequest)) *MockCustomerServiceInterface_CreateCustomer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.CustomerRequest
+		if args[0] != nil {
+			arg0 = args[0].(*models.CustomerRequest)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCustomerServiceInterface_CreateCustomer_Call) Return(customer *models.Customer, err error) *MockCustomerServiceInterface_CreateCustomer_Call {
+	_c.Call.Return(customer, err)
+	return _c
+}
+
+func (_c *MockCustomerServiceInterface_CreateCustomer_Call) RunAndReturn(run func(req *models.CustomerRequest) (*models.Customer, error)) *MockCustomerServiceInterface_CreateCustomer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAddress provides a mock function for the type MockCustomerServiceInterface
+func (_mock *MockCustomerServiceInterface) DeleteAddress(customerID uuid.UUID, addressID uuid.UUID) error {
+	ret := _mock.Called(customerID, addressID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAddress")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(customerID, addressID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCustomerServiceInterface_DeleteAddress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAddress'
+type MockCustomerServiceInterface_DeleteAddress_Call struct {
+	*mock.Call
+}
+
+// DeleteAddress is a helper method to define mock.On call
+//   - customerID uuid.UUID
+//   - addressID uuid.UUID
+func (_e *MockCustomerServiceInterface_Expecter) DeleteAddress(customerID interface{}, addressID interface{}) *MockCustomerServiceInterface_DeleteAddress_Call {
+	return &MockCustomerServiceInterface_DeleteAddress_Call{Call: _e.mock.On("DeleteAddress", customerID, addressID)}
+}
+
+func (_c *MockCustomerServiceInterface_DeleteAddress_Call) Run(run func(customerID uuid.UUID, addressID uuid.UUID)) *MockCustomerServiceInterface_DeleteAddress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCustomerServiceInterface_DeleteAddress_Call) Return(err error) *MockCustomerServiceInterface_DeleteAddress_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCustomerServiceInterface_DeleteAddress_Call) RunAndReturn(run func(customerID uuid.UUID, addressID uuid.UUID) error) *MockCustomerServiceInterface_DeleteAddress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCustomer provides a mock function for the type MockCustomerServiceInterface
+func (_mock *MockCustomerServiceInterface) GetCustomer(customerID uuid.UUID) (*models.Customer, error) {
+	ret := _mock.Called(customerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomer")
+	}
+
+	var r0 *models.Customer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.Customer, error)); ok {
+		return returnFunc(customerID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.Customer); ok {
+		r0 = returnFunc(customerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Customer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(customerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCustomerServiceInterface_GetCustomer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCustomer'
+type MockCustomerServiceInterface_GetCustomer_Call struct {
+	*mock.Call
+}
+
+// GetCustomer is a helper method to define mock.On call
+//   - customerID uuid.UUID
+func (_e *MockCustomerServiceInterface_Expecter) GetCustomer(customerID interface{}) *MockCustomerServiceInterface_GetCustomer_Call {
+	return &MockCustomerServiceInterface_GetCustomer_Call{Call: _e.mock.On("GetCustomer", customerID)}
+}
+
+func (_c *MockCustomerServiceInterface_GetCustomer_Call) Run(run func(customerID uuid.UUID)) *MockCustomerServiceInterface_GetCustomer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCustomerServiceInterface_GetCustomer_Call) Return(customer *models.Customer, err error) *MockCustomerServiceInterface_GetCustomer_Call {
+	_c.Call.Return(customer, err)
+	return _c
+}
+
+func (_c *MockCustomerServiceInterface_GetCustomer_Call) RunAndReturn(run func(customerID uuid.UUID) (*models.Customer, error)) *MockCustomerServiceInterface_GetCustomer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCustomerOrders provides a mock function for the type MockCustomerServiceInterface
+func (_mock *MockCustomerServiceInterface) GetCustomerOrders(customerID uuid.UUID, limit int, offset int) ([]*models.Order, error) {
+	ret := _mock.Called(customerID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomerOrders")
+	}
+
+	var r0 []*models.Order
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, int, int) ([]*models.Order, error)); ok {
+		return returnFunc(customerID, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, int, int) []*models.Order); ok {
+		r0 = returnFunc(customerID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Order)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, int, int) error); ok {
+		r1 = returnFunc(customerID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCustomerServiceInterface_GetCustomerOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCustomerOrders'
+type MockCustomerServiceInterface_GetCustomerOrders_Call struct {
+	*mock.Call
+}
+
+// GetCustomerOrders is a helper method to define mock.On call
+//   - customerID uuid.UUID
+//   - limit int
+//   - offset int
+func (_e *MockCustomerServiceInterface_Expecter) GetCustomerOrders(customerID interface{}, limit interface{}, offset interface{}) *MockCustomerServiceInterface_GetCustomerOrders_Call {
+	return &MockCustomerServiceInterface_GetCustomerOrders_Call{Call: _e.mock.On("GetCustomerOrders", customerID, limit, offset)}
+}
+
+func (_c *MockCustomerServiceInterface_GetCustomerOrders_Call) Run(run func(customerID uuid.UUID, limit int, offset int)) *MockCustomerServiceInterface_GetCustomerOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCustomerServiceInterface_GetCustomerOrders_Call) Return(orders []*models.Order, err error) *MockCustomerServiceInterface_GetCustomerOrders_Call {
+	_c.Call.Return(orders, err)
+	return _c
+}
+
+func (_c *MockCustomerServiceInterface_GetCustomerOrders_Call) RunAndReturn(run func(customerID uuid.UUID, limit int, offset int) ([]*models.Order, error)) *MockCustomerServiceInterface_GetCustomerOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCustomers provides a mock function for the type MockCustomerServiceInterface
+func (_mock *MockCustomerServiceInterface) GetCustomers(phone string, limit int, offset int) ([]*models.Customer, error) {
+	ret := _mock.Called(phone, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomers")
+	}
+
+	var r0 []*models.Customer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, int, int) ([]*models.Customer, error)); ok {
+		return returnFunc(phone, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, int, int) []*models.Customer); ok {
+		r0 = returnFunc(phone, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Customer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = returnFunc(phone, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCustomerServiceInterface_GetCustomers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCustomers'
+type MockCustomerServiceInterface_GetCustomers_Call struct {
+	*mock.Call
+}
+
+// GetCustomers is a helper method to define mock.On call
+//   - phone string
+//   - limit int
+//   - offset int
+func (_e *MockCustomerServiceInterface_Expecter) GetCustomers(phone interface{}, limit interface{}, offset interface{}) *MockCustomerServiceInterface_GetCustomers_Call {
+	return &MockCustomerServiceInterface_GetCustomers_Call{Call: _e.mock.On("GetCustomers", phone, limit, offset)}
+}
+
+func (_c *MockCustomerServiceInterface_GetCustomers_Call) Run(run func(phone string, limit int, offset int)) *MockCustomerServiceInterface_GetCustomers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCustomerServiceInterface_GetCustomers_Call) Return(customers []*models.Customer, err error) *MockCustomerServiceInterface_GetCustomers_Call {
+	_c.Call.Return(customers, err)
+	return _c
+}
+
+func (_c *MockCustomerServiceInterface_GetCustomers_Call) RunAndReturn(run func(phone string, limit int, offset int) ([]*models.Customer, error)) *MockCustomerServiceInterface_GetCustomers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCustomer provides a mock function for the type MockCustomerServiceInterface
+func (_mock *MockCustomerServiceInterface) UpdateCustomer(customerID uuid.UUID, req *models.CustomerRequest) (*models.Customer, error) {
+	ret := _mock.Called(customerID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCustomer")
+	}
+
+	var r0 *models.Customer
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.CustomerRequest) (*models.Customer, error)); ok {
+		return returnFunc(customerID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.CustomerRequest) *models.Customer); ok {
+		r0 = returnFunc(customerID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Customer)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, *models.CustomerRequest) error); ok {
+		r1 = returnFunc(customerID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCustomerServiceInterface_UpdateCustomer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCustomer'
+type MockCustomerServiceInterface_UpdateCustomer_Call struct {
+	*mock.Call
+}
+
+// UpdateCustomer is a helper method to define mock.On call
+//   - customerID uuid.UUID
+//   - req *models.CustomerRequest
+func (_e *MockCustomerServiceInterface_Expecter) UpdateCustomer(customerID interface{}, req interface{}) *MockCustomerServiceInterface_UpdateCustomer_Call {
+	return &MockCustomerServiceInterface_UpdateCustomer_Call{Call: _e.mock.On("UpdateCustomer", customerID, req)}
+}
+
+func (_c *MockCustomerServiceInterface_UpdateCustomer_Call) Run(run func(customerID uuid.UUID, req *models.CustomerRequest)) *MockCustomerServiceInterface_UpdateCustomer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *models.CustomerRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.CustomerRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCustomerServiceInterface_UpdateCustomer_Call) Return(customer *models.Customer, err error) *MockCustomerServiceInterface_UpdateCustomer_Call {
+	_c.Call.Return(customer, err)
+	return _c
+}
+
+func (_c *MockCustomerServiceInterface_UpdateCustomer_Call) RunAndReturn(run func(customerID uuid.UUID, req *models.CustomerRequest) (*models.Customer, error)) *MockCustomerServiceInterface_UpdateCustomer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockMerchantServiceInterface creates a new instance of MockMerchantServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockMerchantServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockMerchantServiceInterface {
+	mock := &MockMerchantServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockMerchantServiceInterface is an autogenerated mock type for the MerchantServiceInterface type
+type MockMerchantServiceInterface struct {
+	mock.Mock
+}
+
+type MockMerchantServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockMerchantServiceInterface) EXPECT() *MockMerchantServiceInterface_Expecter {
+	return &MockMerchantServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// CreateMerchant provides a mock function for the type MockMerchantServiceInterface
+func (_mock *MockMerchantServiceInterface) CreateMerchant(req *models.MerchantRequest) (*models.Merchant, error) {
+	ret := _mock.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateMerchant")
+	}
+
+	var r0 *models.Merchant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*models.MerchantRequest) (*models.Merchant, error)); ok {
+		return returnFunc(req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.MerchantRequest) *models.Merchant); ok {
+		r0 = returnFunc(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Merchant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.MerchantRequest) error); ok {
+		r1 = returnFunc(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMerchantServiceInterface_CreateMerchant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateMerchant'
+type MockMerchantServiceInterface_CreateMerchant_Call struct {
+	*mock.Call
+}
+
+// CreateMerchant is a helper method to define mock.On call
+//   - req *models.MerchantRequest
+func (_e *MockMerchantServiceInterface_Expecter) CreateMerchant(req interface{}) *MockMerchantServiceInterface_CreateMerchant_Call {
+	return &MockMerchantServiceInterface_CreateMerchant_Call{Call: _e.mock.On("CreateMerchant", req)}
+}
+
+func (_c *MockMerchantServiceInterface_CreateMerchant_Call) Run(run func(req *models.MerchantRequest)) *MockMerchantServiceInterface_CreateMerchant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.MerchantRequest
+		if args[0] != nil {
+			arg0 = args[0].(*models.MerchantRequest)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_CreateMerchant_Call) Return(merchant *models.Merchant, err error) *MockMerchantServiceInterface_CreateMerchant_Call {
+	_c.Call.Return(merchant, err)
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_CreateMerchant_Call) RunAndReturn(run func(req *models.MerchantRequest) (*models.Merchant, error)) *MockMerchantServiceInterface_CreateMerchant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateProduct provides a mock function for the type MockMerchantServiceInterface
+func (_mock *MockMerchantServiceInterface) CreateProduct(merchantID uuid.UUID, req *models.ProductRequest) (*models.Product, error) {
+	ret := _mock.Called(merchantID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateProduct")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.ProductRequest) (*models.Product, error)); ok {
+		return returnFunc(merchantID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.ProductRequest) *models.Product); ok {
+		r0 = returnFunc(merchantID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, *models.ProductRequest) error); ok {
+		r1 = returnFunc(merchantID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMerchantServiceInterface_CreateProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateProduct'
+type MockMerchantServiceInterface_CreateProduct_Call struct {
+	*mock.Call
+}
+
+// CreateProduct is a helper method to define mock.On call
+//   - merchantID uuid.UUID
+//   - req *models.ProductRequest
+func (_e *MockMerchantServiceInterface_Expecter) CreateProduct(merchantID interface{}, req interface{}) *MockMerchantServiceInterface_CreateProduct_Call {
+	return &MockMerchantServiceInterface_CreateProduct_Call{Call: _e.mock.On("CreateProduct", merchantID, req)}
+}
+
+func (_c *MockMerchantServiceInterface_CreateProduct_Call) Run(run func(merchantID uuid.UUID, req *models.ProductRequest)) *MockMerchantServiceInterface_CreateProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *models.ProductRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.ProductRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_CreateProduct_Call) Return(product *models.Product, err error) *MockMerchantServiceInterface_CreateProduct_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_CreateProduct_Call) RunAndReturn(run func(merchantID uuid.UUID, req *models.ProductRequest) (*models.Product, error)) *MockMerchantServiceInterface_CreateProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMerchant provides a mock function for the type MockMerchantServiceInterface
+func (_mock *MockMerchantServiceInterface) DeleteMerchant(merchantID uuid.UUID) error {
+	ret := _mock.Called(merchantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMerchant")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = returnFunc(merchantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMerchantServiceInterface_DeleteMerchant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMerchant'
+type MockMerchantServiceInterface_DeleteMerchant_Call struct {
+	*mock.Call
+}
+
+// DeleteMerchant is a helper method to define mock.On call
+//   - merchantID uuid.UUID
+func (_e *MockMerchantServiceInterface_Expecter) DeleteMerchant(merchantID interface{}) *MockMerchantServiceInterface_DeleteMerchant_Call {
+	return &MockMerchantServiceInterface_DeleteMerchant_Call{Call: _e.mock.On("DeleteMerchant", merchantID)}
+}
+
+func (_c *MockMerchantServiceInterface_DeleteMerchant_Call) Run(run func(merchantID uuid.UUID)) *MockMerchantServiceInterface_DeleteMerchant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_DeleteMerchant_Call) Return(err error) *MockMerchantServiceInterface_DeleteMerchant_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_DeleteMerchant_Call) RunAndReturn(run func(merchantID uuid.UUID) error) *MockMerchantServiceInterface_DeleteMerchant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteProduct provides a mock function for the type MockMerchantServiceInterface
+func (_mock *MockMerchantServiceInterface) DeleteProduct(merchantID uuid.UUID, productID uuid.UUID) error {
+	ret := _mock.Called(merchantID, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProduct")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(merchantID, productID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMerchantServiceInterface_DeleteProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProduct'
+type MockMerchantServiceInterface_DeleteProduct_Call struct {
+	*mock.Call
+}
+
+// DeleteProduct is a helper method to define mock.On call
+//   - merchantID uuid.UUID
+//   - productID uuid.UUID
+func (_e *MockMerchantServiceInterface_Expecter) DeleteProduct(merchantID interface{}, productID interface{}) *MockMerchantServiceInterface_DeleteProduct_Call {
+	return &MockMerchantServiceInterface_DeleteProduct_Call{Call: _e.mock.On("DeleteProduct", merchantID, productID)}
+}
+
+func (_c *MockMerchantServiceInterface_DeleteProduct_Call) Run(run func(merchantID uuid.UUID, productID uuid.UUID)) *MockMerchantServiceInterface_DeleteProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_DeleteProduct_Call) Return(err error) *MockMerchantServiceInterface_DeleteProduct_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_DeleteProduct_Call) RunAndReturn(run func(merchantID uuid.UUID, productID uuid.UUID) error) *MockMerchantServiceInterface_DeleteProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMerchant provides a mock function for the type MockMerchantServiceInterface
+func (_mock *MockMerchantServiceInterface) GetMerchant(merchantID uuid.UUID) (*models.Merchant, error) {
+	ret := _mock.Called(merchantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMerchant")
+	}
+
+	var r0 *models.Merchant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.Merchant, error)); ok {
+		return returnFunc(merchantID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.Merchant); ok {
+		r0 = returnFunc(merchantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Merchant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(merchantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMerchantServiceInterface_GetMerchant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMerchant'
+type MockMerchantServiceInterface_GetMerchant_Call struct {
+	*mock.Call
+}
+
+// GetMerchant is a helper method to define mock.On call
+//   - merchantID uuid.UUID
+func (_e *MockMerchantServiceInterface_Expecter) GetMerchant(merchantID interface{}) *MockMerchantServiceInterface_GetMerchant_Call {
+	return &MockMerchantServiceInterface_GetMerchant_Call{Call: _e.mock.On("GetMerchant", merchantID)}
+}
+
+func (_c *MockMerchantServiceInterface_GetMerchant_Call) Run(run func(merchantID uuid.UUID)) *MockMerchantServiceInterface_GetMerchant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_GetMerchant_Call) Return(merchant *models.Merchant, err error) *MockMerchantServiceInterface_GetMerchant_Call {
+	_c.Call.Return(merchant, err)
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_GetMerchant_Call) RunAndReturn(run func(merchantID uuid.UUID) (*models.Merchant, error)) *MockMerchantServiceInterface_GetMerchant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMerchants provides a mock function for the type MockMerchantServiceInterface
+func (_mock *MockMerchantServiceInterface) GetMerchants(activeOnly bool) ([]*models.Merchant, error) {
+	ret := _mock.Called(activeOnly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMerchants")
+	}
+
+	var r0 []*models.Merchant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(bool) ([]*models.Merchant, error)); ok {
+		return returnFunc(activeOnly)
+	}
+	if returnFunc, ok := ret.Get(0).(func(bool) []*models.Merchant); ok {
+		r0 = returnFunc(activeOnly)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Merchant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(bool) error); ok {
+		r1 = returnFunc(activeOnly)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMerchantServiceInterface_GetMerchants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMerchants'
+type MockMerchantServiceInterface_GetMerchants_Call struct {
+	*mock.Call
+}
+
+// GetMerchants is a helper method to define mock.On call
+//   - activeOnly bool
+func (_e *MockMerchantServiceInterface_Expecter) GetMerchants(activeOnly interface{}) *MockMerchantServiceInterface_GetMerchants_Call {
+	return &MockMerchantServiceInterface_GetMerchants_Call{Call: _e.mock.On("GetMerchants", activeOnly)}
+}
+
+func (_c *MockMerchantServiceInterface_GetMerchants_Call) Run(run func(activeOnly bool)) *MockMerchantServiceInterface_GetMerchants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 bool
+		if args[0] != nil {
+			arg0 = args[0].(bool)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_GetMerchants_Call) Return(merchants []*models.Merchant, err error) *MockMerchantServiceInterface_GetMerchants_Call {
+	_c.Call.Return(merchants, err)
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_GetMerchants_Call) RunAndReturn(run func(activeOnly bool) ([]*models.Merchant, error)) *MockMerchantServiceInterface_GetMerchants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProducts provides a mock function for the type MockMerchantServiceInterface
+func (_mock *MockMerchantServiceInterface) GetProducts(merchantID uuid.UUID, availableOnly bool) ([]*models.Product, error) {
+	ret := _mock.Called(merchantID, availableOnly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProducts")
+	}
+
+	var r0 []*models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, bool) ([]*models.Product, error)); ok {
+		return returnFunc(merchantID, availableOnly)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, bool) []*models.Product); ok {
+		r0 = returnFunc(merchantID, availableOnly)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, bool) error); ok {
+		r1 = returnFunc(merchantID, availableOnly)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMerchantServiceInterface_GetProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProducts'
+type MockMerchantServiceInterface_GetProducts_Call struct {
+	*mock.Call
+}
+
+// GetProducts is a helper method to define mock.On call
+//   - merchantID uuid.UUID
+//   - availableOnly bool
+func (_e *MockMerchantServiceInterface_Expecter) GetProducts(merchantID interface{}, availableOnly interface{}) *MockMerchantServiceInterface_GetProducts_Call {
+	return &MockMerchantServiceInterface_GetProducts_Call{Call: _e.mock.On("GetProducts", merchantID, availableOnly)}
+}
+
+func (_c *MockMerchantServiceInterface_GetProducts_Call) Run(run func(merchantID uuid.UUID, availableOnly bool)) *MockMerchantServiceInterface_GetProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_GetProducts_Call) Return(products []*models.Product, err error) *MockMerchantServiceInterface_GetProducts_Call {
+	_c.Call.Return(products, err)
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_GetProducts_Call) RunAndReturn(run func(merchantID uuid.UUID, availableOnly bool) ([]*models.Product, error)) *MockMerchantServiceInterface_GetProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMerchant provides a mock function for the type MockMerchantServiceInterface
+func (_mock *MockMerchantServiceInterface) UpdateMerchant(merchantID uuid.UUID, req *models.MerchantRequest) (*models.Merchant, error) {
+	ret := _mock.Called(merchantID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMerchant")
+	}
+
+	var r0 *models.Merchant
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.MerchantRequest) (*models.Merchant, error)); ok {
+		return returnFunc(merchantID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.MerchantRequest) *models.Merchant); ok {
+		r0 = returnFunc(merchantID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Merchant)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, *models.MerchantRequest) error); ok {
+		r1 = returnFunc(merchantID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMerchantServiceInterface_UpdateMerchant_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMerchant'
+type MockMerchantServiceInterface_UpdateMerchant_Call struct {
+	*mock.Call
+}
+
+// UpdateMerchant is a helper method to define mock.On call
+//   - merchantID uuid.UUID
+//   - req *models.MerchantRequest
+func (_e *MockMerchantServiceInterface_Expecter) UpdateMerchant(merchantID interface{}, req interface{}) *MockMerchantServiceInterface_UpdateMerchant_Call {
+	return &MockMerchantServiceInterface_UpdateMerchant_Call{Call: _e.mock.On("UpdateMerchant", merchantID, req)}
+}
+
+func (_c *MockMerchantServiceInterface_UpdateMerchant_Call) Run(run func(merchantID uuid.UUID, req *models.MerchantRequest)) *MockMerchantServiceInterface_UpdateMerchant_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *models.MerchantRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.MerchantRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_UpdateMerchant_Call) Return(merchant *models.Merchant, err error) *MockMerchantServiceInterface_UpdateMerchant_Call {
+	_c.Call.Return(merchant, err)
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_UpdateMerchant_Call) RunAndReturn(run func(merchantID uuid.UUID, req *models.MerchantRequest) (*models.Merchant, error)) *MockMerchantServiceInterface_UpdateMerchant_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProduct provides a mock function for the type MockMerchantServiceInterface
+func (_mock *MockMerchantServiceInterface) UpdateProduct(merchantID uuid.UUID, productID uuid.UUID, req *models.ProductRequest) (*models.Product, error) {
+	ret := _mock.Called(merchantID, productID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProduct")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, *models.ProductRequest) (*models.Product, error)); ok {
+		return returnFunc(merchantID, productID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, *models.ProductRequest) *models.Product); ok {
+		r0 = returnFunc(merchantID, productID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID, *models.ProductRequest) error); ok {
+		r1 = returnFunc(merchantID, productID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMerchantServiceInterface_UpdateProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProduct'
+type MockMerchantServiceInterface_UpdateProduct_Call struct {
+	*mock.Call
+}
+
+// UpdateProduct is a helper method to define mock.On call
+//   - merchantID uuid.UUID
+//   - productID uuid.UUID
+//   - req *models.ProductRequest
+func (_e *MockMerchantServiceInterface_Expecter) UpdateProduct(merchantID interface{}, productID interface{}, req interface{}) *MockMerchantServiceInterface_UpdateProduct_Call {
+	return &MockMerchantServiceInterface_UpdateProduct_Call{Call: _e.mock.On("UpdateProduct", merchantID, productID, req)}
+}
+
+func (_c *MockMerchantServiceInterface_UpdateProduct_Call) Run(run func(merchantID uuid.UUID, productID uuid.UUID, req *models.ProductRequest)) *MockMerchantServiceInterface_UpdateProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 *models.ProductRequest
+		if args[2] != nil {
+			arg2 = args[2].(*models.ProductRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_UpdateProduct_Call) Return(product *models.Product, err error) *MockMerchantServiceInterface_UpdateProduct_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *MockMerchantServiceInterface_UpdateProduct_Call) RunAndReturn(run func(merchantID uuid.UUID, productID uuid.UUID, req *models.ProductRequest) (*models.Product, error)) *MockMerchantServiceInterface_UpdateProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOrderPreparationServiceInterface creates a new instance of MockOrderPreparationServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOrderPreparationServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOrderPreparationServiceInterface {
+	mock := &MockOrderPreparationServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOrderPreparationServiceInterface is an autogenerated mock type for the OrderPreparationServiceInterface type
+type MockOrderPreparationServiceInterface struct {
+	mock.Mock
+}
+
+type MockOrderPreparationServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOrderPreparationServiceInterface) EXPECT() *MockOrderPreparationServiceInterface_Expecter {
+	return &MockOrderPreparationServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// AcceptOrder provides a mock function for the type MockOrderPreparationServiceInterface
+func (_mock *MockOrderPreparationServiceInterface) AcceptOrder(orderID uuid.UUID, prepMinutes int, actor models.Actor) (*models.OrderPreparationEvent, error) {
+	ret := _mock.Called(orderID, prepMinutes, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptOrder")
+	}
+
+	var r0 *models.OrderPreparationEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, int, models.Actor) (*models.OrderPreparationEvent, error)); ok {
+		return returnFunc(orderID, prepMinutes, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, int, models.Actor) *models.OrderPreparationEvent); ok {
+		r0 = returnFunc(orderID, prepMinutes, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderPreparationEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, int, models.Actor) error); ok {
+		r1 = returnFunc(orderID, prepMinutes, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderPreparationServiceInterface_AcceptOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptOrder'
+type MockOrderPreparationServiceInterface_AcceptOrder_Call struct {
+	*mock.Call
+}
+
+// AcceptOrder is a helper method to define mock.On call
+//   - orderID uuid.UUID
+//   - prepMinutes int
+//   - actor models.Actor
+func (_e *MockOrderPreparationServiceInterface_Expecter) AcceptOrder(orderID interface{}, prepMinutes interface{}, actor interface{}) *MockOrderPreparationServiceInterface_AcceptOrder_Call {
+	return &MockOrderPreparationServiceInterface_AcceptOrder_Call{Call: _e.mock.On("AcceptOrder", orderID, prepMinutes, actor)}
+}
+
+func (_c *MockOrderPreparationServiceInterface_AcceptOrder_Call) Run(run func(orderID uuid.UUID, prepMinutes int, actor models.Actor)) *MockOrderPreparationServiceInterface_AcceptOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 models.Actor
+		if args[2] != nil {
+			arg2 = args[2].(models.Actor)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderPreparationServiceInterface_AcceptOrder_Call) Return(orderPreparationEvent *models.OrderPreparationEvent, err error) *MockOrderPreparationServiceInterface_AcceptOrder_Call {
+	_c.Call.Return(orderPreparationEvent, err)
+	return _c
+}
+
+func (_c *MockOrderPreparationServiceInterface_AcceptOrder_Call) RunAndReturn(run func(orderID uuid.UUID, prepMinutes int, actor models.Actor) (*models.OrderPreparationEvent, error)) *MockOrderPreparationServiceInterface_AcceptOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkReady provides a mock function for the type MockOrderPreparationServiceInterface
+func (_mock *MockOrderPreparationServiceInterface) MarkReady(orderID uuid.UUID, actor models.Actor) (*models.OrderPreparationEvent, error) {
+	ret := _mock.Called(orderID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkReady")
+	}
+
+	var r0 *models.OrderPreparationEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, models.Actor) (*models.OrderPreparationEvent, error)); ok {
+		return returnFunc(orderID, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, models.Actor) *models.OrderPreparationEvent); ok {
+		r0 = returnFunc(orderID, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderPreparationEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, models.Actor) error); ok {
+		r1 = returnFunc(orderID, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderPreparationServiceInterface_MarkReady_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkReady'
+type MockOrderPreparationServiceInterface_MarkReady_Call struct {
+	*mock.Call
+}
+
+// MarkReady is a helper method to define mock.On call
+//   - orderID uuid.UUID
+//   - actor models.Actor
+func (_e *MockOrderPreparationServiceInterface_Expecter) MarkReady(orderID interface{}, actor interface{}) *MockOrderPreparationServiceInterface_MarkReady_Call {
+	return &MockOrderPreparationServiceInterface_MarkReady_Call{Call: _e.mock.On("MarkReady", orderID, actor)}
+}
+
+func (_c *MockOrderPreparationServiceInterface_MarkReady_Call) Run(run func(orderID uuid.UUID, actor models.Actor)) *MockOrderPreparationServiceInterface_MarkReady_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 models.Actor
+		if args[1] != nil {
+			arg1 = args[1].(models.Actor)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderPreparationServiceInterface_MarkReady_Call) Return(orderPreparationEvent *models.OrderPreparationEvent, err error) *MockOrderPreparationServiceInterface_MarkReady_Call {
+	_c.Call.Return(orderPreparationEvent, err)
+	return _c
+}
+
+func (_c *MockOrderPreparationServiceInterface_MarkReady_Call) RunAndReturn(run func(orderID uuid.UUID, actor models.Actor) (*models.OrderPreparationEvent, error)) *MockOrderPreparationServiceInterface_MarkReady_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RejectOrder provides a mock function for the type MockOrderPreparationServiceInterface
+func (_mock *MockOrderPreparationServiceInterface) RejectOrder(orderID uuid.UUID, req *models.CancelOrderRequest, actor models.Actor) (*models.OrderCancellation, error) {
+	ret := _mock.Called(orderID, req, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectOrder")
+	}
+
+	var r0 *models.OrderCancellation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.CancelOrderRequest, models.Actor) (*models.OrderCancellation, error)); ok {
+		return returnFunc(orderID, req, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.CancelOrderRequest, models.Actor) *models.OrderCancellation); ok {
+		r0 = returnFunc(orderID, req, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderCancellation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, *models.CancelOrderRequest, models.Actor) error); ok {
+		r1 = returnFunc(orderID, req, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderPreparationServiceInterface_RejectOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RejectOrder'
+type MockOrderPreparationServiceInterface_RejectOrder_Call struct {
+	*mock.Call
+}
+
+// RejectOrder is a helper method to define mock.On call
+//   - orderID uuid.UUID
+//   - req *models.CancelOrderRequest
+//   - actor models.Actor
+func (_e *MockOrderPreparationServiceInterface_Expecter) RejectOrder(orderID interface{}, req interface{}, actor interface{}) *MockOrderPreparationServiceInterface_RejectOrder_Call {
+	return &MockOrderPreparationServiceInterface_RejectOrder_Call{Call: _e.mock.On("RejectOrder", orderID, req, actor)}
+}
+
+func (_c *MockOrderPreparationServiceInterface_RejectOrder_Call) Run(run func(orderID uuid.UUID, req *models.CancelOrderRequest, actor models.Actor)) *MockOrderPreparationServiceInterface_RejectOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *models.CancelOrderRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.CancelOrderRequest)
+		}
+		var arg2 models.Actor
+		if args[2] != nil {
+			arg2 = args[2].(models.Actor)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderPreparationServiceInterface_RejectOrder_Call) Return(orderCancellation *models.OrderCancellation, err error) *MockOrderPreparationServiceInterface_RejectOrder_Call {
+	_c.Call.Return(orderCancellation, err)
+	return _c
+}
+
+func (_c *MockOrderPreparationServiceInterface_RejectOrder_Call) RunAndReturn(run func(orderID uuid.UUID, req *models.CancelOrderRequest, actor models.Actor) (*models.OrderCancellation, error)) *MockOrderPreparationServiceInterface_RejectOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartPreparing provides a mock function for the type MockOrderPreparationServiceInterface
+func (_mock *MockOrderPreparationServiceInterface) StartPreparing(orderID uuid.UUID, actor models.Actor) (*models.OrderPreparationEvent, error) {
+	ret := _mock.Called(orderID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartPreparing")
+	}
+
+	var r0 *models.OrderPreparationEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, models.Actor) (*models.OrderPreparationEvent, error)); ok {
+		return returnFunc(orderID, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, models.Actor) *models.OrderPreparationEvent); ok {
+		r0 = returnFunc(orderID, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderPreparationEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, models.Actor) error); ok {
+		r1 = returnFunc(orderID, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderPreparationServiceInterface_StartPreparing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartPreparing'
+type MockOrderPreparationServiceInterface_StartPreparing_Call struct {
+	*mock.Call
+}
+
+// StartPreparing is a helper method to define mock.On call
+//   - orderID uuid.UUID
+//   - actor models.Actor
+func (_e *MockOrderPreparationServiceInterface_Expecter) StartPreparing(orderID interface{}, actor interface{}) *MockOrderPreparationServiceInterface_StartPreparing_Call {
+	return &MockOrderPreparationServiceInterface_StartPreparing_Call{Call: _e.mock.On("StartPreparing", orderID, actor)}
+}
+
+func (_c *MockOrderPreparationServiceInterface_StartPreparing_Call) Run(run func(orderID uuid.UUID, actor models.Actor)) *MockOrderPreparationServiceInterface_StartPreparing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 models.Actor
+		if args[1] != nil {
+			arg1 = args[1].(models.Actor)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderPreparationServiceInterface_StartPreparing_Call) Return(orderPreparationEvent *models.OrderPreparationEvent, err error) *MockOrderPreparationServiceInterface_StartPreparing_Call {
+	_c.Call.Return(orderPreparationEvent, err)
+	return _c
+}
+
+func (_c *MockOrderPreparationServiceInterface_StartPreparing_Call) RunAndReturn(run func(orderID uuid.UUID, actor models.Actor) (*models.OrderPreparationEvent, error)) *MockOrderPreparationServiceInterface_StartPreparing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockReviewServiceInterface creates a new instance of MockReviewServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReviewServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReviewServiceInterface {
+	mock := &MockReviewServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockReviewServiceInterface is an autogenerated mock type for the ReviewServiceInterface type
+type MockReviewServiceInterface struct {
+	mock.Mock
+}
+
+type MockReviewServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReviewServiceInterface) EXPECT() *MockReviewServiceInterface_Expecter {
+	return &MockReviewServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// CreateReview provides a mock function for the type MockReviewServiceInterface
+func (_mock *MockReviewServiceInterface) CreateReview(req *models.CreateReviewRequest, order *models.Order, actor models.Actor) (*models.Review, error) {
+	ret := _mock.Called(req, order, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReview")
+	}
+
+	var r0 *models.Review
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*models.CreateReviewRequest, *models.Order, models.Actor) (*models.Review, error)); ok {
+		return returnFunc(req, order, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.CreateReviewRequest, *models.Order, models.Actor) *models.Review); ok {
+		r0 = returnFunc(req, order, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Review)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.CreateReviewRequest, *models.Order, models.Actor) error); ok {
+		r1 = returnFunc(req, order, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockReviewServiceInterface_CreateReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReview'
+type MockReviewServiceInterface_CreateReview_Call struct {
+	*mock.Call
+}
+
+// CreateReview is a helper method to define mock.On call
+//   - req *models.CreateReviewRequest
+//   - order *models.Order
+//   - actor models.Actor
+func (_e *MockReviewServiceInterface_Expecter) CreateReview(req interface{}, order interface{}, actor interface{}) *MockReviewServiceInterface_CreateReview_Call {
+	return &MockReviewServiceInterface_CreateReview_Call{Call: _e.mock.On("CreateReview", req, order, actor)}
+}
+
+func (_c *MockReviewServiceInterface_CreateReview_Call) Run(run func(req *models.CreateReviewRequest, order *models.Order, actor models.Actor)) *MockReviewServiceInterface_CreateReview_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.CreateReviewRequest
+		if args[0] != nil {
+			arg0 = args[0].(*models.CreateReviewRequest)
+		}
+		var arg1 *models.Order
+		if args[1] != nil {
+			arg1 = args[1].(*models.Order)
+		}
+		var arg2 models.Actor
+		if args[2] != nil {
+			arg2 = args[2].(models.Actor)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockReviewServiceInterface_CreateReview_Call) Return(review *models.Review, err error) *MockReviewServiceInterface_CreateReview_Call {
+	_c.Call.Return(review, err)
+	return _c
+}
+
+func (_c *MockReviewServiceInterface_CreateReview_Call) RunAndReturn(run func(req *models.CreateReviewRequest, order *models.Order, actor models.Actor) (*models.Review, error)) *MockReviewServiceInterface_CreateReview_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReviews provides a mock function for the type MockReviewServiceInterface
+func (_mock *MockReviewServiceInterface) GetReviews(courierID uuid.UUID) ([]*models.Review, error) {
+	ret := _mock.Called(courierID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviews")
+	}
+
+	var r0 []*models.Review
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) ([]*models.Review, error)); ok {
+		return returnFunc(courierID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) []*models.Review); ok {
+		r0 = returnFunc(courierID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Review)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(courierID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockReviewServiceInterface_GetReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviews'
+type MockReviewServiceInterface_GetReviews_Call struct {
+	*mock.Call
+}
+
+// GetReviews is a helper method to define mock.On call
+//   - courierID uuid.UUID
+func (_e *MockReviewServiceInterface_Expecter) GetReviews(courierID interface{}) *MockReviewServiceInterface_GetReviews_Call {
+	return &MockReviewServiceInterface_GetReviews_Call{Call: _e.mock.On("GetReviews", courierID)}
+}
+
+func (_c *MockReviewServiceInterface_GetReviews_Call) Run(run func(courierID uuid.UUID)) *MockReviewServiceInterface_GetReviews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockReviewServiceInterface_GetReviews_Call) Return(reviews []*models.Review, err error) *MockReviewServiceInterface_GetReviews_Call {
+	_c.Call.Return(reviews, err)
+	return _c
+}
+
+func (_c *MockReviewServiceInterface_GetReviews_Call) RunAndReturn(run func(courierID uuid.UUID) ([]*models.Review, error)) *MockReviewServiceInterface_GetReviews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecalculateRating provides a mock function for the type MockReviewServiceInterface
+func (_mock *MockReviewServiceInterface) RecalculateRating(courierID uuid.UUID) error {
+	ret := _mock.Called(courierID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecalculateRating")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = returnFunc(courierID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockReviewServiceInterface_RecalculateRating_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecalculateRating'
+type MockReviewServiceInterface_RecalculateRating_Call struct {
+	*mock.Call
+}
+
+// RecalculateRating is a helper method to define mock.On call
+//   - courierID uuid.UUID
+func (_e *MockReviewServiceInterface_Expecter) RecalculateRating(courierID interface{}) *MockReviewServiceInterface_RecalculateRating_Call {
+	return &MockReviewServiceInterface_RecalculateRating_Call{Call: _e.mock.On("RecalculateRating", courierID)}
+}
+
+func (_c *MockReviewServiceInterface_RecalculateRating_Call) Run(run func(courierID uuid.UUID)) *MockReviewServiceInterface_RecalculateRating_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockReviewServiceInterface_RecalculateRating_Call) Return(err error) *MockReviewServiceInterface_RecalculateRating_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockReviewServiceInterface_RecalculateRating_Call) RunAndReturn(run func(courierID uuid.UUID) error) *MockReviewServiceInterface_RecalculateRating_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCourierServiceInterface creates a new instance of MockCourierServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCourierServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCourierServiceInterface {
+	mock := &MockCourierServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCourierServiceInterface is an autogenerated mock type for the CourierServiceInterface type
+type MockCourierServiceInterface struct {
+	mock.Mock
+}
+
+type MockCourierServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCourierServiceInterface) EXPECT() *MockCourierServiceInterface_Expecter {
+	return &MockCourierServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// AssignOrderToCourier provides a mock function for the type MockCourierServiceInterface
+func (_mock *MockCourierServiceInterface) AssignOrderToCourier(orderID uuid.UUID, courierID uuid.UUID, actor models.Actor) error {
+	ret := _mock.Called(orderID, courierID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignOrderToCourier")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, models.Actor) error); ok {
+		r0 = returnFunc(orderID, courierID, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCourierServiceInterface_AssignOrderToCourier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignOrderToCourier'
+type MockCourierServiceInterface_AssignOrderToCourier_Call struct {
+	*mock.Call
+}
+
+// AssignOrderToCourier is a helper method to define mock.On call
+//   - orderID uuid.UUID
+//   - courierID uuid.UUID
+//   - actor models.Actor
+func (_e *MockCourierServiceInterface_Expecter) AssignOrderToCourier(orderID interface{}, courierID interface{}, actor interface{}) *MockCourierServiceInterface_AssignOrderToCourier_Call {
+	return &MockCourierServiceInterface_AssignOrderToCourier_Call{Call: _e.mock.On("AssignOrderToCourier", orderID, courierID, actor)}
+}
+
+func (_c *MockCourierServiceInterface_AssignOrderToCourier_Call) Run(run func(orderID uuid.UUID, courierID uuid.UUID, actor models.Actor)) *MockCourierServiceInterface_AssignOrderToCourier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 models.Actor
+		if args[2] != nil {
+			arg2 = args[2].(models.Actor)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_AssignOrderToCourier_Call) Return(err error) *MockCourierServiceInterface_AssignOrderToCourier_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_AssignOrderToCourier_Call) RunAndReturn(run func(orderID uuid.UUID, courierID uuid.UUID, actor models.Actor) error) *MockCourierServiceInterface_AssignOrderToCourier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AssignOrdersToCourier provides a mock function for the type MockCourierServiceInterface
+func (_mock *MockCourierServiceInterface) AssignOrdersToCourier(orderIDs []uuid.UUID, courierID uuid.UUID, actor models.Actor) error {
+	ret := _mock.Called(orderIDs, courierID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignOrdersToCourier")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func([]uuid.UUID, uuid.UUID, models.Actor) error); ok {
+		r0 = returnFunc(orderIDs, courierID, actor)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCourierServiceInterface_AssignOrdersToCourier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignOrdersToCourier'
+type MockCourierServiceInterface_AssignOrdersToCourier_Call struct {
+	*mock.Call
+}
+
+// AssignOrdersToCourier is a helper method to define mock.On call
+//   - orderIDs []uuid.UUID
+//   - courierID uuid.UUID
+//   - actor models.Actor
+func (_e *MockCourierServiceInterface_Expecter) AssignOrdersToCourier(orderIDs interface{}, courierID interface{}, actor interface{}) *MockCourierServiceInterface_AssignOrdersToCourier_Call {
+	return &MockCourierServiceInterface_AssignOrdersToCourier_Call{Call: _e.mock.On("AssignOrdersToCourier", orderIDs, courierID, actor)}
+}
+
+func (_c *MockCourierServiceInterface_AssignOrdersToCourier_Call) Run(run func(orderIDs []uuid.UUID, courierID uuid.UUID, actor models.Actor)) *MockCourierServiceInterface_AssignOrdersToCourier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].([]uuid.UUID)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 models.Actor
+		if args[2] != nil {
+			arg2 = args[2].(models.Actor)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_AssignOrdersToCourier_Call) Return(err error) *MockCourierServiceInterface_AssignOrdersToCourier_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_AssignOrdersToCourier_Call) RunAndReturn(run func(orderIDs []uuid.UUID, courierID uuid.UUID, actor models.Actor) error) *MockCourierServiceInterface_AssignOrdersToCourier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateCourier provides a mock function for the type MockCourierServiceInterface
+func (_mock *MockCourierServiceInterface) CreateCourier(req *models.CreateCourierRequest) (*models.Courier, error) {
+	ret := _mock.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCourier")
+	}
+
+	var r0 *models.Courier
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*models.CreateCourierRequest) (*models.Courier, error)); ok {
+		return returnFunc(req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.CreateCourierRequest) *models.Courier); ok {
+		r0 = returnFunc(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Courier)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.CreateCourierRequest) error); ok {
+		r1 = returnFunc(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCourierServiceInterface_CreateCourier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCourier'
+type MockCourierServiceInterface_CreateCourier_Call struct {
+	*mock.Call
+}
+
+// CreateCourier is a helper method to define mock.On call
+//   - req *models.CreateCourierRequest
+func (_e *MockCourierServiceInterface_Expecter) CreateCourier(req interface{}) *MockCourierServiceInterface_CreateCourier_Call {
+	return &MockCourierServiceInterface_CreateCourier_Call{Call: _e.mock.On("CreateCourier", req)}
+}
+
+func (_c *MockCourierServiceInterface_CreateCourier_Call) Run(run func(req *models.CreateCourierRequest)) *MockCourierServiceInterface_CreateCourier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.CreateCourierRequest
+		if args[0] != nil {
+			arg0 = args[0].(*models.CreateCourierRequest)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_CreateCourier_Call) Return(courier *models.Courier, err error) *MockCourierServiceInterface_CreateCourier_Call {
+	_c.Call.Return(courier, err)
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_CreateCourier_Call) RunAndReturn(run func(req *models.CreateCourierRequest) (*models.Courier, error)) *MockCourierServiceInterface_CreateCourier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAvailableCouriers provides a mock function for the type MockCourierServiceInterface
+func (_mock *MockCourierServiceInterface) GetAvailableCouriers() ([]*models.Courier, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAvailableCouriers")
+	}
+
+	var r0 []*models.Courier
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]*models.Courier, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []*models.Courier); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Courier)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCourierServiceInterface_GetAvailableCouriers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAvailableCouriers'
+type MockCourierServiceInterface_GetAvailableCouriers_Call struct {
+	*mock.Call
+}
+
+// GetAvailableCouriers is a helper method to define mock.On call
+func (_e *MockCourierServiceInterface_Expecter) GetAvailableCouriers() *MockCourierServiceInterface_GetAvailableCouriers_Call {
+	return &MockCourierServiceInterface_GetAvailableCouriers_Call{Call: _e.mock.On("GetAvailableCouriers")}
+}
+
+func (_c *MockCourierServiceInterface_GetAvailableCouriers_Call) Run(run func()) *MockCourierServiceInterface_GetAvailableCouriers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_GetAvailableCouriers_Call) Return(couriers []*models.Courier, err error) *MockCourierServiceInterface_GetAvailableCouriers_Call {
+	_c.Call.Return(couriers, err)
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_GetAvailableCouriers_Call) RunAndReturn(run func() ([]*models.Courier, error)) *MockCourierServiceInterface_GetAvailableCouriers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCourier provides a mock function for the type MockCourierServiceInterface
+func (_mock *MockCourierServiceInterface) GetCourier(courierID uuid.UUID) (*models.Courier, error) {
+	ret := _mock.Called(courierID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCourier")
+	}
+
+	var r0 *models.Courier
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.Courier, error)); ok {
+		return returnFunc(courierID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.Courier); ok {
+		r0 = returnFunc(courierID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Courier)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(courierID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCourierServiceInterface_GetCourier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCourier'
+type MockCourierServiceInterface_GetCourier_Call struct {
+	*mock.Call
+}
+
+// GetCourier is a helper method to define mock.On call
+//   - courierID uuid.UUID
+func (_e *MockCourierServiceInterface_Expecter) GetCourier(courierID interface{}) *MockCourierServiceInterface_GetCourier_Call {
+	return &MockCourierServiceInterface_GetCourier_Call{Call: _e.mock.On("GetCourier", courierID)}
+}
+
+func (_c *MockCourierServiceInterface_GetCourier_Call) Run(run func(courierID uuid.UUID)) *MockCourierServiceInterface_GetCourier_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_GetCourier_Call) Return(courier *models.Courier, err error) *MockCourierServiceInterface_GetCourier_Call {
+	_c.Call.Return(courier, err)
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_GetCourier_Call) RunAndReturn(run func(courierID uuid.UUID) (*models.Courier, error)) *MockCourierServiceInterface_GetCourier_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCouriers provides a mock function for the type MockCourierServiceInterface
+func (_mock *MockCourierServiceInterface) GetCouriers(status *models.CourierStatus, limit int, offset int, ratingSort bool) ([]*models.Courier, error) {
+	ret := _mock.Called(status, limit, offset, ratingSort)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCouriers")
+	}
+
+	var r0 []*models.Courier
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*models.CourierStatus, int, int, bool) ([]*models.Courier, error)); ok {
+		return returnFunc(status, limit, offset, ratingSort)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.CourierStatus, int, int, bool) []*models.Courier); ok {
+		r0 = returnFunc(status, limit, offset, ratingSort)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Courier)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.CourierStatus, int, int, bool) error); ok {
+		r1 = returnFunc(status, limit, offset, ratingSort)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCourierServiceInterface_GetCouriers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCouriers'
+type MockCourierServiceInterface_GetCouriers_Call struct {
+	*mock.Call
+}
+
+// GetCouriers is a helper method to define mock.On call
+//   - status *models.CourierStatus
+//   - limit int
+//   - offset int
+//   - ratingSort bool
+func (_e *MockCourierServiceInterface_Expecter) GetCouriers(status interface{}, limit interface{}, offset interface{}, ratingSort interface{}) *MockCourierServiceInterface_GetCouriers_Call {
+	return &MockCourierServiceInterface_GetCouriers_Call{Call: _e.mock.On("GetCouriers", status, limit, offset, ratingSort)}
+}
+
+func (_c *MockCourierServiceInterface_GetCouriers_Call) Run(run func(status *models.CourierStatus, limit int, offset int, ratingSort bool)) *MockCourierServiceInterface_GetCouriers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.CourierStatus
+		if args[0] != nil {
+			arg0 = args[0].(*models.CourierStatus)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_GetCouriers_Call) Return(couriers []*models.Courier, err error) *MockCourierServiceInterface_GetCouriers_Call {
+	_c.Call.Return(couriers, err)
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_GetCouriers_Call) RunAndReturn(run func(status *models.CourierStatus, limit int, offset int, ratingSort bool) ([]*models.Courier, error)) *MockCourierServiceInterface_GetCouriers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCourierCapacity provides a mock function for the type MockCourierServiceInterface
+func (_mock *MockCourierServiceInterface) UpdateCourierCapacity(courierID uuid.UUID, capacity *int) error {
+	ret := _mock.Called(courierID, capacity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCourierCapacity")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *int) error); ok {
+		r0 = returnFunc(courierID, capacity)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCourierServiceInterface_UpdateCourierCapacity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCourierCapacity'
+type MockCourierServiceInterface_UpdateCourierCapacity_Call struct {
+	*mock.Call
+}
+
+// UpdateCourierCapacity is a helper method to define mock.On call
+//   - courierID uuid.UUID
+//   - capacity *int
+func (_e *MockCourierServiceInterface_Expecter) UpdateCourierCapacity(courierID interface{}, capacity interface{}) *MockCourierServiceInterface_UpdateCourierCapacity_Call {
+	return &MockCourierServiceInterface_UpdateCourierCapacity_Call{Call: _e.mock.On("UpdateCourierCapacity", courierID, capacity)}
+}
+
+func (_c *MockCourierServiceInterface_UpdateCourierCapacity_Call) Run(run func(courierID uuid.UUID, capacity *int)) *MockCourierServiceInterface_UpdateCourierCapacity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *int
+		if args[1] != nil {
+			arg1 = args[1].(*int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_UpdateCourierCapacity_Call) Return(err error) *MockCourierServiceInterface_UpdateCourierCapacity_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_UpdateCourierCapacity_Call) RunAndReturn(run func(courierID uuid.UUID, capacity *int) error) *MockCourierServiceInterface_UpdateCourierCapacity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCourierStatus provides a mock function for the type MockCourierServiceInterface
+func (_mock *MockCourierServiceInterface) UpdateCourierStatus(courierID uuid.UUID, req *models.UpdateCourierStatusRequest) error {
+	ret := _mock.Called(courierID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCourierStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.UpdateCourierStatusRequest) error); ok {
+		r0 = returnFunc(courierID, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCourierServiceInterface_UpdateCourierStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCourierStatus'
+type MockCourierServiceInterface_UpdateCourierStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateCourierStatus is a helper method to define mock.On call
+//   - courierID uuid.UUID
+//   - req *models.UpdateCourierStatusRequest
+func (_e *MockCourierServiceInterface_Expecter) UpdateCourierStatus(courierID interface{}, req interface{}) *MockCourierServiceInterface_UpdateCourierStatus_Call {
+	return &MockCourierServiceInterface_UpdateCourierStatus_Call{Call: _e.mock.On("UpdateCourierStatus", courierID, req)}
+}
+
+func (_c *MockCourierServiceInterface_UpdateCourierStatus_Call) Run(run func(courierID uuid.UUID, req *models.UpdateCourierStatusRequest)) *MockCourierServiceInterface_UpdateCourierStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *models.UpdateCourierStatusRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.UpdateCourierStatusRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_UpdateCourierStatus_Call) Return(err error) *MockCourierServiceInterface_UpdateCourierStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_UpdateCourierStatus_Call) RunAndReturn(run func(courierID uuid.UUID, req *models.UpdateCourierStatusRequest) error) *MockCourierServiceInterface_UpdateCourierStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCourierZone provides a mock function for the type MockCourierServiceInterface
+func (_mock *MockCourierServiceInterface) UpdateCourierZone(courierID uuid.UUID, zone *string) error {
+	ret := _mock.Called(courierID, zone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCourierZone")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *string) error); ok {
+		r0 = returnFunc(courierID, zone)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCourierServiceInterface_UpdateCourierZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCourierZone'
+type MockCourierServiceInterface_UpdateCourierZone_Call struct {
+	*mock.Call
+}
+
+// UpdateCourierZone is a helper method to define mock.On call
+//   - courierID uuid.UUID
+//   - zone *string
+func (_e *MockCourierServiceInterface_Expecter) UpdateCourierZone(courierID interface{}, zone interface{}) *MockCourierServiceInterface_UpdateCourierZone_Call {
+	return &MockCourierServiceInterface_UpdateCourierZone_Call{Call: _e.mock.On("UpdateCourierZone", courierID, zone)}
+}
+
+func (_c *MockCourierServiceInterface_UpdateCourierZone_Call) Run(run func(courierID uuid.UUID, zone *string)) *MockCourierServiceInterface_UpdateCourierZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *string
+		if args[1] != nil {
+			arg1 = args[1].(*string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_UpdateCourierZone_Call) Return(err error) *MockCourierServiceInterface_UpdateCourierZone_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCourierServiceInterface_UpdateCourierZone_Call) RunAndReturn(run func(courierID uuid.UUID, zone *string) error) *MockCourierServiceInterface_UpdateCourierZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockBatchingServiceInterface creates a new instance of MockBatchingServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBatchingServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBatchingServiceInterface {
+	mock := &MockBatchingServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBatchingServiceInterface is an autogenerated mock type for the BatchingServiceInterface type
+type MockBatchingServiceInterface struct {
+	mock.Mock
+}
+
+type MockBatchingServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBatchingServiceInterface) EXPECT() *MockBatchingServiceInterface_Expecter {
+	return &MockBatchingServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// AssignBatch provides a mock function for the type MockBatchingServiceInterface
+func (_mock *MockBatchingServiceInterface) AssignBatch(courierID uuid.UUID, orderIDs []uuid.UUID, actor models.Actor) (*models.CourierRoute, error) {
+	ret := _mock.Called(courierID, orderIDs, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignBatch")
+	}
+
+	var r0 *models.CourierRoute
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, []uuid.UUID, models.Actor) (*models.CourierRoute, error)); ok {
+		return returnFunc(courierID, orderIDs, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, []uuid.UUID, models.Actor) *models.CourierRoute); ok {
+		r0 = returnFunc(courierID, orderIDs, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CourierRoute)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, []uuid.UUID, models.Actor) error); ok {
+		r1 = returnFunc(courierID, orderIDs, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchingServiceInterface_AssignBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignBatch'
+type MockBatchingServiceInterface_AssignBatch_Call struct {
+	*mock.Call
+}
+
+// AssignBatch is a helper method to define mock.On call
+//   - courierID uuid.UUID
+//   - orderIDs []uuid.UUID
+//   - actor models.Actor
+func (_e *MockBatchingServiceInterface_Expecter) AssignBatch(courierID interface{}, orderIDs interface{}, actor interface{}) *MockBatchingServiceInterface_AssignBatch_Call {
+	return &MockBatchingServiceInterface_AssignBatch_Call{Call: _e.mock.On("AssignBatch", courierID, orderIDs, actor)}
+}
+
+func (_c *MockBatchingServiceInterface_AssignBatch_Call) Run(run func(courierID uuid.UUID, orderIDs []uuid.UUID, actor models.Actor)) *MockBatchingServiceInterface_AssignBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 []uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].([]uuid.UUID)
+		}
+		var arg2 models.Actor
+		if args[2] != nil {
+			arg2 = args[2].(models.Actor)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchingServiceInterface_AssignBatch_Call) Return(courierRoute *models.CourierRoute, err error) *MockBatchingServiceInterface_AssignBatch_Call {
+	_c.Call.Return(courierRoute, err)
+	return _c
+}
+
+func (_c *MockBatchingServiceInterface_AssignBatch_Call) RunAndReturn(run func(courierID uuid.UUID, orderIDs []uuid.UUID, actor models.Actor) (*models.CourierRoute, error)) *MockBatchingServiceInterface_AssignBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBatches provides a mock function for the type MockBatchingServiceInterface
+func (_mock *MockBatchingServiceInterface) GetBatches() ([]*models.OrderBatch, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBatches")
+	}
+
+	var r0 []*models.OrderBatch
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]*models.OrderBatch, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []*models.OrderBatch); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.OrderBatch)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchingServiceInterface_GetBatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBatches'
+type MockBatchingServiceInterface_GetBatches_Call struct {
+	*mock.Call
+}
+
+// GetBatches is a helper method to define mock.On call
+func (_e *MockBatchingServiceInterface_Expecter) GetBatches() *MockBatchingServiceInterface_GetBatches_Call {
+	return &MockBatchingServiceInterface_GetBatches_Call{Call: _e.mock.On("GetBatches")}
+}
+
+func (_c *MockBatchingServiceInterface_GetBatches_Call) Run(run func()) *MockBatchingServiceInterface_GetBatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockBatchingServiceInterface_GetBatches_Call) Return(orderBatchs []*models.OrderBatch, err error) *MockBatchingServiceInterface_GetBatches_Call {
+	_c.Call.Return(orderBatchs, err)
+	return _c
+}
+
+func (_c *MockBatchingServiceInterface_GetBatches_Call) RunAndReturn(run func() ([]*models.OrderBatch, error)) *MockBatchingServiceInterface_GetBatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCourierRoute provides a mock function for the type MockBatchingServiceInterface
+func (_mock *MockBatchingServiceInterface) GetCourierRoute(courierID uuid.UUID) (*models.CourierRoute, error) {
+	ret := _mock.Called(courierID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCourierRoute")
+	}
+
+	var r0 *models.CourierRoute
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.CourierRoute, error)); ok {
+		return returnFunc(courierID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.CourierRoute); ok {
+		r0 = returnFunc(courierID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CourierRoute)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(courierID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBatchingServiceInterface_GetCourierRoute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCourierRoute'
+type MockBatchingServiceInterface_GetCourierRoute_Call struct {
+	*mock.Call
+}
+
+// GetCourierRoute is a helper method to define mock.On call
+//   - courierID uuid.UUID
+func (_e *MockBatchingServiceInterface_Expecter) GetCourierRoute(courierID interface{}) *MockBatchingServiceInterface_GetCourierRoute_Call {
+	return &MockBatchingServiceInterface_GetCourierRoute_Call{Call: _e.mock.On("GetCourierRoute", courierID)}
+}
+
+func (_c *MockBatchingServiceInterface_GetCourierRoute_Call) Run(run func(courierID uuid.UUID)) *MockBatchingServiceInterface_GetCourierRoute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBatchingServiceInterface_GetCourierRoute_Call) Return(courierRoute *models.CourierRoute, err error) *MockBatchingServiceInterface_GetCourierRoute_Call {
+	_c.Call.Return(courierRoute, err)
+	return _c
+}
+
+func (_c *MockBatchingServiceInterface_GetCourierRoute_Call) RunAndReturn(run func(courierID uuid.UUID) (*models.CourierRoute, error)) *MockBatchingServiceInterface_GetCourierRoute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockDeliveryProofServiceInterface creates a new instance of MockDeliveryProofServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDeliveryProofServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDeliveryProofServiceInterface {
+	mock := &MockDeliveryProofServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDeliveryProofServiceInterface is an autogenerated mock type for the DeliveryProofServiceInterface type
+type MockDeliveryProofServiceInterface struct {
+	mock.Mock
+}
+
+type MockDeliveryProofServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDeliveryProofServiceInterface) EXPECT() *MockDeliveryProofServiceInterface_Expecter {
+	return &MockDeliveryProofServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// ConfirmDelivery provides a mock function for the type MockDeliveryProofServiceInterface
+func (_mock *MockDeliveryProofServiceInterface) ConfirmDelivery(orderID uuid.UUID, req *models.DeliveryProofRequest, actor models.Actor) (*models.DeliveryProof, error) {
+	ret := _mock.Called(orderID, req, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmDelivery")
+	}
+
+	var r0 *models.DeliveryProof
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.DeliveryProofRequest, models.Actor) (*models.DeliveryProof, error)); ok {
+		return returnFunc(orderID, req, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.DeliveryProofRequest, models.Actor) *models.DeliveryProof); ok {
+		r0 = returnFunc(orderID, req, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeliveryProof)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, *models.DeliveryProofRequest, models.Actor) error); ok {
+		r1 = returnFunc(orderID, req, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeliveryProofServiceInterface_ConfirmDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmDelivery'
+type MockDeliveryProofServiceInterface_ConfirmDelivery_Call struct {
+	*mock.Call
+}
+
+// ConfirmDelivery is a helper method to define mock.On call
+//   - orderID uuid.UUID
+//   - req *models.DeliveryProofRequest
+//   - actor models.Actor
+func (_e *MockDeliveryProofServiceInterface_Expecter) ConfirmDelivery(orderID interface{}, req interface{}, actor interface{}) *MockDeliveryProofServiceInterface_ConfirmDelivery_Call {
+	return &MockDeliveryProofServiceInterface_ConfirmDelivery_Call{Call: _e.mock.On("ConfirmDelivery", orderID, req, actor)}
+}
+
+func (_c *MockDeliveryProofServiceInterface_ConfirmDelivery_Call) Run(run func(orderID uuid.UUID, req *models.DeliveryProofRequest, actor models.Actor)) *MockDeliveryProofServiceInterface_ConfirmDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *models.DeliveryProofRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.DeliveryProofRequest)
+		}
+		var arg2 models.Actor
+		if args[2] != nil {
+			arg2 = args[2].(models.Actor)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeliveryProofServiceInterface_ConfirmDelivery_Call) Return(deliveryProof *models.DeliveryProof, err error) *MockDeliveryProofServiceInterface_ConfirmDelivery_Call {
+	_c.Call.Return(deliveryProof, err)
+	return _c
+}
+
+func (_c *MockDeliveryProofServiceInterface_ConfirmDelivery_Call) RunAndReturn(run func(orderID uuid.UUID, req *models.DeliveryProofRequest, actor models.Actor) (*models.DeliveryProof, error)) *MockDeliveryProofServiceInterface_ConfirmDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeliveryProof provides a mock function for the type MockDeliveryProofServiceInterface
+func (_mock *MockDeliveryProofServiceInterface) GetDeliveryProof(orderID uuid.UUID) (*models.DeliveryProof, error) {
+	ret := _mock.Called(orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveryProof")
+	}
+
+	var r0 *models.DeliveryProof
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.DeliveryProof, error)); ok {
+		return returnFunc(orderID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.DeliveryProof); ok {
+		r0 = returnFunc(orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeliveryProof)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeliveryProofServiceInterface_GetDeliveryProof_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeliveryProof'
+type MockDeliveryProofServiceInterface_GetDeliveryProof_Call struct {
+	*mock.Call
+}
+
+// GetDeliveryProof is a helper method to define mock.On call
+//   - orderID uuid.UUID
+func (_e *MockDeliveryProofServiceInterface_Expecter) GetDeliveryProof(orderID interface{}) *MockDeliveryProofServiceInterface_GetDeliveryProof_Call {
+	return &MockDeliveryProofServiceInterface_GetDeliveryProof_Call{Call: _e.mock.On("GetDeliveryProof", orderID)}
+}
+
+func (_c *MockDeliveryProofServiceInterface_GetDeliveryProof_Call) Run(run func(orderID uuid.UUID)) *MockDeliveryProofServiceInterface_GetDeliveryProof_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeliveryProofServiceInterface_GetDeliveryProof_Call) Return(deliveryProof *models.DeliveryProof, err error) *MockDeliveryProofServiceInterface_GetDeliveryProof_Call {
+	_c.Call.Return(deliveryProof, err)
+	return _c
+}
+
+func (_c *MockDeliveryProofServiceInterface_GetDeliveryProof_Call) RunAndReturn(run func(orderID uuid.UUID) (*models.DeliveryProof, error)) *MockDeliveryProofServiceInterface_GetDeliveryProof_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeliveryProofFile provides a mock function for the type MockDeliveryProofServiceInterface
+func (_mock *MockDeliveryProofServiceInterface) GetDeliveryProofFile(orderID uuid.UUID, kind models.ProofFileKind) ([]byte, error) {
+	ret := _mock.Called(orderID, kind)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeliveryProofFile")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, models.ProofFileKind) ([]byte, error)); ok {
+		return returnFunc(orderID, kind)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, models.ProofFileKind) []byte); ok {
+		r0 = returnFunc(orderID, kind)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, models.ProofFileKind) error); ok {
+		r1 = returnFunc(orderID, kind)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDeliveryProofServiceInterface_GetDeliveryProofFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeliveryProofFile'
+type MockDeliveryProofServiceInterface_GetDeliveryProofFile_Call struct {
+	*mock.Call
+}
+
+// GetDeliveryProofFile is a helper method to define mock.On call
+//   - orderID uuid.UUID
+//   - kind models.ProofFileKind
+func (_e *MockDeliveryProofServiceInterface_Expecter) GetDeliveryProofFile(orderID interface{}, kind interface{}) *MockDeliveryProofServiceInterface_GetDeliveryProofFile_Call {
+	return &MockDeliveryProofServiceInterface_GetDeliveryProofFile_Call{Call: _e.mock.On("GetDeliveryProofFile", orderID, kind)}
+}
+
+func (_c *MockDeliveryProofServiceInterface_GetDeliveryProofFile_Call) Run(run func(orderID uuid.UUID, kind models.ProofFileKind)) *MockDeliveryProofServiceInterface_GetDeliveryProofFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 models.ProofFileKind
+		if args[1] != nil {
+			arg1 = args[1].(models.ProofFileKind)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockDeliveryProofServiceInterface_GetDeliveryProofFile_Call) Return(bytes []byte, err error) *MockDeliveryProofServiceInterface_GetDeliveryProofFile_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockDeliveryProofServiceInterface_GetDeliveryProofFile_Call) RunAndReturn(run func(orderID uuid.UUID, kind models.ProofFileKind) ([]byte, error)) *MockDeliveryProofServiceInterface_GetDeliveryProofFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCourierLocationServiceInterface creates a new instance of MockCourierLocationServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCourierLocationServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCourierLocationServiceInterface {
+	mock := &MockCourierLocationServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCourierLocationServiceInterface is an autogenerated mock type for the CourierLocationServiceInterface type
+type MockCourierLocationServiceInterface struct {
+	mock.Mock
+}
+
+type MockCourierLocationServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCourierLocationServiceInterface) EXPECT() *MockCourierLocationServiceInterface_Expecter {
+	return &MockCourierLocationServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// GetLastLocation provides a mock function for the type MockCourierLocationServiceInterface
+func (_mock *MockCourierLocationServiceInterface) GetLastLocation(courierID uuid.UUID) (*models.CourierLocation, error) {
+	ret := _mock.Called(courierID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLastLocation")
+	}
+
+	var r0 *models.CourierLocation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.CourierLocation, error)); ok {
+		return returnFunc(courierID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.CourierLocation); ok {
+		r0 = returnFunc(courierID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CourierLocation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(courierID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCourierLocationServiceInterface_GetLastLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLastLocation'
+type MockCourierLocationServiceInterface_GetLastLocation_Call struct {
+	*mock.Call
+}
+
+// GetLastLocation is a helper method to define mock.On call
+//   - courierID uuid.UUID
+func (_e *MockCourierLocationServiceInterface_Expecter) GetLastLocation(courierID interface{}) *MockCourierLocationServiceInterface_GetLastLocation_Call {
+	return &MockCourierLocationServiceInterface_GetLastLocation_Call{Call: _e.mock.On("GetLastLocation", courierID)}
+}
+
+func (_c *MockCourierLocationServiceInterface_GetLastLocation_Call) Run(run func(courierID uuid.UUID)) *MockCourierLocationServiceInterface_GetLastLocation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCourierLocationServiceInterface_GetLastLocation_Call) Return(courierLocation *models.CourierLocation, err error) *MockCourierLocationServiceInterface_GetLastLocation_Call {
+	_c.Call.Return(courierLocation, err)
+	return _c
+}
+
+func (_c *MockCourierLocationServiceInterface_GetLastLocation_Call) RunAndReturn(run func(courierID uuid.UUID) (*models.CourierLocation, error)) *MockCourierLocationServiceInterface_GetLastLocation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNearbyCouriers provides a mock function for the type MockCourierLocationServiceInterface
+func (_mock *MockCourierLocationServiceInterface) GetNearbyCouriers(lat float64, lon float64, radiusMeters float64, limit int) ([]*models.NearbyCourier, error) {
+	ret := _mock.Called(lat, lon, radiusMeters, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNearbyCouriers")
+	}
+
+	var r0 []*models.NearbyCourier
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(float64, float64, float64, int) ([]*models.NearbyCourier, error)); ok {
+		return returnFunc(lat, lon, radiusMeters, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(float64, float64, float64, int) []*models.NearbyCourier); ok {
+		r0 = returnFunc(lat, lon, radiusMeters, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.NearbyCourier)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(float64, float64, float64, int) error); ok {
+		r1 = returnFunc(lat, lon, radiusMeters, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCourierLocationServiceInterface_GetNearbyCouriers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNearbyCouriers'
+type MockCourierLocationServiceInterface_GetNearbyCouriers_Call struct {
+	*mock.Call
+}
+
+// GetNearbyCouriers is a helper method to define mock.On call
+//   - lat float64
+//   - lon float64
+//   - radiusMeters float64
+//   - limit int
+func (_e *MockCourierLocationServiceInterface_Expecter) GetNearbyCouriers(lat interface{}, lon interface{}, radiusMeters interface{}, limit interface{}) *MockCourierLocationServiceInterface_GetNearbyCouriers_Call {
+	return &MockCourierLocationServiceInterface_GetNearbyCouriers_Call{Call: _e.mock.On("GetNearbyCouriers", lat, lon, radiusMeters, limit)}
+}
+
+func (_c *MockCourierLocationServiceInterface_GetNearbyCouriers_Call) Run(run func(lat float64, lon float64, radiusMeters float64, limit int)) *MockCourierLocationServiceInterface_GetNearbyCouriers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 float64
+		if args[0] != nil {
+			arg0 = args[0].(float64)
+		}
+		var arg1 float64
+		if args[1] != nil {
+			arg1 = args[1].(float64)
+		}
+		var arg2 float64
+		if args[2] != nil {
+			arg2 = args[2].(float64)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCourierLocationServiceInterface_GetNearbyCouriers_Call) Return(nearbyCouriers []*models.NearbyCourier, err error) *MockCourierLocationServiceInterface_GetNearbyCouriers_Call {
+	_c.Call.Return(nearbyCouriers, err)
+	return _c
+}
+
+func (_c *MockCourierLocationServiceInterface_GetNearbyCouriers_Call) RunAndReturn(run func(lat float64, lon float64, radiusMeters float64, limit int) ([]*models.NearbyCourier, error)) *MockCourierLocationServiceInterface_GetNearbyCouriers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTrack provides a mock function for the type MockCourierLocationServiceInterface
+func (_mock *MockCourierLocationServiceInterface) GetTrack(courierID uuid.UUID, from time.Time, to time.Time) (*models.GeoJSONFeature, error) {
+	ret := _mock.Called(courierID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrack")
+	}
+
+	var r0 *models.GeoJSONFeature
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, time.Time, time.Time) (*models.GeoJSONFeature, error)); ok {
+		return returnFunc(courierID, from, to)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, time.Time, time.Time) *models.GeoJSONFeature); ok {
+		r0 = returnFunc(courierID, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.GeoJSONFeature)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, time.Time, time.Time) error); ok {
+		r1 = returnFunc(courierID, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCourierLocationServiceInterface_GetTrack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrack'
+type MockCourierLocationServiceInterface_GetTrack_Call struct {
+	*mock.Call
+}
+
+// GetTrack is a helper method to define mock.On call
+//   - courierID uuid.UUID
+//   - from time.Time
+//   - to time.Time
+func (_e *MockCourierLocationServiceInterface_Expecter) GetTrack(courierID interface{}, from interface{}, to interface{}) *MockCourierLocationServiceInterface_GetTrack_Call {
+	return &MockCourierLocationServiceInterface_GetTrack_Call{Call: _e.mock.On("GetTrack", courierID, from, to)}
+}
+
+func (_c *MockCourierLocationServiceInterface_GetTrack_Call) Run(run func(courierID uuid.UUID, from time.Time, to time.Time)) *MockCourierLocationServiceInterface_GetTrack_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCourierLocationServiceInterface_GetTrack_Call) Return(geoJSONFeature *models.GeoJSONFeature, err error) *MockCourierLocationServiceInterface_GetTrack_Call {
+	_c.Call.Return(geoJSONFeature, err)
+	return _c
+}
+
+func (_c *MockCourierLocationServiceInterface_GetTrack_Call) RunAndReturn(run func(courierID uuid.UUID, from time.Time, to time.Time) (*models.GeoJSONFeature, error)) *MockCourierLocationServiceInterface_GetTrack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscribe provides a mock function for the type MockCourierLocationServiceInterface
+func (_mock *MockCourierLocationServiceInterface) Subscribe(ctx context.Context, courierID uuid.UUID) (<-chan *models.CourierLocation, error) {
+	ret := _mock.Called(ctx, courierID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 <-chan *models.CourierLocation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (<-chan *models.CourierLocation, error)); ok {
+		return returnFunc(ctx, courierID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) <-chan *models.CourierLocation); ok {
+		r0 = returnFunc(ctx, courierID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *models.CourierLocation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, courierID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCourierLocationServiceInterface_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type MockCourierLocationServiceInterface_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - courierID uuid.UUID
+func (_e *MockCourierLocationServiceInterface_Expecter) Subscribe(ctx interface{}, courierID interface{}) *MockCourierLocationServiceInterface_Subscribe_Call {
+	return &MockCourierLocationServiceInterface_Subscribe_Call{Call: _e.mock.On("Subscribe", ctx, courierID)}
+}
+
+func (_c *MockCourierLocationServiceInterface_Subscribe_Call) Run(run func(ctx context.Context, courierID uuid.UUID)) *MockCourierLocationServiceInterface_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCourierLocationServiceInterface_Subscribe_Call) Return(courierLocationCh <-chan *models.CourierLocation, err error) *MockCourierLocationServiceInterface_Subscribe_Call {
+	_c.Call.Return(courierLocationCh, err)
+	return _c
+}
+
+func (_c *MockCourierLocationServiceInterface_Subscribe_Call) RunAndReturn(run func(ctx context.Context, courierID uuid.UUID) (<-chan *models.CourierLocation, error)) *MockCourierLocationServiceInterface_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLocation provides a mock function for the type MockCourierLocationServiceInterface
+func (_mock *MockCourierLocationServiceInterface) UpdateLocation(courierID uuid.UUID, req *models.UpdateLocationRequest) (*models.CourierLocation, error) {
+	ret := _mock.Called(courierID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLocation")
+	}
+
+	var r0 *models.CourierLocation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.UpdateLocationRequest) (*models.CourierLocation, error)); ok {
+		return returnFunc(courierID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.UpdateLocationRequest) *models.CourierLocation); ok {
+		r0 = returnFunc(courierID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CourierLocation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, *models.UpdateLocationRequest) error); ok {
+		r1 = returnFunc(courierID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCourierLocationServiceInterface_UpdateLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLocation'
+type MockCourierLocationServiceInterface_UpdateLocation_Call struct {
+	*mock.Call
+}
+
+// UpdateLocation is a helper method to define mock.On call
+//   - courierID uuid.UUID
+//   - req *models.UpdateLocationRequest
+func (_e *MockCourierLocationServiceInterface_Expecter) UpdateLocation(courierID interface{}, req interface{}) *MockCourierLocationServiceInterface_UpdateLocation_Call {
+	return &MockCourierLocationServiceInterface_UpdateLocation_Call{Call: _e.mock.On("UpdateLocation", courierID, req)}
+}
+
+func (_c *MockCourierLocationServiceInterface_UpdateLocation_Call) Run(run func(courierID uuid.UUID, req *models.UpdateLocationRequest)) *MockCourierLocationServiceInterface_UpdateLocation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *models.UpdateLocationRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.UpdateLocationRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCourierLocationServiceInterface_UpdateLocation_Call) Return(courierLocation *models.CourierLocation, err error) *MockCourierLocationServiceInterface_UpdateLocation_Call {
+	_c.Call.Return(courierLocation, err)
+	return _c
+}
+
+func (_c *MockCourierLocationServiceInterface_UpdateLocation_Call) RunAndReturn(run func(courierID uuid.UUID, req *models.UpdateLocationRequest) (*models.CourierLocation, error)) *MockCourierLocationServiceInterface_UpdateLocation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCourierAssignmentServiceInterface creates a new instance of MockCourierAssignmentServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCourierAssignmentServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCourierAssignmentServiceInterface {
+	mock := &MockCourierAssignmentServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockCourierAssignmentServiceInterface is an autogenerated mock type for the CourierAssignmentServiceInterface type
+type MockCourierAssignmentServiceInterface struct {
+	mock.Mock
+}
+
+type MockCourierAssignmentServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCourierAssignmentServiceInterface) EXPECT() *MockCourierAssignmentServiceInterface_Expecter {
+	return &MockCourierAssignmentServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// AutoAssign provides a mock function for the type MockCourierAssignmentServiceInterface
+func (_mock *MockCourierAssignmentServiceInterface) AutoAssign(orderID uuid.UUID, actor models.Actor) (*models.AssignmentResult, error) {
+	ret := _mock.Called(orderID, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AutoAssign")
+	}
+
+	var r0 *models.AssignmentResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, models.Actor) (*models.AssignmentResult, error)); ok {
+		return returnFunc(orderID, actor)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, models.Actor) *models.AssignmentResult); ok {
+		r0 = returnFunc(orderID, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AssignmentResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, models.Actor) error); ok {
+		r1 = returnFunc(orderID, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCourierAssignmentServiceInterface_AutoAssign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AutoAssign'
+type MockCourierAssignmentServiceInterface_AutoAssign_Call struct {
+	*mock.Call
+}
+
+// AutoAssign is a helper method to define mock.On call
+//   - orderID uuid.UUID
+//   - actor models.Actor
+func (_e *MockCourierAssignmentServiceInterface_Expecter) AutoAssign(orderID interface{}, actor interface{}) *MockCourierAssignmentServiceInterface_AutoAssign_Call {
+	return &MockCourierAssignmentServiceInterface_AutoAssign_Call{Call: _e.mock.On("AutoAssign", orderID, actor)}
+}
+
+func (_c *MockCourierAssignmentServiceInterface_AutoAssign_Call) Run(run func(orderID uuid.UUID, actor models.Actor)) *MockCourierAssignmentServiceInterface_AutoAssign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 models.Actor
+		if args[1] != nil {
+			arg1 = args[1].(models.Actor)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCourierAssignmentServiceInterface_AutoAssign_Call) Return(assignmentResult *models.AssignmentResult, err error) *MockCourierAssignmentServiceInterface_AutoAssign_Call {
+	_c.Call.Return(assignmentResult, err)
+	return _c
+}
+
+func (_c *MockCourierAssignmentServiceInterface_AutoAssign_Call) RunAndReturn(run func(orderID uuid.UUID, actor models.Actor) (*models.AssignmentResult, error)) *MockCourierAssignmentServiceInterface_AutoAssign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOrderEventServiceInterface creates a new instance of MockOrderEventServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOrderEventServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOrderEventServiceInterface {
+	mock := &MockOrderEventServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOrderEventServiceInterface is an autogenerated mock type for the OrderEventServiceInterface type
+type MockOrderEventServiceInterface struct {
+	mock.Mock
+}
+
+type MockOrderEventServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOrderEventServiceInterface) EXPECT() *MockOrderEventServiceInterface_Expecter {
+	return &MockOrderEventServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// GetEvents provides a mock function for the type MockOrderEventServiceInterface
+func (_mock *MockOrderEventServiceInterface) GetEvents(orderID uuid.UUID) ([]*models.OrderEvent, error) {
+	ret := _mock.Called(orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEvents")
+	}
+
+	var r0 []*models.OrderEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) ([]*models.OrderEvent, error)); ok {
+		return returnFunc(orderID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) []*models.OrderEvent); ok {
+		r0 = returnFunc(orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.OrderEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderEventServiceInterface_GetEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEvents'
+type MockOrderEventServiceInterface_GetEvents_Call struct {
+	*mock.Call
+}
+
+// GetEvents is a helper method to define mock.On call
+//   - orderID uuid.UUID
+func (_e *MockOrderEventServiceInterface_Expecter) GetEvents(orderID interface{}) *MockOrderEventServiceInterface_GetEvents_Call {
+	return &MockOrderEventServiceInterface_GetEvents_Call{Call: _e.mock.On("GetEvents", orderID)}
+}
+
+func (_c *MockOrderEventServiceInterface_GetEvents_Call) Run(run func(orderID uuid.UUID)) *MockOrderEventServiceInterface_GetEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderEventServiceInterface_GetEvents_Call) Return(orderEvents []*models.OrderEvent, err error) *MockOrderEventServiceInterface_GetEvents_Call {
+	_c.Call.Return(orderEvents, err)
+	return _c
+}
+
+func (_c *MockOrderEventServiceInterface_GetEvents_Call) RunAndReturn(run func(orderID uuid.UUID) ([]*models.OrderEvent, error)) *MockOrderEventServiceInterface_GetEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Replay provides a mock function for the type MockOrderEventServiceInterface
+func (_mock *MockOrderEventServiceInterface) Replay(orderID uuid.UUID, version int) (*models.OrderReplayResult, error) {
+	ret := _mock.Called(orderID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Replay")
+	}
+
+	var r0 *models.OrderReplayResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, int) (*models.OrderReplayResult, error)); ok {
+		return returnFunc(orderID, version)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, int) *models.OrderReplayResult); ok {
+		r0 = returnFunc(orderID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OrderReplayResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, int) error); ok {
+		r1 = returnFunc(orderID, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOrderEventServiceInterface_Replay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Replay'
+type MockOrderEventServiceInterface_Replay_Call struct {
+	*mock.Call
+}
+
+// Replay is a helper method to define mock.On call
+//   - orderID uuid.UUID
+//   - version int
+func (_e *MockOrderEventServiceInterface_Expecter) Replay(orderID interface{}, version interface{}) *MockOrderEventServiceInterface_Replay_Call {
+	return &MockOrderEventServiceInterface_Replay_Call{Call: _e.mock.On("Replay", orderID, version)}
+}
+
+func (_c *MockOrderEventServiceInterface_Replay_Call) Run(run func(orderID uuid.UUID, version int)) *MockOrderEventServiceInterface_Replay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOrderEventServiceInterface_Replay_Call) Return(orderReplayResult *models.OrderReplayResult, err error) *MockOrderEventServiceInterface_Replay_Call {
+	_c.Call.Return(orderReplayResult, err)
+	return _c
+}
+
+func (_c *MockOrderEventServiceInterface_Replay_Call) RunAndReturn(run func(orderID uuid.UUID, version int) (*models.OrderReplayResult, error)) *MockOrderEventServiceInterface_Replay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPromoCodeServiceInterface creates a new instance of MockPromoCodeServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPromoCodeServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPromoCodeServiceInterface {
+	mock := &MockPromoCodeServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockPromoCodeServiceInterface is an autogenerated mock type for the PromoCodeServiceInterface type
+type MockPromoCodeServiceInterface struct {
+	mock.Mock
+}
+
+type MockPromoCodeServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPromoCodeServiceInterface) EXPECT() *MockPromoCodeServiceInterface_Expecter {
+	return &MockPromoCodeServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// CreatePromoCode provides a mock function for the type MockPromoCodeServiceInterface
+func (_mock *MockPromoCodeServiceInterface) CreatePromoCode(req *models.PromoCodeRequest) (*models.PromoCode, error) {
+	ret := _mock.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePromoCode")
+	}
+
+	var r0 *models.PromoCode
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*models.PromoCodeRequest) (*models.PromoCode, error)); ok {
+		return returnFunc(req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.PromoCodeRequest) *models.PromoCode); ok {
+		r0 = returnFunc(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PromoCode)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.PromoCodeRequest) error); ok {
+		r1 = returnFunc(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPromoCodeServiceInterface_CreatePromoCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreatePromoCode'
+type MockPromoCodeServiceInterface_CreatePromoCode_Call struct {
+	*mock.Call
+}
+
+// CreatePromoCode is a helper method to define mock.On call
+//   - req *models.PromoCodeRequest
+func (_e *MockPromoCodeServiceInterface_Expecter) CreatePromoCode(req interface{}) *MockPromoCodeServiceInterface_CreatePromoCode_Call {
+	return &MockPromoCodeServiceInterface_CreatePromoCode_Call{Call: _e.mock.On("CreatePromoCode", req)}
+}
+
+func (_c *MockPromoCodeServiceInterface_CreatePromoCode_Call) Run(run func(req *models.PromoCodeRequest)) *MockPromoCodeServiceInterface_CreatePromoCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.PromoCodeRequest
+		if args[0] != nil {
+			arg0 = args[0].(*models.PromoCodeRequest)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPromoCodeServiceInterface_CreatePromoCode_Call) Return(promoCode *models.PromoCode, err error) *MockPromoCodeServiceInterface_CreatePromoCode_Call {
+	_c.Call.Return(promoCode, err)
+	return _c
+}
+
+func (_c *MockPromoCodeServiceInterface_CreatePromoCode_Call) RunAndReturn(run func(req *models.PromoCodeRequest) (*models.PromoCode, error)) *MockPromoCodeServiceInterface_CreatePromoCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletePromoCode provides a mock function for the type MockPromoCodeServiceInterface
+func (_mock *MockPromoCodeServiceInterface) DeletePromoCode(promoCodeID uuid.UUID) error {
+	ret := _mock.Called(promoCodeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePromoCode")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = returnFunc(promoCodeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockPromoCodeServiceInterface_DeletePromoCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePromoCode'
+type MockPromoCodeServiceInterface_DeletePromoCode_Call struct {
+	*mock.Call
+}
+
+// DeletePromoCode is a helper method to define mock.On call
+//   - promoCodeID uuid.UUID
+func (_e *MockPromoCodeServiceInterface_Expecter) DeletePromoCode(promoCodeID interface{}) *MockPromoCodeServiceInterface_DeletePromoCode_Call {
+	return &MockPromoCodeServiceInterface_DeletePromoCode_Call{Call: _e.mock.On("DeletePromoCode", promoCodeID)}
+}
+
+func (_c *MockPromoCodeServiceInterface_DeletePromoCode_Call) Run(run func(promoCodeID uuid.UUID)) *MockPromoCodeServiceInterface_DeletePromoCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPromoCodeServiceInterface_DeletePromoCode_Call) Return(err error) *MockPromoCodeServiceInterface_DeletePromoCode_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockPromoCodeServiceInterface_DeletePromoCode_Call) RunAndReturn(run func(promoCodeID uuid.UUID) error) *MockPromoCodeServiceInterface_DeletePromoCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPromoCode provides a mock function for the type MockPromoCodeServiceInterface
+func (_mock *MockPromoCodeServiceInterface) GetPromoCode(promoCodeID uuid.UUID) (*models.PromoCode, error) {
+	ret := _mock.Called(promoCodeID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPromoCode")
+	}
+
+	var r0 *models.PromoCode
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.PromoCode, error)); ok {
+		return returnFunc(promoCodeID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.PromoCode); ok {
+		r0 = returnFunc(promoCodeID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PromoCode)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(promoCodeID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPromoCodeServiceInterface_GetPromoCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPromoCode'
+type MockPromoCodeServiceInterface_GetPromoCode_Call struct {
+	*mock.Call
+}
+
+// GetPromoCode is a helper method to define mock.On call
+//   - promoCodeID uuid.UUID
+func (_e *MockPromoCodeServiceInterface_Expecter) GetPromoCode(promoCodeID interface{}) *MockPromoCodeServiceInterface_GetPromoCode_Call {
+	return &MockPromoCodeServiceInterface_GetPromoCode_Call{Call: _e.mock.On("GetPromoCode", promoCodeID)}
+}
+
+func (_c *MockPromoCodeServiceInterface_GetPromoCode_Call) Run(run func(promoCodeID uuid.UUID)) *MockPromoCodeServiceInterface_GetPromoCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPromoCodeServiceInterface_GetPromoCode_Call) Return(promoCode *models.PromoCode, err error) *MockPromoCodeServiceInterface_GetPromoCode_Call {
+	_c.Call.Return(promoCode, err)
+	return _c
+}
+
+func (_c *MockPromoCodeServiceInterface_GetPromoCode_Call) RunAndReturn(run func(promoCodeID uuid.UUID) (*models.PromoCode, error)) *MockPromoCodeServiceInterface_GetPromoCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPromoCodes provides a mock function for the type MockPromoCodeServiceInterface
+func (_mock *MockPromoCodeServiceInterface) GetPromoCodes(activeOnly bool, limit int, offset int) ([]*models.PromoCode, error) {
+	ret := _mock.Called(activeOnly, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPromoCodes")
+	}
+
+	var r0 []*models.PromoCode
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(bool, int, int) ([]*models.PromoCode, error)); ok {
+		return returnFunc(activeOnly, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(bool, int, int) []*models.PromoCode); ok {
+		r0 = returnFunc(activeOnly, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PromoCode)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(bool, int, int) error); ok {
+		r1 = returnFunc(activeOnly, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPromoCodeServiceInterface_GetPromoCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPromoCodes'
+type MockPromoCodeServiceInterface_GetPromoCodes_Call struct {
+	*mock.Call
+}
+
+// GetPromoCodes is a helper method to define mock.On call
+//   - activeOnly bool
+//   - limit int
+//   - offset int
+func (_e *MockPromoCodeServiceInterface_Expecter) GetPromoCodes(activeOnly interface{}, limit interface{}, offset interface{}) *MockPromoCodeServiceInterface_GetPromoCodes_Call {
+	return &MockPromoCodeServiceInterface_GetPromoCodes_Call{Call: _e.mock.On("GetPromoCodes", activeOnly, limit, offset)}
+}
+
+func (_c *MockPromoCodeServiceInterface_GetPromoCodes_Call) Run(run func(activeOnly bool, limit int, offset int)) *MockPromoCodeServiceInterface_GetPromoCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 bool
+		if args[0] != nil {
+			arg0 = args[0].(bool)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPromoCodeServiceInterface_GetPromoCodes_Call) Return(promoCodes []*models.PromoCode, err error) *MockPromoCodeServiceInterface_GetPromoCodes_Call {
+	_c.Call.Return(promoCodes, err)
+	return _c
+}
+
+func (_c *MockPromoCodeServiceInterface_GetPromoCodes_Call) RunAndReturn(run func(activeOnly bool, limit int, offset int) ([]*models.PromoCode, error)) *MockPromoCodeServiceInterface_GetPromoCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePromoCode provides a mock function for the type MockPromoCodeServiceInterface
+func (_mock *MockPromoCodeServiceInterface) UpdatePromoCode(promoCodeID uuid.UUID, req *models.PromoCodeRequest) (*models.PromoCode, error) {
+	ret := _mock.Called(promoCodeID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePromoCode")
+	}
+
+	var r0 *models.PromoCode
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.PromoCodeRequest) (*models.PromoCode, error)); ok {
+		return returnFunc(promoCodeID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.PromoCodeRequest) *models.PromoCode); ok {
+		r0 = returnFunc(promoCodeID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PromoCode)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, *models.PromoCodeRequest) error); ok {
+		r1 = returnFunc(promoCodeID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockPromoCodeServiceInterface_UpdatePromoCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePromoCode'
+type MockPromoCodeServiceInterface_UpdatePromoCode_Call struct {
+	*mock.Call
+}
+
+// UpdatePromoCode is a helper method to define mock.On call
+//   - promoCodeID uuid.UUID
+//   - req *models.PromoCodeRequest
+func (_e *MockPromoCodeServiceInterface_Expecter) UpdatePromoCode(promoCodeID interface{}, req interface{}) *MockPromoCodeServiceInterface_UpdatePromoCode_Call {
+	return &MockPromoCodeServiceInterface_UpdatePromoCode_Call{Call: _e.mock.On("UpdatePromoCode", promoCodeID, req)}
+}
+
+func (_c *MockPromoCodeServiceInterface_UpdatePromoCode_Call) Run(run func(promoCodeID uuid.UUID, req *models.PromoCodeRequest)) *MockPromoCodeServiceInterface_UpdatePromoCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *models.PromoCodeRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.PromoCodeRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPromoCodeServiceInterface_UpdatePromoCode_Call) Return(promoCode *models.PromoCode, err error) *MockPromoCodeServiceInterface_UpdatePromoCode_Call {
+	_c.Call.Return(promoCode, err)
+	return _c
+}
+
+func (_c *MockPromoCodeServiceInterface_UpdatePromoCode_Call) RunAndReturn(run func(promoCodeID uuid.UUID, req *models.PromoCodeRequest) (*models.PromoCode, error)) *MockPromoCodeServiceInterface_UpdatePromoCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTariffServiceInterface creates a new instance of MockTariffServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTariffServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTariffServiceInterface {
+	mock := &MockTariffServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTariffServiceInterface is an autogenerated mock type for the TariffServiceInterface type
+type MockTariffServiceInterface struct {
+	mock.Mock
+}
+
+type MockTariffServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTariffServiceInterface) EXPECT() *MockTariffServiceInterface_Expecter {
+	return &MockTariffServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// CreateTariff provides a mock function for the type MockTariffServiceInterface
+func (_mock *MockTariffServiceInterface) CreateTariff(req *models.TariffRequest) (*models.Tariff, error) {
+	ret := _mock.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTariff")
+	}
+
+	var r0 *models.Tariff
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*models.TariffRequest) (*models.Tariff, error)); ok {
+		return returnFunc(req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.TariffRequest) *models.Tariff); ok {
+		r0 = returnFunc(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tariff)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.TariffRequest) error); ok {
+		r1 = returnFunc(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTariffServiceInterface_CreateTariff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTariff'
+type MockTariffServiceInterface_CreateTariff_Call struct {
+	*mock.Call
+}
+
+// CreateTariff is a helper method to define mock.On call
+//   - req *models.TariffRequest
+func (_e *MockTariffServiceInterface_Expecter) CreateTariff(req interface{}) *MockTariffServiceInterface_CreateTariff_Call {
+	return &MockTariffServiceInterface_CreateTariff_Call{Call: _e.mock.On("CreateTariff", req)}
+}
+
+func (_c *MockTariffServiceInterface_CreateTariff_Call) Run(run func(req *models.TariffRequest)) *MockTariffServiceInterface_CreateTariff_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.TariffRequest
+		if args[0] != nil {
+			arg0 = args[0].(*models.TariffRequest)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTariffServiceInterface_CreateTariff_Call) Return(tariff *models.Tariff, err error) *MockTariffServiceInterface_CreateTariff_Call {
+	_c.Call.Return(tariff, err)
+	return _c
+}
+
+func (_c *MockTariffServiceInterface_CreateTariff_Call) RunAndReturn(run func(req *models.TariffRequest) (*models.Tariff, error)) *MockTariffServiceInterface_CreateTariff_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTariff provides a mock function for the type MockTariffServiceInterface
+func (_mock *MockTariffServiceInterface) GetTariff(tariffID uuid.UUID) (*models.Tariff, error) {
+	ret := _mock.Called(tariffID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTariff")
+	}
+
+	var r0 *models.Tariff
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.Tariff, error)); ok {
+		return returnFunc(tariffID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.Tariff); ok {
+		r0 = returnFunc(tariffID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tariff)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(tariffID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTariffServiceInterface_GetTariff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTariff'
+type MockTariffServiceInterface_GetTariff_Call struct {
+	*mock.Call
+}
+
+// GetTariff is a helper method to define mock.On call
+//   - tariffID uuid.UUID
+func (_e *MockTariffServiceInterface_Expecter) GetTariff(tariffID interface{}) *MockTariffServiceInterface_GetTariff_Call {
+	return &MockTariffServiceInterface_GetTariff_Call{Call: _e.mock.On("GetTariff", tariffID)}
+}
+
+func (_c *MockTariffServiceInterface_GetTariff_Call) Run(run func(tariffID uuid.UUID)) *MockTariffServiceInterface_GetTariff_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTariffServiceInterface_GetTariff_Call) Return(tariff *models.Tariff, err error) *MockTariffServiceInterface_GetTariff_Call {
+	_c.Call.Return(tariff, err)
+	return _c
+}
+
+func (_c *MockTariffServiceInterface_GetTariff_Call) RunAndReturn(run func(tariffID uuid.UUID) (*models.Tariff, error)) *MockTariffServiceInterface_GetTariff_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTariffs provides a mock function for the type MockTariffServiceInterface
+func (_mock *MockTariffServiceInterface) GetTariffs(code string, activeOnly bool, limit int, offset int) ([]*models.Tariff, error) {
+	ret := _mock.Called(code, activeOnly, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTariffs")
+	}
+
+	var r0 []*models.Tariff
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, bool, int, int) ([]*models.Tariff, error)); ok {
+		return returnFunc(code, activeOnly, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, bool, int, int) []*models.Tariff); ok {
+		r0 = returnFunc(code, activeOnly, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Tariff)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, bool, int, int) error); ok {
+		r1 = returnFunc(code, activeOnly, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTariffServiceInterface_GetTariffs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTariffs'
+type MockTariffServiceInterface_GetTariffs_Call struct {
+	*mock.Call
+}
+
+// GetTariffs is a helper method to define mock.On call
+//   - code string
+//   - activeOnly bool
+//   - limit int
+//   - offset int
+func (_e *MockTariffServiceInterface_Expecter) GetTariffs(code interface{}, activeOnly interface{}, limit interface{}, offset interface{}) *MockTariffServiceInterface_GetTariffs_Call {
+	return &MockTariffServiceInterface_GetTariffs_Call{Call: _e.mock.On("GetTariffs", code, activeOnly, limit, offset)}
+}
+
+func (_c *MockTariffServiceInterface_GetTariffs_Call) Run(run func(code string, activeOnly bool, limit int, offset int)) *MockTariffServiceInterface_GetTariffs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTariffServiceInterface_GetTariffs_Call) Return(tariffs []*models.Tariff, err error) *MockTariffServiceInterface_GetTariffs_Call {
+	_c.Call.Return(tariffs, err)
+	return _c
+}
+
+func (_c *MockTariffServiceInterface_GetTariffs_Call) RunAndReturn(run func(code string, activeOnly bool, limit int, offset int) ([]*models.Tariff, error)) *MockTariffServiceInterface_GetTariffs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QuoteDelivery provides a mock function for the type MockTariffServiceInterface
+func (_mock *MockTariffServiceInterface) QuoteDelivery(req *models.DeliveryQuoteRequest) (*models.DeliveryBreakdown, error) {
+	ret := _mock.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QuoteDelivery")
+	}
+
+	var r0 *models.DeliveryBreakdown
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*models.DeliveryQuoteRequest) (*models.DeliveryBreakdown, error)); ok {
+		return returnFunc(req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.DeliveryQuoteRequest) *models.DeliveryBreakdown); ok {
+		r0 = returnFunc(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeliveryBreakdown)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.DeliveryQuoteRequest) error); ok {
+		r1 = returnFunc(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTariffServiceInterface_QuoteDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QuoteDelivery'
+type MockTariffServiceInterface_QuoteDelivery_Call struct {
+	*mock.Call
+}
+
+// QuoteDelivery is a helper method to define mock.On call
+//   - req *models.DeliveryQuoteRequest
+func (_e *MockTariffServiceInterface_Expecter) QuoteDelivery(req interface{}) *MockTariffServiceInterface_QuoteDelivery_Call {
+	return &MockTariffServiceInterface_QuoteDelivery_Call{Call: _e.mock.On("QuoteDelivery", req)}
+}
+
+func (_c *MockTariffServiceInterface_QuoteDelivery_Call) Run(run func(req *models.DeliveryQuoteRequest)) *MockTariffServiceInterface_QuoteDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.DeliveryQuoteRequest
+		if args[0] != nil {
+			arg0 = args[0].(*models.DeliveryQuoteRequest)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTariffServiceInterface_QuoteDelivery_Call) Return(deliveryBreakdown *models.DeliveryBreakdown, err error) *MockTariffServiceInterface_QuoteDelivery_Call {
+	_c.Call.Return(deliveryBreakdown, err)
+	return _c
+}
+
+func (_c *MockTariffServiceInterface_QuoteDelivery_Call) RunAndReturn(run func(req *models.DeliveryQuoteRequest) (*models.DeliveryBreakdown, error)) *MockTariffServiceInterface_QuoteDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetireTariff provides a mock function for the type MockTariffServiceInterface
+func (_mock *MockTariffServiceInterface) RetireTariff(tariffID uuid.UUID) (*models.Tariff, error) {
+	ret := _mock.Called(tariffID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetireTariff")
+	}
+
+	var r0 *models.Tariff
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.Tariff, error)); ok {
+		return returnFunc(tariffID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.Tariff); ok {
+		r0 = returnFunc(tariffID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tariff)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(tariffID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTariffServiceInterface_RetireTariff_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetireTariff'
+type MockTariffServiceInterface_RetireTariff_Call struct {
+	*mock.Call
+}
+
+// RetireTariff is a helper method to define mock.On call
+//   - tariffID uuid.UUID
+func (_e *MockTariffServiceInterface_Expecter) RetireTariff(tariffID interface{}) *MockTariffServiceInterface_RetireTariff_Call {
+	return &MockTariffServiceInterface_RetireTariff_Call{Call: _e.mock.On("RetireTariff", tariffID)}
+}
+
+func (_c *MockTariffServiceInterface_RetireTariff_Call) Run(run func(tariffID uuid.UUID)) *MockTariffServiceInterface_RetireTariff_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTariffServiceInterface_RetireTariff_Call) Return(tariff *models.Tariff, err error) *MockTariffServiceInterface_RetireTariff_Call {
+	_c.Call.Return(tariff, err)
+	return _c
+}
+
+func (_c *MockTariffServiceInterface_RetireTariff_Call) RunAndReturn(run func(tariffID uuid.UUID) (*models.Tariff, error)) *MockTariffServiceInterface_RetireTariff_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSurgeServiceInterface creates a new instance of MockSurgeServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSurgeServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSurgeServiceInterface {
+	mock := &MockSurgeServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSurgeServiceInterface is an autogenerated mock type for the SurgeServiceInterface type
+type MockSurgeServiceInterface struct {
+	mock.Mock
+}
+
+type MockSurgeServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSurgeServiceInterface) EXPECT() *MockSurgeServiceInterface_Expecter {
+	return &MockSurgeServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// GetSurgeMultipliers provides a mock function for the type MockSurgeServiceInterface
+func (_mock *MockSurgeServiceInterface) GetSurgeMultipliers() ([]*models.SurgeMultiplier, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSurgeMultipliers")
+	}
+
+	var r0 []*models.SurgeMultiplier
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]*models.SurgeMultiplier, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []*models.SurgeMultiplier); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.SurgeMultiplier)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSurgeServiceInterface_GetSurgeMultipliers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSurgeMultipliers'
+type MockSurgeServiceInterface_GetSurgeMultipliers_Call struct {
+	*mock.Call
+}
+
+// GetSurgeMultipliers is a helper method to define mock.On call
+func (_e *MockSurgeServiceInterface_Expecter) GetSurgeMultipliers() *MockSurgeServiceInterface_GetSurgeMultipliers_Call {
+	return &MockSurgeServiceInterface_GetSurgeMultipliers_Call{Call: _e.mock.On("GetSurgeMultipliers")}
+}
+
+func (_c *MockSurgeServiceInterface_GetSurgeMultipliers_Call) Run(run func()) *MockSurgeServiceInterface_GetSurgeMultipliers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockSurgeServiceInterface_GetSurgeMultipliers_Call) Return(surgeMultipliers []*models.SurgeMultiplier, err error) *MockSurgeServiceInterface_GetSurgeMultipliers_Call {
+	_c.Call.Return(surgeMultipliers, err)
+	return _c
+}
+
+func (_c *MockSurgeServiceInterface_GetSurgeMultipliers_Call) RunAndReturn(run func() ([]*models.SurgeMultiplier, error)) *MockSurgeServiceInterface_GetSurgeMultipliers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockZoneServiceInterface creates a new instance of MockZoneServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockZoneServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockZoneServiceInterface {
+	mock := &MockZoneServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockZoneServiceInterface is an autogenerated mock type for the ZoneServiceInterface type
+type MockZoneServiceInterface struct {
+	mock.Mock
+}
+
+type MockZoneServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockZoneServiceInterface) EXPECT() *MockZoneServiceInterface_Expecter {
+	return &MockZoneServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// CreateZone provides a mock function for the type MockZoneServiceInterface
+func (_mock *MockZoneServiceInterface) CreateZone(req *models.DeliveryZoneRequest) (*models.DeliveryZone, error) {
+	ret := _mock.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateZone")
+	}
+
+	var r0 *models.DeliveryZone
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*models.DeliveryZoneRequest) (*models.DeliveryZone, error)); ok {
+		return returnFunc(req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.DeliveryZoneRequest) *models.DeliveryZone); ok {
+		r0 = returnFunc(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeliveryZone)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.DeliveryZoneRequest) error); ok {
+		r1 = returnFunc(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockZoneServiceInterface_CreateZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateZone'
+type MockZoneServiceInterface_CreateZone_Call struct {
+	*mock.Call
+}
+
+// CreateZone is a helper method to define mock.On call
+//   - req *models.DeliveryZoneRequest
+func (_e *MockZoneServiceInterface_Expecter) CreateZone(req interface{}) *MockZoneServiceInterface_CreateZone_Call {
+	return &MockZoneServiceInterface_CreateZone_Call{Call: _e.mock.On("CreateZone", req)}
+}
+
+func (_c *MockZoneServiceInterface_CreateZone_Call) Run(run func(req *models.DeliveryZoneRequest)) *MockZoneServiceInterface_CreateZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.DeliveryZoneRequest
+		if args[0] != nil {
+			arg0 = args[0].(*models.DeliveryZoneRequest)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockZoneServiceInterface_CreateZone_Call) Return(deliveryZone *models.DeliveryZone, err error) *MockZoneServiceInterface_CreateZone_Call {
+	_c.Call.Return(deliveryZone, err)
+	return _c
+}
+
+func (_c *MockZoneServiceInterface_CreateZone_Call) RunAndReturn(run func(req *models.DeliveryZoneRequest) (*models.DeliveryZone, error)) *MockZoneServiceInterface_CreateZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteZone provides a mock function for the type MockZoneServiceInterface
+func (_mock *MockZoneServiceInterface) DeleteZone(zoneID uuid.UUID) error {
+	ret := _mock.Called(zoneID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteZone")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = returnFunc(zoneID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockZoneServiceInterface_DeleteZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteZone'
+type MockZoneServiceInterface_DeleteZone_Call struct {
+	*mock.Call
+}
+
+// DeleteZone is a helper method to define mock.On call
+//   - zoneID uuid.UUID
+func (_e *MockZoneServiceInterface_Expecter) DeleteZone(zoneID interface{}) *MockZoneServiceInterface_DeleteZone_Call {
+	return &MockZoneServiceInterface_DeleteZone_Call{Call: _e.mock.On("DeleteZone", zoneID)}
+}
+
+func (_c *MockZoneServiceInterface_DeleteZone_Call) Run(run func(zoneID uuid.UUID)) *MockZoneServiceInterface_DeleteZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockZoneServiceInterface_DeleteZone_Call) Return(err error) *MockZoneServiceInterface_DeleteZone_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockZoneServiceInterface_DeleteZone_Call) RunAndReturn(run func(zoneID uuid.UUID) error) *MockZoneServiceInterface_DeleteZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetZone provides a mock function for the type MockZoneServiceInterface
+func (_mock *MockZoneServiceInterface) GetZone(zoneID uuid.UUID) (*models.DeliveryZone, error) {
+	ret := _mock.Called(zoneID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetZone")
+	}
+
+	var r0 *models.DeliveryZone
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.DeliveryZone, error)); ok {
+		return returnFunc(zoneID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.DeliveryZone); ok {
+		r0 = returnFunc(zoneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeliveryZone)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(zoneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockZoneServiceInterface_GetZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetZone'
+type MockZoneServiceInterface_GetZone_Call struct {
+	*mock.Call
+}
+
+// GetZone is a helper method to define mock.On call
+//   - zoneID uuid.UUID
+func (_e *MockZoneServiceInterface_Expecter) GetZone(zoneID interface{}) *MockZoneServiceInterface_GetZone_Call {
+	return &MockZoneServiceInterface_GetZone_Call{Call: _e.mock.On("GetZone", zoneID)}
+}
+
+func (_c *MockZoneServiceInterface_GetZone_Call) Run(run func(zoneID uuid.UUID)) *MockZoneServiceInterface_GetZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockZoneServiceInterface_GetZone_Call) Return(deliveryZone *models.DeliveryZone, err error) *MockZoneServiceInterface_GetZone_Call {
+	_c.Call.Return(deliveryZone, err)
+	return _c
+}
+
+func (_c *MockZoneServiceInterface_GetZone_Call) RunAndReturn(run func(zoneID uuid.UUID) (*models.DeliveryZone, error)) *MockZoneServiceInterface_GetZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetZones provides a mock function for the type MockZoneServiceInterface
+func (_mock *MockZoneServiceInterface) GetZones(activeOnly bool) ([]*models.DeliveryZone, error) {
+	ret := _mock.Called(activeOnly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetZones")
+	}
+
+	var r0 []*models.DeliveryZone
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(bool) ([]*models.DeliveryZone, error)); ok {
+		return returnFunc(activeOnly)
+	}
+	if returnFunc, ok := ret.Get(0).(func(bool) []*models.DeliveryZone); ok {
+		r0 = returnFunc(activeOnly)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.DeliveryZone)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(bool) error); ok {
+		r1 = returnFunc(activeOnly)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockZoneServiceInterface_GetZones_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetZones'
+type MockZoneServiceInterface_GetZones_Call struct {
+	*mock.Call
+}
+
+// GetZones is a helper method to define mock.On call
+//   - activeOnly bool
+func (_e *MockZoneServiceInterface_Expecter) GetZones(activeOnly interface{}) *MockZoneServiceInterface_GetZones_Call {
+	return &MockZoneServiceInterface_GetZones_Call{Call: _e.mock.On("GetZones", activeOnly)}
+}
+
+func (_c *MockZoneServiceInterface_GetZones_Call) Run(run func(activeOnly bool)) *MockZoneServiceInterface_GetZones_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 bool
+		if args[0] != nil {
+			arg0 = args[0].(bool)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockZoneServiceInterface_GetZones_Call) Return(deliveryZones []*models.DeliveryZone, err error) *MockZoneServiceInterface_GetZones_Call {
+	_c.Call.Return(deliveryZones, err)
+	return _c
+}
+
+func (_c *MockZoneServiceInterface_GetZones_Call) RunAndReturn(run func(activeOnly bool) ([]*models.DeliveryZone, error)) *MockZoneServiceInterface_GetZones_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateZone provides a mock function for the type MockZoneServiceInterface
+func (_mock *MockZoneServiceInterface) UpdateZone(zoneID uuid.UUID, req *models.DeliveryZoneRequest) (*models.DeliveryZone, error) {
+	ret := _mock.Called(zoneID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateZone")
+	}
+
+	var r0 *models.DeliveryZone
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.DeliveryZoneRequest) (*models.DeliveryZone, error)); ok {
+		return returnFunc(zoneID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.DeliveryZoneRequest) *models.DeliveryZone); ok {
+		r0 = returnFunc(zoneID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeliveryZone)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, *models.DeliveryZoneRequest) error); ok {
+		r1 = returnFunc(zoneID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockZoneServiceInterface_UpdateZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateZone'
+type MockZoneServiceInterface_UpdateZone_Call struct {
+	*mock.Call
+}
+
+// UpdateZone is a helper method to define mock.On call
+//   - zoneID uuid.UUID
+//   - req *models.DeliveryZoneRequest
+func (_e *MockZoneServiceInterface_Expecter) UpdateZone(zoneID interface{}, req interface{}) *MockZoneServiceInterface_UpdateZone_Call {
+	return &MockZoneServiceInterface_UpdateZone_Call{Call: _e.mock.On("UpdateZone", zoneID, req)}
+}
+
+func (_c *MockZoneServiceInterface_UpdateZone_Call) Run(run func(zoneID uuid.UUID, req *models.DeliveryZoneRequest)) *MockZoneServiceInterface_UpdateZone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *models.DeliveryZoneRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.DeliveryZoneRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockZoneServiceInterface_UpdateZone_Call) Return(deliveryZone *models.DeliveryZone, err error) *MockZoneServiceInterface_UpdateZone_Call {
+	_c.Call.Return(deliveryZone, err)
+	return _c
+}
+
+func (_c *MockZoneServiceInterface_UpdateZone_Call) RunAndReturn(run func(zoneID uuid.UUID, req *models.DeliveryZoneRequest) (*models.DeliveryZone, error)) *MockZoneServiceInterface_UpdateZone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockShiftServiceInterface creates a new instance of MockShiftServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockShiftServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockShiftServiceInterface {
+	mock := &MockShiftServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockShiftServiceInterface is an autogenerated mock type for the ShiftServiceInterface type
+type MockShiftServiceInterface struct {
+	mock.Mock
+}
+
+type MockShiftServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockShiftServiceInterface) EXPECT() *MockShiftServiceInterface_Expecter {
+	return &MockShiftServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// CancelShift provides a mock function for the type MockShiftServiceInterface
+func (_mock *MockShiftServiceInterface) CancelShift(shiftID uuid.UUID) error {
+	ret := _mock.Called(shiftID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelShift")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = returnFunc(shiftID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockShiftServiceInterface_CancelShift_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelShift'
+type MockShiftServiceInterface_CancelShift_Call struct {
+	*mock.Call
+}
+
+// CancelShift is a helper method to define mock.On call
+//   - shiftID uuid.UUID
+func (_e *MockShiftServiceInterface_Expecter) CancelShift(shiftID interface{}) *MockShiftServiceInterface_CancelShift_Call {
+	return &MockShiftServiceInterface_CancelShift_Call{Call: _e.mock.On("CancelShift", shiftID)}
+}
+
+func (_c *MockShiftServiceInterface_CancelShift_Call) Run(run func(shiftID uuid.UUID)) *MockShiftServiceInterface_CancelShift_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_CancelShift_Call) Return(err error) *MockShiftServiceInterface_CancelShift_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_CancelShift_Call) RunAndReturn(run func(shiftID uuid.UUID) error) *MockShiftServiceInterface_CancelShift_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EndBreak provides a mock function for the type MockShiftServiceInterface
+func (_mock *MockShiftServiceInterface) EndBreak(courierID uuid.UUID) (*models.CourierShift, error) {
+	ret := _mock.Called(courierID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EndBreak")
+	}
+
+	var r0 *models.CourierShift
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.CourierShift, error)); ok {
+		return returnFunc(courierID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.CourierShift); ok {
+		r0 = returnFunc(courierID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CourierShift)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(courierID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShiftServiceInterface_EndBreak_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EndBreak'
+type MockShiftServiceInterface_EndBreak_Call struct {
+	*mock.Call
+}
+
+// EndBreak is a helper method to define mock.On call
+//   - courierID uuid.UUID
+func (_e *MockShiftServiceInterface_Expecter) EndBreak(courierID interface{}) *MockShiftServiceInterface_EndBreak_Call {
+	return &MockShiftServiceInterface_EndBreak_Call{Call: _e.mock.On("EndBreak", courierID)}
+}
+
+func (_c *MockShiftServiceInterface_EndBreak_Call) Run(run func(courierID uuid.UUID)) *MockShiftServiceInterface_EndBreak_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_EndBreak_Call) Return(courierShift *models.CourierShift, err error) *MockShiftServiceInterface_EndBreak_Call {
+	_c.Call.Return(courierShift, err)
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_EndBreak_Call) RunAndReturn(run func(courierID uuid.UUID) (*models.CourierShift, error)) *MockShiftServiceInterface_EndBreak_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EndShift provides a mock function for the type MockShiftServiceInterface
+func (_mock *MockShiftServiceInterface) EndShift(courierID uuid.UUID) (*models.CourierShift, error) {
+	ret := _mock.Called(courierID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EndShift")
+	}
+
+	var r0 *models.CourierShift
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.CourierShift, error)); ok {
+		return returnFunc(courierID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.CourierShift); ok {
+		r0 = returnFunc(courierID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CourierShift)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(courierID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShiftServiceInterface_EndShift_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EndShift'
+type MockShiftServiceInterface_EndShift_Call struct {
+	*mock.Call
+}
+
+// EndShift is a helper method to define mock.On call
+//   - courierID uuid.UUID
+func (_e *MockShiftServiceInterface_Expecter) EndShift(courierID interface{}) *MockShiftServiceInterface_EndShift_Call {
+	return &MockShiftServiceInterface_EndShift_Call{Call: _e.mock.On("EndShift", courierID)}
+}
+
+func (_c *MockShiftServiceInterface_EndShift_Call) Run(run func(courierID uuid.UUID)) *MockShiftServiceInterface_EndShift_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_EndShift_Call) Return(courierShift *models.CourierShift, err error) *MockShiftServiceInterface_EndShift_Call {
+	_c.Call.Return(courierShift, err)
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_EndShift_Call) RunAndReturn(run func(courierID uuid.UUID) (*models.CourierShift, error)) *MockShiftServiceInterface_EndShift_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCourierShifts provides a mock function for the type MockShiftServiceInterface
+func (_mock *MockShiftServiceInterface) GetCourierShifts(courierID uuid.UUID, from *time.Time, to *time.Time, limit int, offset int) ([]*models.CourierShift, error) {
+	ret := _mock.Called(courierID, from, to, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCourierShifts")
+	}
+
+	var r0 []*models.CourierShift
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *time.Time, *time.Time, int, int) ([]*models.CourierShift, error)); ok {
+		return returnFunc(courierID, from, to, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *time.Time, *time.Time, int, int) []*models.CourierShift); ok {
+		r0 = returnFunc(courierID, from, to, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.CourierShift)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, *time.Time, *time.Time, int, int) error); ok {
+		r1 = returnFunc(courierID, from, to, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShiftServiceInterface_GetCourierShifts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCourierShifts'
+type MockShiftServiceInterface_GetCourierShifts_Call struct {
+	*mock.Call
+}
+
+// GetCourierShifts is a helper method to define mock.On call
+//   - courierID uuid.UUID
+//   - from *time.Time
+//   - to *time.Time
+//   - limit int
+//   - offset int
+func (_e *MockShiftServiceInterface_Expecter) GetCourierShifts(courierID interface{}, from interface{}, to interface{}, limit interface{}, offset interface{}) *MockShiftServiceInterface_GetCourierShifts_Call {
+	return &MockShiftServiceInterface_GetCourierShifts_Call{Call: _e.mock.On("GetCourierShifts", courierID, from, to, limit, offset)}
+}
+
+func (_c *MockShiftServiceInterface_GetCourierShifts_Call) Run(run func(courierID uuid.UUID, from *time.Time, to *time.Time, limit int, offset int)) *MockShiftServiceInterface_GetCourierShifts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *time.Time
+		if args[1] != nil {
+			arg1 = args[1].(*time.Time)
+		}
+		var arg2 *time.Time
+		if args[2] != nil {
+			arg2 = args[2].(*time.Time)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_GetCourierShifts_Call) Return(courierShifts []*models.CourierShift, err error) *MockShiftServiceInterface_GetCourierShifts_Call {
+	_c.Call.Return(courierShifts, err)
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_GetCourierShifts_Call) RunAndReturn(run func(courierID uuid.UUID, from *time.Time, to *time.Time, limit int, offset int) ([]*models.CourierShift, error)) *MockShiftServiceInterface_GetCourierShifts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShift provides a mock function for the type MockShiftServiceInterface
+func (_mock *MockShiftServiceInterface) GetShift(shiftID uuid.UUID) (*models.CourierShift, error) {
+	ret := _mock.Called(shiftID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShift")
+	}
+
+	var r0 *models.CourierShift
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.CourierShift, error)); ok {
+		return returnFunc(shiftID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.CourierShift); ok {
+		r0 = returnFunc(shiftID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CourierShift)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(shiftID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShiftServiceInterface_GetShift_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShift'
+type MockShiftServiceInterface_GetShift_Call struct {
+	*mock.Call
+}
+
+// GetShift is a helper method to define mock.On call
+//   - shiftID uuid.UUID
+func (_e *MockShiftServiceInterface_Expecter) GetShift(shiftID interface{}) *MockShiftServiceInterface_GetShift_Call {
+	return &MockShiftServiceInterface_GetShift_Call{Call: _e.mock.On("GetShift", shiftID)}
+}
+
+func (_c *MockShiftServiceInterface_GetShift_Call) Run(run func(shiftID uuid.UUID)) *MockShiftServiceInterface_GetShift_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_GetShift_Call) Return(courierShift *models.CourierShift, err error) *MockShiftServiceInterface_GetShift_Call {
+	_c.Call.Return(courierShift, err)
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_GetShift_Call) RunAndReturn(run func(shiftID uuid.UUID) (*models.CourierShift, error)) *MockShiftServiceInterface_GetShift_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleShift provides a mock function for the type MockShiftServiceInterface
+func (_mock *MockShiftServiceInterface) ScheduleShift(courierID uuid.UUID, req *models.ScheduleShiftRequest) (*models.CourierShift, error) {
+	ret := _mock.Called(courierID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleShift")
+	}
+
+	var r0 *models.CourierShift
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.ScheduleShiftRequest) (*models.CourierShift, error)); ok {
+		return returnFunc(courierID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.ScheduleShiftRequest) *models.CourierShift); ok {
+		r0 = returnFunc(courierID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CourierShift)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, *models.ScheduleShiftRequest) error); ok {
+		r1 = returnFunc(courierID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShiftServiceInterface_ScheduleShift_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleShift'
+type MockShiftServiceInterface_ScheduleShift_Call struct {
+	*mock.Call
+}
+
+// ScheduleShift is a helper method to define mock.On call
+//   - courierID uuid.UUID
+//   - req *models.ScheduleShiftRequest
+func (_e *MockShiftServiceInterface_Expecter) ScheduleShift(courierID interface{}, req interface{}) *MockShiftServiceInterface_ScheduleShift_Call {
+	return &MockShiftServiceInterface_ScheduleShift_Call{Call: _e.mock.On("ScheduleShift", courierID, req)}
+}
+
+func (_c *MockShiftServiceInterface_ScheduleShift_Call) Run(run func(courierID uuid.UUID, req *models.ScheduleShiftRequest)) *MockShiftServiceInterface_ScheduleShift_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		var arg1 *models.ScheduleShiftRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.ScheduleShiftRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_ScheduleShift_Call) Return(courierShift *models.CourierShift, err error) *MockShiftServiceInterface_ScheduleShift_Call {
+	_c.Call.Return(courierShift, err)
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_ScheduleShift_Call) RunAndReturn(run func(courierID uuid.UUID, req *models.ScheduleShiftRequest) (*models.CourierShift, error)) *MockShiftServiceInterface_ScheduleShift_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartBreak provides a mock function for the type MockShiftServiceInterface
+func (_mock *MockShiftServiceInterface) StartBreak(courierID uuid.UUID) (*models.CourierShift, error) {
+	ret := _mock.Called(courierID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartBreak")
+	}
+
+	var r0 *models.CourierShift
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.CourierShift, error)); ok {
+		return returnFunc(courierID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.CourierShift); ok {
+		r0 = returnFunc(courierID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CourierShift)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(courierID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShiftServiceInterface_StartBreak_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartBreak'
+type MockShiftServiceInterface_StartBreak_Call struct {
+	*mock.Call
+}
+
+// StartBreak is a helper method to define mock.On call
+//   - courierID uuid.UUID
+func (_e *MockShiftServiceInterface_Expecter) StartBreak(courierID interface{}) *MockShiftServiceInterface_StartBreak_Call {
+	return &MockShiftServiceInterface_StartBreak_Call{Call: _e.mock.On("StartBreak", courierID)}
+}
+
+func (_c *MockShiftServiceInterface_StartBreak_Call) Run(run func(courierID uuid.UUID)) *MockShiftServiceInterface_StartBreak_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_StartBreak_Call) Return(courierShift *models.CourierShift, err error) *MockShiftServiceInterface_StartBreak_Call {
+	_c.Call.Return(courierShift, err)
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_StartBreak_Call) RunAndReturn(run func(courierID uuid.UUID) (*models.CourierShift, error)) *MockShiftServiceInterface_StartBreak_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartShift provides a mock function for the type MockShiftServiceInterface
+func (_mock *MockShiftServiceInterface) StartShift(courierID uuid.UUID) (*models.CourierShift, error) {
+	ret := _mock.Called(courierID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartShift")
+	}
+
+	var r0 *models.CourierShift
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.CourierShift, error)); ok {
+		return returnFunc(courierID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.CourierShift); ok {
+		r0 = returnFunc(courierID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CourierShift)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(courierID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockShiftServiceInterface_StartShift_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartShift'
+type MockShiftServiceInterface_StartShift_Call struct {
+	*mock.Call
+}
+
+// StartShift is a helper method to define mock.On call
+//   - courierID uuid.UUID
+func (_e *MockShiftServiceInterface_Expecter) StartShift(courierID interface{}) *MockShiftServiceInterface_StartShift_Call {
+	return &MockShiftServiceInterface_StartShift_Call{Call: _e.mock.On("StartShift", courierID)}
+}
+
+func (_c *MockShiftServiceInterface_StartShift_Call) Run(run func(courierID uuid.UUID)) *MockShiftServiceInterface_StartShift_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_StartShift_Call) Return(courierShift *models.CourierShift, err error) *MockShiftServiceInterface_StartShift_Call {
+	_c.Call.Return(courierShift, err)
+	return _c
+}
+
+func (_c *MockShiftServiceInterface_StartShift_Call) RunAndReturn(run func(courierID uuid.UUID) (*models.CourierShift, error)) *MockShiftServiceInterface_StartShift_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockETAServiceInterface creates a new instance of MockETAServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockETAServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockETAServiceInterface {
+	mock := &MockETAServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockETAServiceInterface is an autogenerated mock type for the ETAServiceInterface type
+type MockETAServiceInterface struct {
+	mock.Mock
+}
+
+type MockETAServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockETAServiceInterface) EXPECT() *MockETAServiceInterface_Expecter {
+	return &MockETAServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// GetAtRiskOrders provides a mock function for the type MockETAServiceInterface
+func (_mock *MockETAServiceInterface) GetAtRiskOrders(limit int) ([]*models.AtRiskOrder, error) {
+	ret := _mock.Called(limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAtRiskOrders")
+	}
+
+	var r0 []*models.AtRiskOrder
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int) ([]*models.AtRiskOrder, error)); ok {
+		return returnFunc(limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int) []*models.AtRiskOrder); ok {
+		r0 = returnFunc(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.AtRiskOrder)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int) error); ok {
+		r1 = returnFunc(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockETAServiceInterface_GetAtRiskOrders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAtRiskOrders'
+type MockETAServiceInterface_GetAtRiskOrders_Call struct {
+	*mock.Call
+}
+
+// GetAtRiskOrders is a helper method to define mock.On call
+//   - limit int
+func (_e *MockETAServiceInterface_Expecter) GetAtRiskOrders(limit interface{}) *MockETAServiceInterface_GetAtRiskOrders_Call {
+	return &MockETAServiceInterface_GetAtRiskOrders_Call{Call: _e.mock.On("GetAtRiskOrders", limit)}
+}
+
+func (_c *MockETAServiceInterface_GetAtRiskOrders_Call) Run(run func(limit int)) *MockETAServiceInterface_GetAtRiskOrders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockETAServiceInterface_GetAtRiskOrders_Call) Return(atRiskOrders []*models.AtRiskOrder, err error) *MockETAServiceInterface_GetAtRiskOrders_Call {
+	_c.Call.Return(atRiskOrders, err)
+	return _c
+}
+
+func (_c *MockETAServiceInterface_GetAtRiskOrders_Call) RunAndReturn(run func(limit int) ([]*models.AtRiskOrder, error)) *MockETAServiceInterface_GetAtRiskOrders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOutboxServiceInterface creates a new instance of MockOutboxServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutboxServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutboxServiceInterface {
+	mock := &MockOutboxServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOutboxServiceInterface is an autogenerated mock type for the OutboxServiceInterface type
+type MockOutboxServiceInterface struct {
+	mock.Mock
+}
+
+type MockOutboxServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOutboxServiceInterface) EXPECT() *MockOutboxServiceInterface_Expecter {
+	return &MockOutboxServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// GetMetrics provides a mock function for the type MockOutboxServiceInterface
+func (_mock *MockOutboxServiceInterface) GetMetrics() (*models.OutboxMetrics, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMetrics")
+	}
+
+	var r0 *models.OutboxMetrics
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() (*models.OutboxMetrics, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() *models.OutboxMetrics); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.OutboxMetrics)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOutboxServiceInterface_GetMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMetrics'
+type MockOutboxServiceInterface_GetMetrics_Call struct {
+	*mock.Call
+}
+
+// GetMetrics is a helper method to define mock.On call
+func (_e *MockOutboxServiceInterface_Expecter) GetMetrics() *MockOutboxServiceInterface_GetMetrics_Call {
+	return &MockOutboxServiceInterface_GetMetrics_Call{Call: _e.mock.On("GetMetrics")}
+}
+
+func (_c *MockOutboxServiceInterface_GetMetrics_Call) Run(run func()) *MockOutboxServiceInterface_GetMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockOutboxServiceInterface_GetMetrics_Call) Return(outboxMetrics *models.OutboxMetrics, err error) *MockOutboxServiceInterface_GetMetrics_Call {
+	_c.Call.Return(outboxMetrics, err)
+	return _c
+}
+
+func (_c *MockOutboxServiceInterface_GetMetrics_Call) RunAndReturn(run func() (*models.OutboxMetrics, error)) *MockOutboxServiceInterface_GetMetrics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAuthServiceInterface creates a new instance of MockAuthServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAuthServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAuthServiceInterface {
+	mock := &MockAuthServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAuthServiceInterface is an autogenerated mock type for the AuthServiceInterface type
+type MockAuthServiceInterface struct {
+	mock.Mock
+}
+
+type MockAuthServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAuthServiceInterface) EXPECT() *MockAuthServiceInterface_Expecter {
+	return &MockAuthServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function for the type MockAuthServiceInterface
+func (_mock *MockAuthServiceInterface) Authenticate(token string) (models.Actor, error) {
+	ret := _mock.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 models.Actor
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (models.Actor, error)); ok {
+		return returnFunc(token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) models.Actor); ok {
+		r0 = returnFunc(token)
+	} else {
+		r0 = ret.Get(0).(models.Actor)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthServiceInterface_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type MockAuthServiceInterface_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - token string
+func (_e *MockAuthServiceInterface_Expecter) Authenticate(token interface{}) *MockAuthServiceInterface_Authenticate_Call {
+	return &MockAuthServiceInterface_Authenticate_Call{Call: _e.mock.On("Authenticate", token)}
+}
+
+func (_c *MockAuthServiceInterface_Authenticate_Call) Run(run func(token string)) *MockAuthServiceInterface_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_Authenticate_Call) Return(actor models.Actor, err error) *MockAuthServiceInterface_Authenticate_Call {
+	_c.Call.Return(actor, err)
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_Authenticate_Call) RunAndReturn(run func(token string) (models.Actor, error)) *MockAuthServiceInterface_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IssueToken provides a mock function for the type MockAuthServiceInterface
+func (_mock *MockAuthServiceInterface) IssueToken(req *models.TokenRequest) (*models.AuthToken, error) {
+	ret := _mock.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueToken")
+	}
+
+	var r0 *models.AuthToken
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*models.TokenRequest) (*models.AuthToken, error)); ok {
+		return returnFunc(req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.TokenRequest) *models.AuthToken); ok {
+		r0 = returnFunc(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthToken)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.TokenRequest) error); ok {
+		r1 = returnFunc(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAuthServiceInterface_IssueToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IssueToken'
+type MockAuthServiceInterface_IssueToken_Call struct {
+	*mock.Call
+}
+
+// IssueToken is a helper method to define mock.On call
+//   - req *models.TokenRequest
+func (_e *MockAuthServiceInterface_Expecter) IssueToken(req interface{}) *MockAuthServiceInterface_IssueToken_Call {
+	return &MockAuthServiceInterface_IssueToken_Call{Call: _e.mock.On("IssueToken", req)}
+}
+
+func (_c *MockAuthServiceInterface_IssueToken_Call) Run(run func(req *models.TokenRequest)) *MockAuthServiceInterface_IssueToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.TokenRequest
+		if args[0] != nil {
+			arg0 = args[0].(*models.TokenRequest)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_IssueToken_Call) Return(authToken *models.AuthToken, err error) *MockAuthServiceInterface_IssueToken_Call {
+	_c.Call.Return(authToken, err)
+	return _c
+}
+
+func (_c *MockAuthServiceInterface_IssueToken_Call) RunAndReturn(run func(req *models.TokenRequest) (*models.AuthToken, error)) *MockAuthServiceInterface_IssueToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockAPIKeyServiceInterface creates a new instance of MockAPIKeyServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAPIKeyServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAPIKeyServiceInterface {
+	mock := &MockAPIKeyServiceInterface{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAPIKeyServiceInterface is an autogenerated mock type for the APIKeyServiceInterface type
+type MockAPIKeyServiceInterface struct {
+	mock.Mock
+}
+
+type MockAPIKeyServiceInterface_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAPIKeyServiceInterface) EXPECT() *MockAPIKeyServiceInterface_Expecter {
+	return &MockAPIKeyServiceInterface_Expecter{mock: &_m.Mock}
+}
+
+// Authenticate provides a mock function for the type MockAPIKeyServiceInterface
+func (_mock *MockAPIKeyServiceInterface) Authenticate(key string) (*models.APIKey, error) {
+	ret := _mock.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Authenticate")
+	}
+
+	var r0 *models.APIKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*models.APIKey, error)); ok {
+		return returnFunc(key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *models.APIKey); ok {
+		r0 = returnFunc(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIKeyServiceInterface_Authenticate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Authenticate'
+type MockAPIKeyServiceInterface_Authenticate_Call struct {
+	*mock.Call
+}
+
+// Authenticate is a helper method to define mock.On call
+//   - key string
+func (_e *MockAPIKeyServiceInterface_Expecter) Authenticate(key interface{}) *MockAPIKeyServiceInterface_Authenticate_Call {
+	return &MockAPIKeyServiceInterface_Authenticate_Call{Call: _e.mock.On("Authenticate", key)}
+}
+
+func (_c *MockAPIKeyServiceInterface_Authenticate_Call) Run(run func(key string)) *MockAPIKeyServiceInterface_Authenticate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyServiceInterface_Authenticate_Call) Return(aPIKey *models.APIKey, err error) *MockAPIKeyServiceInterface_Authenticate_Call {
+	_c.Call.Return(aPIKey, err)
+	return _c
+}
+
+func (_c *MockAPIKeyServiceInterface_Authenticate_Call) RunAndReturn(run func(key string) (*models.APIKey, error)) *MockAPIKeyServiceInterface_Authenticate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAPIKey provides a mock function for the type MockAPIKeyServiceInterface
+func (_mock *MockAPIKeyServiceInterface) GetAPIKey(keyID uuid.UUID) (*models.APIKey, error) {
+	ret := _mock.Called(keyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAPIKey")
+	}
+
+	var r0 *models.APIKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.APIKey, error)); ok {
+		return returnFunc(keyID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.APIKey); ok {
+		r0 = returnFunc(keyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(keyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIKeyServiceInterface_GetAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAPIKey'
+type MockAPIKeyServiceInterface_GetAPIKey_Call struct {
+	*mock.Call
+}
+
+// GetAPIKey is a helper method to define mock.On call
+//   - keyID uuid.UUID
+func (_e *MockAPIKeyServiceInterface_Expecter) GetAPIKey(keyID interface{}) *MockAPIKeyServiceInterface_GetAPIKey_Call {
+	return &MockAPIKeyServiceInterface_GetAPIKey_Call{Call: _e.mock.On("GetAPIKey", keyID)}
+}
+
+func (_c *MockAPIKeyServiceInterface_GetAPIKey_Call) Run(run func(keyID uuid.UUID)) *MockAPIKeyServiceInterface_GetAPIKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 uuid.UUID
+		if args[0] != nil {
+			arg0 = args[0].(uuid.UUID)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAPIKeyServiceInterface_GetAPIKey_Call) Return(aPIKey *models.APIKey, err error) *MockAPIKeyServiceInterface_GetAPIKey_Call {
+	_c.Call.Return(aPIKey, err)
+	return _c
+}
+
+func (_c *MockAPIKeyServiceInterface_GetAPIKey_Call) RunAndReturn(run func(keyID uuid.UUID) (*models.APIKey, error)) *MockAPIKeyServiceInterface_GetAPIKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAPIKeys provides a mock function for the type MockAPIKeyServiceInterface
+func (_mock *MockAPIKeyServiceInterface) GetAPIKeys(owner *models.Actor) ([]*models.APIKey, error) {
+	ret := _mock.Called(owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAPIKeys")
+	}
+
+	var r0 []*models.APIKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(*models.Actor) ([]*models.APIKey, error)); ok {
+		return returnFunc(owner)
+	}
+	if returnFunc, ok := ret.Get(0).(func(*models.Actor) []*models.APIKey); ok {
+		r0 = returnFunc(owner)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.APIKey)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(*models.Actor) error); ok {
+		r1 = returnFunc(owner)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAPIKeyServiceInterface_GetAPIKeys_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAPIKeys'
+type MockAPIKeyServiceInterface_GetAPIKeys_Call struct {
+	*mock.Call
+}
+
+// GetAPIKeys is a helper method to define mock.On call
+//   - owner *models.Actor
+func (_e *MockAPIKeyServiceInterface_Expecter) GetAPIKeys(owner interface{}) *MockAPIKeyServiceInterface_GetAPIKeys_Call {
+	return &MockAPIKeyServiceInterface_GetAPIKeys_Call{Call: _e.mock.On("GetAPIKeys", owner)}
+}
+
+func (_c *MockAPIKeyServiceInterface_GetAPIKeys_Call) Run(run func(owner *models.Actor)) *MockAPIKeyServiceInterface_GetAPIKeys_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *models.Actor
+		if args[0] != nil {
+			arg0 = args[0].(*models.Actor)
 		}
 		run(
 			arg0,
-			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockOrderServiceInterface_GetOrders_Call) Return(orders []*models.Order, err error) *MockOrderServiceInterface_GetOrders_Call {
-	_c.Call.Return(orders, err)
+func (_c *MockAPIKeyServiceInterface_GetAPIKeys_Call) Return(aPIKeys []*models.APIKey, err error) *MockAPIKeyServiceInterface_GetAPIKeys_Call {
+	_c.Call.Return(aPIKeys, err)
 	return _c
 }
 
-func (_c *MockOrderServiceInterface_GetOrders_Call) RunAndReturn(run func(status *models.OrderStatus, courierID *uuid.UUID, limit int, offset int) ([]*models.Order, error)) *MockOrderServiceInterface_GetOrders_Call {
+func (_c *MockAPIKeyServiceInterface_GetAPIKeys_Call) RunAndReturn(run func(owner *models.Actor) ([]*models.APIKey, error)) *MockAPIKeyServiceInterface_GetAPIKeys_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateOrderStatus provides a mock function for the type MockOrderServiceInterface
-func (_mock *MockOrderServiceInterface) UpdateOrderStatus(orderID uuid.UUID, req *models.UpdateOrderStatusRequest) error {
-	ret := _mock.Called(orderID, req)
+// GetUsage provides a mock function for the type MockAPIKeyServiceInterface
+func (_mock *MockAPIKeyServiceInterface) GetUsage(keyID uuid.UUID, days int) (*models.APIKeyUsage, error) {
+	ret := _mock.Called(keyID, days)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateOrderStatus")
+		panic("no return value specified for GetUsage")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.UpdateOrderStatusRequest) error); ok {
-		r0 = returnFunc(orderID, req)
+	var r0 *models.APIKeyUsage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, int) (*models.APIKeyUsage, error)); ok {
+		return returnFunc(keyID, days)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, int) *models.APIKeyUsage); ok {
+		r0 = returnFunc(keyID, days)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKeyUsage)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID, int) error); ok {
+		r1 = returnFunc(keyID, days)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockOrderServiceInterface_UpdateOrderStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOrderStatus'
-type MockOrderServiceInterface_UpdateOrderStatus_Call struct {
+// MockAPIKeyServiceInterface_GetUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUsage'
+type MockAPIKeyServiceInterface_GetUsage_Call struct {
 	*mock.Call
 }
 
-// UpdateOrderStatus is a helper method to define mock.On call
-//   - orderID uuid.UUID
-//   - req *models.UpdateOrderStatusRequest
-func (_e *MockOrderServiceInterface_Expecter) UpdateOrderStatus(orderID interface{}, req interface{}) *MockOrderServiceInterface_UpdateOrderStatus_Call {
-	return &MockOrderServiceInterface_UpdateOrderStatus_Call{Call: _e.mock.On("UpdateOrderStatus", orderID, req)}
+// GetUsage is a helper method to define mock.On call
+//   - keyID uuid.UUID
+//   - days int
+func (_e *MockAPIKeyServiceInterface_Expecter) GetUsage(keyID interface{}, days interface{}) *MockAPIKeyServiceInterface_GetUsage_Call {
+	return &MockAPIKeyServiceInterface_GetUsage_Call{Call: _e.mock.On("GetUsage", keyID, days)}
 }
 
-func (_c *MockOrderServiceInterface_UpdateOrderStatus_Call) Run(run func(orderID uuid.UUID, req *models.UpdateOrderStatusRequest)) *MockOrderServiceInterface_UpdateOrderStatus_Call {
+func (_c *MockAPIKeyServiceInterface_GetUsage_Call) Run(run func(keyID uuid.UUID, days int)) *MockAPIKeyServiceInterface_GetUsage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 uuid.UUID
 		if args[0] != nil {
 			arg0 = args[0].(uuid.UUID)
 		}
-		var arg1 *models.UpdateOrderStatusRequest
+		var arg1 int
 		if args[1] != nil {
-			arg1 = args[1].(*models.UpdateOrderStatusRequest)
+			arg1 = args[1].(int)
 		}
 		run(
 			arg0,
@@ -495,151 +6689,107 @@ func (_c *MockOrderServiceInterface_UpdateOrderStatus_Call) Run(run func(orderID
 	return _c
 }
 
-func (_c *MockOrderServiceInterface_UpdateOrderStatus_Call) Return(err error) *MockOrderServiceInterface_UpdateOrderStatus_Call {
-	_c.Call.Return(err)
+func (_c *MockAPIKeyServiceInterface_GetUsage_Call) Return(aPIKeyUsage *models.APIKeyUsage, err error) *MockAPIKeyServiceInterface_GetUsage_Call {
+	_c.Call.Return(aPIKeyUsage, err)
 	return _c
 }
 
-func (_c *MockOrderServiceInterface_UpdateOrderStatus_Call) RunAndReturn(run func(orderID uuid.UUID, req *models.UpdateOrderStatusRequest) error) *MockOrderServiceInterface_UpdateOrderStatus_Call {
+func (_c *MockAPIKeyServiceInterface_GetUsage_Call) RunAndReturn(run func(keyID uuid.UUID, days int) (*models.APIKeyUsage, error)) *MockAPIKeyServiceInterface_GetUsage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockReviewServiceInterface creates a new instance of MockReviewServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockReviewServiceInterface(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockReviewServiceInterface {
-	mock := &MockReviewServiceInterface{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockReviewServiceInterface is an autogenerated mock type for the ReviewServiceInterface type
-type MockReviewServiceInterface struct {
-	mock.Mock
-}
-
-type MockReviewServiceInterface_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockReviewServiceInterface) EXPECT() *MockReviewServiceInterface_Expecter {
-	return &MockReviewServiceInterface_Expecter{mock: &_m.Mock}
-}
-
-// CreateReview provides a mock function for the type MockReviewServiceInterface
-func (_mock *MockReviewServiceInterface) CreateReview(req *models.CreateReviewRequest, order *models.Order) (*models.Review, error) {
-	ret := _mock.Called(req, order)
+// IssueAPIKey provides a mock function for the type MockAPIKeyServiceInterface
+func (_mock *MockAPIKeyServiceInterface) IssueAPIKey(req *models.APIKeyRequest) (*models.IssuedAPIKey, error) {
+	ret := _mock.Called(req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateReview")
+		panic("no return value specified for IssueAPIKey")
 	}
 
-	var r0 *models.Review
+	var r0 *models.IssuedAPIKey
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*models.CreateReviewRequest, *models.Order) (*models.Review, error)); ok {
-		return returnFunc(req, order)
+	if returnFunc, ok := ret.Get(0).(func(*models.APIKeyRequest) (*models.IssuedAPIKey, error)); ok {
+		return returnFunc(req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*models.CreateReviewRequest, *models.Order) *models.Review); ok {
-		r0 = returnFunc(req, order)
+	if returnFunc, ok := ret.Get(0).(func(*models.APIKeyRequest) *models.IssuedAPIKey); ok {
+		r0 = returnFunc(req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Review)
+			r0 = ret.Get(0).(*models.IssuedAPIKey)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*models.CreateReviewRequest, *models.Order) error); ok {
-		r1 = returnFunc(req, order)
+	if returnFunc, ok := ret.Get(1).(func(*models.APIKeyRequest) error); ok {
+		r1 = returnFunc(req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockReviewServiceInterface_CreateReview_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReview'
-type MockReviewServiceInterface_CreateReview_Call struct {
+// MockAPIKeyServiceInterface_IssueAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IssueAPIKey'
+type MockAPIKeyServiceInterface_IssueAPIKey_Call struct {
 	*mock.Call
 }
 
-// CreateReview is a helper method to define mock.On call
-//   - req *models.CreateReviewRequest
-//   - order *models.Order
-func (_e *MockReviewServiceInterface_Expecter) CreateReview(req interface{}, order interface{}) *MockReviewServiceInterface_CreateReview_Call {
-	return &MockReviewServiceInterface_CreateReview_Call{Call: _e.mock.On("CreateReview", req, order)}
+// IssueAPIKey is a helper method to define mock.On call
+//   - req *models.APIKeyRequest
+func (_e *MockAPIKeyServiceInterface_Expecter) IssueAPIKey(req interface{}) *MockAPIKeyServiceInterface_IssueAPIKey_Call {
+	return &MockAPIKeyServiceInterface_IssueAPIKey_Call{Call: _e.mock.On("IssueAPIKey", req)}
 }
 
-func (_c *MockReviewServiceInterface_CreateReview_Call) Run(run func(req *models.CreateReviewRequest, order *models.Order)) *MockReviewServiceInterface_CreateReview_Call {
+func (_c *MockAPIKeyServiceInterface_IssueAPIKey_Call) Run(run func(req *models.APIKeyRequest)) *MockAPIKeyServiceInterface_IssueAPIKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *models.CreateReviewRequest
+		var arg0 *models.APIKeyRequest
 		if args[0] != nil {
-			arg0 = args[0].(*models.CreateReviewRequest)
-		}
-		var arg1 *models.Order
-		if args[1] != nil {
-			arg1 = args[1].(*models.Order)
+			arg0 = args[0].(*models.APIKeyRequest)
 		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockReviewServiceInterface_CreateReview_Call) Return(review *models.Review, err error) *MockReviewServiceInterface_CreateReview_Call {
-	_c.Call.Return(review, err)
+func (_c *MockAPIKeyServiceInterface_IssueAPIKey_Call) Return(issuedAPIKey *models.IssuedAPIKey, err error) *MockAPIKeyServiceInterface_IssueAPIKey_Call {
+	_c.Call.Return(issuedAPIKey, err)
 	return _c
 }
 
-func (_c *MockReviewServiceInterface_CreateReview_Call) RunAndReturn(run func(req *models.CreateReviewRequest, order *models.Order) (*models.Review, error)) *MockReviewServiceInterface_CreateReview_Call {
+func (_c *MockAPIKeyServiceInterface_IssueAPIKey_Call) RunAndReturn(run func(req *models.APIKeyRequest) (*models.IssuedAPIKey, error)) *MockAPIKeyServiceInterface_IssueAPIKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetReviews provides a mock function for the type MockReviewServiceInterface
-func (_mock *MockReviewServiceInterface) GetReviews(courierID uuid.UUID) ([]*models.Review, error) {
-	ret := _mock.Called(courierID)
+// RevokeAPIKey provides a mock function for the type MockAPIKeyServiceInterface
+func (_mock *MockAPIKeyServiceInterface) RevokeAPIKey(keyID uuid.UUID) error {
+	ret := _mock.Called(keyID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetReviews")
+		panic("no return value specified for RevokeAPIKey")
 	}
 
-	var r0 []*models.Review
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) ([]*models.Review, error)); ok {
-		return returnFunc(courierID)
-	}
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) []*models.Review); ok {
-		r0 = returnFunc(courierID)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Review)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = returnFunc(courierID)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = returnFunc(keyID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockReviewServiceInterface_GetReviews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReviews'
-type MockReviewServiceInterface_GetReviews_Call struct {
+// MockAPIKeyServiceInterface_RevokeAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAPIKey'
+type MockAPIKeyServiceInterface_RevokeAPIKey_Call struct {
 	*mock.Call
 }
 
-// GetReviews is a helper method to define mock.On call
-//   - courierID uuid.UUID
-func (_e *MockReviewServiceInterface_Expecter) GetReviews(courierID interface{}) *MockReviewServiceInterface_GetReviews_Call {
-	return &MockReviewServiceInterface_GetReviews_Call{Call: _e.mock.On("GetReviews", courierID)}
+// RevokeAPIKey is a helper method to define mock.On call
+//   - keyID uuid.UUID
+func (_e *MockAPIKeyServiceInterface_Expecter) RevokeAPIKey(keyID interface{}) *MockAPIKeyServiceInterface_RevokeAPIKey_Call {
+	return &MockAPIKeyServiceInterface_RevokeAPIKey_Call{Call: _e.mock.On("RevokeAPIKey", keyID)}
 }
 
-func (_c *MockReviewServiceInterface_GetReviews_Call) Run(run func(courierID uuid.UUID)) *MockReviewServiceInterface_GetReviews_Call {
+func (_c *MockAPIKeyServiceInterface_RevokeAPIKey_Call) Run(run func(keyID uuid.UUID)) *MockAPIKeyServiceInterface_RevokeAPIKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 uuid.UUID
 		if args[0] != nil {
@@ -652,45 +6802,56 @@ func (_c *MockReviewServiceInterface_GetReviews_Call) Run(run func(courierID uui
 	return _c
 }
 
-func (_c *MockReviewServiceInterface_GetReviews_Call) Return(reviews []*models.Review, err error) *MockReviewServiceInterface_GetReviews_Call {
-	_c.Call.Return(reviews, err)
+func (_c *MockAPIKeyServiceInterface_RevokeAPIKey_Call) Return(err error) *MockAPIKeyServiceInterface_RevokeAPIKey_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockReviewServiceInterface_GetReviews_Call) RunAndReturn(run func(courierID uuid.UUID) ([]*models.Review, error)) *MockReviewServiceInterface_GetReviews_Call {
+func (_c *MockAPIKeyServiceInterface_RevokeAPIKey_Call) RunAndReturn(run func(keyID uuid.UUID) error) *MockAPIKeyServiceInterface_RevokeAPIKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RecalculateRating provides a mock function for the type MockReviewServiceInterface
-func (_mock *MockReviewServiceInterface) RecalculateRating(courierID uuid.UUID) error {
-	ret := _mock.Called(courierID)
+// RotateAPIKey provides a mock function for the type MockAPIKeyServiceInterface
+func (_mock *MockAPIKeyServiceInterface) RotateAPIKey(keyID uuid.UUID) (*models.IssuedAPIKey, error) {
+	ret := _mock.Called(keyID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RecalculateRating")
+		panic("no return value specified for RotateAPIKey")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) error); ok {
-		r0 = returnFunc(courierID)
+	var r0 *models.IssuedAPIKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.IssuedAPIKey, error)); ok {
+		return returnFunc(keyID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.IssuedAPIKey); ok {
+		r0 = returnFunc(keyID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IssuedAPIKey)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = returnFunc(keyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockReviewServiceInterface_RecalculateRating_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecalculateRating'
-type MockReviewServiceInterface_RecalculateRating_Call struct {
+// MockAPIKeyServiceInterface_RotateAPIKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RotateAPIKey'
+type MockAPIKeyServiceInterface_RotateAPIKey_Call struct {
 	*mock.Call
 }
 
-// RecalculateRating is a helper method to define mock.On call
-//   - courierID uuid.UUID
-func (_e *MockReviewServiceInterface_Expecter) RecalculateRating(courierID interface{}) *MockReviewServiceInterface_RecalculateRating_Call {
-	return &MockReviewServiceInterface_RecalculateRating_Call{Call: _e.mock.On("RecalculateRating", courierID)}
+// RotateAPIKey is a helper method to define mock.On call
+//   - keyID uuid.UUID
+func (_e *MockAPIKeyServiceInterface_Expecter) RotateAPIKey(keyID interface{}) *MockAPIKeyServiceInterface_RotateAPIKey_Call {
+	return &MockAPIKeyServiceInterface_RotateAPIKey_Call{Call: _e.mock.On("RotateAPIKey", keyID)}
 }
 
-func (_c *MockReviewServiceInterface_RecalculateRating_Call) Run(run func(courierID uuid.UUID)) *MockReviewServiceInterface_RecalculateRating_Call {
+func (_c *MockAPIKeyServiceInterface_RotateAPIKey_Call) Run(run func(keyID uuid.UUID)) *MockAPIKeyServiceInterface_RotateAPIKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 uuid.UUID
 		if args[0] != nil {
@@ -703,23 +6864,23 @@ func (_c *MockReviewServiceInterface_RecalculateRating_Call) Run(run func(courie
 	return _c
 }
 
-func (_c *MockReviewServiceInterface_RecalculateRating_Call) Return(err error) *MockReviewServiceInterface_RecalculateRating_Call {
-	_c.Call.Return(err)
+func (_c *MockAPIKeyServiceInterface_RotateAPIKey_Call) Return(issuedAPIKey *models.IssuedAPIKey, err error) *MockAPIKeyServiceInterface_RotateAPIKey_Call {
+	_c.Call.Return(issuedAPIKey, err)
 	return _c
 }
 
-func (_c *MockReviewServiceInterface_RecalculateRating_Call) RunAndReturn(run func(courierID uuid.UUID) error) *MockReviewServiceInterface_RecalculateRating_Call {
+func (_c *MockAPIKeyServiceInterface_RotateAPIKey_Call) RunAndReturn(run func(keyID uuid.UUID) (*models.IssuedAPIKey, error)) *MockAPIKeyServiceInterface_RotateAPIKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockCourierServiceInterface creates a new instance of MockCourierServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// NewMockRateLimitServiceInterface creates a new instance of MockRateLimitServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
-func NewMockCourierServiceInterface(t interface {
+func NewMockRateLimitServiceInterface(t interface {
 	mock.TestingT
 	Cleanup(func())
-}) *MockCourierServiceInterface {
-	mock := &MockCourierServiceInterface{}
+}) *MockRateLimitServiceInterface {
+	mock := &MockRateLimitServiceInterface{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -727,120 +6888,116 @@ func NewMockCourierServiceInterface(t interface {
 	return mock
 }
 
-// MockCourierServiceInterface is an autogenerated mock type for the CourierServiceInterface type
-type MockCourierServiceInterface struct {
+// MockRateLimitServiceInterface is an autogenerated mock type for the RateLimitServiceInterface type
+type MockRateLimitServiceInterface struct {
 	mock.Mock
 }
 
-type MockCourierServiceInterface_Expecter struct {
+type MockRateLimitServiceInterface_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockCourierServiceInterface) EXPECT() *MockCourierServiceInterface_Expecter {
-	return &MockCourierServiceInterface_Expecter{mock: &_m.Mock}
+func (_m *MockRateLimitServiceInterface) EXPECT() *MockRateLimitServiceInterface_Expecter {
+	return &MockRateLimitServiceInterface_Expecter{mock: &_m.Mock}
 }
 
-// AssignOrderToCourier provides a mock function for the type MockCourierServiceInterface
-func (_mock *MockCourierServiceInterface) AssignOrderToCourier(orderID uuid.UUID, courierID uuid.UUID) error {
-	ret := _mock.Called(orderID, courierID)
+// Allow provides a mock function for the type MockRateLimitServiceInterface
+func (_mock *MockRateLimitServiceInterface) Allow(client models.RateLimitClient) *models.RateLimitStatus {
+	ret := _mock.Called(client)
 
 	if len(ret) == 0 {
-		panic("no return value specified for AssignOrderToCourier")
+		panic("no return value specified for Allow")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
-		r0 = returnFunc(orderID, courierID)
+	var r0 *models.RateLimitStatus
+	if returnFunc, ok := ret.Get(0).(func(models.RateLimitClient) *models.RateLimitStatus); ok {
+		r0 = returnFunc(client)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RateLimitStatus)
+		}
 	}
 	return r0
 }
 
-// MockCourierServiceInterface_AssignOrderToCourier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignOrderToCourier'
-type MockCourierServiceInterface_AssignOrderToCourier_Call struct {
+// MockRateLimitServiceInterface_Allow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Allow'
+type MockRateLimitServiceInterface_Allow_Call struct {
 	*mock.Call
 }
 
-// AssignOrderToCourier is a helper method to define mock.On call
-//   - orderID uuid.UUID
-//   - courierID uuid.UUID
-func (_e *MockCourierServiceInterface_Expecter) AssignOrderToCourier(orderID interface{}, courierID interface{}) *MockCourierServiceInterface_AssignOrderToCourier_Call {
-	return &MockCourierServiceInterface_AssignOrderToCourier_Call{Call: _e.mock.On("AssignOrderToCourier", orderID, courierID)}
+// Allow is a helper method to define mock.On call
+//   - client models.RateLimitClient
+func (_e *MockRateLimitServiceInterface_Expecter) Allow(client interface{}) *MockRateLimitServiceInterface_Allow_Call {
+	return &MockRateLimitServiceInterface_Allow_Call{Call: _e.mock.On("Allow", client)}
 }
 
-func (_c *MockCourierServiceInterface_AssignOrderToCourier_Call) Run(run func(orderID uuid.UUID, courierID uuid.UUID)) *MockCourierServiceInterface_AssignOrderToCourier_Call {
+func (_c *MockRateLimitServiceInterface_Allow_Call) Run(run func(client models.RateLimitClient)) *MockRateLimitServiceInterface_Allow_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 uuid.UUID
+		var arg0 models.RateLimitClient
 		if args[0] != nil {
-			arg0 = args[0].(uuid.UUID)
-		}
-		var arg1 uuid.UUID
-		if args[1] != nil {
-			arg1 = args[1].(uuid.UUID)
+			arg0 = args[0].(models.RateLimitClient)
 		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockCourierServiceInterface_AssignOrderToCourier_Call) Return(err error) *MockCourierServiceInterface_AssignOrderToCourier_Call {
-	_c.Call.Return(err)
+func (_c *MockRateLimitServiceInterface_Allow_Call) Return(rateLimitStatus *models.RateLimitStatus) *MockRateLimitServiceInterface_Allow_Call {
+	_c.Call.Return(rateLimitStatus)
 	return _c
 }
 
-func (_c *MockCourierServiceInterface_AssignOrderToCourier_Call) RunAndReturn(run func(orderID uuid.UUID, courierID uuid.UUID) error) *MockCourierServiceInterface_AssignOrderToCourier_Call {
+func (_c *MockRateLimitServiceInterface_Allow_Call) RunAndReturn(run func(client models.RateLimitClient) *models.RateLimitStatus) *MockRateLimitServiceInterface_Allow_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateCourier provides a mock function for the type MockCourierServiceInterface
-func (_mock *MockCourierServiceInterface) CreateCourier(req *models.CreateCourierRequest) (*models.Courier, error) {
-	ret := _mock.Called(req)
+// GetStatus provides a mock function for the type MockRateLimitServiceInterface
+func (_mock *MockRateLimitServiceInterface) GetStatus(client models.RateLimitClient) (*models.RateLimitStatus, error) {
+	ret := _mock.Called(client)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateCourier")
+		panic("no return value specified for GetStatus")
 	}
 
-	var r0 *models.Courier
+	var r0 *models.RateLimitStatus
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*models.CreateCourierRequest) (*models.Courier, error)); ok {
-		return returnFunc(req)
+	if returnFunc, ok := ret.Get(0).(func(models.RateLimitClient) (*models.RateLimitStatus, error)); ok {
+		return returnFunc(client)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*models.CreateCourierRequest) *models.Courier); ok {
-		r0 = returnFunc(req)
+	if returnFunc, ok := ret.Get(0).(func(models.RateLimitClient) *models.RateLimitStatus); ok {
+		r0 = returnFunc(client)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Courier)
+			r0 = ret.Get(0).(*models.RateLimitStatus)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*models.CreateCourierRequest) error); ok {
-		r1 = returnFunc(req)
+	if returnFunc, ok := ret.Get(1).(func(models.RateLimitClient) error); ok {
+		r1 = returnFunc(client)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockCourierServiceInterface_CreateCourier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCourier'
-type MockCourierServiceInterface_CreateCourier_Call struct {
+// MockRateLimitServiceInterface_GetStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStatus'
+type MockRateLimitServiceInterface_GetStatus_Call struct {
 	*mock.Call
 }
 
-// CreateCourier is a helper method to define mock.On call
-//   - req *models.CreateCourierRequest
-func (_e *MockCourierServiceInterface_Expecter) CreateCourier(req interface{}) *MockCourierServiceInterface_CreateCourier_Call {
-	return &MockCourierServiceInterface_CreateCourier_Call{Call: _e.mock.On("CreateCourier", req)}
+// GetStatus is a helper method to define mock.On call
+//   - client models.RateLimitClient
+func (_e *MockRateLimitServiceInterface_Expecter) GetStatus(client interface{}) *MockRateLimitServiceInterface_GetStatus_Call {
+	return &MockRateLimitServiceInterface_GetStatus_Call{Call: _e.mock.On("GetStatus", client)}
 }
 
-func (_c *MockCourierServiceInterface_CreateCourier_Call) Run(run func(req *models.CreateCourierRequest)) *MockCourierServiceInterface_CreateCourier_Call {
+func (_c *MockRateLimitServiceInterface_GetStatus_Call) Run(run func(client models.RateLimitClient)) *MockRateLimitServiceInterface_GetStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *models.CreateCourierRequest
+		var arg0 models.RateLimitClient
 		if args[0] != nil {
-			arg0 = args[0].(*models.CreateCourierRequest)
+			arg0 = args[0].(models.RateLimitClient)
 		}
 		run(
 			arg0,
@@ -849,115 +7006,87 @@ func (_c *MockCourierServiceInterface_CreateCourier_Call) Run(run func(req *mode
 	return _c
 }
 
-func (_c *MockCourierServiceInterface_CreateCourier_Call) Return(courier *models.Courier, err error) *MockCourierServiceInterface_CreateCourier_Call {
-	_c.Call.Return(courier, err)
+func (_c *MockRateLimitServiceInterface_GetStatus_Call) Return(rateLimitStatus *models.RateLimitStatus, err error) *MockRateLimitServiceInterface_GetStatus_Call {
+	_c.Call.Return(rateLimitStatus, err)
 	return _c
 }
 
-func (_c *MockCourierServiceInterface_CreateCourier_Call) RunAndReturn(run func(req *models.CreateCourierRequest) (*models.Courier, error)) *MockCourierServiceInterface_CreateCourier_Call {
+func (_c *MockRateLimitServiceInterface_GetStatus_Call) RunAndReturn(run func(client models.RateLimitClient) (*models.RateLimitStatus, error)) *MockRateLimitServiceInterface_GetStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAvailableCouriers provides a mock function for the type MockCourierServiceInterface
-func (_mock *MockCourierServiceInterface) GetAvailableCouriers() ([]*models.Courier, error) {
-	ret := _mock.Called()
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetAvailableCouriers")
-	}
-
-	var r0 []*models.Courier
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() ([]*models.Courier, error)); ok {
-		return returnFunc()
-	}
-	if returnFunc, ok := ret.Get(0).(func() []*models.Courier); ok {
-		r0 = returnFunc()
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Courier)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
-	} else {
-		r1 = ret.Error(1)
-	}
-	return r0, r1
-}
+// NewMockAnalyticsServiceInterface creates a new instance of MockAnalyticsServiceInterface. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAnalyticsServiceInterface(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAnalyticsServiceInterface {
+	mock := &MockAnalyticsServiceInterface{}
+	mock.Mock.Test(t)
 
-// MockCourierServiceInterface_GetAvailableCouriers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAvailableCouriers'
-type MockCourierServiceInterface_GetAvailableCouriers_Call struct {
-	*mock.Call
-}
+	t.Cleanup(func() { mock.AssertExpectations(t) })
 
-// GetAvailableCouriers is a helper method to define mock.On call
-func (_e *MockCourierServiceInterface_Expecter) GetAvailableCouriers() *MockCourierServiceInterface_GetAvailableCouriers_Call {
-	return &MockCourierServiceInterface_GetAvailableCouriers_Call{Call: _e.mock.On("GetAvailableCouriers")}
+	return mock
 }
 
-func (_c *MockCourierServiceInterface_GetAvailableCouriers_Call) Run(run func()) *MockCourierServiceInterface_GetAvailableCouriers_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		run()
-	})
-	return _c
+// MockAnalyticsServiceInterface is an autogenerated mock type for the AnalyticsServiceInterface type
+type MockAnalyticsServiceInterface struct {
+	mock.Mock
 }
 
-func (_c *MockCourierServiceInterface_GetAvailableCouriers_Call) Return(couriers []*models.Courier, err error) *MockCourierServiceInterface_GetAvailableCouriers_Call {
-	_c.Call.Return(couriers, err)
-	return _c
+type MockAnalyticsServiceInterface_Expecter struct {
+	mock *mock.Mock
 }
 
-func (_c *MockCourierServiceInterface_GetAvailableCouriers_Call) RunAndReturn(run func() ([]*models.Courier, error)) *MockCourierServiceInterface_GetAvailableCouriers_Call {
-	_c.Call.Return(run)
-	return _c
+func (_m *MockAnalyticsServiceInterface) EXPECT() *MockAnalyticsServiceInterface_Expecter {
+	return &MockAnalyticsServiceInterface_Expecter{mock: &_m.Mock}
 }
 
-// GetCourier provides a mock function for the type MockCourierServiceInterface
-func (_mock *MockCourierServiceInterface) GetCourier(courierID uuid.UUID) (*models.Courier, error) {
-	ret := _mock.Called(courierID)
+// GetCourierReport provides a mock function for the type MockAnalyticsServiceInterface
+func (_mock *MockAnalyticsServiceInterface) GetCourierReport(filter models.AnalyticsFilter) (*models.CourierReport, error) {
+	ret := _mock.Called(filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCourier")
+		panic("no return value specified for GetCourierReport")
 	}
 
-	var r0 *models.Courier
+	var r0 *models.CourierReport
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*models.Courier, error)); ok {
-		return returnFunc(courierID)
+	if returnFunc, ok := ret.Get(0).(func(models.AnalyticsFilter) (*models.CourierReport, error)); ok {
+		return returnFunc(filter)
 	}
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *models.Courier); ok {
-		r0 = returnFunc(courierID)
+	if returnFunc, ok := ret.Get(0).(func(models.AnalyticsFilter) *models.CourierReport); ok {
+		r0 = returnFunc(filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Courier)
+			r0 = ret.Get(0).(*models.CourierReport)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = returnFunc(courierID)
+	if returnFunc, ok := ret.Get(1).(func(models.AnalyticsFilter) error); ok {
+		r1 = returnFunc(filter)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockCourierServiceInterface_GetCourier_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCourier'
-type MockCourierServiceInterface_GetCourier_Call struct {
+// MockAnalyticsServiceInterface_GetCourierReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCourierReport'
+type MockAnalyticsServiceInterface_GetCourierReport_Call struct {
 	*mock.Call
 }
 
-// GetCourier is a helper method to define mock.On call
-//   - courierID uuid.UUID
-func (_e *MockCourierServiceInterface_Expecter) GetCourier(courierID interface{}) *MockCourierServiceInterface_GetCourier_Call {
-	return &MockCourierServiceInterface_GetCourier_Call{Call: _e.mock.On("GetCourier", courierID)}
+// GetCourierReport is a helper method to define mock.On call
+//   - filter models.AnalyticsFilter
+func (_e *MockAnalyticsServiceInterface_Expecter) GetCourierReport(filter interface{}) *MockAnalyticsServiceInterface_GetCourierReport_Call {
+	return &MockAnalyticsServiceInterface_GetCourierReport_Call{Call: _e.mock.On("GetCourierReport", filter)}
 }
 
-func (_c *MockCourierServiceInterface_GetCourier_Call) Run(run func(courierID uuid.UUID)) *MockCourierServiceInterface_GetCourier_Call {
+func (_c *MockAnalyticsServiceInterface_GetCourierReport_Call) Run(run func(filter models.AnalyticsFilter)) *MockAnalyticsServiceInterface_GetCourierReport_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 uuid.UUID
+		var arg0 models.AnalyticsFilter
 		if args[0] != nil {
-			arg0 = args[0].(uuid.UUID)
+			arg0 = args[0].(models.AnalyticsFilter)
 		}
 		run(
 			arg0,
@@ -966,149 +7095,136 @@ func (_c *MockCourierServiceInterface_GetCourier_Call) Run(run func(courierID uu
 	return _c
 }
 
-func (_c *MockCourierServiceInterface_GetCourier_Call) Return(courier *models.Courier, err error) *MockCourierServiceInterface_GetCourier_Call {
-	_c.Call.Return(courier, err)
+func (_c *MockAnalyticsServiceInterface_GetCourierReport_Call) Return(courierReport *models.CourierReport, err error) *MockAnalyticsServiceInterface_GetCourierReport_Call {
+	_c.Call.Return(courierReport, err)
 	return _c
 }
 
-func (_c *MockCourierServiceInterface_GetCourier_Call) RunAndReturn(run func(courierID uuid.UUID) (*models.Courier, error)) *MockCourierServiceInterface_GetCourier_Call {
+func (_c *MockAnalyticsServiceInterface_GetCourierReport_Call) RunAndReturn(run func(filter models.AnalyticsFilter) (*models.CourierReport, error)) *MockAnalyticsServiceInterface_GetCourierReport_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetCouriers provides a mock function for the type MockCourierServiceInterface
-func (_mock *MockCourierServiceInterface) GetCouriers(status *models.CourierStatus, limit int, offset int, ratingSort bool) ([]*models.Courier, error) {
-	ret := _mock.Called(status, limit, offset, ratingSort)
+// GetSummary provides a mock function for the type MockAnalyticsServiceInterface
+func (_mock *MockAnalyticsServiceInterface) GetSummary(filter models.AnalyticsFilter) (*models.SummaryReport, error) {
+	ret := _mock.Called(filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCouriers")
+		panic("no return value specified for GetSummary")
 	}
 
-	var r0 []*models.Courier
+	var r0 *models.SummaryReport
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(*models.CourierStatus, int, int, bool) ([]*models.Courier, error)); ok {
-		return returnFunc(status, limit, offset, ratingSort)
+	if returnFunc, ok := ret.Get(0).(func(models.AnalyticsFilter) (*models.SummaryReport, error)); ok {
+		return returnFunc(filter)
 	}
-	if returnFunc, ok := ret.Get(0).(func(*models.CourierStatus, int, int, bool) []*models.Courier); ok {
-		r0 = returnFunc(status, limit, offset, ratingSort)
+	if returnFunc, ok := ret.Get(0).(func(models.AnalyticsFilter) *models.SummaryReport); ok {
+		r0 = returnFunc(filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Courier)
+			r0 = ret.Get(0).(*models.SummaryReport)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(*models.CourierStatus, int, int, bool) error); ok {
-		r1 = returnFunc(status, limit, offset, ratingSort)
+	if returnFunc, ok := ret.Get(1).(func(models.AnalyticsFilter) error); ok {
+		r1 = returnFunc(filter)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockCourierServiceInterface_GetCouriers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCouriers'
-type MockCourierServiceInterface_GetCouriers_Call struct {
+// MockAnalyticsServiceInterface_GetSummary_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSummary'
+type MockAnalyticsServiceInterface_GetSummary_Call struct {
 	*mock.Call
 }
 
-// GetCouriers is a helper method to define mock.On call
-//   - status *models.CourierStatus
-//   - limit int
-//   - offset int
-//   - ratingSort bool
-func (_e *MockCourierServiceInterface_Expecter) GetCouriers(status interface{}, limit interface{}, offset interface{}, ratingSort interface{}) *MockCourierServiceInterface_GetCouriers_Call {
-	return &MockCourierServiceInterface_GetCouriers_Call{Call: _e.mock.On("GetCouriers", status, limit, offset, ratingSort)}
+// GetSummary is a helper method to define mock.On call
+//   - filter models.AnalyticsFilter
+func (_e *MockAnalyticsServiceInterface_Expecter) GetSummary(filter interface{}) *MockAnalyticsServiceInterface_GetSummary_Call {
+	return &MockAnalyticsServiceInterface_GetSummary_Call{Call: _e.mock.On("GetSummary", filter)}
 }
 
-func (_c *MockCourierServiceInterface_GetCouriers_Call) Run(run func(status *models.CourierStatus, limit int, offset int, ratingSort bool)) *MockCourierServiceInterface_GetCouriers_Call {
+func (_c *MockAnalyticsServiceInterface_GetSummary_Call) Run(run func(filter models.AnalyticsFilter)) *MockAnalyticsServiceInterface_GetSummary_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *models.CourierStatus
+		var arg0 models.AnalyticsFilter
 		if args[0] != nil {
-			arg0 = args[0].(*models.CourierStatus)
-		}
-		var arg1 int
-		if args[1] != nil {
-			arg1 = args[1].(int)
-		}
-		var arg2 int
-		if args[2] != nil {
-			arg2 = args[2].(int)
-		}
-		var arg3 bool
-		if args[3] != nil {
-			arg3 = args[3].(bool)
+			arg0 = args[0].(models.AnalyticsFilter)
 		}
 		run(
 			arg0,
-			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockCourierServiceInterface_GetCouriers_Call) Return(couriers []*models.Courier, err error) *MockCourierServiceInterface_GetCouriers_Call {
-	_c.Call.Return(couriers, err)
+func (_c *MockAnalyticsServiceInterface_GetSummary_Call) Return(summaryReport *models.SummaryReport, err error) *MockAnalyticsServiceInterface_GetSummary_Call {
+	_c.Call.Return(summaryReport, err)
 	return _c
 }
 
-func (_c *MockCourierServiceInterface_GetCouriers_Call) RunAndReturn(run func(status *models.CourierStatus, limit int, offset int, ratingSort bool) ([]*models.Courier, error)) *MockCourierServiceInterface_GetCouriers_Call {
+func (_c *MockAnalyticsServiceInterface_GetSummary_Call) RunAndReturn(run func(filter models.AnalyticsFilter) (*models.SummaryReport, error)) *MockAnalyticsServiceInterface_GetSummary_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateCourierStatus provides a mock function for the type MockCourierServiceInterface
-func (_mock *MockCourierServiceInterface) UpdateCourierStatus(courierID uuid.UUID, req *models.UpdateCourierStatusRequest) error {
-	ret := _mock.Called(courierID, req)
+// GetTopItems provides a mock function for the type MockAnalyticsServiceInterface
+func (_mock *MockAnalyticsServiceInterface) GetTopItems(filter models.AnalyticsFilter) (*models.TopItemsReport, error) {
+	ret := _mock.Called(filter)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateCourierStatus")
+		panic("no return value specified for GetTopItems")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, *models.UpdateCourierStatusRequest) error); ok {
-		r0 = returnFunc(courierID, req)
+	var r0 *models.TopItemsReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(models.AnalyticsFilter) (*models.TopItemsReport, error)); ok {
+		return returnFunc(filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(models.AnalyticsFilter) *models.TopItemsReport); ok {
+		r0 = returnFunc(filter)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TopItemsReport)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(models.AnalyticsFilter) error); ok {
+		r1 = returnFunc(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockCourierServiceInterface_UpdateCourierStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCourierStatus'
-type MockCourierServiceInterface_UpdateCourierStatus_Call struct {
+// MockAnalyticsServiceInterface_GetTopItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTopItems'
+type MockAnalyticsServiceInterface_GetTopItems_Call struct {
 	*mock.Call
 }
 
-// UpdateCourierStatus is a helper method to define mock.On call
-//   - courierID uuid.UUID
-//   - req *models.UpdateCourierStatusRequest
-func (_e *MockCourierServiceInterface_Expecter) UpdateCourierStatus(courierID interface{}, req interface{}) *MockCourierServiceInterface_UpdateCourierStatus_Call {
-	return &MockCourierServiceInterface_UpdateCourierStatus_Call{Call: _e.mock.On("UpdateCourierStatus", courierID, req)}
+// GetTopItems is a helper method to define mock.On call
+//   - filter models.AnalyticsFilter
+func (_e *MockAnalyticsServiceInterface_Expecter) GetTopItems(filter interface{}) *MockAnalyticsServiceInterface_GetTopItems_Call {
+	return &MockAnalyticsServiceInterface_GetTopItems_Call{Call: _e.mock.On("GetTopItems", filter)}
 }
 
-func (_c *MockCourierServiceInterface_UpdateCourierStatus_Call) Run(run func(courierID uuid.UUID, req *models.UpdateCourierStatusRequest)) *MockCourierServiceInterface_UpdateCourierStatus_Call {
+func (_c *MockAnalyticsServiceInterface_GetTopItems_Call) Run(run func(filter models.AnalyticsFilter)) *MockAnalyticsServiceInterface_GetTopItems_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 uuid.UUID
+		var arg0 models.AnalyticsFilter
 		if args[0] != nil {
-			arg0 = args[0].(uuid.UUID)
-		}
-		var arg1 *models.UpdateCourierStatusRequest
-		if args[1] != nil {
-			arg1 = args[1].(*models.UpdateCourierStatusRequest)
+			arg0 = args[0].(models.AnalyticsFilter)
 		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockCourierServiceInterface_UpdateCourierStatus_Call) Return(err error) *MockCourierServiceInterface_UpdateCourierStatus_Call {
-	_c.Call.Return(err)
+func (_c *MockAnalyticsServiceInterface_GetTopItems_Call) Return(topItemsReport *models.TopItemsReport, err error) *MockAnalyticsServiceInterface_GetTopItems_Call {
+	_c.Call.Return(topItemsReport, err)
 	return _c
 }
 
-func (_c *MockCourierServiceInterface_UpdateCourierStatus_Call) RunAndReturn(run func(courierID uuid.UUID, req *models.UpdateCourierStatusRequest) error) *MockCourierServiceInterface_UpdateCourierStatus_Call {
+func (_c *MockAnalyticsServiceInterface_GetTopItems_Call) RunAndReturn(run func(filter models.AnalyticsFilter) (*models.TopItemsReport, error)) *MockAnalyticsServiceInterface_GetTopItems_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1274,3 +7390,262 @@ func (_c *MockRedisServiceInterface_GetStatistics_Call) RunAndReturn(run func(ct
 	_c.Call.Return(run)
 	return _c
 }
+
+// newMockqueryer creates a new instance of mockqueryer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockqueryer(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockqueryer {
+	mock := &mockqueryer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockqueryer is an autogenerated mock type for the queryer type
+type mockqueryer struct {
+	mock.Mock
+}
+
+type mockqueryer_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockqueryer) EXPECT() *mockqueryer_Expecter {
+	return &mockqueryer_Expecter{mock: &_m.Mock}
+}
+
+// Query provides a mock function for the type mockqueryer
+func (_mock *mockqueryer) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	var tmpRet mock.Arguments
+	if len(args) > 0 {
+		tmpRet = _mock.Called(query, args)
+	} else {
+		tmpRet = _mock.Called(query)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for Query")
+	}
+
+	var r0 *sql.Rows
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, ...interface{}) (*sql.Rows, error)); ok {
+		return returnFunc(query, args...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, ...interface{}) *sql.Rows); ok {
+		r0 = returnFunc(query, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sql.Rows)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, ...interface{}) error); ok {
+		r1 = returnFunc(query, args...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// mockqueryer_Query_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Query'
+type mockqueryer_Query_Call struct {
+	*mock.Call
+}
+
+// Query is a helper method to define mock.On call
+//   - query string
+//   - args ...interface{}
+func (_e *mockqueryer_Expecter) Query(query interface{}, args ...interface{}) *mockqueryer_Query_Call {
+	return &mockqueryer_Query_Call{Call: _e.mock.On("Query",
+		append([]interface{}{query}, args...)...)}
+}
+
+func (_c *mockqueryer_Query_Call) Run(run func(query string, args ...interface{})) *mockqueryer_Query_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 []interface{}
+		var variadicArgs []interface{}
+		if len(args) > 1 {
+			variadicArgs = args[1].([]interface{})
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *mockqueryer_Query_Call) Return(rows *sql.Rows, err error) *mockqueryer_Query_Call {
+	_c.Call.Return(rows, err)
+	return _c
+}
+
+func (_c *mockqueryer_Query_Call) RunAndReturn(run func(query string, args ...interface{}) (*sql.Rows, error)) *mockqueryer_Query_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QueryRow provides a mock function for the type mockqueryer
+func (_mock *mockqueryer) QueryRow(query string, args ...interface{}) *sql.Row {
+	var tmpRet mock.Arguments
+	if len(args) > 0 {
+		tmpRet = _mock.Called(query, args)
+	} else {
+		tmpRet = _mock.Called(query)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryRow")
+	}
+
+	var r0 *sql.Row
+	if returnFunc, ok := ret.Get(0).(func(string, ...interface{}) *sql.Row); ok {
+		r0 = returnFunc(query, args...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sql.Row)
+		}
+	}
+	return r0
+}
+
+// mockqueryer_QueryRow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryRow'
+type mockqueryer_QueryRow_Call struct {
+	*mock.Call
+}
+
+// QueryRow is a helper method to define mock.On call
+//   - query string
+//   - args ...interface{}
+func (_e *mockqueryer_Expecter) QueryRow(query interface{}, args ...interface{}) *mockqueryer_QueryRow_Call {
+	return &mockqueryer_QueryRow_Call{Call: _e.mock.On("QueryRow",
+		append([]interface{}{query}, args...)...)}
+}
+
+func (_c *mockqueryer_QueryRow_Call) Run(run func(query string, args ...interface{})) *mockqueryer_QueryRow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 []interface{}
+		var variadicArgs []interface{}
+		if len(args) > 1 {
+			variadicArgs = args[1].([]interface{})
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *mockqueryer_QueryRow_Call) Return(row *sql.Row) *mockqueryer_QueryRow_Call {
+	_c.Call.Return(row)
+	return _c
+}
+
+func (_c *mockqueryer_QueryRow_Call) RunAndReturn(run func(query string, args ...interface{}) *sql.Row) *mockqueryer_QueryRow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// newMockrowScanner creates a new instance of mockrowScanner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func newMockrowScanner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *mockrowScanner {
+	mock := &mockrowScanner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// mockrowScanner is an autogenerated mock type for the rowScanner type
+type mockrowScanner struct {
+	mock.Mock
+}
+
+type mockrowScanner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *mockrowScanner) EXPECT() *mockrowScanner_Expecter {
+	return &mockrowScanner_Expecter{mock: &_m.Mock}
+}
+
+// Scan provides a mock function for the type mockrowScanner
+func (_mock *mockrowScanner) Scan(dest ...interface{}) error {
+	var tmpRet mock.Arguments
+	if len(dest) > 0 {
+		tmpRet = _mock.Called(dest)
+	} else {
+		tmpRet = _mock.Called()
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for Scan")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(...interface{}) error); ok {
+		r0 = returnFunc(dest...)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// mockrowScanner_Scan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Scan'
+type mockrowScanner_Scan_Call struct {
+	*mock.Call
+}
+
+// Scan is a helper method to define mock.On call
+//   - dest ...interface{}
+func (_e *mockrowScanner_Expecter) Scan(dest ...interface{}) *mockrowScanner_Scan_Call {
+	return &mockrowScanner_Scan_Call{Call: _e.mock.On("Scan",
+		append([]interface{}{}, dest...)...)}
+}
+
+func (_c *mockrowScanner_Scan_Call) Run(run func(dest ...interface{})) *mockrowScanner_Scan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []interface{}
+		var variadicArgs []interface{}
+		if len(args) > 0 {
+			variadicArgs = args[0].([]interface{})
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *mockrowScanner_Scan_Call) Return(err error) *mockrowScanner_Scan_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *mockrowScanner_Scan_Call) RunAndReturn(run func(dest ...interface{}) error) *mockrowScanner_Scan_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,817 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// shiftColumns - список колонок смены в порядке сканирования scanShift
+const shiftColumns = `id, courier_id, status, scheduled_start, scheduled_end, started_at, ended_at,
+	orders_delivered, distance_m, online_seconds, break_seconds, created_at, updated_at`
+
+// ShiftService - сервис смен курьеров. Курьер принимает заказы только во время активной смены
+// и вне перерыва. Фоновый процесс помечает пропущенные смены, закрывает просроченные
+// и переводит в offline курьеров, оставшихся доступными без смены
+type ShiftService struct {
+	db  *database.DB
+	log *logger.Logger
+	cfg *config.ShiftConfig
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewShiftService создаёт новый экземпляр сервиса смен курьеров
+func NewShiftService(db *database.DB, log *logger.Logger, cfg *config.ShiftConfig) *ShiftService {
+	return &ShiftService{
+		db:   db,
+		log:  log,
+		cfg:  cfg,
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+}
+
+// Start запускает периодическую проверку смен
+func (s *ShiftService) Start() {
+	if !s.cfg.Enabled {
+		close(s.done)
+		s.log.Info("Courier shift enforcement is disabled")
+		return
+	}
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(time.Duration(s.cfg.Interval) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				if err := s.Enforce(); err != nil {
+					s.log.WithError(err).Error("Failed to enforce courier shifts")
+				}
+			}
+		}
+	}()
+
+	s.log.Info("Courier shift enforcement started")
+}
+
+// Stop останавливает проверку смен и дожидается завершения текущего прохода
+func (s *ShiftService) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+		s.log.Info("Courier shift enforcement stopped")
+	})
+}
+
+// ScheduleShift планирует смену курьера. Смена не должна пересекаться с другими
+// запланированными или активными сменами курьера
+func (s *ShiftService) ScheduleShift(courierID uuid.UUID, req *models.ScheduleShiftRequest) (*models.CourierShift, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = lockCourier(tx, courierID); err != nil {
+		return nil, err
+	}
+
+	var overlaps bool
+	overlapQuery := `
+		SELECT EXISTS(
+			SELECT 1 FROM courier_shifts
+			WHERE courier_id = $1 AND status IN ($2, $3) AND scheduled_start < $5 AND scheduled_end > $4
+		)`
+	err = tx.QueryRow(overlapQuery, courierID, models.ShiftStatusScheduled, models.ShiftStatusActive,
+		req.ScheduledStart, req.ScheduledEnd).Scan(&overlaps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check shift overlap: %w", err)
+	}
+	if overlaps {
+		return nil, &ShiftError{CourierID: courierID, Reason: "shift overlaps with another shift"}
+	}
+
+	query := `
+		INSERT INTO courier_shifts (id, courier_id, status, scheduled_start, scheduled_end)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + shiftColumns
+
+	shift, err := scanShift(tx.QueryRow(query, uuid.New(), courierID, models.ShiftStatusScheduled,
+		req.ScheduledStart, req.ScheduledEnd))
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule shift: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"shift_id":        shift.ID,
+		"courier_id":      courierID,
+		"scheduled_start": req.ScheduledStart,
+		"scheduled_end":   req.ScheduledEnd,
+	}).Info("Courier shift scheduled")
+
+	return shift, nil
+}
+
+// StartShift начинает смену курьера. Если на текущий момент у курьера есть запланированная смена
+// (с учётом допустимого раннего начала), начинается она, иначе открывается внеплановая смена.
+// Курьер становится доступным для заказов
+func (s *ShiftService) StartShift(courierID uuid.UUID) (*models.CourierShift, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = lockCourier(tx, courierID); err != nil {
+		return nil, err
+	}
+
+	active, err := getActiveShift(tx, courierID)
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, &ShiftError{CourierID: courierID, Reason: "shift is already started"}
+	}
+
+	now := time.Now()
+	earliest := now.Add(time.Duration(s.cfg.EarlyStart) * time.Minute)
+
+	query := `
+		UPDATE courier_shifts
+		SET status = $1, started_at = $2
+		WHERE id = (
+			SELECT id FROM courier_shifts
+			WHERE courier_id = $3 AND status = $4 AND scheduled_start <= $5 AND scheduled_end > $2
+			ORDER BY scheduled_start
+			LIMIT 1
+		)
+		RETURNING ` + shiftColumns
+
+	shift, err := scanShift(tx.QueryRow(query, models.ShiftStatusActive, now, courierID,
+		models.ShiftStatusScheduled, earliest))
+	if err == sql.ErrNoRows {
+		insertQuery := `
+			INSERT INTO courier_shifts (id, courier_id, status, started_at)
+			VALUES ($1, $2, $3, $4)
+			RETURNING ` + shiftColumns
+		shift, err = scanShift(tx.QueryRow(insertQuery, uuid.New(), courierID, models.ShiftStatusActive, now))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to start shift: %w", err)
+	}
+
+	// Курьер, у которого остались заказы с прошлой смены, остаётся занятым
+	courierQuery := `UPDATE couriers SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4`
+	if _, err = tx.Exec(courierQuery, models.CourierStatusAvailable, now, courierID, models.CourierStatusOffline); err != nil {
+		return nil, fmt.Errorf("failed to update courier status: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"shift_id":   shift.ID,
+		"courier_id": courierID,
+		"scheduled":  shift.ScheduledStart != nil,
+	}).Info("Courier shift started")
+
+	return shift, nil
+}
+
+// EndShift завершает активную смену курьера, фиксирует её статистику и переводит курьера в offline.
+// Смену нельзя завершить, пока у курьера есть незавершённые заказы
+func (s *ShiftService) EndShift(courierID uuid.UUID) (*models.CourierShift, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = lockCourier(tx, courierID); err != nil {
+		return nil, err
+	}
+
+	shift, err := getActiveShift(tx, courierID)
+	if err != nil {
+		return nil, err
+	}
+	if shift == nil {
+		return nil, &ShiftError{CourierID: courierID, Reason: "no active shift"}
+	}
+
+	busy, err := hasActiveOrders(tx, courierID)
+	if err != nil {
+		return nil, err
+	}
+	if busy {
+		return nil, &ShiftError{CourierID: courierID, Reason: "courier has active orders"}
+	}
+
+	if err = s.finishShift(tx, shift, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"shift_id":         shift.ID,
+		"courier_id":       courierID,
+		"orders_delivered": shift.Stats.OrdersDelivered,
+		"distance_m":       shift.Stats.DistanceMeters,
+		"online_seconds":   shift.Stats.OnlineSeconds,
+	}).Info("Courier shift ended")
+
+	return shift, nil
+}
+
+// StartBreak начинает перерыв в активной смене. На время перерыва курьер переводится в offline
+// и не получает новых заказов, поэтому перерыв нельзя начать с незавершёнными заказами
+func (s *ShiftService) StartBreak(courierID uuid.UUID) (*models.CourierShift, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = lockCourier(tx, courierID); err != nil {
+		return nil, err
+	}
+
+	shift, err := getActiveShift(tx, courierID)
+	if err != nil {
+		return nil, err
+	}
+	if shift == nil {
+		return nil, &ShiftError{CourierID: courierID, Reason: "no active shift"}
+	}
+
+	busy, err := hasActiveOrders(tx, courierID)
+	if err != nil {
+		return nil, err
+	}
+	if busy {
+		return nil, &ShiftError{CourierID: courierID, Reason: "courier has active orders"}
+	}
+
+	now := time.Now()
+	breakQuery := `INSERT INTO courier_shift_breaks (id, shift_id, started_at) VALUES ($1, $2, $3)`
+	if _, err = tx.Exec(breakQuery, uuid.New(), shift.ID, now); err != nil {
+		if isUniqueViolation(err) {
+			return nil, &ShiftError{CourierID: courierID, Reason: "break is already started"}
+		}
+		return nil, fmt.Errorf("failed to start break: %w", err)
+	}
+
+	courierQuery := `UPDATE couriers SET status = $1, updated_at = $2 WHERE id = $3`
+	if _, err = tx.Exec(courierQuery, models.CourierStatusOffline, now, courierID); err != nil {
+		return nil, fmt.Errorf("failed to update courier status: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"shift_id":   shift.ID,
+		"courier_id": courierID,
+	}).Info("Courier break started")
+
+	return s.GetShift(shift.ID)
+}
+
+// EndBreak завершает перерыв и возвращает курьера в статус available
+func (s *ShiftService) EndBreak(courierID uuid.UUID) (*models.CourierShift, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err = lockCourier(tx, courierID); err != nil {
+		return nil, err
+	}
+
+	shift, err := getActiveShift(tx, courierID)
+	if err != nil {
+		return nil, err
+	}
+	if shift == nil {
+		return nil, &ShiftError{CourierID: courierID, Reason: "no active shift"}
+	}
+
+	now := time.Now()
+	result, err := tx.Exec(`UPDATE courier_shift_breaks SET ended_at = $1 WHERE shift_id = $2 AND ended_at IS NULL`,
+		now, shift.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to end break: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return nil, &ShiftError{CourierID: courierID, Reason: "no break in progress"}
+	}
+
+	courierQuery := `UPDATE couriers SET status = $1, updated_at = $2 WHERE id = $3`
+	if _, err = tx.Exec(courierQuery, models.CourierStatusAvailable, now, courierID); err != nil {
+		return nil, fmt.Errorf("failed to update courier status: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"shift_id":   shift.ID,
+		"courier_id": courierID,
+	}).Info("Courier break ended")
+
+	return s.GetShift(shift.ID)
+}
+
+// CancelShift отменяет запланированную смену
+func (s *ShiftService) CancelShift(shiftID uuid.UUID) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var courierID uuid.UUID
+	var status models.ShiftStatus
+	err = tx.QueryRow(`SELECT courier_id, status FROM courier_shifts WHERE id = $1 FOR UPDATE`, shiftID).
+		Scan(&courierID, &status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("shift not found")
+		}
+		return fmt.Errorf("failed to get shift: %w", err)
+	}
+	if status != models.ShiftStatusScheduled {
+		return &ShiftError{CourierID: courierID, Reason: fmt.Sprintf("shift is %s, only scheduled shifts can be cancelled", status)}
+	}
+
+	if _, err = tx.Exec(`UPDATE courier_shifts SET status = $1 WHERE id = $2`, models.ShiftStatusCancelled, shiftID); err != nil {
+		return fmt.Errorf("failed to cancel shift: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"shift_id":   shiftID,
+		"courier_id": courierID,
+	}).Info("Courier shift cancelled")
+
+	return nil
+}
+
+// GetShift получает смену по ID. Статистика активной смены рассчитывается на текущий момент
+func (s *ShiftService) GetShift(shiftID uuid.UUID) (*models.CourierShift, error) {
+	shift, err := scanShift(s.db.QueryRow(`SELECT `+shiftColumns+` FROM courier_shifts WHERE id = $1`, shiftID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("shift not found")
+		}
+		return nil, fmt.Errorf("failed to get shift: %w", err)
+	}
+
+	if err = s.loadDetails([]*models.CourierShift{shift}); err != nil {
+		return nil, err
+	}
+
+	return shift, nil
+}
+
+// GetCourierShifts получает смены курьера, начавшиеся или запланированные в интервале [from, to)
+func (s *ShiftService) GetCourierShifts(courierID uuid.UUID, from, to *time.Time, limit, offset int) ([]*models.CourierShift, error) {
+	query := `SELECT ` + shiftColumns + ` FROM courier_shifts WHERE courier_id = $1`
+	args := []interface{}{courierID}
+	argIndex := 2
+
+	if from != nil {
+		query += fmt.Sprintf(" AND COALESCE(started_at, scheduled_start) >= $%d", argIndex)
+		args = append(args, *from)
+		argIndex++
+	}
+	if to != nil {
+		query += fmt.Sprintf(" AND COALESCE(started_at, scheduled_start) < $%d", argIndex)
+		args = append(args, *to)
+		argIndex++
+	}
+
+	query += " ORDER BY COALESCE(started_at, scheduled_start) DESC"
+
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, limit)
+		argIndex++
+	}
+	if offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, offset)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shifts: %w", err)
+	}
+	defer rows.Close()
+
+	var shifts []*models.CourierShift
+	for rows.Next() {
+		shift, err := scanShift(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan shift: %w", err)
+		}
+		shifts = append(shifts, shift)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get shifts: %w", err)
+	}
+
+	if err = s.loadDetails(shifts); err != nil {
+		return nil, err
+	}
+
+	return shifts, nil
+}
+
+// CheckOnDuty проверяет, что курьер находится на активной смене и не на перерыве.
+// Если контроль смен отключён, проверка всегда проходит
+func (s *ShiftService) CheckOnDuty(courierID uuid.UUID) error {
+	if !s.cfg.Enabled {
+		return nil
+	}
+
+	query := `
+		SELECT
+			EXISTS(SELECT 1 FROM courier_shifts WHERE courier_id = $1 AND status = $2),
+			EXISTS(
+				SELECT 1 FROM courier_shift_breaks b
+				JOIN courier_shifts cs ON cs.id = b.shift_id
+				WHERE cs.courier_id = $1 AND cs.status = $2 AND b.ended_at IS NULL
+			)`
+
+	var onShift, onBreak bool
+	if err := s.db.QueryRow(query, courierID, models.ShiftStatusActive).Scan(&onShift, &onBreak); err != nil {
+		return fmt.Errorf("failed to check courier shift: %w", err)
+	}
+	if !onShift {
+		return &ShiftError{CourierID: courierID, Reason: "courier is not on shift"}
+	}
+	if onBreak {
+		return &ShiftError{CourierID: courierID, Reason: "courier is on break"}
+	}
+
+	return nil
+}
+
+// Enforce помечает пропущенными запланированные смены, которые так и не начались, завершает
+// активные смены, просроченные дольше допустимого и без незавершённых заказов, и переводит в offline
+// доступных курьеров вне смены. Проверку выполняет только один инстанс сервиса: остальные пропускают проход
+func (s *ShiftService) Enforce() error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err = tx.QueryRow(`SELECT pg_try_advisory_xact_lock(hashtext('courier_shifts'))`).Scan(&locked); err != nil {
+		return fmt.Errorf("failed to lock courier shifts: %w", err)
+	}
+	if !locked {
+		return nil
+	}
+
+	now := time.Now()
+
+	result, err := tx.Exec(`UPDATE courier_shifts SET status = $1 WHERE status = $2 AND scheduled_end <= $3`,
+		models.ShiftStatusMissed, models.ShiftStatusScheduled, now)
+	if err != nil {
+		return fmt.Errorf("failed to mark missed shifts: %w", err)
+	}
+	if missed, _ := result.RowsAffected(); missed > 0 {
+		s.log.WithField("count", missed).Info("Courier shifts marked as missed")
+	}
+
+	overdueQuery := `
+		SELECT ` + shiftColumns + `
+		FROM courier_shifts cs
+		WHERE status = $1 AND scheduled_end <= $2
+		  AND NOT EXISTS (SELECT 1 FROM orders o WHERE o.courier_id = cs.courier_id AND o.status = ANY($3))
+		FOR UPDATE SKIP LOCKED`
+
+	rows, err := tx.Query(overdueQuery, models.ShiftStatusActive,
+		now.Add(-time.Duration(s.cfg.AutoEndGrace)*time.Minute), pq.Array(activeOrderStatuses))
+	if err != nil {
+		return fmt.Errorf("failed to get overdue shifts: %w", err)
+	}
+	var overdue []*models.CourierShift
+	for rows.Next() {
+		shift, err := scanShift(rows)
+		if err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan shift: %w", err)
+		}
+		overdue = append(overdue, shift)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to get overdue shifts: %w", err)
+	}
+
+	for _, shift := range overdue {
+		if err = s.finishShift(tx, shift, now); err != nil {
+			return err
+		}
+		s.log.WithFields(map[string]interface{}{
+			"shift_id":   shift.ID,
+			"courier_id": shift.CourierID,
+		}).Info("Overdue courier shift ended automatically")
+	}
+
+	offlineQuery := `
+		UPDATE couriers c
+		SET status = $1, updated_at = $2
+		WHERE c.status = $3 AND NOT EXISTS (
+			SELECT 1 FROM courier_shifts cs
+			WHERE cs.courier_id = c.id AND cs.status = $4
+			  AND NOT EXISTS (SELECT 1 FROM courier_shift_breaks b WHERE b.shift_id = cs.id AND b.ended_at IS NULL)
+		)`
+	result, err = tx.Exec(offlineQuery, models.CourierStatusOffline, now, models.CourierStatusAvailable,
+		models.ShiftStatusActive)
+	if err != nil {
+		return fmt.Errorf("failed to set off-shift couriers offline: %w", err)
+	}
+	if offline, _ := result.RowsAffected(); offline > 0 {
+		s.log.WithField("count", offline).Info("Off-shift couriers set offline")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// finishShift закрывает незавершённый перерыв, фиксирует статистику смены на момент endedAt
+// и переводит курьера в offline
+func (s *ShiftService) finishShift(tx *sql.Tx, shift *models.CourierShift, endedAt time.Time) error {
+	if _, err := tx.Exec(`UPDATE courier_shift_breaks SET ended_at = $1 WHERE shift_id = $2 AND ended_at IS NULL`,
+		endedAt, shift.ID); err != nil {
+		return fmt.Errorf("failed to end break: %w", err)
+	}
+
+	stats, err := calculateShiftStats(tx, shift, endedAt)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE courier_shifts
+		SET status = $1, ended_at = $2, orders_delivered = $3, distance_m = $4, online_seconds = $5, break_seconds = $6
+		WHERE id = $7`
+	_, err = tx.Exec(query, models.ShiftStatusCompleted, endedAt, stats.OrdersDelivered, stats.DistanceMeters,
+		stats.OnlineSeconds, stats.BreakSeconds, shift.ID)
+	if err != nil {
+		return fmt.Errorf("failed to end shift: %w", err)
+	}
+
+	courierQuery := `UPDATE couriers SET status = $1, updated_at = $2 WHERE id = $3`
+	if _, err = tx.Exec(courierQuery, models.CourierStatusOffline, endedAt, shift.CourierID); err != nil {
+		return fmt.Errorf("failed to update courier status: %w", err)
+	}
+
+	shift.Status = models.ShiftStatusCompleted
+	shift.EndedAt = &endedAt
+	shift.Stats = stats
+	shift.Breaks, err = getShiftBreaks(tx, shift.ID)
+	return err
+}
+
+// loadDetails загружает перерывы смен и рассчитывает текущую статистику активных смен
+func (s *ShiftService) loadDetails(shifts []*models.CourierShift) error {
+	if len(shifts) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(shifts))
+	byID := make(map[uuid.UUID]*models.CourierShift, len(shifts))
+	for _, shift := range shifts {
+		shift.Breaks = []models.ShiftBreak{}
+		ids = append(ids, shift.ID.String())
+		byID[shift.ID] = shift
+	}
+
+	query := `
+		SELECT id, shift_id, started_at, ended_at
+		FROM courier_shift_breaks
+		WHERE shift_id = ANY($1::uuid[])
+		ORDER BY started_at`
+
+	rows, err := s.db.Query(query, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to get shift breaks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b models.ShiftBreak
+		var shiftID uuid.UUID
+		if err := rows.Scan(&b.ID, &shiftID, &b.StartedAt, &b.EndedAt); err != nil {
+			return fmt.Errorf("failed to scan shift break: %w", err)
+		}
+		if shift, ok := byID[shiftID]; ok {
+			shift.Breaks = append(shift.Breaks, b)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("failed to get shift breaks: %w", err)
+	}
+
+	now := time.Now()
+	for _, shift := range shifts {
+		if shift.Status != models.ShiftStatusActive {
+			continue
+		}
+		if shift.Stats, err = calculateShiftStats(s.db, shift, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// calculateShiftStats рассчитывает статистику смены от её начала до момента until: доставленные
+// за это время заказы, пройденное по истории координат расстояние и время на линии без перерывов
+func calculateShiftStats(q queryer, shift *models.CourierShift, until time.Time) (*models.ShiftStats, error) {
+	stats := &models.ShiftStats{}
+	if shift.StartedAt == nil {
+		return stats, nil
+	}
+	startedAt := *shift.StartedAt
+
+	ordersQuery := `
+		SELECT COUNT(*) FROM orders
+		WHERE courier_id = $1 AND status = $2 AND delivered_at >= $3 AND delivered_at <= $4`
+	err := q.QueryRow(ordersQuery, shift.CourierID, models.OrderStatusDelivered, startedAt, until).
+		Scan(&stats.OrdersDelivered)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count delivered orders: %w", err)
+	}
+
+	locationsQuery := `
+		SELECT lat, lon FROM courier_locations
+		WHERE courier_id = $1 AND recorded_at >= $2 AND recorded_at <= $3
+		ORDER BY recorded_at`
+	rows, err := q.Query(locationsQuery, shift.CourierID, startedAt, until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get courier track: %w", err)
+	}
+	defer rows.Close()
+
+	first := true
+	var prevLat, prevLon float64
+	for rows.Next() {
+		var lat, lon float64
+		if err := rows.Scan(&lat, &lon); err != nil {
+			return nil, fmt.Errorf("failed to scan courier location: %w", err)
+		}
+		if !first {
+			stats.DistanceMeters += haversineDistance(prevLat, prevLon, lat, lon)
+		}
+		prevLat, prevLon, first = lat, lon, false
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get courier track: %w", err)
+	}
+
+	var breakSeconds float64
+	breaksQuery := `
+		SELECT COALESCE(SUM(EXTRACT(EPOCH FROM (COALESCE(ended_at, $2) - started_at))), 0)
+		FROM courier_shift_breaks
+		WHERE shift_id = $1`
+	if err = q.QueryRow(breaksQuery, shift.ID, until).Scan(&breakSeconds); err != nil {
+		return nil, fmt.Errorf("failed to sum shift breaks: %w", err)
+	}
+
+	stats.BreakSeconds = int64(breakSeconds)
+	stats.OnlineSeconds = int64(until.Sub(startedAt).Seconds()) - stats.BreakSeconds
+	if stats.OnlineSeconds < 0 {
+		stats.OnlineSeconds = 0
+	}
+
+	return stats, nil
+}
+
+// lockCourier блокирует строку курьера до конца транзакции, чтобы действия со сменами
+// одного курьера выполнялись последовательно
+func lockCourier(tx *sql.Tx, courierID uuid.UUID) error {
+	var id uuid.UUID
+	err := tx.QueryRow(`SELECT id FROM couriers WHERE id = $1 FOR UPDATE`, courierID).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("courier not found")
+		}
+		return fmt.Errorf("failed to lock courier: %w", err)
+	}
+	return nil
+}
+
+// getActiveShift возвращает активную смену курьера или nil, если курьер не на смене
+func getActiveShift(tx *sql.Tx, courierID uuid.UUID) (*models.CourierShift, error) {
+	query := `SELECT ` + shiftColumns + ` FROM courier_shifts WHERE courier_id = $1 AND status = $2 FOR UPDATE`
+	shift, err := scanShift(tx.QueryRow(query, courierID, models.ShiftStatusActive))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get active shift: %w", err)
+	}
+	return shift, nil
+}
+
+// getShiftBreaks получает перерывы смены в порядке начала
+func getShiftBreaks(q queryer, shiftID uuid.UUID) ([]models.ShiftBreak, error) {
+	rows, err := q.Query(`SELECT id, started_at, ended_at FROM courier_shift_breaks WHERE shift_id = $1 ORDER BY started_at`, shiftID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shift breaks: %w", err)
+	}
+	defer rows.Close()
+
+	breaks := []models.ShiftBreak{}
+	for rows.Next() {
+		var b models.ShiftBreak
+		if err := rows.Scan(&b.ID, &b.StartedAt, &b.EndedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan shift break: %w", err)
+		}
+		breaks = append(breaks, b)
+	}
+
+	return breaks, rows.Err()
+}
+
+// hasActiveOrders проверяет, есть ли у курьера незавершённые заказы
+func hasActiveOrders(q queryer, courierID uuid.UUID) (bool, error) {
+	var exists bool
+	err := q.QueryRow(`SELECT EXISTS(SELECT 1 FROM orders WHERE courier_id = $1 AND status = ANY($2))`,
+		courierID, pq.Array(activeOrderStatuses)).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check active orders: %w", err)
+	}
+	return exists, nil
+}
+
+func scanShift(row rowScanner) (*models.CourierShift, error) {
+	shift := &models.CourierShift{Breaks: []models.ShiftBreak{}}
+	var ordersDelivered, onlineSeconds, breakSeconds sql.NullInt64
+	var distance sql.NullFloat64
+	err := row.Scan(&shift.ID, &shift.CourierID, &shift.Status, &shift.ScheduledStart, &shift.ScheduledEnd,
+		&shift.StartedAt, &shift.EndedAt, &ordersDelivered, &distance, &onlineSeconds, &breakSeconds,
+		&shift.CreatedAt, &shift.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if ordersDelivered.Valid {
+		shift.Stats = &models.ShiftStats{
+			OrdersDelivered: int(ordersDelivered.Int64),
+			DistanceMeters:  distance.Float64,
+			OnlineSeconds:   onlineSeconds.Int64,
+			BreakSeconds:    breakSeconds.Int64,
+		}
+	}
+
+	return shift, nil
+}
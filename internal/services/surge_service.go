@@ -0,0 +1,330 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+)
+
+// SurgeService - сервис динамического коэффициента стоимости доставки. Фоновый процесс периодически
+// сравнивает число открытых заказов зоны с числом доступных курьеров и плавно подводит коэффициент
+// зоны к целевому значению. Каждое изменение коэффициента публикуется в Kafka через outbox
+type SurgeService struct {
+	db     *database.DB
+	log    *logger.Logger
+	outbox *OutboxService
+	cfg    *config.SurgeConfig
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewSurgeService создаёт новый экземпляр сервиса динамического коэффициента
+func NewSurgeService(db *database.DB, log *logger.Logger, outbox *OutboxService, cfg *config.SurgeConfig) *SurgeService {
+	return &SurgeService{
+		db:     db,
+		log:    log,
+		outbox: outbox,
+		cfg:    cfg,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// Start запускает периодический пересчёт коэффициентов
+func (s *SurgeService) Start() {
+	if !s.cfg.Enabled {
+		close(s.done)
+		s.log.Info("Surge pricing is disabled")
+		return
+	}
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(time.Duration(s.cfg.Interval) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				if err := s.Recalculate(); err != nil {
+					s.log.WithError(err).Error("Failed to recalculate surge multipliers")
+				}
+			}
+		}
+	}()
+
+	s.log.Info("Surge pricing started")
+}
+
+// Stop останавливает пересчёт и дожидается завершения текущего прохода
+func (s *SurgeService) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+		s.log.Info("Surge pricing stopped")
+	})
+}
+
+// Recalculate пересчитывает коэффициенты всех зон с открытыми заказами или повышенным коэффициентом.
+// Открытыми считаются заказы в статусах created и ready - ожидающие курьера.
+// Пересчёт выполняет только один инстанс сервиса: остальные пропускают проход
+func (s *SurgeService) Recalculate() error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var locked bool
+	if err = tx.QueryRow(`SELECT pg_try_advisory_xact_lock(hashtext('surge_multipliers'))`).Scan(&locked); err != nil {
+		return fmt.Errorf("failed to lock surge multipliers: %w", err)
+	}
+	if !locked {
+		return nil
+	}
+
+	supply, err := s.countAvailableCouriers(tx)
+	if err != nil {
+		return err
+	}
+
+	demand, err := s.countOpenOrders(tx)
+	if err != nil {
+		return err
+	}
+
+	current, err := s.getMultipliers(tx)
+	if err != nil {
+		return err
+	}
+
+	zones := make(map[string]struct{}, len(demand)+len(current))
+	for zone := range demand {
+		zones[zone] = struct{}{}
+	}
+	for zone := range current {
+		zones[zone] = struct{}{}
+	}
+
+	now := time.Now()
+	for zone := range zones {
+		previous := 1.0
+		if multiplier, ok := current[zone]; ok {
+			previous = multiplier
+		}
+		couriers := supply(zone)
+		target := s.target(demand[zone], couriers)
+		next := s.smooth(previous, target)
+
+		query := `
+			INSERT INTO surge_multipliers (zone, multiplier, target_multiplier, open_orders, available_couriers, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (zone) DO UPDATE
+			SET multiplier = EXCLUDED.multiplier, target_multiplier = EXCLUDED.target_multiplier,
+			    open_orders = EXCLUDED.open_orders, available_couriers = EXCLUDED.available_couriers,
+			    updated_at = EXCLUDED.updated_at
+		`
+		if _, err = tx.Exec(query, zone, next, target, demand[zone], couriers, now); err != nil {
+			return fmt.Errorf("failed to save surge multiplier: %w", err)
+		}
+
+		if next == previous {
+			continue
+		}
+
+		change := &models.SurgeChangedEvent{
+			Zone:              zone,
+			OldMultiplier:     previous,
+			NewMultiplier:     next,
+			TargetMultiplier:  target,
+			OpenOrders:        demand[zone],
+			AvailableCouriers: couriers,
+			Timestamp:         now,
+		}
+		if err = s.outbox.EnqueueSurgeChanged(tx, change); err != nil {
+			return err
+		}
+
+		s.log.WithFields(map[string]interface{}{
+			"zone":               zone,
+			"old_multiplier":     previous,
+			"new_multiplier":     next,
+			"open_orders":        demand[zone],
+			"available_couriers": couriers,
+		}).Info("Surge multiplier changed")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetMultiplier возвращает текущий коэффициент зоны. Для зоны без коэффициента и при выключенном
+// динамическом ценообразовании возвращается 1
+func (s *SurgeService) GetMultiplier(zone string) (float64, error) {
+	if !s.cfg.Enabled {
+		return 1, nil
+	}
+
+	var multiplier float64
+	err := s.db.QueryRow(`SELECT multiplier FROM surge_multipliers WHERE zone = $1`, zone).Scan(&multiplier)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 1, nil
+		}
+		return 0, fmt.Errorf("failed to get surge multiplier: %w", err)
+	}
+
+	return multiplier, nil
+}
+
+// GetSurgeMultipliers возвращает текущие коэффициенты всех зон
+func (s *SurgeService) GetSurgeMultipliers() ([]*models.SurgeMultiplier, error) {
+	query := `
+		SELECT zone, multiplier, target_multiplier, open_orders, available_couriers, updated_at
+		FROM surge_multipliers
+		ORDER BY multiplier DESC, zone
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get surge multipliers: %w", err)
+	}
+	defer rows.Close()
+
+	var multipliers []*models.SurgeMultiplier
+	for rows.Next() {
+		m := &models.SurgeMultiplier{}
+		if err := rows.Scan(&m.Zone, &m.Multiplier, &m.TargetMultiplier, &m.OpenOrders,
+			&m.AvailableCouriers, &m.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan surge multiplier: %w", err)
+		}
+		multipliers = append(multipliers, m)
+	}
+
+	return multipliers, nil
+}
+
+// countAvailableCouriers считает доступных курьеров и возвращает функцию предложения по зоне.
+// Курьеры без зоны работают во всех зонах, поэтому учитываются в каждой из них, а для заказов
+// без зоны учитываются все доступные курьеры
+func (s *SurgeService) countAvailableCouriers(tx *sql.Tx) (func(zone string) int, error) {
+	query := `
+		SELECT COALESCE(zone, ''), COUNT(*)
+		FROM couriers
+		WHERE status = $1
+		GROUP BY 1
+	`
+	rows, err := tx.Query(query, models.CourierStatusAvailable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count available couriers: %w", err)
+	}
+	defer rows.Close()
+
+	byZone := make(map[string]int)
+	total := 0
+	for rows.Next() {
+		var zone string
+		var count int
+		if err := rows.Scan(&zone, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan available couriers: %w", err)
+		}
+		byZone[zone] = count
+		total += count
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count available couriers: %w", err)
+	}
+
+	return func(zone string) int {
+		if zone == "" {
+			return total
+		}
+		return byZone[zone] + byZone[""]
+	}, nil
+}
+
+// countOpenOrders считает заказы, ожидающие курьера, по зонам
+func (s *SurgeService) countOpenOrders(tx *sql.Tx) (map[string]int, error) {
+	query := `
+		SELECT COALESCE(zone, ''), COUNT(*)
+		FROM orders
+		WHERE status IN ($1, $2)
+		GROUP BY 1
+	`
+	rows, err := tx.Query(query, models.OrderStatusCreated, models.OrderStatusReady)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count open orders: %w", err)
+	}
+	defer rows.Close()
+
+	demand := make(map[string]int)
+	for rows.Next() {
+		var zone string
+		var count int
+		if err := rows.Scan(&zone, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan open orders: %w", err)
+		}
+		demand[zone] = count
+	}
+
+	return demand, rows.Err()
+}
+
+// getMultipliers блокирует и возвращает текущие коэффициенты зон
+func (s *SurgeService) getMultipliers(tx *sql.Tx) (map[string]float64, error) {
+	rows, err := tx.Query(`SELECT zone, multiplier FROM surge_multipliers FOR UPDATE`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get surge multipliers: %w", err)
+	}
+	defer rows.Close()
+
+	multipliers := make(map[string]float64)
+	for rows.Next() {
+		var zone string
+		var multiplier float64
+		if err := rows.Scan(&zone, &multiplier); err != nil {
+			return nil, fmt.Errorf("failed to scan surge multiplier: %w", err)
+		}
+		multipliers[zone] = multiplier
+	}
+
+	return multipliers, rows.Err()
+}
+
+// target рассчитывает целевой коэффициент по отношению открытых заказов к доступным курьерам.
+// При отсутствии курьеров отношение считается как на одного курьера
+func (s *SurgeService) target(openOrders, couriers int) float64 {
+	ratio := float64(openOrders) / math.Max(float64(couriers), 1)
+	target := 1 + s.cfg.Sensitivity*(ratio-s.cfg.RatioThreshold)
+	return roundMultiplier(math.Min(math.Max(target, 1), math.Max(s.cfg.MaxMultiplier, 1)))
+}
+
+// smooth сдвигает коэффициент к целевому на долю Smoothing. Если до цели остаётся
+// меньше шага округления, коэффициент принимает целевое значение, чтобы не застрять рядом с ним
+func (s *SurgeService) smooth(previous, target float64) float64 {
+	next := roundMultiplier(previous + s.cfg.Smoothing*(target-previous))
+	if math.Abs(target-next) < 0.015 {
+		return target
+	}
+	return next
+}
+
+// roundMultiplier округляет коэффициент до сотых, как он хранится в БД
+func roundMultiplier(multiplier float64) float64 {
+	return math.Round(multiplier*100) / 100
+}
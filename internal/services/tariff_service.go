@@ -0,0 +1,319 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// tariffColumns - список колонок тарифа в порядке сканирования scanTariff
+const tariffColumns = `id, code, version, zone, base_fee, distance_bands, min_price, max_price, weight_surcharges,
+		       item_count_surcharges, night_multiplier, night_start_hour, night_end_hour, weekend_multiplier,
+		       effective_from, effective_to, created_at`
+
+// TariffService - сервис тарифов доставки и расчёта её стоимости
+type TariffService struct {
+	db       *database.DB
+	log      *logger.Logger
+	geo      GeolocationServiceInterface
+	surge    *SurgeService
+	zones    *ZoneService
+	location *time.Location
+}
+
+// NewTariffService создаёт новый экземпляр сервиса тарифов.
+// Ночные часы и выходные дни определяются в часовом поясе из настроек бизнеса
+func NewTariffService(
+	db *database.DB,
+	log *logger.Logger,
+	geo GeolocationServiceInterface,
+	surge *SurgeService,
+	zones *ZoneService,
+	cfg *config.BusinessConfig,
+) *TariffService {
+	location, err := time.LoadLocation(cfg.TimeZone)
+	if err != nil {
+		log.WithError(err).WithField("time_zone", cfg.TimeZone).Warn("Unknown business time zone, falling back to UTC")
+		location = time.UTC
+	}
+
+	return &TariffService{
+		db:       db,
+		log:      log,
+		geo:      geo,
+		surge:    surge,
+		zones:    zones,
+		location: location,
+	}
+}
+
+// CreateTariff создаёт новую версию тарифа. Версия увеличивается на единицу относительно
+// последней версии тарифа с тем же кодом
+func (s *TariffService) CreateTariff(req *models.TariffRequest) (*models.Tariff, error) {
+	distanceBands, err := json.Marshal(req.DistanceBands)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal distance bands: %w", err)
+	}
+	weightSurcharges, err := json.Marshal(nonNilSurcharges(req.WeightSurcharges))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal weight surcharges: %w", err)
+	}
+	itemCountSurcharges, err := json.Marshal(nonNilSurcharges(req.ItemCountSurcharges))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal item count surcharges: %w", err)
+	}
+
+	effectiveFrom := time.Now()
+	if req.EffectiveFrom != nil {
+		effectiveFrom = *req.EffectiveFrom
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Блокировка по коду тарифа не даёт двум запросам получить один номер версии
+	if _, err = tx.Exec(`SELECT pg_advisory_xact_lock(hashtext($1))`, req.Code); err != nil {
+		return nil, fmt.Errorf("failed to lock tariff code: %w", err)
+	}
+
+	query := `
+		INSERT INTO tariffs (id, code, version, zone, base_fee, distance_bands, min_price, max_price,
+		            weight_surcharges, item_count_surcharges, night_multiplier, night_start_hour, night_end_hour,
+		            weekend_multiplier, effective_from, effective_to)
+		SELECT $1, $2, COALESCE(MAX(version), 0) + 1, $3, $4, $5, $6, $7, $8, $9,
+		       COALESCE($10, 1), COALESCE($11, 23), COALESCE($12, 6), COALESCE($13, 1), $14, $15
+		FROM tariffs WHERE code = $2
+		RETURNING ` + tariffColumns
+
+	tariff, err := scanTariff(tx.QueryRow(query, uuid.New(), req.Code, req.Zone, req.BaseFee, distanceBands,
+		req.MinPrice, req.MaxPrice, weightSurcharges, itemCountSurcharges, req.NightMultiplier, req.NightStartHour,
+		req.NightEndHour, req.WeekendMultiplier, effectiveFrom, req.EffectiveTo))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("tariff version already exists")
+		}
+		return nil, fmt.Errorf("failed to create tariff: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"tariff_id":      tariff.ID,
+		"code":           tariff.Code,
+		"version":        tariff.Version,
+		"effective_from": tariff.EffectiveFrom,
+	}).Info("Tariff created successfully")
+
+	return tariff, nil
+}
+
+// GetTariff получает версию тарифа по ID
+func (s *TariffService) GetTariff(tariffID uuid.UUID) (*models.Tariff, error) {
+	query := `SELECT ` + tariffColumns + ` FROM tariffs WHERE id = $1`
+
+	tariff, err := scanTariff(s.db.QueryRow(query, tariffID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("tariff not found")
+		}
+		return nil, fmt.Errorf("failed to get tariff: %w", err)
+	}
+
+	return tariff, nil
+}
+
+// GetTariffs получает список версий тарифов, при activeOnly - только действующих в текущий момент
+func (s *TariffService) GetTariffs(code string, activeOnly bool, limit, offset int) ([]*models.Tariff, error) {
+	query := `SELECT ` + tariffColumns + ` FROM tariffs WHERE 1=1`
+	args := []interface{}{}
+	argIndex := 1
+
+	if code != "" {
+		query += fmt.Sprintf(" AND code = $%d", argIndex)
+		args = append(args, code)
+		argIndex++
+	}
+
+	if activeOnly {
+		query += " AND effective_from <= NOW() AND (effective_to IS NULL OR effective_to > NOW())"
+	}
+
+	query += " ORDER BY code, version DESC"
+
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d", argIndex)
+		args = append(args, limit)
+		argIndex++
+	}
+
+	if offset > 0 {
+		query += fmt.Sprintf(" OFFSET $%d", argIndex)
+		args = append(args, offset)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tariffs: %w", err)
+	}
+	defer rows.Close()
+
+	var tariffs []*models.Tariff
+	for rows.Next() {
+		tariff, err := scanTariff(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tariff: %w", err)
+		}
+		tariffs = append(tariffs, tariff)
+	}
+
+	return tariffs, nil
+}
+
+// RetireTariff завершает действие версии тарифа в текущий момент. Ещё не вступившая в силу версия
+// получает пустой период действия. Версии не удаляются, чтобы расчёты в заказах ссылались на существующий тариф
+func (s *TariffService) RetireTariff(tariffID uuid.UUID) (*models.Tariff, error) {
+	query := `
+		UPDATE tariffs
+		SET effective_to = GREATEST(effective_from, LEAST(COALESCE(effective_to, NOW()), NOW()))
+		WHERE id = $1
+		RETURNING ` + tariffColumns
+
+	tariff, err := scanTariff(s.db.QueryRow(query, tariffID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("tariff not found")
+		}
+		return nil, fmt.Errorf("failed to retire tariff: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"tariff_id":    tariff.ID,
+		"code":         tariff.Code,
+		"version":      tariff.Version,
+		"effective_to": tariff.EffectiveTo,
+	}).Info("Tariff retired")
+
+	return tariff, nil
+}
+
+// FindTariff выбирает тариф, действующий в момент at. Тариф зоны имеет приоритет над общим,
+// среди подходящих выбирается вступивший в силу последним
+func (s *TariffService) FindTariff(zone string, at time.Time) (*models.Tariff, error) {
+	query := `
+		SELECT ` + tariffColumns + `
+		FROM tariffs
+		WHERE (zone IS NULL OR zone = $1)
+		  AND effective_from <= $2 AND (effective_to IS NULL OR effective_to > $2)
+		ORDER BY zone IS NULL, effective_from DESC, version DESC
+		LIMIT 1`
+
+	tariff, err := scanTariff(s.db.QueryRow(query, zone, at))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNoActiveTariff
+		}
+		return nil, fmt.Errorf("failed to find tariff: %w", err)
+	}
+
+	return tariff, nil
+}
+
+// CalculateDeliveryCost рассчитывает стоимость доставки по тарифу, действующему в момент at.
+// Динамический коэффициент зоны отражает текущий спрос, поэтому применяется только к расчёту
+// на текущий момент: для будущего времени (отложенный заказ) он равен 1
+func (s *TariffService) CalculateDeliveryCost(distanceMeters float64, items []models.CreateOrderItemRequest,
+	zone string, at time.Time) (*models.DeliveryBreakdown, error) {
+	tariff, err := s.FindTariff(zone, at)
+	if err != nil {
+		return nil, err
+	}
+
+	surge := 1.0
+	if !at.After(time.Now()) {
+		if surge, err = s.surge.GetMultiplier(zone); err != nil {
+			return nil, err
+		}
+	}
+
+	itemCount, weightKg := models.ItemsSummary(items)
+	return tariff.Price(distanceMeters, itemCount, weightKg, at.In(s.location), surge), nil
+}
+
+// QuoteDelivery рассчитывает стоимость доставки между адресами без создания заказа.
+// Адреса проверяются на обслуживаемость так же, как при создании заказа
+func (s *TariffService) QuoteDelivery(req *models.DeliveryQuoteRequest) (*models.DeliveryBreakdown, error) {
+	pickupLng, pickupLat, err := s.geo.GetCoordinates(req.PickupAddress)
+	if err != nil {
+		return nil, err
+	}
+	deliveryLng, deliveryLat, err := s.geo.GetCoordinates(req.DeliveryAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	distance, err := s.geo.MakeRoute([][2]float64{{pickupLng, pickupLat}, {deliveryLng, deliveryLat}})
+	if err != nil {
+		return nil, err
+	}
+
+	zone, err := s.zones.CheckRoute([2]float64{pickupLng, pickupLat}, [2]float64{deliveryLng, deliveryLat}, distance)
+	if err != nil {
+		return nil, err
+	}
+	var zoneCode string
+	if zone != nil {
+		zoneCode = zone.Code
+	}
+
+	at := time.Now()
+	if req.At != nil {
+		at = *req.At
+	}
+
+	return s.CalculateDeliveryCost(distance, req.Items, zoneCode, at)
+}
+
+func scanTariff(row rowScanner) (*models.Tariff, error) {
+	tariff := &models.Tariff{}
+	var distanceBands, weightSurcharges, itemCountSurcharges []byte
+	err := row.Scan(&tariff.ID, &tariff.Code, &tariff.Version, &tariff.Zone, &tariff.BaseFee, &distanceBands,
+		&tariff.MinPrice, &tariff.MaxPrice, &weightSurcharges, &itemCountSurcharges, &tariff.NightMultiplier,
+		&tariff.NightStartHour, &tariff.NightEndHour, &tariff.WeekendMultiplier, &tariff.EffectiveFrom,
+		&tariff.EffectiveTo, &tariff.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(distanceBands, &tariff.DistanceBands); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal distance bands: %w", err)
+	}
+	if err = json.Unmarshal(weightSurcharges, &tariff.WeightSurcharges); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal weight surcharges: %w", err)
+	}
+	if err = json.Unmarshal(itemCountSurcharges, &tariff.ItemCountSurcharges); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal item count surcharges: %w", err)
+	}
+
+	return tariff, nil
+}
+
+// nonNilSurcharges заменяет отсутствующий список надбавок пустым, чтобы в JSONB хранился массив
+func nonNilSurcharges(surcharges []models.Surcharge) []models.Surcharge {
+	if surcharges == nil {
+		return []models.Surcharge{}
+	}
+	return surcharges
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"delivery-system/internal/config"
+	"delivery-system/internal/database"
+	"delivery-system/internal/logger"
+	"delivery-system/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// zoneColumns - список колонок зоны доставки в порядке сканирования scanZone
+const zoneColumns = `id, code, name, polygon, max_route_distance_m, sla_minutes, is_active, created_at, updated_at`
+
+// ZoneService - сервис зон доставки и проверки обслуживаемости адресов
+type ZoneService struct {
+	db  *database.DB
+	log *logger.Logger
+	cfg *config.BusinessConfig
+}
+
+// NewZoneService создаёт новый экземпляр сервиса зон доставки
+func NewZoneService(db *database.DB, log *logger.Logger, cfg *config.BusinessConfig) *ZoneService {
+	return &ZoneService{
+		db:  db,
+		log: log,
+		cfg: cfg,
+	}
+}
+
+// CreateZone создаёт новую зону доставки
+func (s *ZoneService) CreateZone(req *models.DeliveryZoneRequest) (*models.DeliveryZone, error) {
+	polygon, err := json.Marshal(req.Polygon)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal polygon: %w", err)
+	}
+	minLng, minLat, maxLng, maxLat := req.Polygon.Bounds()
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	query := `
+		INSERT INTO delivery_zones (id, code, name, polygon, min_lat, max_lat, min_lon, max_lon,
+		            max_route_distance_m, sla_minutes, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING ` + zoneColumns
+
+	zone, err := scanZone(s.db.QueryRow(query, uuid.New(), req.Code, req.Name, polygon, minLat, maxLat, minLng, maxLng,
+		req.MaxRouteDistance, req.SLAMinutes, isActive))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("delivery zone already exists")
+		}
+		return nil, fmt.Errorf("failed to create delivery zone: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"zone_id": zone.ID,
+		"code":    zone.Code,
+	}).Info("Delivery zone created successfully")
+
+	return zone, nil
+}
+
+// GetZone получает зону доставки по ID
+func (s *ZoneService) GetZone(zoneID uuid.UUID) (*models.DeliveryZone, error) {
+	query := `SELECT ` + zoneColumns + ` FROM delivery_zones WHERE id = $1`
+
+	zone, err := scanZone(s.db.QueryRow(query, zoneID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("delivery zone not found")
+		}
+		return nil, fmt.Errorf("failed to get delivery zone: %w", err)
+	}
+
+	return zone, nil
+}
+
+// GetZones получает список зон доставки
+func (s *ZoneService) GetZones(activeOnly bool) ([]*models.DeliveryZone, error) {
+	query := `SELECT ` + zoneColumns + ` FROM delivery_zones`
+	if activeOnly {
+		query += " WHERE is_active = TRUE"
+	}
+	query += " ORDER BY code"
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delivery zones: %w", err)
+	}
+	defer rows.Close()
+
+	var zones []*models.DeliveryZone
+	for rows.Next() {
+		zone, err := scanZone(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delivery zone: %w", err)
+		}
+		zones = append(zones, zone)
+	}
+
+	return zones, nil
+}
+
+// UpdateZone обновляет название, границу, ограничения и SLA зоны. Код зоны не изменяется, так как
+// на него ссылаются курьеры, тарифы и заказы
+func (s *ZoneService) UpdateZone(zoneID uuid.UUID, req *models.DeliveryZoneRequest) (*models.DeliveryZone, error) {
+	polygon, err := json.Marshal(req.Polygon)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal polygon: %w", err)
+	}
+	minLng, minLat, maxLng, maxLat := req.Polygon.Bounds()
+
+	query := `
+		UPDATE delivery_zones
+		SET name = $1, polygon = $2, min_lat = $3, max_lat = $4, min_lon = $5, max_lon = $6,
+		    max_route_distance_m = $7, sla_minutes = $8, is_active = COALESCE($9, is_active)
+		WHERE id = $10
+		RETURNING ` + zoneColumns
+
+	zone, err := scanZone(s.db.QueryRow(query, req.Name, polygon, minLat, maxLat, minLng, maxLng,
+		req.MaxRouteDistance, req.SLAMinutes, req.IsActive, zoneID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("delivery zone not found")
+		}
+		return nil, fmt.Errorf("failed to update delivery zone: %w", err)
+	}
+
+	s.log.WithFields(map[string]interface{}{
+		"zone_id": zone.ID,
+		"code":    zone.Code,
+	}).Info("Delivery zone updated")
+
+	return zone, nil
+}
+
+// DeleteZone деактивирует зону доставки. Запись сохраняется, чтобы не терять привязку курьеров и заказов
+func (s *ZoneService) DeleteZone(zoneID uuid.UUID) error {
+	result, err := s.db.Exec("UPDATE delivery_zones SET is_active = FALSE WHERE id = $1", zoneID)
+	if err != nil {
+		return fmt.Errorf("failed to delete delivery zone: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("delivery zone not found")
+	}
+
+	s.log.WithField("zone_id", zoneID).Info("Delivery zone deactivated")
+	return nil
+}
+
+// FindZone возвращает активную зону, содержащую точку. При пересечении зон выбирается зона
+// с наименьшим ограничивающим прямоугольником как более точная. Если активных зон нет, адрес считается
+// обслуживаемым и возвращается nil, если зоны есть, но точка не попадает ни в одну - ErrOutsideDeliveryArea
+func (s *ZoneService) FindZone(lng, lat float64) (*models.DeliveryZone, error) {
+	query := `
+		SELECT ` + zoneColumns + `
+		FROM delivery_zones
+		WHERE is_active = TRUE AND $1 BETWEEN min_lat AND max_lat AND $2 BETWEEN min_lon AND max_lon
+		ORDER BY (max_lat - min_lat) * (max_lon - min_lon)`
+
+	rows, err := s.db.Query(query, lat, lng)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find delivery zone: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		zone, err := scanZone(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delivery zone: %w", err)
+		}
+		if zone.Polygon.Contains(lng, lat) {
+			return zone, nil
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to find delivery zone: %w", err)
+	}
+
+	var hasZones bool
+	if err = s.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM delivery_zones WHERE is_active = TRUE)`).Scan(&hasZones); err != nil {
+		return nil, fmt.Errorf("failed to check delivery zones: %w", err)
+	}
+	if hasZones {
+		return nil, ErrOutsideDeliveryArea
+	}
+
+	return nil, nil
+}
+
+// CheckRoute проверяет, что точки забора и доставки обслуживаются, а длина маршрута не превышает
+// ограничение зоны забора или общее ограничение из настроек. Возвращает зону забора, по которой
+// определяются тариф, динамический коэффициент и курьеры заказа
+func (s *ZoneService) CheckRoute(pickup, delivery [2]float64, distanceMeters float64) (*models.DeliveryZone, error) {
+	pickupZone, err := s.FindZone(pickup[0], pickup[1])
+	if err != nil {
+		return nil, fmt.Errorf("pickup point: %w", err)
+	}
+	if _, err = s.FindZone(delivery[0], delivery[1]); err != nil {
+		return nil, fmt.Errorf("delivery point: %w", err)
+	}
+
+	limit := s.cfg.MaxRouteDistance
+	if pickupZone != nil && pickupZone.MaxRouteDistance != nil {
+		limit = *pickupZone.MaxRouteDistance
+	}
+	if limit > 0 && distanceMeters > float64(limit) {
+		return nil, fmt.Errorf("%w: %.0f m exceeds the limit of %d m", ErrRouteTooLong, distanceMeters, limit)
+	}
+
+	return pickupZone, nil
+}
+
+func scanZone(row rowScanner) (*models.DeliveryZone, error) {
+	zone := &models.DeliveryZone{}
+	var polygon []byte
+	err := row.Scan(&zone.ID, &zone.Code, &zone.Name, &polygon, &zone.MaxRouteDistance, &zone.SLAMinutes, &zone.IsActive,
+		&zone.CreatedAt, &zone.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(polygon, &zone.Polygon); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal polygon: %w", err)
+	}
+
+	return zone, nil
+}
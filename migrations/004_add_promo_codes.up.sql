@@ -0,0 +1,37 @@
+-- Таблица промокодов
+CREATE TABLE promo_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(64) NOT NULL UNIQUE,
+    discount_type VARCHAR(20) NOT NULL CHECK (discount_type IN ('fixed', 'percent', 'free_delivery')),
+    discount_value DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (discount_value >= 0),
+    min_order_amount DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (min_order_amount >= 0),
+    valid_from TIMESTAMP WITH TIME ZONE,
+    valid_until TIMESTAMP WITH TIME ZONE,
+    max_uses INTEGER CHECK (max_uses > 0),
+    max_uses_per_customer INTEGER CHECK (max_uses_per_customer > 0),
+    used_count INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Таблица использований промокодов (для лимитов на клиента и аудита)
+CREATE TABLE promo_code_redemptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    promo_code_id UUID NOT NULL REFERENCES promo_codes(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL UNIQUE REFERENCES orders(id) ON DELETE CASCADE,
+    customer_phone VARCHAR(20) NOT NULL,
+    discount_amount DECIMAL(10, 2) NOT NULL,
+    redeemed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE orders
+ADD COLUMN promo_code VARCHAR(64),
+ADD COLUMN discount_amount DECIMAL(10, 2) NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_promo_code_redemptions_customer ON promo_code_redemptions(promo_code_id, customer_phone);
+
+CREATE TRIGGER update_promo_codes_updated_at
+    BEFORE UPDATE ON promo_codes
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,47 @@
+-- Журнал событий заказа (event sourcing). Версия события монотонно растет в рамках заказа
+CREATE TABLE order_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL CHECK (version > 0),
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    actor_role VARCHAR(20) NOT NULL,
+    actor_id VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_order_event_version UNIQUE (order_id, version)
+);
+
+-- Снимки состояния заказа, ускоряющие восстановление из журнала событий
+CREATE TABLE order_snapshots (
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    state JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (order_id, version)
+);
+
+-- События неизменяемы: запрещаем их редактирование
+CREATE OR REPLACE FUNCTION prevent_order_events_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'order events are immutable';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER prevent_order_events_update_trigger
+    BEFORE UPDATE ON order_events
+    FOR EACH ROW
+    EXECUTE FUNCTION prevent_order_events_update();
+
+-- История статусов записывает инициатора изменения, переданного приложением через app.current_actor
+CREATE OR REPLACE FUNCTION log_order_status_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.status IS DISTINCT FROM NEW.status THEN
+        INSERT INTO order_status_history (order_id, old_status, new_status, courier_id, changed_by)
+        VALUES (NEW.id, OLD.status, NEW.status, NEW.courier_id,
+                COALESCE(NULLIF(current_setting('app.current_actor', true), ''), 'system'));
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
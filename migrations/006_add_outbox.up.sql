@@ -0,0 +1,19 @@
+-- Transactional outbox: события Kafka записываются в той же транзакции, что и изменение данных,
+-- и публикуются фоновым релеем. seq задает порядок публикации
+CREATE TABLE outbox (
+    id UUID PRIMARY KEY,
+    seq BIGSERIAL NOT NULL UNIQUE,
+    topic VARCHAR(255) NOT NULL,
+    event_key VARCHAR(255) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_outbox_pending ON outbox(seq) WHERE sent_at IS NULL;
+CREATE INDEX idx_outbox_pending_key ON outbox(event_key, seq) WHERE sent_at IS NULL;
+CREATE INDEX idx_outbox_sent_at ON outbox(sent_at) WHERE sent_at IS NOT NULL;
@@ -0,0 +1,14 @@
+-- История перемещений курьеров. Последняя позиция дополнительно хранится в Redis GEO-наборе
+CREATE TABLE courier_locations (
+    id BIGSERIAL PRIMARY KEY,
+    courier_id UUID NOT NULL REFERENCES couriers(id) ON DELETE CASCADE,
+    lat DECIMAL(10, 8) NOT NULL,
+    lon DECIMAL(11, 8) NOT NULL,
+    accuracy DECIMAL(8, 2),
+    speed DECIMAL(8, 2),
+    heading DECIMAL(5, 2),
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_courier_locations_courier_recorded ON courier_locations(courier_id, recorded_at);
@@ -0,0 +1,3 @@
+-- Индекс для поиска доступных курьеров поблизости по ограничивающему прямоугольнику
+CREATE INDEX idx_couriers_available_location ON couriers(current_lat, current_lon)
+    WHERE status = 'available' AND current_lat IS NOT NULL AND current_lon IS NOT NULL;
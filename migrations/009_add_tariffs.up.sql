@@ -0,0 +1,32 @@
+-- Таблица тарифов доставки. Каждая правка тарифа создаёт новую версию с тем же кодом
+CREATE TABLE tariffs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(64) NOT NULL,
+    version INTEGER NOT NULL CHECK (version > 0),
+    zone VARCHAR(64),
+    base_fee DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (base_fee >= 0),
+    distance_bands JSONB NOT NULL DEFAULT '[]',
+    min_price DECIMAL(10, 2) CHECK (min_price >= 0),
+    max_price DECIMAL(10, 2) CHECK (max_price >= 0),
+    weight_surcharges JSONB NOT NULL DEFAULT '[]',
+    item_count_surcharges JSONB NOT NULL DEFAULT '[]',
+    night_multiplier DECIMAL(5, 2) NOT NULL DEFAULT 1 CHECK (night_multiplier > 0),
+    night_start_hour SMALLINT NOT NULL DEFAULT 23 CHECK (night_start_hour BETWEEN 0 AND 23),
+    night_end_hour SMALLINT NOT NULL DEFAULT 6 CHECK (night_end_hour BETWEEN 0 AND 23),
+    weekend_multiplier DECIMAL(5, 2) NOT NULL DEFAULT 1 CHECK (weekend_multiplier > 0),
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    effective_to TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (code, version),
+    CHECK (min_price IS NULL OR max_price IS NULL OR min_price <= max_price),
+    CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+CREATE INDEX idx_tariffs_effective ON tariffs(zone, effective_from DESC);
+
+-- Тариф по умолчанию повторяет прежний расчёт: 100 за километр без надбавок
+INSERT INTO tariffs (code, version, distance_bands, effective_from)
+VALUES ('default', 1, '[{"per_km": 100}]', '2000-01-01T00:00:00Z');
+
+-- Детализация расчёта стоимости доставки заказа
+ALTER TABLE orders ADD COLUMN delivery_breakdown JSONB;
@@ -0,0 +1,14 @@
+-- Зона тарифа, указанная при создании заказа. Используется для расчёта спроса по зонам
+ALTER TABLE orders ADD COLUMN zone VARCHAR(64);
+
+CREATE INDEX idx_orders_open_zone ON orders(zone) WHERE status IN ('created', 'ready');
+
+-- Текущий динамический коэффициент стоимости доставки по зонам. Пустая строка - заказы без зоны
+CREATE TABLE surge_multipliers (
+    zone VARCHAR(64) PRIMARY KEY,
+    multiplier DECIMAL(5, 2) NOT NULL DEFAULT 1 CHECK (multiplier >= 1),
+    target_multiplier DECIMAL(5, 2) NOT NULL DEFAULT 1,
+    open_orders INTEGER NOT NULL DEFAULT 0,
+    available_couriers INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,28 @@
+-- Зоны доставки. Граница зоны хранится как GeoJSON Polygon с координатами [долгота, широта],
+-- ограничивающий прямоугольник используется для предварительного отбора зон по точке
+CREATE TABLE delivery_zones (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    code VARCHAR(64) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    polygon JSONB NOT NULL,
+    min_lat DOUBLE PRECISION NOT NULL,
+    max_lat DOUBLE PRECISION NOT NULL,
+    min_lon DOUBLE PRECISION NOT NULL,
+    max_lon DOUBLE PRECISION NOT NULL,
+    max_route_distance_m INTEGER CHECK (max_route_distance_m > 0),
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_delivery_zones_bounds ON delivery_zones(min_lat, max_lat, min_lon, max_lon) WHERE is_active;
+
+CREATE TRIGGER update_delivery_zones_updated_at
+    BEFORE UPDATE ON delivery_zones
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Зона курьера. Курьер без зоны работает во всех зонах
+ALTER TABLE couriers ADD COLUMN zone VARCHAR(64) REFERENCES delivery_zones(code) ON DELETE SET NULL;
+
+CREATE INDEX idx_couriers_zone ON couriers(zone);
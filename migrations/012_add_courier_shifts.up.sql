@@ -0,0 +1,41 @@
+-- Смены курьеров. Запланированная смена имеет плановые границы, внеплановая создаётся при начале работы.
+-- Итоги смены сохраняются при её завершении
+CREATE TABLE courier_shifts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    courier_id UUID NOT NULL REFERENCES couriers(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('scheduled', 'active', 'completed', 'cancelled', 'missed')),
+    scheduled_start TIMESTAMP WITH TIME ZONE,
+    scheduled_end TIMESTAMP WITH TIME ZONE,
+    started_at TIMESTAMP WITH TIME ZONE,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    orders_delivered INTEGER,
+    distance_m DECIMAL(12, 2),
+    online_seconds BIGINT,
+    break_seconds BIGINT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (scheduled_end > scheduled_start),
+    CHECK (ended_at >= started_at)
+);
+
+-- У курьера может быть только одна активная смена
+CREATE UNIQUE INDEX idx_courier_shifts_active ON courier_shifts(courier_id) WHERE status = 'active';
+CREATE INDEX idx_courier_shifts_courier_start ON courier_shifts(courier_id, COALESCE(started_at, scheduled_start));
+CREATE INDEX idx_courier_shifts_scheduled_end ON courier_shifts(scheduled_end) WHERE status IN ('scheduled', 'active');
+
+-- Перерывы внутри смены
+CREATE TABLE courier_shift_breaks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    shift_id UUID NOT NULL REFERENCES courier_shifts(id) ON DELETE CASCADE,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE,
+    CHECK (ended_at >= started_at)
+);
+
+-- В смене может быть только один незавершённый перерыв
+CREATE UNIQUE INDEX idx_courier_shift_breaks_open ON courier_shift_breaks(shift_id) WHERE ended_at IS NULL;
+
+CREATE TRIGGER update_courier_shifts_updated_at
+    BEFORE UPDATE ON courier_shifts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
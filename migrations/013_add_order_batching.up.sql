@@ -0,0 +1,14 @@
+-- Вместимость курьера - сколько активных заказов он может везти одновременно.
+-- NULL - значение по умолчанию из настроек (COURIER_DEFAULT_CAPACITY)
+ALTER TABLE couriers ADD COLUMN capacity INTEGER CHECK (capacity > 0);
+
+-- Координаты точек получения и доставки сохраняются при создании заказа
+-- для объединения заказов в пакеты и построения маршрута курьера
+ALTER TABLE orders ADD COLUMN pickup_lat DOUBLE PRECISION;
+ALTER TABLE orders ADD COLUMN pickup_lon DOUBLE PRECISION;
+ALTER TABLE orders ADD COLUMN delivery_lat DOUBLE PRECISION;
+ALTER TABLE orders ADD COLUMN delivery_lon DOUBLE PRECISION;
+
+-- Поиск заказов, ожидающих курьера, и активных заказов курьера
+CREATE INDEX idx_orders_unassigned ON orders(created_at) WHERE courier_id IS NULL AND status = 'created';
+CREATE INDEX idx_orders_courier_status ON orders(courier_id, status);
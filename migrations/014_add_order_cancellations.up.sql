@@ -0,0 +1,17 @@
+-- Отмены заказов: причина, инициатор, этап заказа на момент отмены, плата за отмену и сумма возврата клиенту
+CREATE TABLE order_cancellations (
+    order_id UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    reason VARCHAR(40) NOT NULL,
+    comment TEXT,
+    stage VARCHAR(20) NOT NULL,
+    actor_role VARCHAR(20) NOT NULL,
+    actor_id VARCHAR(255),
+    paid_amount DECIMAL(10, 2) NOT NULL CHECK (paid_amount >= 0),
+    fee DECIMAL(10, 2) NOT NULL DEFAULT 0 CHECK (fee >= 0),
+    refund_amount DECIMAL(10, 2) NOT NULL CHECK (refund_amount >= 0),
+    courier_id UUID REFERENCES couriers(id) ON DELETE SET NULL,
+    cancelled_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (fee + refund_amount = paid_amount)
+);
+
+CREATE INDEX idx_order_cancellations_reason ON order_cancellations(reason, cancelled_at);
@@ -0,0 +1,18 @@
+-- PIN-код для подтверждения доставки выдаётся покупателю при создании заказа.
+-- У заказов, созданных раньше, PIN-кода нет
+ALTER TABLE orders
+ADD COLUMN delivery_pin VARCHAR(4),
+ADD COLUMN delivery_pin_attempts INTEGER NOT NULL DEFAULT 0;
+
+-- Подтверждения доставки: местоположение курьера и ключи фото и подписи в хранилище файлов
+CREATE TABLE delivery_proofs (
+    order_id UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    courier_id UUID REFERENCES couriers(id) ON DELETE SET NULL,
+    pin_verified BOOLEAN NOT NULL,
+    lat DOUBLE PRECISION NOT NULL,
+    lon DOUBLE PRECISION NOT NULL,
+    distance_m DECIMAL(10, 2) NOT NULL,
+    photo_key VARCHAR(255),
+    signature_key VARCHAR(255),
+    delivered_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,16 @@
+-- Срок доставки зоны в минутах от создания заказа. NULL - значение по умолчанию из настроек (SLA_DEFAULT_MINUTES)
+ALTER TABLE delivery_zones ADD COLUMN sla_minutes INTEGER CHECK (sla_minutes > 0);
+
+-- Прогноз времени доставки, срок доставки по SLA и момент обнаружения его нарушения
+ALTER TABLE orders
+ADD COLUMN eta TIMESTAMP WITH TIME ZONE,
+ADD COLUMN eta_updated_at TIMESTAMP WITH TIME ZONE,
+ADD COLUMN sla_deadline TIMESTAMP WITH TIME ZONE,
+ADD COLUMN sla_breached_at TIMESTAMP WITH TIME ZONE;
+
+-- Поиск активных заказов с приближающимся или нарушенным сроком
+CREATE INDEX idx_orders_sla_deadline ON orders(sla_deadline)
+    WHERE sla_deadline IS NOT NULL AND status NOT IN ('delivered', 'cancelled');
+
+-- Расчёт средней длительности этапов по недавней истории статусов
+CREATE INDEX idx_order_status_history_changed_at ON order_status_history(changed_at);
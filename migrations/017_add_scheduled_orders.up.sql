@@ -0,0 +1,11 @@
+-- Отложенные заказы: статус scheduled, время доставки к слоту и время передачи в работу
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check
+    CHECK (status IN ('scheduled', 'created', 'accepted', 'preparing', 'ready', 'in_delivery', 'delivered', 'cancelled'));
+
+ALTER TABLE orders
+ADD COLUMN scheduled_for TIMESTAMP WITH TIME ZONE,
+ADD COLUMN dispatch_at TIMESTAMP WITH TIME ZONE;
+
+-- Поиск отложенных заказов, которые пора передать в работу
+CREATE INDEX idx_orders_dispatch_at ON orders(dispatch_at) WHERE status = 'scheduled';
@@ -0,0 +1,66 @@
+-- Нормализация телефона: только цифры, российский номер приводится к 11 цифрам с ведущей 7.
+-- Должна совпадать с models.NormalizePhone
+CREATE OR REPLACE FUNCTION normalize_phone(phone TEXT) RETURNS TEXT AS $$
+DECLARE
+    digits TEXT := regexp_replace(COALESCE(phone, ''), '\D', '', 'g');
+BEGIN
+    IF length(digits) = 11 AND left(digits, 1) = '8' THEN
+        RETURN '7' || substr(digits, 2);
+    ELSIF length(digits) = 10 THEN
+        RETURN '7' || digits;
+    END IF;
+    RETURN digits;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- Клиенты. Телефон хранится нормализованным и однозначно определяет клиента
+CREATE TABLE customers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    phone VARCHAR(20) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_customers_updated_at
+    BEFORE UPDATE ON customers
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Сохранённые адреса клиента с координатами, определёнными при сохранении
+CREATE TABLE customer_addresses (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    customer_id UUID NOT NULL REFERENCES customers(id) ON DELETE CASCADE,
+    label VARCHAR(64),
+    address TEXT NOT NULL,
+    lat DOUBLE PRECISION NOT NULL,
+    lon DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_customer_addresses_customer_id ON customer_addresses(customer_id);
+
+ALTER TABLE orders ADD COLUMN customer_id UUID REFERENCES customers(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_orders_customer_id ON orders(customer_id, created_at DESC);
+
+-- Клиенты существующих заказов: по одному на нормализованный телефон, имя берётся из последнего заказа
+INSERT INTO customers (name, phone, created_at, updated_at)
+SELECT DISTINCT ON (normalize_phone(customer_phone))
+       customer_name,
+       normalize_phone(customer_phone),
+       MIN(created_at) OVER (PARTITION BY normalize_phone(customer_phone)),
+       NOW()
+FROM orders
+WHERE normalize_phone(customer_phone) <> ''
+ORDER BY normalize_phone(customer_phone), created_at DESC;
+
+-- Привязка заказов к клиентам не считается изменением заказа: updated_at не обновляется
+ALTER TABLE orders DISABLE TRIGGER update_orders_updated_at;
+
+UPDATE orders o
+SET customer_id = c.id
+FROM customers c
+WHERE c.phone = normalize_phone(o.customer_phone);
+
+ALTER TABLE orders ENABLE TRIGGER update_orders_updated_at;
@@ -0,0 +1,45 @@
+-- Продавцы (рестораны, магазины). Координаты точки получения определяются при сохранении адреса,
+-- часы работы хранятся как JSON-массив интервалов [{"weekday": 1, "open": "09:00", "close": "22:00"}]
+CREATE TABLE merchants (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    phone VARCHAR(20),
+    pickup_address TEXT NOT NULL,
+    lat DOUBLE PRECISION NOT NULL,
+    lon DOUBLE PRECISION NOT NULL,
+    opening_hours JSONB NOT NULL DEFAULT '[]',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_merchants_updated_at
+    BEFORE UPDATE ON merchants
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Каталог товаров продавца
+CREATE TABLE products (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    merchant_id UUID NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    price DECIMAL(10, 2) NOT NULL CHECK (price >= 0),
+    weight_kg DECIMAL(10, 3) CHECK (weight_kg >= 0),
+    is_available BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_products_merchant_id ON products(merchant_id);
+
+CREATE TRIGGER update_products_updated_at
+    BEFORE UPDATE ON products
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Заказ продавца и товары каталога. Название и цена товара копируются в заказ на момент его создания
+ALTER TABLE orders ADD COLUMN merchant_id UUID REFERENCES merchants(id) ON DELETE SET NULL;
+ALTER TABLE order_items ADD COLUMN product_id UUID REFERENCES products(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_orders_merchant_id ON orders(merchant_id, created_at DESC);
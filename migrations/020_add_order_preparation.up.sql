@@ -0,0 +1,10 @@
+-- Приготовление заказа продавцом: заявленное время приготовления, ожидаемое (после готовности - фактическое)
+-- время готовности и время поиска курьера, рассчитанное так, чтобы курьер прибыл к готовности заказа
+ALTER TABLE orders
+ADD COLUMN prep_minutes INTEGER CHECK (prep_minutes > 0),
+ADD COLUMN ready_at TIMESTAMP WITH TIME ZONE,
+ADD COLUMN courier_dispatch_at TIMESTAMP WITH TIME ZONE;
+
+-- Поиск заказов продавцов, которым пора назначить курьера
+CREATE INDEX idx_orders_courier_dispatch_at ON orders(courier_dispatch_at)
+    WHERE merchant_id IS NOT NULL AND courier_id IS NULL;
@@ -0,0 +1,30 @@
+-- API-ключи машинных клиентов (кассы продавцов, аналитические выгрузки). Сам ключ не хранится,
+-- только SHA-256 хеш; префикс ключа сохраняется, чтобы его можно было узнать в списке.
+-- После ротации прежний хеш принимается до previous_key_expires_at. rate_limit_tier - тарифный план
+-- ограничения частоты запросов по ключу
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    previous_key_hash CHAR(64),
+    previous_key_expires_at TIMESTAMP WITH TIME ZONE,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    owner_role VARCHAR(32) NOT NULL,
+    owner_id VARCHAR(255),
+    rate_limit_tier VARCHAR(16) NOT NULL DEFAULT 'default',
+    expires_at TIMESTAMP WITH TIME ZONE,
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    rotated_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_api_keys_previous_key_hash ON api_keys(previous_key_hash) WHERE previous_key_hash IS NOT NULL;
+CREATE INDEX idx_api_keys_owner ON api_keys(owner_role, owner_id);
+
+CREATE TRIGGER update_api_keys_updated_at
+    BEFORE UPDATE ON api_keys
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS update_promo_codes_updated_at ON promo_codes;
+
+DROP INDEX IF EXISTS idx_promo_code_redemptions_customer;
+
+ALTER TABLE orders
+DROP COLUMN IF EXISTS promo_code,
+DROP COLUMN IF EXISTS discount_amount;
+
+DROP TABLE IF EXISTS promo_code_redemptions;
+DROP TABLE IF EXISTS promo_codes;
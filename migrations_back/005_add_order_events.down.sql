@@ -0,0 +1,16 @@
+CREATE OR REPLACE FUNCTION log_order_status_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.status IS DISTINCT FROM NEW.status THEN
+        INSERT INTO order_status_history (order_id, old_status, new_status, courier_id, changed_by)
+        VALUES (NEW.id, OLD.status, NEW.status, NEW.courier_id, 'system');
+    END IF;
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+DROP TRIGGER IF EXISTS prevent_order_events_update_trigger ON order_events;
+DROP FUNCTION IF EXISTS prevent_order_events_update();
+
+DROP TABLE IF EXISTS order_snapshots;
+DROP TABLE IF EXISTS order_events;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_couriers_available_location;
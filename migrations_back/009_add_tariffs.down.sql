@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_tariffs_effective;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS delivery_breakdown;
+
+DROP TABLE IF EXISTS tariffs;
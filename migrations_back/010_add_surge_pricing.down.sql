@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS surge_multipliers;
+
+DROP INDEX IF EXISTS idx_orders_open_zone;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS zone;
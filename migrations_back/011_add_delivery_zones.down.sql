@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_couriers_zone;
+
+ALTER TABLE couriers DROP COLUMN IF EXISTS zone;
+
+DROP TRIGGER IF EXISTS update_delivery_zones_updated_at ON delivery_zones;
+
+DROP INDEX IF EXISTS idx_delivery_zones_bounds;
+
+DROP TABLE IF EXISTS delivery_zones;
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS update_courier_shifts_updated_at ON courier_shifts;
+
+DROP INDEX IF EXISTS idx_courier_shift_breaks_open;
+DROP INDEX IF EXISTS idx_courier_shifts_scheduled_end;
+DROP INDEX IF EXISTS idx_courier_shifts_courier_start;
+DROP INDEX IF EXISTS idx_courier_shifts_active;
+
+DROP TABLE IF EXISTS courier_shift_breaks;
+DROP TABLE IF EXISTS courier_shifts;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_orders_courier_status;
+DROP INDEX IF EXISTS idx_orders_unassigned;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS delivery_lon;
+ALTER TABLE orders DROP COLUMN IF EXISTS delivery_lat;
+ALTER TABLE orders DROP COLUMN IF EXISTS pickup_lon;
+ALTER TABLE orders DROP COLUMN IF EXISTS pickup_lat;
+
+ALTER TABLE couriers DROP COLUMN IF EXISTS capacity;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_order_cancellations_reason;
+
+DROP TABLE IF EXISTS order_cancellations;
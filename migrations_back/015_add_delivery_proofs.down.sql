@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS delivery_proofs;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS delivery_pin_attempts;
+ALTER TABLE orders DROP COLUMN IF EXISTS delivery_pin;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_order_status_history_changed_at;
+DROP INDEX IF EXISTS idx_orders_sla_deadline;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS sla_breached_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS sla_deadline;
+ALTER TABLE orders DROP COLUMN IF EXISTS eta_updated_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS eta;
+
+ALTER TABLE delivery_zones DROP COLUMN IF EXISTS sla_minutes;
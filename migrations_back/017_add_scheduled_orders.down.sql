@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_orders_dispatch_at;
+
+-- Невыпущенные отложенные заказы передаются в работу, чтобы не нарушить ограничение статуса
+UPDATE orders SET status = 'created' WHERE status = 'scheduled';
+
+ALTER TABLE orders DROP COLUMN IF EXISTS dispatch_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS scheduled_for;
+
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check
+    CHECK (status IN ('created', 'accepted', 'preparing', 'ready', 'in_delivery', 'delivered', 'cancelled'));
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_orders_customer_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS customer_id;
+
+DROP TABLE IF EXISTS customer_addresses;
+
+DROP TRIGGER IF EXISTS update_customers_updated_at ON customers;
+DROP TABLE IF EXISTS customers;
+
+DROP FUNCTION IF EXISTS normalize_phone(TEXT);
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_orders_merchant_id;
+ALTER TABLE order_items DROP COLUMN IF EXISTS product_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS merchant_id;
+
+DROP TRIGGER IF EXISTS update_products_updated_at ON products;
+DROP TABLE IF EXISTS products;
+
+DROP TRIGGER IF EXISTS update_merchants_updated_at ON merchants;
+DROP TABLE IF EXISTS merchants;
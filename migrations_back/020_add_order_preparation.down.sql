@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_orders_courier_dispatch_at;
+
+ALTER TABLE orders
+DROP COLUMN IF EXISTS courier_dispatch_at,
+DROP COLUMN IF EXISTS ready_at,
+DROP COLUMN IF EXISTS prep_minutes;
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS update_api_keys_updated_at ON api_keys;
+
+DROP INDEX IF EXISTS idx_api_keys_owner;
+DROP INDEX IF EXISTS idx_api_keys_previous_key_hash;
+
+DROP TABLE IF EXISTS api_keys;